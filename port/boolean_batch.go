@@ -0,0 +1,41 @@
+package clipper
+
+// ==============================================================================
+// Concurrent Batch Boolean Operations
+// ==============================================================================
+
+// BooleanJob is one BooleanOp64 call of a batch
+type BooleanJob struct {
+	ClipType     ClipType
+	FillRule     FillRule
+	Subjects     Paths64
+	SubjectsOpen Paths64
+	Clips        Paths64
+	Options      []Option // passed to BooleanOp64
+}
+
+// BooleanResult is the outcome of one BooleanJob
+type BooleanResult struct {
+	Solution     Paths64
+	SolutionOpen Paths64
+	Err          error
+}
+
+// BatchBooleanOp64 runs many independent boolean operations, such as clipping
+// thousands of small polygons to map tiles or label boxes, on a pool of
+// worker goroutines (GOMAXPROCS when workers <= 0). Each worker sweeps its
+// jobs on one VattiEngine, reusing its buffers from job to job. Results are
+// returned in job order, and a failing job only sets its own Err; input paths
+// are not modified.
+func BatchBooleanOp64(jobs []BooleanJob, workers int) []BooleanResult {
+	results := make([]BooleanResult, len(jobs))
+	runPool(len(jobs), workers, func() func(int) {
+		ve := NewVattiEngine(Union, NonZero)
+		return func(i int) {
+			job := jobs[i]
+			solution, solutionOpen, err := booleanOp64(ve, job.ClipType, job.FillRule, job.Subjects, job.SubjectsOpen, job.Clips, job.Options...)
+			results[i] = BooleanResult{Solution: solution, SolutionOpen: solutionOpen, Err: err}
+		}
+	})
+	return results
+}
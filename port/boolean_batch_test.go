@@ -0,0 +1,43 @@
+package clipper
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestBatchBooleanOp64(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var jobs []BooleanJob
+	for i := 0; i < 60; i++ {
+		jobs = append(jobs, BooleanJob{
+			ClipType: ClipType(i % 4),
+			FillRule: FillRule(i % 2),
+			Subjects: Paths64{randomPath(r, 3+r.Intn(6), 100)},
+			Clips:    Paths64{randomPath(r, 3+r.Intn(6), 100)},
+		})
+	}
+	jobs[5].SubjectsOpen = Paths64{{{-10, 50}, {110, 50}}}
+	jobs[11].Options = []Option{ClipperOptions{PreserveCollinear: true}}
+	jobs[17].Options = []Option{ClipperOptions{MaxOutputVertices: -1}}
+
+	for _, workers := range []int{0, 1, 4, 100} {
+		results := BatchBooleanOp64(jobs, workers)
+		if len(results) != len(jobs) {
+			t.Fatalf("workers %d: expected %d results, got %d", workers, len(jobs), len(results))
+		}
+		for i, job := range jobs {
+			expected, expectedOpen, err := BooleanOp64(job.ClipType, job.FillRule, job.Subjects, job.SubjectsOpen, job.Clips, job.Options...)
+			got := results[i]
+			if !errors.Is(got.Err, err) || !reflect.DeepEqual(got.Solution, expected) || !reflect.DeepEqual(got.SolutionOpen, expectedOpen) {
+				t.Errorf("workers %d, job %d: expected %v, %v, %v; got %v, %v, %v",
+					workers, i, expected, expectedOpen, err, got.Solution, got.SolutionOpen, got.Err)
+			}
+		}
+	}
+
+	if results := BatchBooleanOp64(nil, 4); len(results) != 0 {
+		t.Errorf("expected no results for no jobs, got %v", results)
+	}
+}
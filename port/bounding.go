@@ -0,0 +1,200 @@
+package clipper
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// ==============================================================================
+// Minimal Bounding Shapes
+// ==============================================================================
+
+// MinBoundingCircle64 returns the smallest circle enclosing every vertex of
+// paths, for example as a collision proxy for a clipping result. It runs
+// Welzl's algorithm on the convex hull in a fixed pseudo-random order, so
+// results are reproducible. Empty input returns the origin and radius 0.
+func MinBoundingCircle64(paths Paths64) (center PointD, r float64) {
+	hull := convexHull64(paths)
+	if len(hull) == 0 {
+		return PointD{}, 0
+	}
+
+	pts := make([]PointD, len(hull))
+	for i, pt := range hull {
+		pts[i] = PointD{X: float64(pt.X), Y: float64(pt.Y)}
+	}
+	rand.New(rand.NewSource(1)).Shuffle(len(pts), func(i, j int) { pts[i], pts[j] = pts[j], pts[i] })
+
+	c := circle{center: pts[0]}
+	for i := 1; i < len(pts); i++ {
+		if c.contains(pts[i]) {
+			continue
+		}
+		c = circle{center: pts[i]}
+		for j := 0; j < i; j++ {
+			if c.contains(pts[j]) {
+				continue
+			}
+			c = circleThrough2(pts[i], pts[j])
+			for k := 0; k < j; k++ {
+				if !c.contains(pts[k]) {
+					c = circleThrough3(pts[i], pts[j], pts[k])
+				}
+			}
+		}
+	}
+	return c.center, c.r
+}
+
+// circle is a candidate enclosing circle of MinBoundingCircle64
+type circle struct {
+	center PointD
+	r      float64
+}
+
+// contains reports whether pt lies inside c, allowing for rounding
+func (c circle) contains(pt PointD) bool {
+	return math.Hypot(pt.X-c.center.X, pt.Y-c.center.Y) <= c.r*(1+1e-12)+1e-9
+}
+
+// circleThrough2 returns the circle with diameter a-b
+func circleThrough2(a, b PointD) circle {
+	center := PointD{X: (a.X + b.X) / 2, Y: (a.Y + b.Y) / 2}
+	return circle{center: center, r: math.Hypot(a.X-center.X, a.Y-center.Y)}
+}
+
+// circleThrough3 returns the circumcircle of a, b and c, or for collinear
+// points the circle spanning the two farthest apart
+func circleThrough3(a, b, c PointD) circle {
+	bx, by := b.X-a.X, b.Y-a.Y
+	cx, cy := c.X-a.X, c.Y-a.Y
+	d := 2 * (bx*cy - by*cx)
+	if d == 0 {
+		best := circleThrough2(a, b)
+		for _, cand := range []circle{circleThrough2(a, c), circleThrough2(b, c)} {
+			if cand.r > best.r {
+				best = cand
+			}
+		}
+		return best
+	}
+	b2, c2 := bx*bx+by*by, cx*cx+cy*cy
+	ux, uy := (cy*b2-by*c2)/d, (bx*c2-cx*b2)/d
+	return circle{center: PointD{X: a.X + ux, Y: a.Y + uy}, r: math.Hypot(ux, uy)}
+}
+
+// OrientedBounds64 returns the minimum-area rectangle enclosing every vertex
+// of paths, which need not be axis aligned, for example as a label box. The
+// rectangle has one side on an edge of the convex hull and is found with
+// rotating calipers in linear time after the hull. Its four corners are
+// returned counter-clockwise, rounded to the nearest integer; for collinear
+// input the rectangle has zero width and repeats corners. Empty input
+// returns nil.
+func OrientedBounds64(paths Paths64) Path64 {
+	hull := convexHull64(paths)
+	switch len(hull) {
+	case 0:
+		return nil
+	case 1:
+		return Path64{hull[0], hull[0], hull[0], hull[0]}
+	case 2:
+		return Path64{hull[0], hull[1], hull[1], hull[0]}
+	}
+
+	// Coordinates relative to the first hull vertex keep the floating-point
+	// projections accurate for large inputs
+	n := len(hull)
+	pts := make([]PointD, n)
+	for i, pt := range hull {
+		d := pt.Sub(hull[0])
+		pts[i] = PointD{X: float64(d.X), Y: float64(d.Y)}
+	}
+	dot := func(p PointD, ux, uy float64) float64 { return p.X*ux + p.Y*uy }
+
+	bestArea := math.Inf(1)
+	var best [4]PointD
+	right, top, left := 0, 0, 0
+	for i := 0; i < n; i++ {
+		p, q := pts[i], pts[(i+1)%n]
+		length := math.Hypot(q.X-p.X, q.Y-p.Y)
+		ux, uy := (q.X-p.X)/length, (q.Y-p.Y)/length
+		vx, vy := -uy, ux // the hull lies to the left of each edge
+
+		// Projections around a convex hull rise and fall once, so each
+		// caliper only ever moves forward to the next extreme
+		advance := func(k int, better func(next, cur PointD) bool) int {
+			for step := 0; step < n && better(pts[(k+1)%n], pts[k]); step++ {
+				k = (k + 1) % n
+			}
+			return k
+		}
+		if i == 0 {
+			right = 1
+		}
+		right = advance(right, func(next, cur PointD) bool { return dot(next, ux, uy) >= dot(cur, ux, uy) })
+		if i == 0 {
+			top = right
+		}
+		top = advance(top, func(next, cur PointD) bool { return dot(next, vx, vy) >= dot(cur, vx, vy) })
+		if i == 0 {
+			left = top
+		}
+		left = advance(left, func(next, cur PointD) bool { return dot(next, ux, uy) <= dot(cur, ux, uy) })
+
+		minU := dot(pts[left], ux, uy) - dot(p, ux, uy)
+		maxU := dot(pts[right], ux, uy) - dot(p, ux, uy)
+		maxV := dot(pts[top], vx, vy) - dot(p, vx, vy)
+		if area := (maxU - minU) * maxV; area < bestArea {
+			bestArea = area
+			corner := func(s, t float64) PointD {
+				return PointD{X: p.X + s*ux + t*vx, Y: p.Y + s*uy + t*vy}
+			}
+			best = [4]PointD{corner(minU, 0), corner(maxU, 0), corner(maxU, maxV), corner(minU, maxV)}
+		}
+	}
+
+	result := make(Path64, 4)
+	for i, pt := range best {
+		result[i] = Point64{X: hull[0].X + RoundHalfAway(pt.X), Y: hull[0].Y + RoundHalfAway(pt.Y)}
+	}
+	return result
+}
+
+// convexHull64 returns the convex hull of all vertices of paths,
+// counter-clockwise without collinear points (Andrew's monotone chain)
+func convexHull64(paths Paths64) Path64 {
+	var pts Path64
+	for _, path := range paths {
+		pts = append(pts, path...)
+	}
+	sort.Slice(pts, func(i, j int) bool { return pointLess(pts[i], pts[j]) })
+	unique := pts[:0]
+	for _, pt := range pts {
+		if len(unique) == 0 || pt != unique[len(unique)-1] {
+			unique = append(unique, pt)
+		}
+	}
+	if len(unique) < 3 {
+		return unique
+	}
+
+	hull := make(Path64, 0, 2*len(unique))
+	build := func(pt Point64, floor int) {
+		for len(hull) > floor {
+			if c := CrossProduct128(hull[len(hull)-2], hull[len(hull)-1], pt); !c.IsNegative() && !c.IsZero() {
+				break // left turn
+			}
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, pt)
+	}
+	for _, pt := range unique {
+		build(pt, 1)
+	}
+	lower := len(hull)
+	for i := len(unique) - 2; i >= 0; i-- {
+		build(unique[i], lower)
+	}
+	return hull[:len(hull)-1]
+}
@@ -0,0 +1,134 @@
+package clipper
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestConvexHull64(t *testing.T) {
+	paths := Paths64{
+		{{0, 0}, {10, 0}, {5, 5}, {10, 10}},
+		{{0, 10}, {5, 0}, {0, 5}, {0, 0}}, // duplicates and collinear points
+	}
+	expected := Path64{{0, 0}, {10, 0}, {10, 10}, {0, 10}}
+	if hull := convexHull64(paths); !identicalPath(hull, expected) {
+		t.Errorf("expected %v, got %v", expected, hull)
+	}
+	if hull := convexHull64(Paths64{{{0, 0}, {5, 5}, {10, 10}}}); len(hull) != 2 {
+		t.Errorf("expected collinear points to give 2 hull points, got %v", hull)
+	}
+}
+
+func TestMinBoundingCircle64(t *testing.T) {
+	tests := []struct {
+		name           string
+		paths          Paths64
+		expectedCenter PointD
+		expectedR      float64
+	}{
+		{"empty", nil, PointD{}, 0},
+		{"single point", Paths64{{{7, 3}}}, PointD{7, 3}, 0},
+		{"square", Paths64{{{0, 0}, {100, 0}, {100, 100}, {0, 100}}}, PointD{50, 50}, 50 * math.Sqrt2},
+		{"obtuse triangle", Paths64{{{0, 0}, {100, 0}, {50, 10}}}, PointD{50, 0}, 50},
+		{"equilateral-ish triangle", Paths64{{{0, 0}, {100, 0}, {50, 100}}}, PointD{50, 37.5}, 62.5},
+	}
+	for _, tt := range tests {
+		center, r := MinBoundingCircle64(tt.paths)
+		if math.Abs(center.X-tt.expectedCenter.X) > 1e-9 || math.Abs(center.Y-tt.expectedCenter.Y) > 1e-9 ||
+			math.Abs(r-tt.expectedR) > 1e-9 {
+			t.Errorf("%s: expected center %v radius %v, got %v %v", tt.name, tt.expectedCenter, tt.expectedR, center, r)
+		}
+	}
+}
+
+func TestMinBoundingCircle64Random(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	for trial := 0; trial < 50; trial++ {
+		path := randomPath(r, 3+r.Intn(30), 1000)
+		center, radius := MinBoundingCircle64(Paths64{path})
+
+		// encloses every vertex, with at least two on the circle
+		onCircle := 0
+		for _, pt := range path {
+			d := math.Hypot(float64(pt.X)-center.X, float64(pt.Y)-center.Y)
+			if d > radius+1e-6 {
+				t.Fatalf("trial %d: %v outside circle %v r=%v", trial, pt, center, radius)
+			}
+			if radius-d < 1e-6 {
+				onCircle++
+			}
+		}
+		if onCircle < 2 {
+			t.Errorf("trial %d: expected at least 2 vertices on the circle, got %d", trial, onCircle)
+		}
+	}
+}
+
+func TestOrientedBounds64(t *testing.T) {
+	// a rectangle rotated by 30 degrees is its own oriented bounds
+	c, s := math.Cos(math.Pi/6), math.Sin(math.Pi/6)
+	var rect Path64
+	for _, p := range [][2]float64{{-200, -50}, {200, -50}, {200, 50}, {-200, 50}} {
+		rect = append(rect, Point64{X: RoundHalfAway(1000 + p[0]*c - p[1]*s), Y: RoundHalfAway(1000 + p[0]*s + p[1]*c)})
+	}
+	box := OrientedBounds64(Paths64{rect, {{1000, 1000}}})
+	if len(box) != 4 {
+		t.Fatalf("expected 4 corners, got %v", box)
+	}
+	if area := Area64(box); math.Abs(area-40000) > 400 {
+		t.Errorf("expected area near 40000, got %v (%v)", area, box)
+	}
+
+	if box := OrientedBounds64(Paths64{{{0, 0}, {10, 0}, {10, 5}, {0, 5}}}); Area64(box) != 50 {
+		t.Errorf("expected the axis-aligned rectangle, got %v", box)
+	}
+	if box := OrientedBounds64(nil); box != nil {
+		t.Errorf("expected nil for empty input, got %v", box)
+	}
+	if box := OrientedBounds64(Paths64{{{0, 0}, {5, 5}, {10, 10}}}); len(box) != 4 || Area64(box) != 0 {
+		t.Errorf("expected a zero-width box for collinear input, got %v", box)
+	}
+}
+
+func TestOrientedBounds64Random(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	for trial := 0; trial < 50; trial++ {
+		path := randomPath(r, 3+r.Intn(30), 1000)
+		box := OrientedBounds64(Paths64{path})
+		area := Area64(box)
+		if area <= 0 {
+			t.Fatalf("trial %d: expected a counter-clockwise box, got %v", trial, box)
+		}
+		if expected := minEdgeAlignedArea(convexHull64(Paths64{path})); math.Abs(area-expected) > 0.01*expected+10 {
+			t.Errorf("trial %d: expected area %v, got %v", trial, expected, area)
+		}
+		for _, pt := range path {
+			for i, a := range box {
+				b := box[(i+1)%4]
+				if cross := CrossProduct128(a, b, pt).ToFloat64(); cross < -2*a.DistanceTo(b) {
+					t.Fatalf("trial %d: %v outside box %v", trial, pt, box)
+				}
+			}
+		}
+	}
+}
+
+// minEdgeAlignedArea returns the smallest area of the rectangles aligned with
+// each hull edge, by brute force
+func minEdgeAlignedArea(hull Path64) float64 {
+	best := math.Inf(1)
+	for i, p := range hull {
+		q := hull[(i+1)%len(hull)]
+		length := p.DistanceTo(q)
+		ux, uy := float64(q.X-p.X)/length, float64(q.Y-p.Y)/length
+		minU, maxU, maxV := math.Inf(1), math.Inf(-1), 0.0
+		for _, pt := range hull {
+			dx, dy := float64(pt.X-p.X), float64(pt.Y-p.Y)
+			minU, maxU = math.Min(minU, dx*ux+dy*uy), math.Max(maxU, dx*ux+dy*uy)
+			maxV = math.Max(maxV, dy*ux-dx*uy)
+		}
+		best = math.Min(best, (maxU-minU)*maxV)
+	}
+	return best
+}
@@ -0,0 +1,112 @@
+package clipper
+
+import "fmt"
+
+// ==============================================================================
+// Output Vertex Budget
+// ==============================================================================
+
+// maxBudgetRounds bounds how often the simplification tolerance is doubled
+const maxBudgetRounds = 64
+
+// applyOutputBudget enforces options.MaxOutputVertices on a finished
+// solution. OutputBudgetSimplify simplifies closed and open paths with a
+// tolerance doubling from one unit, re-unioning the rings each round so
+// simplification cannot leave them self-intersecting, and stops at the
+// first tolerance that fits. Rings too small for the tolerance vanish.
+func applyOutputBudget(options ClipperOptions, solution, solutionOpen Paths64) (Paths64, Paths64, error) {
+	budget := options.MaxOutputVertices
+	count := countVertices(solution) + countVertices(solutionOpen)
+	if count <= budget {
+		return solution, solutionOpen, nil
+	}
+	if options.OutputBudget != OutputBudgetSimplify {
+		return nil, nil, fmt.Errorf("%w: %d output vertices exceed the budget of %d", ErrComplexityExceeded, count, budget)
+	}
+
+	epsilon := 1.0
+	for round := 0; round < maxBudgetRounds; round++ {
+		rings := make(Paths64, 0, len(solution))
+		for _, ring := range solution {
+			if simplified := simplifyRing(ring, epsilon); len(simplified) >= 3 {
+				rings = append(rings, simplified)
+			}
+		}
+		rings, _, err := BooleanOp64(Union, NonZero, rings, nil, nil, ClipperOptions{Rounding: options.Rounding})
+		if err != nil {
+			return nil, nil, err
+		}
+		if options.KeepTouchingPointsAsVertices {
+			rings = insertTouchingVertices(rings)
+		}
+		lines := make(Paths64, len(solutionOpen))
+		for i, line := range solutionOpen {
+			lines[i] = douglasPeucker(line, epsilon)
+		}
+
+		if countVertices(rings)+countVertices(lines) <= budget {
+			return rings, lines, nil
+		}
+		epsilon *= 2
+	}
+	return nil, nil, fmt.Errorf("%w: output cannot be simplified to %d vertices", ErrComplexityExceeded, budget)
+}
+
+// countVertices returns the total number of vertices in paths
+func countVertices(paths Paths64) int {
+	n := 0
+	for _, path := range paths {
+		n += len(path)
+	}
+	return n
+}
+
+// douglasPeucker simplifies an open polyline so that no removed vertex lies
+// farther than epsilon from the segment replacing it. Endpoints are kept.
+func douglasPeucker(path Path64, epsilon float64) Path64 {
+	if len(path) <= 2 {
+		return path.Clone()
+	}
+	keep := make([]bool, len(path))
+	keep[0], keep[len(path)-1] = true, true
+	stack := [][2]int{{0, len(path) - 1}}
+	for len(stack) > 0 {
+		span := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		farthest, maxDist := -1, epsilon
+		for i := span[0] + 1; i < span[1]; i++ {
+			if d := perpendicularDistance(path[i], path[span[0]], path[span[1]]); d > maxDist {
+				farthest, maxDist = i, d
+			}
+		}
+		if farthest >= 0 {
+			keep[farthest] = true
+			stack = append(stack, [2]int{span[0], farthest}, [2]int{farthest, span[1]})
+		}
+	}
+
+	result := make(Path64, 0, len(path))
+	for i, pt := range path {
+		if keep[i] {
+			result = append(result, pt)
+		}
+	}
+	return result
+}
+
+// simplifyRing applies douglasPeucker to a closed ring, split at its first
+// vertex and the vertex farthest from it so both halves keep an anchor
+func simplifyRing(ring Path64, epsilon float64) Path64 {
+	if len(ring) < 3 {
+		return ring.Clone()
+	}
+	far, maxDist := 0, -1.0
+	for i, pt := range ring {
+		if d := pt.DistanceTo(ring[0]); d > maxDist {
+			far, maxDist = i, d
+		}
+	}
+	first := douglasPeucker(ring[:far+1], epsilon)
+	second := douglasPeucker(append(ring[far:].Clone(), ring[0]), epsilon)
+	return append(first, second[1:len(second)-1]...)
+}
@@ -0,0 +1,77 @@
+package clipper
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMaxOutputVertices(t *testing.T) {
+	subject := Paths64{RegularPolygon64(Point64{0, 0}, 10000, 200, 0)}
+	clip := Paths64{{{-20000, -20000}, {0, -20000}, {0, 20000}, {-20000, 20000}}}
+
+	full, _, err := BooleanOp64(Intersection, NonZero, subject, nil, clip)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	n := countVertices(full)
+
+	// within budget: unchanged
+	solution, _, err := BooleanOp64(Intersection, NonZero, subject, nil, clip, ClipperOptions{MaxOutputVertices: n})
+	if err != nil || countVertices(solution) != n {
+		t.Errorf("expected %d vertices within budget, got %d (err %v)", n, countVertices(solution), err)
+	}
+
+	// over budget: abort by default
+	solution, _, err = BooleanOp64(Intersection, NonZero, subject, nil, clip, ClipperOptions{MaxOutputVertices: n - 1})
+	if !errors.Is(err, ErrComplexityExceeded) || solution != nil {
+		t.Errorf("expected ErrComplexityExceeded and no solution, got %v, %d paths", err, len(solution))
+	}
+	if !errors.Is(err, ErrClipperExecution) {
+		t.Errorf("expected the budget error to match ErrClipperExecution, got %v", err)
+	}
+
+	// over budget: simplify
+	budget := 20
+	solution, _, err = BooleanOp64(Intersection, NonZero, subject, nil, clip,
+		ClipperOptions{MaxOutputVertices: budget, OutputBudget: OutputBudgetSimplify})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := countVertices(solution); got > budget || got < 3 {
+		t.Errorf("expected between 3 and %d vertices, got %d", budget, got)
+	}
+	if area, fullArea := Area64(solution[0]), Area64(full[0]); area < 0.9*fullArea || area > fullArea {
+		t.Errorf("expected the simplified area close to %v, got %v", fullArea, area)
+	}
+
+	if _, _, err := BooleanOp64(Union, NonZero, subject, nil, nil, ClipperOptions{MaxOutputVertices: -1}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for a negative budget, got %v", err)
+	}
+}
+
+func TestMaxOutputVerticesOpenPaths(t *testing.T) {
+	var line Path64
+	for i := int64(0); i <= 100; i++ {
+		line = append(line, Point64{i * 10, (i % 2) * 3})
+	}
+	clip := Paths64{{{-10, -10}, {2000, -10}, {2000, 10}, {-10, 10}}}
+	_, open, err := BooleanOp64(Intersection, NonZero, nil, Paths64{line}, clip,
+		ClipperOptions{MaxOutputVertices: 10, OutputBudget: OutputBudgetSimplify})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := countVertices(open); got > 10 || got < 2 {
+		t.Errorf("expected between 2 and 10 vertices, got %d: %v", got, open)
+	}
+}
+
+func TestDouglasPeucker(t *testing.T) {
+	line := Path64{{0, 0}, {10, 1}, {20, 0}, {30, 10}, {40, 0}}
+	expected := Path64{{0, 0}, {20, 0}, {30, 10}, {40, 0}}
+	if got := douglasPeucker(line, 2); !identicalPath(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	if got := simplifyRing(Path64{{0, 0}, {100, 0}, {100, 1}, {0, 1}}, 2); len(got) >= 3 {
+		t.Errorf("expected a thin ring to collapse, got %v", got)
+	}
+}
@@ -0,0 +1,59 @@
+//go:build clipper_cgo
+
+package cgoengine
+
+import (
+	"fmt"
+
+	"github.com/go-clipper/clipper2/capi"
+	clipper "github.com/go-clipper/clipper2/port"
+)
+
+func init() {
+	clipper.RegisterCGOBackend(backend{})
+}
+
+// backend forwards operations to the C++ library through capi
+type backend struct{}
+
+// BooleanOp64 implements clipper.Backend
+func (backend) BooleanOp64(clipType clipper.ClipType, fillRule clipper.FillRule, subjects, subjectsOpen, clips clipper.Paths64) (clipper.Paths64, clipper.Paths64, error) {
+	solution, solutionOpen, err := capi.BooleanOp64(uint8(clipType), uint8(fillRule), toCAPI(subjects), toCAPI(subjectsOpen), toCAPI(clips))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", clipper.ErrClipperExecution, err)
+	}
+	return fromCAPI(solution), fromCAPI(solutionOpen), nil
+}
+
+// InflatePaths64 implements clipper.Backend
+func (backend) InflatePaths64(paths clipper.Paths64, delta float64, joinType clipper.JoinType, endType clipper.EndType, opts clipper.OffsetOptions) (clipper.Paths64, error) {
+	result, err := capi.InflatePaths64(toCAPI(paths), delta, uint8(joinType), uint8(endType), opts.MiterLimit, opts.ArcTolerance)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", clipper.ErrClipperExecution, err)
+	}
+	return fromCAPI(result), nil
+}
+
+// toCAPI converts paths to capi types
+func toCAPI(paths clipper.Paths64) capi.Paths64 {
+	result := make(capi.Paths64, len(paths))
+	for i, path := range paths {
+		result[i] = make(capi.Path64, len(path))
+		for j, pt := range path {
+			result[i][j] = capi.Point64{X: pt.X, Y: pt.Y}
+		}
+	}
+	return result
+}
+
+// fromCAPI converts capi paths back
+func fromCAPI(paths capi.Paths64) clipper.Paths64 {
+	result := make(clipper.Paths64, len(paths))
+	for i, path := range paths {
+		result[i] = make(clipper.Path64, len(path))
+		for j, pt := range path {
+			result[i][j] = clipper.Point64{X: pt.X, Y: pt.Y}
+		}
+	}
+	return result
+}
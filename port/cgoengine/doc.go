@@ -0,0 +1,16 @@
+// Package cgoengine registers the C++ Clipper2 library as the clipper
+// package's EngineCGO backend. Import it for its side effect:
+//
+//	import _ "github.com/go-clipper/clipper2/port/cgoengine"
+//
+// and select the engine at run time with clipper.SetEngine. It is a module
+// of its own, so only applications requiring it depend on the cgo backend.
+//
+// The backend is still only compiled in with -tags=clipper_cgo. It links
+// the capi package of the root module, and capi builds the C++ sources
+// only under that tag so that building the root module never needs a C++
+// toolchain. Choosing among the registered engines needs no tag; without
+// it this package is empty, applications still import it unconditionally,
+// and SetEngine(clipper.EngineCGO) reports clipper.ErrNotImplemented
+// instead of failing to build.
+package cgoengine
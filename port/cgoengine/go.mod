@@ -0,0 +1,8 @@
+module github.com/go-clipper/clipper2/port/cgoengine
+
+go 1.23.3
+
+require github.com/go-clipper/clipper2 v0.0.0-20261016170825-f6d7f3a96d98
+
+// Inside the repository the backend always builds against the working tree.
+replace github.com/go-clipper/clipper2 => ../..
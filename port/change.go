@@ -0,0 +1,53 @@
+package clipper
+
+// ==============================================================================
+// Change Detection
+// ==============================================================================
+
+// ChangedRegions64 compares two versions of a footprint and returns the
+// regions the new version adds (after - before) and the ones it removes
+// (before - after), as needed by map and CAD revision tools. Both versions
+// are filled with NonZero. Rounding and small edits leave thin slivers along
+// boundaries that did not really change, so regions whose area, holes
+// subtracted, is below minArea are dropped (0 keeps everything); islands
+// inside a dropped region's holes are judged on their own.
+func ChangedRegions64(before, after Paths64, minArea float64) (added, removed Paths64, err error) {
+	added, err = Difference64(after, before, NonZero)
+	if err != nil {
+		return nil, nil, err
+	}
+	removed, err = Difference64(before, after, NonZero)
+	if err != nil {
+		return nil, nil, err
+	}
+	return dropSlivers(added, minArea), dropSlivers(removed, minArea), nil
+}
+
+// dropSlivers removes the regions of a clipping solution whose net area is
+// below minArea, each outer together with its holes
+func dropSlivers(solution Paths64, minArea float64) Paths64 {
+	if !(minArea > 0) || len(solution) == 0 {
+		return solution
+	}
+	rings, parents := nestRings(solution)
+	net := make([]float64, len(rings))
+	for i, ring := range rings {
+		area := Area64(ring)
+		if area > 0 {
+			net[i] += area
+		} else if p := parents[i]; p >= 0 {
+			net[p] += area
+		}
+	}
+	var result Paths64
+	for i, ring := range rings {
+		outer := i
+		if Area64(ring) < 0 && parents[i] >= 0 {
+			outer = parents[i]
+		}
+		if net[outer] >= minArea {
+			result = append(result, ring)
+		}
+	}
+	return result
+}
@@ -0,0 +1,68 @@
+package clipper
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+// TestChangedRegions tests added and removed regions between two footprint versions
+func TestChangedRegions(t *testing.T) {
+	before := Paths64{{{0, 0}, {100, 0}, {100, 100}, {0, 100}}}
+	// The new version grows an annex to the right and loses a corner; its
+	// left and bottom edges moved by one unit, leaving an L-shaped sliver
+	// of 199 square units
+	after := Paths64{
+		{{1, 1}, {100, 1}, {100, 20}, {150, 20}, {150, 60}, {100, 60}, {100, 70}, {70, 70}, {70, 100}, {1, 100}},
+	}
+
+	tests := []struct {
+		name                 string
+		minArea              float64
+		addedArea            float64
+		removedArea          float64
+		addedRegions, remove int
+	}{
+		{"unfiltered", 0, 2000, 900 + 199, 1, 2},
+		{"slivers dropped", 200, 2000, 900, 1, 1},
+		{"everything dropped", 5000, 0, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		added, removed, err := ChangedRegions64(before, after, tt.minArea)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		if got := totalArea(added); math.Abs(got-tt.addedArea) > 1e-9 || len(added) != tt.addedRegions {
+			t.Errorf("%s: expected %d added regions of area %v, got %v", tt.name, tt.addedRegions, tt.addedArea, added)
+		}
+		if got := totalArea(removed); math.Abs(got-tt.removedArea) > 1e-9 || len(removed) != tt.remove {
+			t.Errorf("%s: expected %d removed regions of area %v, got %v", tt.name, tt.remove, tt.removedArea, removed)
+		}
+	}
+}
+
+// TestDropSlivers tests that a region's holes count against its area
+func TestDropSlivers(t *testing.T) {
+	// A 100x100 outer with a 96x96 hole is a thin frame of 784 square units;
+	// the island inside the hole is judged on its own
+	frame := Path64{{0, 0}, {100, 0}, {100, 100}, {0, 100}}
+	hole := Path64{{2, 2}, {2, 98}, {98, 98}, {98, 2}}
+	island := Path64{{10, 10}, {90, 10}, {90, 90}, {10, 90}}
+
+	got := dropSlivers(Paths64{frame, hole, island}, 1000)
+	if len(got) != 1 || !reflect.DeepEqual(got[0], island) {
+		t.Errorf("expected only the island, got %v", got)
+	}
+	if got := dropSlivers(Paths64{frame, hole, island}, 500); len(got) != 3 {
+		t.Errorf("expected all rings, got %v", got)
+	}
+}
+
+// totalArea sums the signed areas of paths
+func totalArea(paths Paths64) float64 {
+	total := 0.0
+	for _, path := range paths {
+		total += Area64(path)
+	}
+	return total
+}
@@ -0,0 +1,47 @@
+package clipper
+
+import "math"
+
+// ==============================================================================
+// Sliver Removal
+// ==============================================================================
+
+// CleanPaths64 removes the slivers and micro-polygons boolean operations
+// leave in map data. In every closed path, a vertex closer than minEdgeLen
+// to the last vertex kept is dropped, so short edges collapse onto their
+// first end. Paths then left with fewer than three vertices, or with an
+// absolute area below minArea or of zero, are dropped; that removes small
+// holes as well as small outers. A threshold of zero or less disables its
+// step.
+func CleanPaths64(paths Paths64, minArea float64, minEdgeLen int64) Paths64 {
+	result := make(Paths64, 0, len(paths))
+	for _, path := range paths {
+		if minEdgeLen > 0 {
+			path = collapseShortEdges(path, float64(minEdgeLen))
+		}
+		if len(path) < 3 {
+			continue
+		}
+		if area := math.Abs(Area64(path)); area == 0 || area < minArea {
+			continue
+		}
+		result = append(result, path)
+	}
+	return result
+}
+
+// collapseShortEdges returns a copy of a closed path without the vertices
+// closer than minLen to the previous vertex kept, including across the
+// closing edge
+func collapseShortEdges(path Path64, minLen float64) Path64 {
+	result := make(Path64, 0, len(path))
+	for _, pt := range path {
+		if len(result) == 0 || result[len(result)-1].DistanceTo(pt) >= minLen {
+			result = append(result, pt)
+		}
+	}
+	for len(result) > 1 && result[len(result)-1].DistanceTo(result[0]) < minLen {
+		result = result[:len(result)-1]
+	}
+	return result
+}
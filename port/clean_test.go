@@ -0,0 +1,55 @@
+package clipper
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCleanPaths64(t *testing.T) {
+	square := Path64{{0, 0}, {100, 0}, {100, 100}, {0, 100}}
+	sliver := Path64{{0, 0}, {100, 0}, {100, 1}}
+	tests := []struct {
+		name       string
+		paths      Paths64
+		minArea    float64
+		minEdgeLen int64
+		expected   Paths64
+	}{
+		{"Drops slivers", Paths64{square, sliver}, 60, 0, Paths64{square}},
+		{"Drops small holes", Paths64{square, {{40, 40}, {40, 45}, {45, 45}, {45, 40}}}, 30, 0, Paths64{square}},
+		{"Collapses short edges", Paths64{{{0, 0}, {1, 0}, {100, 0}, {100, 100}, {0, 100}, {0, 1}}}, 0, 2, Paths64{{{0, 0}, {100, 0}, {100, 100}, {0, 100}}}},
+		{"Collapsed ring dropped", Paths64{{{0, 0}, {2, 0}, {2, 2}}, square}, 0, 3, Paths64{square}},
+		{"Zero-area ring dropped", Paths64{{{0, 0}, {5, 0}, {10, 0}}}, 0, 0, Paths64{}},
+		{"Disabled", Paths64{square, sliver}, 0, 0, Paths64{square, sliver}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CleanPaths64(tt.paths, tt.minArea, tt.minEdgeLen); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestBooleanOp64Cleaning(t *testing.T) {
+	// Two parcels that overlap by one unit along a shared side
+	a := Paths64{{{0, 0}, {100, 0}, {100, 100}, {0, 100}}}
+	b := Paths64{{{99, 0}, {200, 0}, {200, 100}, {99, 100}}}
+	raw, err := Intersect64(a, b, NonZero)
+	if err != nil || len(raw) != 1 {
+		t.Fatalf("expected a sliver, got %v (err %v)", raw, err)
+	}
+
+	cleaned, _, err := BooleanOp64(Intersection, NonZero, a, nil, b, WithCleaning(200, 0))
+	if err != nil || len(cleaned) != 0 {
+		t.Errorf("expected the sliver removed, got %v (err %v)", cleaned, err)
+	}
+
+	for _, opts := range []ClipperOptions{{MinArea: -1}, {MinEdgeLength: -1}} {
+		if _, _, err := BooleanOp64(Union, NonZero, a, nil, b, opts); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("%+v: expected ErrInvalidInput, got %v", opts, err)
+		}
+	}
+}
@@ -2,7 +2,14 @@
 // This is a port of the Clipper2 library (https://github.com/AngusJohnson/Clipper2).
 package clipper
 
-// Union64 returns the union of subject and clip polygons
+import (
+	"context"
+	"errors"
+	"math"
+)
+
+// Union64 returns the union of subject and clip polygons. With nil clips it
+// resolves the self-intersections of subjects (see SimplifySelfIntersections64).
 func Union64(subjects, clips Paths64, fillRule FillRule) (Paths64, error) {
 	result, _, err := BooleanOp64(Union, fillRule, subjects, nil, clips)
 	return result, err
@@ -26,38 +33,376 @@ func Xor64(subjects, clips Paths64, fillRule FillRule) (Paths64, error) {
 	return result, err
 }
 
-// BooleanOp64 performs the specified boolean operation on the input polygons
-func BooleanOp64(clipType ClipType, fillRule FillRule, subjects, subjectsOpen, clips Paths64) (solution, solutionOpen Paths64, err error) {
-	return booleanOp64Impl(clipType, fillRule, subjects, subjectsOpen, clips)
+// DifferenceWithRemainder64 splits subjects by clips into the part outside
+// the clips (subject - clip) and the part removed by them (subject ∩ clip).
+// The pure Go engine classifies every region once, in a single sweep that
+// builds both results, so together they cover exactly the filled subject
+// region. opts apply as for BooleanOp64.
+func DifferenceWithRemainder64(subjects, clips Paths64, fillRule FillRule, opts ...Option) (difference, remainder Paths64, err error) {
+	s := resolveOptions(opts)
+	options := s.clipper
+	if s.fillRule != nil {
+		fillRule = *s.fillRule
+	}
+	subjects, _, clips, err = prepareBooleanOp64(s, subjects, nil, clips)
+	if err != nil {
+		return nil, nil, err
+	}
+	if options.Rounding != nil || options.PreserveCollinear || s.ctx != nil || s.tracer != nil || s.budget != (SweepBudget{}) {
+		ve := NewVattiEngine(Difference, fillRule)
+		s.attach(ve)
+		difference, remainder, err = ve.executeDifferenceWithRemainder(subjects, clips)
+	} else {
+		difference, remainder, err = engineDifferenceWithRemainder64(fillRule, subjects, clips)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if difference, _, err = finishBooleanOp64(Difference, fillRule, subjects, clips, options, difference, nil, nil); err != nil {
+		return nil, nil, err
+	}
+	if remainder, _, err = finishBooleanOp64(Intersection, fillRule, subjects, clips, options, remainder, nil, nil); err != nil {
+		return nil, nil, err
+	}
+	return difference, remainder, nil
 }
 
-// InflatePaths64 inflates (offsets) paths by the specified delta
-func InflatePaths64(paths Paths64, delta float64, joinType JoinType, endType EndType, opts ...OffsetOptions) (Paths64, error) {
-	var options OffsetOptions
-	if len(opts) > 0 {
-		options = opts[0]
+// BooleanOp64 performs the specified boolean operation on the input polygons.
+// opts are usually a ClipperOptions or With* options (see Option).
+func BooleanOp64(clipType ClipType, fillRule FillRule, subjects, subjectsOpen, clips Paths64, opts ...Option) (solution, solutionOpen Paths64, err error) {
+	return booleanOp64(nil, clipType, fillRule, subjects, subjectsOpen, clips, opts...)
+}
+
+// booleanOp64 is BooleanOp64 sweeping on ve whenever the pure Go engine
+// runs, so a caller performing many operations reuses its buffers; a nil ve
+// allocates a new engine
+func booleanOp64(ve *VattiEngine, clipType ClipType, fillRule FillRule, subjects, subjectsOpen, clips Paths64, opts ...Option) (solution, solutionOpen Paths64, err error) {
+	s := resolveOptions(opts)
+	options := s.clipper
+	if s.fillRule != nil {
+		fillRule = *s.fillRule
+	}
+	subjects, subjectsOpen, clips, err = prepareBooleanOp64(s, subjects, subjectsOpen, clips)
+	if err != nil {
+		return nil, nil, err
+	}
+	if options.Rounding != nil || options.PreserveCollinear || s.ctx != nil || s.tracer != nil || s.budget != (SweepBudget{}) {
+		ve = renewVattiEngine(ve, clipType, fillRule)
+		s.attach(ve)
+		solution, solutionOpen, err = ve.ExecuteClipping(subjects, subjectsOpen, clips)
 	} else {
-		options = OffsetOptions{
-			MiterLimit:   2.0,
-			ArcTolerance: 0.25,
+		solution, solutionOpen, err = engineBooleanOp64On(ve, clipType, fillRule, subjects, subjectsOpen, clips)
+	}
+	return finishBooleanOp64(clipType, fillRule, subjects, clips, options, solution, solutionOpen, err)
+}
+
+// prepareBooleanOp64 checks the settings of a boolean operation and applies
+// those that rewrite the input
+func prepareBooleanOp64(s settings, subjects, subjectsOpen, clips Paths64) (Paths64, Paths64, Paths64, error) {
+	if err := checkContext(s.ctx); err != nil {
+		return nil, nil, nil, err
+	}
+	options := s.clipper
+	if err := validateClipperOptions(options); err != nil {
+		return nil, nil, nil, err
+	}
+	subjects, err := applyRingClosure(s.closure, "subject", subjects)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if clips, err = applyRingClosure(s.closure, "clip", clips); err != nil {
+		return nil, nil, nil, err
+	}
+	subjects, subjectsOpen, clips, err = applyZeroAreaPolicy(options.ZeroAreaRings, subjects, subjectsOpen, clips)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	subjects = sanitizeRings(subjects, options.SkipSanitize)
+	clips = sanitizeRings(clips, options.SkipSanitize)
+	if options.PreNodeSelfIntersections {
+		subjects = nodeSelfIntersections(subjects)
+		clips = nodeSelfIntersections(clips)
+	}
+	return subjects, subjectsOpen, clips, nil
+}
+
+// validateClipperOptions rejects boolean operation settings out of range
+func validateClipperOptions(options ClipperOptions) error {
+	if options.WeldTolerance < 0 || options.MaxOutputVertices < 0 {
+		return ErrInvalidInput
+	}
+	if !(options.MinArea >= 0) || math.IsInf(options.MinArea, 0) || options.MinEdgeLength < 0 {
+		return ErrInvalidInput
+	}
+	if g, ok := options.Rounding.(GridRounding); ok && g.Spacing < 1 {
+		return ErrInvalidInput
+	}
+	return nil
+}
+
+// finishBooleanOp64 applies the output options to the result of a sweep over
+// the preprocessed subjects and clips
+func finishBooleanOp64(clipType ClipType, fillRule FillRule, subjects, clips Paths64, options ClipperOptions,
+	solution, solutionOpen Paths64, err error) (Paths64, Paths64, error) {
+	if err != nil {
+		if options.PartialResults && errors.Is(err, ErrClipperExecution) && solution != nil {
+			return solution, solutionOpen, err
+		}
+		return nil, nil, err
+	}
+	solution = orderOutersBeforeHoles(solution)
+	if options.SharedEdges == SharedEdgesInclude {
+		lines, err := sharedBoundaryLines(clipType, fillRule, subjects, clips)
+		if err != nil {
+			return nil, nil, err
+		}
+		solutionOpen = append(solutionOpen, lines...)
+	}
+	if options.WeldTolerance > 0 {
+		solution, solutionOpen = weldPaths(solution, solutionOpen, options.WeldTolerance)
+	}
+	if options.MinArea > 0 || options.MinEdgeLength > 0 {
+		solution = CleanPaths64(solution, options.MinArea, options.MinEdgeLength)
+	}
+	if options.KeepTouchingPointsAsVertices {
+		solution = insertTouchingVertices(solution)
+	}
+	if options.MaxOutputVertices > 0 {
+		solution, solutionOpen, err = applyOutputBudget(options, solution, solutionOpen)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	if options.ReverseSolution {
+		solution, solutionOpen = reversePaths64(solution), reversePaths64(solutionOpen)
+	}
+	return solution, solutionOpen, nil
+}
+
+// reversePaths64 reverses every path, for ClipperOptions.ReverseSolution.
+// Solutions are nested and ordered with their usual orientation, so this is
+// the last step.
+func reversePaths64(paths Paths64) Paths64 {
+	for i, path := range paths {
+		paths[i] = Reverse64(path)
+	}
+	return paths
+}
+
+// BooleanOp64Ctx is BooleanOp64 aborted when ctx is cancelled or times out,
+// the same as passing WithContext(ctx). The sweep checks ctx after every
+// scanbeam, so even a pathological input stops promptly; the error then
+// matches both ErrClipperExecution and ctx.Err().
+func BooleanOp64Ctx(ctx context.Context, clipType ClipType, fillRule FillRule, subjects, subjectsOpen, clips Paths64, opts ...Option) (solution, solutionOpen Paths64, err error) {
+	return BooleanOp64(clipType, fillRule, subjects, subjectsOpen, clips, append(opts[:len(opts):len(opts)], WithContext(ctx))...)
+}
+
+// BooleanOp64TreeCtx is BooleanOp64Tree aborted when ctx is done, like
+// BooleanOp64Ctx
+func BooleanOp64TreeCtx(ctx context.Context, clipType ClipType, fillRule FillRule, subjects, subjectsOpen, clips Paths64, opts ...Option) (solution *PolyTree64, solutionOpen Paths64, err error) {
+	return BooleanOp64Tree(clipType, fillRule, subjects, subjectsOpen, clips, append(opts[:len(opts):len(opts)], WithContext(ctx))...)
+}
+
+// BooleanOp64Tree performs the specified boolean operation and returns the closed
+// solution as a PolyTree64 preserving the outer/hole hierarchy
+func BooleanOp64Tree(clipType ClipType, fillRule FillRule, subjects, subjectsOpen, clips Paths64, opts ...Option) (solution *PolyTree64, solutionOpen Paths64, err error) {
+	closed, solutionOpen, err := BooleanOp64(clipType, fillRule, subjects, subjectsOpen, clips, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return buildPolyTree64(closed), solutionOpen, nil
+}
+
+// BooleanOp64Func performs the specified boolean operation on closed paths
+// and passes every ring of the solution to emit during the sweep, with its
+// nesting, instead of building the solution. A ring is handed over once the
+// rings that might enclose it are finished and its parent has been handed
+// over, so a ring is held only while a ring around it, or the next one to its
+// left, is unfinished, and peak memory stays flat for huge results of many
+// separate polygons. parentIdx is the position in the emitted sequence of the
+// ring directly enclosing ring, or -1 for a top-level outer; a parent always
+// precedes its children, but other rings may come in between. Rings are
+// cleaned as by BooleanOp64Incremental, without the solution-wide
+// normalization and output options, and the sweep always runs on the pure Go
+// engine. An error from emit stops the sweep and is returned.
+func BooleanOp64Func(clipType ClipType, fillRule FillRule, subjects, clips Paths64, emit func(ring Path64, isHole bool, parentIdx int) error, opts ...Option) error {
+	s := resolveOptions(opts)
+	if s.fillRule != nil {
+		fillRule = *s.fillRule
+	}
+	subjects, _, clips, err := prepareBooleanOp64(s, subjects, nil, clips)
+	if err != nil {
+		return err
+	}
+	ve := NewVattiEngine(clipType, fillRule)
+	s.attach(ve)
+	return ve.executeNesting(subjects, clips, emit)
+}
+
+// BooleanOp64Incremental performs the specified boolean operation and
+// passes every output path to emit as soon as the sweep has finished it,
+// releasing its points before the sweep goes on. Peak memory then grows with
+// the rings open at one scanline rather than with the whole solution, which
+// bounds the memory of huge unions of many small disjoint polygons feeding a
+// rasterizer. Paths arrive bottom to top in the order they close, so holes
+// can precede their outers; BooleanOp64Func reports nesting instead. A ring
+// touching an unfinished one along a horizontal edge is held until the two
+// are joined. Each ring is cleaned like in BooleanOp64, but the
+// solution-wide normalization and the output options (welding, touching
+// points, shared edges, budgets) need the whole solution and do not apply.
+// It always runs on the pure Go engine. An error from emit stops the sweep
+// and is returned.
+func BooleanOp64Incremental(clipType ClipType, fillRule FillRule, subjects, subjectsOpen, clips Paths64, emit func(path Path64, isOpen bool) error, opts ...Option) error {
+	s := resolveOptions(opts)
+	if s.fillRule != nil {
+		fillRule = *s.fillRule
+	}
+	subjects, subjectsOpen, clips, err := prepareBooleanOp64(s, subjects, subjectsOpen, clips)
+	if err != nil {
+		return err
+	}
+	ve := NewVattiEngine(clipType, fillRule)
+	s.attach(ve)
+	return ve.executeFlushing(subjects, subjectsOpen, clips, emit)
+}
+
+// AreaOfBooleanOp64 returns the area of the region produced by a boolean
+// operation without building the output polygons. The input is prepared as
+// for BooleanOp64 and holes are subtracted, so the result is the summed
+// signed area of BooleanOp64's solution to within 1e-9 relative (default
+// output options such as MinArea are not applied).
+func AreaOfBooleanOp64(clipType ClipType, fillRule FillRule, subjects, clips Paths64) (float64, error) {
+	return areaOfBooleanOp64(resolveOptions(nil), clipType, fillRule, subjects, clips)
+}
+
+// areaOfBooleanOp64 is AreaOfBooleanOp64 with settings s, running on the pure
+// Go engine when s needs it like booleanOp64
+func areaOfBooleanOp64(s settings, clipType ClipType, fillRule FillRule, subjects, clips Paths64) (float64, error) {
+	options := s.clipper
+	subjects, _, clips, err := prepareBooleanOp64(s, subjects, nil, clips)
+	if err != nil {
+		return 0, err
+	}
+	if options.Rounding != nil || options.PreserveCollinear || s.ctx != nil || s.tracer != nil || s.budget != (SweepBudget{}) {
+		ve := NewVattiEngine(clipType, fillRule)
+		s.attach(ve)
+		return ve.ExecuteArea(subjects, clips)
+	}
+	return engineAreaOfBooleanOp64(clipType, fillRule, subjects, clips)
+}
+
+// InflatePaths64 inflates (offsets) paths by the specified delta. opts are
+// usually an OffsetOptions or With* options (see Option).
+//
+// The pure Go engine does not offset yet, so this needs the C++ library:
+// either a build with -tags=clipper_cgo, or port/cgoengine registered and
+// selected with SetEngine. Otherwise it returns ErrNotImplemented.
+func InflatePaths64(paths Paths64, delta float64, joinType JoinType, endType EndType, opts ...Option) (Paths64, error) {
+	s := resolveOptions(opts)
+	if err := checkContext(s.ctx); err != nil {
+		return nil, err
+	}
+	options := s.offset
+	if endType == ClosedPolygon || endType == ClosedLine {
+		var err error
+		if paths, err = applyRingClosure(s.closure, "input", paths); err != nil {
+			return nil, err
 		}
 	}
-	return inflatePathsImpl(paths, delta, joinType, endType, options)
+	options.ArcTolerance = cornerLimitedArcTolerance(delta, options)
+	if endType == ClosedPolygon && options.OrientByContainment {
+		paths = orientByContainment(paths)
+	}
+	return engineInflatePaths(paths, delta, joinType, endType, options)
+}
+
+// InflatePaths64Tree is InflatePaths64 returning the result as a PolyTree64,
+// like Clipper2's ClipperOffset::Execute(delta, PolyTree64&), so outer
+// boundaries and the holes inside them can be told apart directly. It needs
+// the C++ library as InflatePaths64 does.
+func InflatePaths64Tree(paths Paths64, delta float64, joinType JoinType, endType EndType, opts ...Option) (*PolyTree64, error) {
+	solution, err := InflatePaths64(paths, delta, joinType, endType, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return buildPolyTree64(solution), nil
 }
 
-// RectClip64 clips paths against a rectangular window
-func RectClip64(rect Path64, paths Paths64) (Paths64, error) {
+// RectClip64 clips paths against a rectangular window given by its four
+// corners, in any order and orientation. Closed paths are clipped as rings
+// that keep their orientation, so holes in the input remain holes in the
+// output. Of the options only WithContext and WithRingClosure apply.
+//
+// The pure Go build also merges rings that end up covering the whole window,
+// so an outer polygon and a hole surrounding the window cancel out. With
+// -tags=clipper_cgo the C++ RectClip64 runs instead; it clips every ring on
+// its own and returns such covers as they are.
+func RectClip64(rect Path64, paths Paths64, opts ...Option) (Paths64, error) {
 	if len(rect) != 4 {
 		return nil, ErrInvalidRectangle
 	}
-	return rectClipImpl(rect, paths)
+	return RectClipRect64(Bounds64(rect), paths, opts...)
+}
+
+// RectClipRect64 is RectClip64 for a Rect64 window. Left and Right, like Top
+// and Bottom, may come in either order; InvalidRect64 clips everything away.
+// In the pure Go build paths lying entirely inside the window are kept
+// without being copied, and if every path does so the input itself is
+// returned, so clone the result before mutating it; the clipper_cgo build
+// always returns fresh paths.
+func RectClipRect64(rect Rect64, paths Paths64, opts ...Option) (Paths64, error) {
+	s := resolveOptions(opts)
+	if err := checkContext(s.ctx); err != nil {
+		return nil, err
+	}
+	if rect == InvalidRect64 {
+		return Paths64{}, nil
+	}
+	if rect.Left > rect.Right {
+		rect.Left, rect.Right = rect.Right, rect.Left
+	}
+	if rect.Top > rect.Bottom {
+		rect.Top, rect.Bottom = rect.Bottom, rect.Top
+	}
+	paths, err := applyRingClosure(s.closure, "input", paths)
+	if err != nil {
+		return nil, err
+	}
+	return rectClipImpl(rect.AsPath(), paths)
 }
 
-// Area64 calculates the area of a path
+// areaFloatMaxCoord is the largest coordinate magnitude for which each
+// product of the shoelace formula converts to float64 exactly. Only the
+// products are exact: summing them in float64 still rounds once the running
+// total needs more than 53 bits.
+const areaFloatMaxCoord = 1 << 26
+
+// Area64 calculates the area of a path. The pure Go build always sums with
+// Area128. With -tags=clipper_cgo paths within areaFloatMaxCoord are summed in
+// float64, and paths with a coordinate beyond it fall back to Area128, so
+// products too large for int64 or float64 cannot flip the sign (and with it
+// the orientation) of rings far from the origin.
 func Area64(path Path64) float64 {
+	for _, pt := range path {
+		if pt.X > areaFloatMaxCoord || pt.X < -areaFloatMaxCoord ||
+			pt.Y > areaFloatMaxCoord || pt.Y < -areaFloatMaxCoord {
+			return Area128(path).ToFloat64() / 2
+		}
+	}
 	return areaImpl(path)
 }
 
+// AreaPaths64 returns the summed signed area of paths. With outers
+// counter-clockwise and holes clockwise, as in boolean operation output, this
+// is the area of the region they bound, holes subtracted.
+func AreaPaths64(paths Paths64) float64 {
+	area := 0.0
+	for _, path := range paths {
+		area += Area64(path)
+	}
+	return area
+}
+
 // IsPositive64 returns true if the path has positive orientation (counter-clockwise)
 func IsPositive64(path Path64) bool {
 	return Area64(path) > 0
@@ -0,0 +1,220 @@
+package clipper
+
+import "slices"
+
+// ==============================================================================
+// Incremental Clipper
+// ==============================================================================
+
+// Clipper64 holds subjects and clips for any number of boolean operations,
+// like the Clipper64 class of Clipper2. Added paths are converted to vertex
+// chains and local minima once; every Execute reuses them, so a viewer
+// toggling between clip types or fill rules over a large input only pays for
+// the sweep. A Clipper64 must not be used from several goroutines at once.
+//
+// Execute runs the pure Go engine on the stored vertex lists. When the
+// selected engine is not pure Go, or an option has to rewrite the input
+// (WithRingClosure, ZeroAreaRings other than ZeroAreaDrop,
+// PreNodeSelfIntersections or SkipSanitize), or edges of the closed paths
+// cross and have to be snap rounded first, it passes the stored paths to
+// BooleanOp64 instead, with the same result.
+type Clipper64 struct {
+	data     PreparedPaths64 // paths added directly or through AddReuseableData
+	sorted   []*LocalMinima  // all minima sorted for the sweep, nil after an Add
+	crossing bool            // edges of the closed paths cross, set with sorted
+}
+
+// NewClipper64 creates an empty Clipper64
+func NewClipper64() *Clipper64 {
+	return &Clipper64{}
+}
+
+// AddSubject adds closed subject paths. Paths rejected by the engine return
+// ErrInvalidInput and leave the Clipper64 unchanged.
+func (c *Clipper64) AddSubject(paths Paths64) error {
+	c.sorted = nil
+	return c.data.AddSubject(paths)
+}
+
+// AddOpenSubject adds open subject paths
+func (c *Clipper64) AddOpenSubject(paths Paths64) error {
+	c.sorted = nil
+	return c.data.AddOpenSubject(paths)
+}
+
+// AddClip adds closed clip paths
+func (c *Clipper64) AddClip(paths Paths64) error {
+	c.sorted = nil
+	return c.data.AddClip(paths)
+}
+
+// AddReuseableData adds the paths of a PreparedPaths64, like Clipper2's
+// Clipper64::AddReuseableData. Their vertex chains are shared rather than
+// copied, so adding them costs no more than copying their local minima.
+func (c *Clipper64) AddReuseableData(p *PreparedPaths64) {
+	for group := range c.data.paths {
+		c.data.paths[group] = append(c.data.paths[group], p.paths[group]...)
+		// The copies number p's vertices after those already added
+		copies := make([]LocalMinima, len(p.minima[group]))
+		for i, lm := range p.minima[group] {
+			copies[i] = *lm
+			copies[i].vertexBase += c.data.vertices
+			c.data.minima[group] = append(c.data.minima[group], &copies[i])
+		}
+	}
+	c.data.scanlines = append(c.data.scanlines, p.scanlines...)
+	c.data.vertices += p.vertices
+	c.data.hasOpen = c.data.hasOpen || p.hasOpen
+	c.sorted = nil
+}
+
+// Clear removes all paths
+func (c *Clipper64) Clear() {
+	*c = Clipper64{}
+}
+
+// Execute performs a boolean operation on the paths added so far, with the
+// same result and options as BooleanOp64. It can be called any number of
+// times, and paths can be added between calls.
+func (c *Clipper64) Execute(clipType ClipType, fillRule FillRule, opts ...Option) (solution, solutionOpen Paths64, err error) {
+	s := resolveOptions(opts)
+	options := s.clipper
+	d := &c.data
+	reusable := c.reusable(s)
+	if reusable && c.sorted == nil {
+		c.sorted = make([]*LocalMinima, 0, len(d.minima[0])+len(d.minima[1])+len(d.minima[2]))
+		for _, group := range d.minima {
+			c.sorted = append(c.sorted, group...)
+		}
+		slices.Sort(d.scanlines)
+		d.scanlines = slices.Compact(d.scanlines)
+		c.crossing = ringsCross(d.paths[clipperSubjects], d.paths[clipperClips])
+	}
+	// The stored vertex chains are not snap rounded, so crossing input the
+	// engine would snap goes through BooleanOp64
+	if !reusable || (c.crossing && snapsCrossings(s.clipper.Rounding)) {
+		return BooleanOp64(clipType, fillRule, d.paths[clipperSubjects], d.paths[clipperSubjectsOpen], d.paths[clipperClips], opts...)
+	}
+	if s.fillRule != nil {
+		fillRule = *s.fillRule
+	}
+	if err := checkContext(s.ctx); err != nil {
+		return nil, nil, err
+	}
+	if err := validateClipperOptions(options); err != nil {
+		return nil, nil, err
+	}
+
+	ve := NewVattiEngine(clipType, fillRule)
+	s.attach(ve)
+	ve.budget = ve.budget.resolve(d.vertices)
+	ve.vertexCount = d.vertices
+	ve.minimaList = c.sorted
+	ve.scanlines = d.scanlines
+	ve.hasOpenPaths = d.hasOpen
+	solution, solutionOpen, err = ve.execute()
+	return finishBooleanOp64(clipType, fillRule, d.paths[clipperSubjects], d.paths[clipperClips], options, solution, solutionOpen, err)
+}
+
+// ExecuteTree is Execute returning the closed solution as a PolyTree64
+func (c *Clipper64) ExecuteTree(clipType ClipType, fillRule FillRule, opts ...Option) (solution *PolyTree64, solutionOpen Paths64, err error) {
+	closed, solutionOpen, err := c.Execute(clipType, fillRule, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return buildPolyTree64(closed), solutionOpen, nil
+}
+
+// reusable reports whether an operation with settings s can run on the
+// stored vertex lists
+func (c *Clipper64) reusable(s settings) bool {
+	if s.closure != RingClosureImplicit || s.clipper.ZeroAreaRings != ZeroAreaDrop || s.clipper.PreNodeSelfIntersections || s.clipper.SkipSanitize {
+		return false
+	}
+	if s.clipper.Rounding != nil || s.clipper.PreserveCollinear || s.ctx != nil || s.tracer != nil || s.budget != (SweepBudget{}) {
+		return true // BooleanOp64 runs these on the pure Go engine too
+	}
+	switch engine, _ := selectedEngine(); engine {
+	case EngineGo:
+		return true
+	case EngineDefault:
+		return defaultEngineIsGo
+	}
+	return false
+}
+
+// ==============================================================================
+// Reusable Data
+// ==============================================================================
+
+// PreparedPaths64 holds paths converted to vertex chains and local minima,
+// like Clipper2's ReuseableDataContainer64. Preparing a large static input
+// once, such as a country boundary that many subjects are clipped against,
+// and passing it to Clipper64.AddReuseableData skips that conversion for
+// every operation. The vertex chains are shared by every Clipper64 they are
+// added to and are only read by their sweeps, so those Clipper64s may
+// execute concurrently, but no Clipper64 may add the same PreparedPaths64
+// twice.
+type PreparedPaths64 struct {
+	paths     [3]Paths64        // subjects, open subjects and clips as added
+	minima    [3][]*LocalMinima // local minima of each group, in the order added
+	scanlines []int64           // Y of every vertex
+	vertices  int               // number of vertices, which sizes the default sweep budget
+	hasOpen   bool              // true if an open subject has a local minimum
+}
+
+// Groups of PreparedPaths64.paths and PreparedPaths64.minima, in the order
+// BooleanOp64 adds them to the engine
+const (
+	clipperSubjects = iota
+	clipperSubjectsOpen
+	clipperClips
+)
+
+// NewPreparedPaths64 creates an empty PreparedPaths64
+func NewPreparedPaths64() *PreparedPaths64 {
+	return &PreparedPaths64{}
+}
+
+// AddSubject prepares closed subject paths. Paths rejected by the engine
+// return ErrInvalidInput and leave p unchanged.
+func (p *PreparedPaths64) AddSubject(paths Paths64) error {
+	return p.add(clipperSubjects, PathTypeSubject, paths)
+}
+
+// AddOpenSubject prepares open subject paths
+func (p *PreparedPaths64) AddOpenSubject(paths Paths64) error {
+	return p.add(clipperSubjectsOpen, PathTypeSubject, paths)
+}
+
+// AddClip prepares closed clip paths
+func (p *PreparedPaths64) AddClip(paths Paths64) error {
+	return p.add(clipperClips, PathTypeClip, paths)
+}
+
+// Clear removes all paths. Clipper64s they were added to keep them.
+func (p *PreparedPaths64) Clear() {
+	*p = PreparedPaths64{}
+}
+
+// add converts paths to vertex chains on a scratch engine and keeps its
+// local minima
+func (p *PreparedPaths64) add(group int, pathType PathType, paths Paths64) error {
+	chains := paths
+	if group != clipperSubjectsOpen {
+		chains = sanitizeRings(paths, false)
+	}
+	ve := NewVattiEngine(Union, NonZero)
+	ve.vertexCount = p.vertices // number the vertices after those already prepared
+	if err := ve.addPaths(chains, pathType, group == clipperSubjectsOpen); err != nil {
+		return err
+	}
+	p.minima[group] = append(p.minima[group], ve.minimaList...)
+	p.scanlines = append(p.scanlines, ve.scanlines...)
+	p.vertices = ve.vertexCount
+	p.hasOpen = p.hasOpen || ve.hasOpenPaths
+	for _, path := range paths {
+		p.paths[group] = append(p.paths[group], append(Path64(nil), path...))
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package clipper
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+// TestClipper64MatchesBooleanOp64 tests repeated executions against BooleanOp64
+func TestClipper64MatchesBooleanOp64(t *testing.T) {
+	subjects := Paths64{
+		{{0, 0}, {100, 0}, {100, 100}, {0, 100}},
+		{{20, 20}, {80, 20}, {50, 120}},
+	}
+	subjectsOpen := Paths64{{{-10, 50}, {50, 60}, {110, 40}}}
+	clips := Paths64{
+		{{50, -20}, {150, 30}, {60, 150}},
+		{{70, 70}, {90, 70}, {90, 90}, {70, 90}},
+	}
+
+	c := NewClipper64()
+	if err := c.AddSubject(subjects); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.AddOpenSubject(subjectsOpen); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.AddClip(clips); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for pass := 0; pass < 2; pass++ {
+		for _, clipType := range []ClipType{Intersection, Union, Difference, Xor} {
+			for _, fillRule := range []FillRule{EvenOdd, NonZero} {
+				expected, expectedOpen, err := BooleanOp64(clipType, fillRule, subjects, subjectsOpen, clips)
+				if err != nil {
+					t.Fatalf("BooleanOp64(%v, %v): %v", clipType, fillRule, err)
+				}
+				got, gotOpen, err := c.Execute(clipType, fillRule)
+				if err != nil {
+					t.Fatalf("Execute(%v, %v): %v", clipType, fillRule, err)
+				}
+				if !sameRings(got, expected) || !sameRings(gotOpen, expectedOpen) {
+					t.Errorf("pass %d, %v %v: expected %v %v, got %v %v",
+						pass, clipType, fillRule, expected, expectedOpen, got, gotOpen)
+				}
+			}
+		}
+	}
+}
+
+// TestClipper64AddAfterExecute tests that paths added later join the next execution
+func TestClipper64AddAfterExecute(t *testing.T) {
+	a := Paths64{{{0, 0}, {100, 0}, {100, 100}, {0, 100}}}
+	b := Paths64{{{50, 50}, {150, 50}, {150, 150}, {50, 150}}}
+
+	c := NewClipper64()
+	_ = c.AddSubject(a)
+	got, _, err := c.Execute(Union, NonZero)
+	if err != nil || !sameRings(got, a) {
+		t.Errorf("expected %v, got %v (err %v)", a, got, err)
+	}
+
+	_ = c.AddSubject(b)
+	expected, _, _ := BooleanOp64(Union, NonZero, append(a, b...), nil, nil)
+	got, _, err = c.Execute(Union, NonZero)
+	if err != nil || !sameRings(got, expected) {
+		t.Errorf("expected %v, got %v (err %v)", expected, got, err)
+	}
+
+	c.Clear()
+	got, gotOpen, err := c.Execute(Union, NonZero)
+	if err != nil || len(got) != 0 || len(gotOpen) != 0 {
+		t.Errorf("expected an empty solution after Clear, got %v %v (err %v)", got, gotOpen, err)
+	}
+}
+
+// TestClipper64Options tests options, including those that fall back to BooleanOp64
+func TestClipper64Options(t *testing.T) {
+	bowtie := Paths64{{{0, 0}, {100, 100}, {100, 0}, {0, 100}}}
+	clip := Paths64{{{20, -10}, {80, -10}, {80, 110}, {20, 110}}}
+
+	c := NewClipper64()
+	_ = c.AddSubject(bowtie)
+	_ = c.AddClip(clip)
+	for _, opts := range [][]Option{
+		{WithPreNodeSelfIntersections(true)},
+		{WithFillRule(EvenOdd), WithWeldTolerance(1)},
+		{WithRounding(GridRounding{Spacing: 10})},
+		{WithRingClosure(RingClosureAutoClose)},
+	} {
+		expected, _, err := BooleanOp64(Intersection, NonZero, bowtie, nil, clip, opts...)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got, _, err := c.Execute(Intersection, NonZero, opts...)
+		if err != nil || !sameRings(got, expected) {
+			t.Errorf("expected %v, got %v (err %v)", expected, got, err)
+		}
+	}
+
+	if _, _, err := c.Execute(Union, NonZero, WithWeldTolerance(-1)); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, got %v", err)
+	}
+
+	tree, _, err := c.ExecuteTree(Union, NonZero)
+	if err != nil || tree == nil || len(tree.Children) == 0 {
+		t.Errorf("expected a tree with outers, got %v (err %v)", tree, err)
+	}
+}
+
+// TestPreparedPaths64 tests clipping several subjects against prepared clips
+func TestPreparedPaths64(t *testing.T) {
+	clips := Paths64{RegularPolygon64(Point64{0, 0}, 1000, 500, 0), {{-100, -100}, {100, -100}, {100, 100}, {-100, 100}}}
+	prepared := NewPreparedPaths64()
+	if err := prepared.AddClip(clips); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i, subjects := range []Paths64{
+		{{{500, 500}, {1500, 500}, {1500, 1500}, {500, 1500}}},
+		{{{-2000, -10}, {2000, -10}, {2000, 10}, {-2000, 10}}},
+		{{{-50, -50}, {50, -50}, {0, 50}}},
+	} {
+		subjectsOpen := Paths64{{{-1500, int64(i * 100)}, {1500, int64(i*100 + 50)}}}
+		c := NewClipper64()
+		c.AddReuseableData(prepared)
+		if err := c.AddSubject(subjects); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := c.AddOpenSubject(subjectsOpen); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		for _, clipType := range []ClipType{Intersection, Union, Difference, Xor} {
+			expected, expectedOpen, err := BooleanOp64(clipType, NonZero, subjects, subjectsOpen, clips)
+			if err != nil {
+				t.Fatalf("BooleanOp64(%v): %v", clipType, err)
+			}
+			got, gotOpen, err := c.Execute(clipType, NonZero)
+			if err != nil {
+				t.Fatalf("Execute(%v): %v", clipType, err)
+			}
+			if !sameRings(got, expected) || !sameRings(gotOpen, expectedOpen) {
+				t.Errorf("subject %d, %v: expected %v %v, got %v %v", i, clipType, expected, expectedOpen, got, gotOpen)
+			}
+		}
+	}
+
+	// clearing the prepared paths leaves Clipper64s holding them unchanged
+	c := NewClipper64()
+	c.AddReuseableData(prepared)
+	prepared.Clear()
+	expected, _, _ := BooleanOp64(Union, NonZero, nil, nil, clips)
+	got, _, err := c.Execute(Union, NonZero)
+	if err != nil || !sameRings(got, expected) {
+		t.Errorf("expected %v after Clear, got %v (err %v)", expected, got, err)
+	}
+
+	// options that rewrite the input fall back to BooleanOp64 on the stored paths
+	got, _, err = c.Execute(Union, NonZero, WithRingClosure(RingClosureAutoClose))
+	if err != nil || !sameRings(got, expected) {
+		t.Errorf("expected %v through BooleanOp64, got %v (err %v)", expected, got, err)
+	}
+}
+
+// TestPreparedPaths64Concurrent tests prepared paths executed by several
+// Clipper64s at once
+func TestPreparedPaths64Concurrent(t *testing.T) {
+	clips := Paths64{RegularPolygon64(Point64{0, 0}, 1000, 500, 0)}
+	prepared := NewPreparedPaths64()
+	if err := prepared.AddClip(clips); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			subjects := Paths64{{{int64(i * 100), -2000}, {int64(i*100 + 50), -2000}, {int64(i*100 + 50), 2000}, {int64(i * 100), 2000}}}
+			c := NewClipper64()
+			c.AddReuseableData(prepared)
+			if err := c.AddSubject(subjects); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			for j := 0; j < 20; j++ {
+				expected, _, _ := BooleanOp64(Intersection, NonZero, subjects, nil, clips)
+				got, _, err := c.Execute(Intersection, NonZero)
+				if err != nil || !sameRings(got, expected) {
+					t.Errorf("subject %d: expected %v, got %v (err %v)", i, expected, got, err)
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
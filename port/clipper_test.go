@@ -2,6 +2,7 @@ package clipper
 
 import (
 	"math"
+	"math/rand"
 	"testing"
 )
 
@@ -92,6 +93,29 @@ func TestArea64(t *testing.T) {
 	}
 }
 
+// TestArea64LargeCoordinates tests exact areas and orientation far from the origin
+func TestArea64LargeCoordinates(t *testing.T) {
+	const c = 1_000_000_000_000_000
+	tests := []struct {
+		name     string
+		path     Path64
+		expected float64
+	}{
+		{"unit triangle", Path64{{c, c}, {c + 1, c}, {c, c + 1}}, 0.5},
+		{"clockwise unit triangle", Path64{{c, c}, {c, c + 1}, {c + 1, c}}, -0.5},
+		{"sliver", Path64{{-c, -c}, {c, c + 1}, {c, c}}, -c},
+		{"collinear", Path64{{-c, -c}, {0, 0}, {c, c}}, 0},
+	}
+	for _, tt := range tests {
+		if got := Area64(tt.path); got != tt.expected {
+			t.Errorf("%s: expected area %v, got %v", tt.name, tt.expected, got)
+		}
+		if got := IsPositive64(tt.path); got != (tt.expected > 0) {
+			t.Errorf("%s: expected IsPositive64 %v, got %v", tt.name, tt.expected > 0, got)
+		}
+	}
+}
+
 func TestIsPositive64(t *testing.T) {
 	// Counter-clockwise square (positive)
 	ccwSquare := Path64{{0, 0}, {10, 0}, {10, 10}, {0, 10}}
@@ -188,6 +212,21 @@ func TestRectClip64InvalidRectangle(t *testing.T) {
 	}
 }
 
+// TestAreaPaths64 tests that clockwise holes subtract from the summed area
+func TestAreaPaths64(t *testing.T) {
+	paths := Paths64{
+		{{0, 0}, {10, 0}, {10, 10}, {0, 10}},
+		{{2, 2}, {2, 4}, {4, 4}, {4, 2}},
+		{{20, 0}, {25, 0}, {25, 2}},
+	}
+	if got := AreaPaths64(paths); got != 100-4+5 {
+		t.Errorf("expected area 101, got %v", got)
+	}
+	if got := AreaPaths64(nil); got != 0 {
+		t.Errorf("expected area 0 for no paths, got %v", got)
+	}
+}
+
 func TestArea64EmptyPath(t *testing.T) {
 	// Test with empty path
 	emptyPath := Path64{}
@@ -204,6 +243,182 @@ func TestArea64EmptyPath(t *testing.T) {
 	}
 }
 
+func TestDifferenceWithRemainder64(t *testing.T) {
+	subject := Paths64{{{0, 0}, {10, 0}, {10, 10}, {0, 10}}}
+	clip := Paths64{{{5, 5}, {15, 5}, {15, 15}, {5, 15}}}
+
+	difference, remainder, err := DifferenceWithRemainder64(subject, clip, NonZero)
+	if err == ErrNotImplemented {
+		t.Skip("Boolean operations not yet implemented in pure Go")
+	}
+	if err != nil {
+		t.Fatalf("DifferenceWithRemainder64 failed: %v", err)
+	}
+	if len(difference) == 0 || len(remainder) == 0 {
+		t.Fatalf("Expected both parts to be non-empty, got %v and %v", difference, remainder)
+	}
+	t.Logf("Difference: %v, remainder: %v", difference, remainder)
+
+	// A clockwise subject is empty under Positive but not under Negative
+	cw := Paths64{Reverse64(subject[0])}
+	difference, remainder, err = DifferenceWithRemainder64(cw, clip, Positive, WithFillRule(Negative))
+	if err != nil {
+		t.Fatalf("DifferenceWithRemainder64 failed: %v", err)
+	}
+	if len(difference) == 0 {
+		t.Errorf("Expected WithFillRule(Negative) to keep the clockwise subject, got %v and %v", difference, remainder)
+	}
+}
+
+func TestAreaOfBooleanOp64(t *testing.T) {
+	subject := Paths64{{{0, 0}, {10, 0}, {10, 10}, {0, 10}}}
+	clip := Paths64{{{5, 5}, {15, 5}, {15, 15}, {5, 15}}}
+	// Clockwise copy of the clip square, so its winding number is -1
+	clipCW := Paths64{Reverse64(clip[0])}
+	// Crossing edges inside a scanbeam: a bow-tie has two triangular lobes
+	bowTie := Paths64{{{0, 0}, {10, 10}, {10, 0}, {0, 10}}}
+
+	tests := []struct {
+		name     string
+		clipType ClipType
+		fillRule FillRule
+		subjects Paths64
+		clips    Paths64
+		expected float64
+	}{
+		{"Union", Union, NonZero, subject, clip, 175},
+		{"Intersection", Intersection, NonZero, subject, clip, 25},
+		{"Difference", Difference, NonZero, subject, clip, 75},
+		{"Xor", Xor, NonZero, subject, clip, 150},
+		{"Annulus", Union, NonZero, Annulus64(Point64{}, 100, 50, 4), nil, 15000},
+		{"Positive ignores clockwise", Union, Positive, subject, clipCW, 100},
+		{"Negative keeps clockwise", Union, Negative, subject, clipCW, 100},
+		{"Bow-tie", Union, EvenOdd, bowTie, nil, 50},
+		{"Empty", Union, NonZero, nil, nil, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			area, err := AreaOfBooleanOp64(test.clipType, test.fillRule, test.subjects, test.clips)
+			if err != nil {
+				t.Fatalf("AreaOfBooleanOp64 failed: %v", err)
+			}
+			if math.Abs(area-test.expected) > 1e-6 {
+				t.Errorf("Expected area %v, got %v", test.expected, area)
+			}
+		})
+	}
+}
+
+// TestAreaOfBooleanOp64MatchesSolution tests that the area-only sweep agrees
+// with the area of BooleanOp64's solution within the documented tolerance
+func TestAreaOfBooleanOp64MatchesSolution(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		span := []int64{20, 1000, 1 << 40}[r.Intn(3)]
+		subjects := Paths64{randomPath(r, 3+r.Intn(12), span), randomPath(r, 3+r.Intn(12), span)}
+		clips := Paths64{randomPath(r, 3+r.Intn(12), span)}
+		clipType, fillRule := ClipType(r.Intn(4)), FillRule(r.Intn(4))
+
+		area, err := AreaOfBooleanOp64(clipType, fillRule, subjects, clips)
+		if err != nil {
+			t.Fatalf("AreaOfBooleanOp64 failed: %v", err)
+		}
+		solution, _, err := BooleanOp64(clipType, fillRule, subjects, nil, clips)
+		if err != nil {
+			t.Fatalf("BooleanOp64 failed: %v", err)
+		}
+		if expected := totalArea(solution); math.Abs(area-expected) > 1e-9*math.Max(1, math.Abs(expected)) {
+			t.Fatalf("%v %v of %v and %v: expected area %v, got %v", clipType, fillRule, subjects, clips, expected, area)
+		}
+	}
+}
+
+// TestExecuteAreaBuildsNoPoints tests that the area-only sweep sums the area
+// without creating output points
+func TestExecuteAreaBuildsNoPoints(t *testing.T) {
+	r := rand.New(rand.NewSource(1697))
+	subjects := Paths64{randomPath(r, 40, 1000), randomPath(r, 40, 1000)}
+	clips := Paths64{randomPath(r, 40, 1000)}
+	ve := NewVattiEngine(Xor, EvenOdd)
+	area, err := ve.ExecuteArea(subjects, clips)
+	if err != nil || area <= 0 || len(ve.outPts.blocks) != 0 {
+		t.Errorf("expected an area without output points, got %v with %d point blocks (err %v)", area, len(ve.outPts.blocks), err)
+	}
+}
+
+// TestPositiveNegativeFillRules tests that Positive and Negative take the sign
+// of the winding number into account: counter-clockwise paths wind +1 and
+// clockwise paths -1
+func TestPositiveNegativeFillRules(t *testing.T) {
+	ccw := Path64{{0, 0}, {10, 0}, {10, 10}, {0, 10}}
+	clip := Path64{{5, 5}, {15, 5}, {15, 15}, {5, 15}}
+	cw, clipCW := Reverse64(ccw), Reverse64(clip)
+
+	tests := []struct {
+		name     string
+		clipType ClipType
+		fillRule FillRule
+		subjects Paths64
+		clips    Paths64
+		expected float64
+	}{
+		{"Positive keeps counter-clockwise", Union, Positive, Paths64{ccw}, nil, 100},
+		{"Positive drops clockwise", Union, Positive, Paths64{cw}, nil, 0},
+		{"Negative keeps clockwise", Union, Negative, Paths64{cw}, nil, 100},
+		{"Negative drops counter-clockwise", Union, Negative, Paths64{ccw}, nil, 0},
+		{"Positive union", Union, Positive, Paths64{ccw}, Paths64{clip}, 175},
+		{"Positive union of mixed", Union, Positive, Paths64{ccw}, Paths64{clipCW}, 100},
+		{"Negative union of mixed", Union, Negative, Paths64{ccw}, Paths64{clipCW}, 100},
+		{"Positive intersection", Intersection, Positive, Paths64{ccw}, Paths64{clip}, 25},
+		{"Negative intersection", Intersection, Negative, Paths64{cw}, Paths64{clipCW}, 25},
+		{"Negative intersection of counter-clockwise", Intersection, Negative, Paths64{ccw}, Paths64{clip}, 0},
+		{"Positive difference of clockwise clip", Difference, Positive, Paths64{ccw}, Paths64{clipCW}, 100},
+		{"Negative difference", Difference, Negative, Paths64{cw}, Paths64{clipCW}, 75},
+		{"Positive xor of mixed", Xor, Positive, Paths64{ccw}, Paths64{clipCW}, 100},
+		// overlapping counter-clockwise and clockwise subjects cancel out
+		{"Positive overlap", Union, Positive, Paths64{ccw, clipCW}, nil, 75},
+		{"Negative overlap", Union, Negative, Paths64{ccw, clipCW}, nil, 75},
+		{"NonZero overlap", Union, NonZero, Paths64{ccw, clipCW}, nil, 150},
+		// the inner ring cancels the outer: winding 0 in the middle
+		{"Positive annulus", Union, Positive, Paths64{ccw, Reverse64(Path64{{2, 2}, {8, 2}, {8, 8}, {2, 8}})}, nil, 64},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			solution, _, err := BooleanOp64(test.clipType, test.fillRule, test.subjects, nil, test.clips)
+			if err != nil {
+				t.Fatalf("BooleanOp64 failed: %v", err)
+			}
+			if area := math.Abs(AreaPaths64(solution)); area != test.expected {
+				t.Errorf("Expected area %v, got %v (%v)", test.expected, area, solution)
+			}
+			area, err := AreaOfBooleanOp64(test.clipType, test.fillRule, test.subjects, test.clips)
+			if err != nil || math.Abs(area-test.expected) > 1e-6 {
+				t.Errorf("Expected AreaOfBooleanOp64 %v, got %v (err %v)", test.expected, area, err)
+			}
+		})
+	}
+
+	// open paths are clipped against the region the fill rule fills
+	line := Paths64{{{-5, 5}, {20, 5}}}
+	for _, test := range []struct {
+		fillRule FillRule
+		clips    Paths64
+		expected int
+	}{
+		{Positive, Paths64{ccw}, 1},
+		{Positive, Paths64{cw}, 0},
+		{Negative, Paths64{cw}, 1},
+		{Negative, Paths64{ccw}, 0},
+	} {
+		_, open, err := BooleanOp64(Intersection, test.fillRule, nil, line, test.clips)
+		if err != nil || len(open) != test.expected {
+			t.Errorf("fill rule %d: expected %d open paths, got %v (err %v)", test.fillRule, test.expected, open, err)
+		}
+	}
+}
+
 // M2 Geometry Kernel Tests
 
 // TestMath128Operations tests the 128-bit math operations
@@ -680,6 +895,63 @@ func TestRectClip64RandomOrientedRectangle(t *testing.T) {
 	t.Logf("Random order rectangle result: %v", result)
 }
 
+// TestRectClipRect64 tests Rect64 windows and the inside/outside fast paths
+func TestRectClipRect64(t *testing.T) {
+	inside := Path64{{2, 2}, {8, 2}, {8, 8}, {2, 8}}
+	outside := Path64{{20, 20}, {30, 20}, {30, 30}}
+	crossing := Path64{{5, 5}, {15, 5}, {15, 15}, {5, 15}}
+
+	tests := []struct {
+		name     string
+		rect     Rect64
+		paths    Paths64
+		expected Paths64
+	}{
+		{"Inside", Rect64{0, 0, 10, 10}, Paths64{inside}, Paths64{inside}},
+		{"Outside", Rect64{0, 0, 10, 10}, Paths64{outside}, Paths64{}},
+		{"Crossing", Rect64{0, 0, 10, 10}, Paths64{crossing}, Paths64{{{5, 5}, {10, 5}, {10, 10}, {5, 10}}}},
+		{"Swapped edges", Rect64{10, 10, 0, 0}, Paths64{outside, crossing, inside}, Paths64{{{5, 5}, {10, 5}, {10, 10}, {5, 10}}, inside}},
+		{"Invalid rectangle", InvalidRect64, Paths64{inside}, Paths64{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RectClipRect64(tt.rect, tt.paths)
+			if err != nil || !sameRings(got, tt.expected) {
+				t.Errorf("expected %v, got %v (err %v)", tt.expected, got, err)
+			}
+		})
+	}
+
+	// Paths wholly inside the window come back without being copied
+	paths := Paths64{inside, Reverse64(Path64{{3, 3}, {4, 3}, {4, 4}})}
+	got, err := RectClipRect64(Rect64{0, 0, 10, 10}, paths)
+	if err != nil || len(got) != 2 || &got[0] != &paths[0] {
+		t.Errorf("expected the input itself, got %v (err %v)", got, err)
+	}
+	got, err = RectClipRect64(Rect64{0, 0, 10, 10}, Paths64{outside, inside})
+	if err != nil || len(got) != 1 || &got[0][0] != &inside[0] {
+		t.Errorf("expected the inside path without a copy, got %v (err %v)", got, err)
+	}
+
+	// A ring tracing the window still merges with a surrounding hole
+	got, err = RectClipRect64(Rect64{0, 0, 10, 10}, Paths64{
+		{{0, 0}, {10, 0}, {10, 10}, {0, 10}},
+		Reverse64(Path64{{-5, -5}, {15, -5}, {15, 15}, {-5, 15}}),
+	})
+	if err != nil || len(got) != 0 {
+		t.Errorf("expected covers to cancel, got %v (err %v)", got, err)
+	}
+
+	// RectClip64 agrees for every corner order
+	corners := Path64{{0, 10}, {10, 0}, {0, 0}, {10, 10}}
+	expected, _ := RectClipRect64(Rect64{0, 0, 10, 10}, Paths64{crossing, outside})
+	got, err = RectClip64(corners, Paths64{crossing, outside})
+	if err != nil || !sameRings(got, expected) {
+		t.Errorf("expected %v, got %v (err %v)", expected, got, err)
+	}
+}
+
 func TestRectClip64RandomPaths(t *testing.T) {
 	// Test with various random rectangles and paths
 	testCases := []struct {
@@ -742,6 +1014,103 @@ func TestRectClip64RandomPaths(t *testing.T) {
 	}
 }
 
+func TestRectClip64Donut(t *testing.T) {
+	outer := Path64{{0, 0}, {100, 0}, {100, 100}, {0, 100}}
+	hole := Reverse64(Path64{{30, 30}, {70, 30}, {70, 70}, {30, 70}})
+	donut := Paths64{outer, hole}
+	reversedDonut := Paths64{Reverse64(outer), Reverse64(hole)}
+	secondDonut := Paths64{
+		{{200, 0}, {260, 0}, {260, 60}, {200, 60}},
+		Reverse64(Path64{{220, 20}, {240, 20}, {240, 40}, {220, 40}}),
+	}
+
+	tests := []struct {
+		name      string
+		rect      Path64
+		paths     Paths64
+		netArea   float64
+		wantRings int
+	}{
+		{"Hole inside window", Path64{{20, 20}, {80, 20}, {80, 80}, {20, 80}}, donut, 3600 - 1600, 2},
+		{"Window inside hole", Path64{{40, 40}, {60, 40}, {60, 60}, {40, 60}}, donut, 0, 0},
+		{"Window straddles hole", Path64{{50, 50}, {150, 50}, {150, 150}, {50, 150}}, donut, 2500 - 400, 2},
+		{"Clockwise outer", Path64{{20, 20}, {80, 20}, {80, 80}, {20, 80}}, reversedDonut, -(3600 - 1600), 2},
+		{"Multiple donuts", Path64{{20, 20}, {250, 20}, {250, 80}, {20, 80}}, append(Paths64{outer, hole}, secondDonut...), 4800 - 1600 + 2000 - 400, 4},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := RectClip64(test.rect, test.paths)
+			if err != nil {
+				t.Fatalf("RectClip64 failed: %v", err)
+			}
+			if len(result) != test.wantRings {
+				t.Fatalf("Expected %d rings, got %d: %v", test.wantRings, len(result), result)
+			}
+
+			net := 0.0
+			for _, path := range result {
+				net += Area64(path)
+			}
+			if net != test.netArea {
+				t.Errorf("Expected net area %v, got %v (result %v)", test.netArea, net, result)
+			}
+
+			// The largest ring is an outer; every hole must be oriented against it
+			if len(result) > 1 {
+				outerPositive := Area64(result[0]) > 0
+				for i, path := range result[1:] {
+					if i%2 == 0 && IsPositive64(path) == outerPositive {
+						t.Errorf("Hole %v lost its orientation", path)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRectClip64CoverWindingAndFigureEight(t *testing.T) {
+	window := Path64{{20, 20}, {80, 20}, {80, 80}, {20, 80}}
+
+	// Two outers around the window: one cover per unit of winding, so EvenOdd
+	// still sees the window as unfilled
+	outer := Path64{{0, 0}, {100, 0}, {100, 100}, {0, 100}}
+	result, err := RectClip64(window, Paths64{outer, outer})
+	if err != nil {
+		t.Fatalf("RectClip64 failed: %v", err)
+	}
+	if len(result) != 2 || Area64(result[0]) != 3600 || Area64(result[1]) != 3600 {
+		t.Errorf("Expected two positive covers, got %v", result)
+	}
+
+	// A figure-8 has zero signed area but still encloses its loops
+	figureEight := Path64{{30, 30}, {70, 70}, {70, 30}, {30, 70}}
+	result, err = RectClip64(window, Paths64{figureEight})
+	if err != nil {
+		t.Fatalf("RectClip64 failed: %v", err)
+	}
+	if len(result) != 1 || len(result[0]) != 4 {
+		t.Errorf("Expected the figure-8 to be kept, got %v", result)
+	}
+	crossing := Path64{{0, 0}, {100, 100}, {100, 0}, {0, 100}}
+	result, err = RectClip64(window, Paths64{crossing})
+	if err != nil {
+		t.Fatalf("RectClip64 failed: %v", err)
+	}
+	if len(result) != 1 || Area64(result[0]) != 0 {
+		t.Errorf("Expected the clipped figure-8, got %v", result)
+	}
+
+	// A ring clipped down to the window's boundary still disappears
+	result, err = RectClip64(window, Paths64{{{0, 0}, {100, 0}, {100, 20}, {20, 20}, {20, 100}, {0, 100}}})
+	if err != nil {
+		t.Fatalf("RectClip64 failed: %v", err)
+	}
+	if len(result) != 0 {
+		t.Errorf("Expected no rings, got %v", result)
+	}
+}
+
 // getBounds extracts the bounding box from a rectangle path
 func getBounds(rect Path64) (left, right, top, bottom int64) {
 	if len(rect) == 0 {
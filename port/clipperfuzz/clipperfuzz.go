@@ -0,0 +1,413 @@
+// Package clipperfuzz fuzzes the boolean operations of the clipper package.
+// Fuzz input bytes are decoded as a small program that draws subject and
+// clip paths with the degeneracies that break sweep-line clippers:
+// collinear runs, duplicate points, spikes and near-parallel edges. Every
+// decoded case is checked for termination and for area invariants that
+// hold for any correct clipper, such as |A∪B| ≥ max(|A|,|B|).
+//
+// To fuzz from another package, call Fuzz from a fuzz target:
+//
+//	func FuzzBoolean(f *testing.F) { clipperfuzz.Fuzz(f) }
+//
+// and run it with go test -fuzz=FuzzBoolean. Geometry from a bug report can
+// be added to the corpus with Encode and WriteCorpusFile, so the fuzzer
+// replays it on every go test and mutates it when fuzzing.
+package clipperfuzz
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	clipper "github.com/go-clipper/clipper2/port"
+)
+
+// CheckTimeout bounds the boolean operations of one Check; an operation
+// still running after it is reported as not terminating
+var CheckTimeout = 10 * time.Second
+
+// MaxPoints bounds the number of points Decode produces, so a long fuzz
+// input does not turn into a slow case
+const MaxPoints = 1024
+
+// Case is a boolean operation input
+type Case struct {
+	Subjects clipper.Paths64
+	Clips    clipper.Paths64
+	FillRule clipper.FillRule
+}
+
+// ==============================================================================
+// Decoding
+// ==============================================================================
+
+// Operations of the drawing program; each is one byte, modulo opCount,
+// followed by its operands
+const (
+	opSubject      = iota // start a subject path
+	opClip                // start a clip path
+	opPoint               // absolute point: two varints
+	opStep                // relative point: two signed bytes
+	opDuplicate           // repeat the last point
+	opCollinear           // count byte: extend the last edge 1 to 8 times
+	opSpike               // direction byte: out and back to the last point
+	opNearParallel        // an edge one unit off parallel to the last edge
+	opCount
+)
+
+// spikeDirections are the eight directions a spike can point in
+var spikeDirections = [8]clipper.Point64{{X: 1}, {X: 1, Y: 1}, {Y: 1}, {X: -1, Y: 1}, {X: -1}, {X: -1, Y: -1}, {Y: -1}, {X: 1, Y: -1}}
+
+// Decode turns fuzz input into a case. The first byte selects the fill rule
+// and the rest is a drawing program; operations before the first path start
+// draw a subject, and a truncated operation ends the program. Any input
+// decodes to some case.
+func Decode(data []byte) Case {
+	var c Case
+	if len(data) == 0 {
+		return c
+	}
+	c.FillRule = clipper.FillRule(data[0] % 4)
+	d := decoder{data: data[1:]}
+
+	var path clipper.Path64
+	toClips, points := false, 0
+	flush := func() {
+		if len(path) > 0 {
+			if toClips {
+				c.Clips = append(c.Clips, path)
+			} else {
+				c.Subjects = append(c.Subjects, path)
+			}
+		}
+		path = nil
+	}
+	add := func(pt clipper.Point64) {
+		path = append(path, pt)
+		points++
+	}
+
+	for points < MaxPoints {
+		op, ok := d.byte()
+		if !ok {
+			break
+		}
+		last, edge := lastEdge(path)
+		switch op % opCount {
+		case opSubject, opClip:
+			flush()
+			toClips = op%opCount == opClip
+		case opPoint:
+			x, okX := d.varint()
+			y, okY := d.varint()
+			if !okX || !okY {
+				flush()
+				return c
+			}
+			add(clipper.Point64{X: x, Y: y})
+		case opStep:
+			dx, okX := d.byte()
+			dy, okY := d.byte()
+			if !okX || !okY {
+				flush()
+				return c
+			}
+			add(last.Add(clipper.Point64{X: int64(int8(dx)), Y: int64(int8(dy))}))
+		case opDuplicate:
+			add(last)
+		case opCollinear:
+			n, ok := d.byte()
+			if !ok {
+				flush()
+				return c
+			}
+			for i := 0; i <= int(n%8) && points < MaxPoints; i++ {
+				last = last.Add(edge)
+				add(last)
+			}
+		case opSpike:
+			k, ok := d.byte()
+			if !ok {
+				flush()
+				return c
+			}
+			length := int64(8 * (1 + k>>3))
+			dir := spikeDirections[k%8]
+			add(last.Add(clipper.Point64{X: dir.X * length, Y: dir.Y * length}))
+			add(last)
+		case opNearParallel:
+			off := clipper.Point64{Y: 1}
+			if abs(edge.Y) > abs(edge.X) {
+				off = clipper.Point64{X: 1}
+			}
+			add(last.Add(edge).Add(off))
+		}
+	}
+	flush()
+	return c
+}
+
+// lastEdge returns the last point of path and the vector of its last edge,
+// defaulting to the origin and a unit step along X
+func lastEdge(path clipper.Path64) (last, edge clipper.Point64) {
+	edge = clipper.Point64{X: 1}
+	if len(path) > 0 {
+		last = path[len(path)-1]
+	}
+	if len(path) > 1 {
+		if e := last.Sub(path[len(path)-2]); e != (clipper.Point64{}) {
+			edge = e
+		}
+	}
+	return last, edge
+}
+
+// Encode turns a case into fuzz input that decodes back to it, except that
+// empty paths are dropped and points beyond MaxPoints are cut off
+func Encode(c Case) []byte {
+	data := []byte{byte(c.FillRule)}
+	for _, group := range []struct {
+		op    byte
+		paths clipper.Paths64
+	}{{opSubject, c.Subjects}, {opClip, c.Clips}} {
+		for _, path := range group.paths {
+			if len(path) == 0 {
+				continue
+			}
+			data = append(data, group.op)
+			for _, pt := range path {
+				data = append(data, opPoint)
+				data = binary.AppendVarint(data, pt.X)
+				data = binary.AppendVarint(data, pt.Y)
+			}
+		}
+	}
+	return data
+}
+
+// decoder reads operands from fuzz input
+type decoder struct {
+	data []byte
+}
+
+// byte reads one byte
+func (d *decoder) byte() (byte, bool) {
+	if len(d.data) == 0 {
+		return 0, false
+	}
+	b := d.data[0]
+	d.data = d.data[1:]
+	return b, true
+}
+
+// varint reads a zigzag varint
+func (d *decoder) varint() (int64, bool) {
+	v, n := binary.Varint(d.data)
+	if n <= 0 {
+		d.data = nil
+		return 0, false
+	}
+	d.data = d.data[n:]
+	return v, true
+}
+
+// abs returns the absolute value of v
+func abs(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// ==============================================================================
+// Invariants
+// ==============================================================================
+
+// Check runs the four boolean operations on a case and reports the first
+// failure: an error other than ErrInvalidInput, an operation that does not
+// finish within CheckTimeout, or an area invariant broken by more than the
+// rounding of intersections to integer coordinates can explain. With |X|
+// the area X covers under the case's fill rule, the invariants are
+// |A∪B| ≥ max(|A|,|B|), |A∩B| ≤ min(|A|,|B|), |A−B| ≤ |A| and
+// |A⊕B| ≤ |A∪B|.
+func Check(c Case) error {
+	ctx, cancel := context.WithTimeout(context.Background(), CheckTimeout)
+	defer cancel()
+
+	area := func(clipType clipper.ClipType, subjects, clips clipper.Paths64) (float64, error) {
+		solution, _, err := clipper.BooleanOp64(clipType, c.FillRule, subjects, nil, clips, clipper.WithContext(ctx))
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				return 0, fmt.Errorf("%v did not terminate within %v: %w", clipType, CheckTimeout, err)
+			}
+			return 0, fmt.Errorf("%v: %w", clipType, err)
+		}
+		return math.Abs(clipper.AreaPaths64(solution)), nil
+	}
+
+	var areas [6]float64
+	for i, op := range []struct {
+		clipType        clipper.ClipType
+		subjects, clips clipper.Paths64
+	}{
+		{clipper.Union, c.Subjects, nil},
+		{clipper.Union, c.Clips, nil},
+		{clipper.Union, c.Subjects, c.Clips},
+		{clipper.Intersection, c.Subjects, c.Clips},
+		{clipper.Difference, c.Subjects, c.Clips},
+		{clipper.Xor, c.Subjects, c.Clips},
+	} {
+		a, err := area(op.clipType, op.subjects, op.clips)
+		if errors.Is(err, clipper.ErrInvalidInput) {
+			return nil // coordinates out of range are rejected, not clipped
+		}
+		if err != nil {
+			return err
+		}
+		areas[i] = a
+	}
+
+	a, b, union, intersection, difference, xor := areas[0], areas[1], areas[2], areas[3], areas[4], areas[5]
+	tol := tolerance(c)
+	switch {
+	case union < max(a, b)-tol:
+		return fmt.Errorf("|A∪B| = %v is less than max(|A|, |B|) = %v", union, max(a, b))
+	case intersection > min(a, b)+tol:
+		return fmt.Errorf("|A∩B| = %v is more than min(|A|, |B|) = %v", intersection, min(a, b))
+	case difference > a+tol:
+		return fmt.Errorf("|A−B| = %v is more than |A| = %v", difference, a)
+	case xor > union+tol:
+		return fmt.Errorf("|A⊕B| = %v is more than |A∪B| = %v", xor, union)
+	}
+	return nil
+}
+
+// tolerance bounds the area that rounding intersections by up to half a
+// unit can add or remove: half a unit along every input edge, whose
+// lengths bound the output edges, and then some
+func tolerance(c Case) float64 {
+	length := 0.0
+	for _, paths := range []clipper.Paths64{c.Subjects, c.Clips} {
+		for _, path := range paths {
+			for i := range path {
+				next := path[(i+1)%len(path)]
+				length += math.Hypot(float64(next.X-path[i].X), float64(next.Y-path[i].Y))
+			}
+		}
+	}
+	return length + 1
+}
+
+// ==============================================================================
+// Fuzz Target and Corpus
+// ==============================================================================
+
+// Seeds returns the built-in seed corpus: simple overlaps plus one case for
+// each degeneracy the drawing program can produce
+func Seeds() [][]byte {
+	square := func(x, y, size int64) clipper.Path64 {
+		return clipper.Path64{{X: x, Y: y}, {X: x + size, Y: y}, {X: x + size, Y: y + size}, {X: x, Y: y + size}}
+	}
+	seeds := [][]byte{
+		Encode(Case{Subjects: clipper.Paths64{square(0, 0, 100)}, Clips: clipper.Paths64{square(50, 50, 100)}}),
+		Encode(Case{Subjects: clipper.Paths64{square(0, 0, 100), square(25, 25, 50)}, Clips: clipper.Paths64{square(0, 0, 100)}, FillRule: clipper.NonZero}),
+		Encode(Case{Subjects: clipper.Paths64{square(0, 0, 100)}, Clips: clipper.Paths64{square(100, 0, 100)}, FillRule: clipper.Positive}),
+	}
+	// Bowtie with a collinear run, duplicate point, spike and near-parallel
+	// edge, clipped by a triangle of small steps
+	program := []byte{byte(clipper.EvenOdd),
+		opSubject, opStep, 0, 0, opStep, 40, 0, opCollinear, 3, opDuplicate, opStep, 0, 60,
+		opSpike, 21, opStep, 216, 196, opNearParallel,
+		opClip, opStep, 10, 10, opStep, 100, 20, opStep, 156, 60,
+	}
+	return append(seeds, program)
+}
+
+// Fuzz adds the seed corpus to f and fuzzes Check with decoded cases
+func Fuzz(f *testing.F) {
+	for _, seed := range Seeds() {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		c := Decode(data)
+		if err := Check(c); err != nil {
+			t.Fatalf("%v\nsubjects: %v\nclips: %v\nfill rule: %v", err, c.Subjects, c.Clips, c.FillRule)
+		}
+	})
+}
+
+// corpusHeader starts every file of a go test fuzz corpus
+const corpusHeader = "go test fuzz v1\n"
+
+// WriteCorpusFile writes data to dir as a go test corpus entry for a fuzz
+// target taking one []byte, such as testdata/fuzz/FuzzBoolean, and returns
+// the file's path. The file is named by a hash of data, so writing the same
+// input twice leaves one file.
+func WriteCorpusFile(dir string, data []byte) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	name := filepath.Join(dir, hex.EncodeToString(sum[:8]))
+	content := corpusHeader + "[]byte(" + strconv.Quote(string(data)) + ")\n"
+	return name, os.WriteFile(name, []byte(content), 0o644)
+}
+
+// ReadCorpusFile reads a corpus entry written by WriteCorpusFile or by go
+// test for a failing input. It fails with ErrInvalidInput for a file that
+// is not a single []byte value.
+func ReadCorpusFile(name string) ([]byte, error) {
+	content, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	rest, ok := bytes.CutPrefix(content, []byte(corpusHeader))
+	if !ok {
+		return nil, fmt.Errorf("%w: %s is not a fuzz corpus file", clipper.ErrInvalidInput, name)
+	}
+	value, ok := bytes.CutPrefix(bytes.TrimSpace(rest), []byte("[]byte("))
+	if !ok || !bytes.HasSuffix(value, []byte(")")) {
+		return nil, fmt.Errorf("%w: %s does not hold a []byte value", clipper.ErrInvalidInput, name)
+	}
+	data, err := strconv.Unquote(string(value[:len(value)-1]))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", clipper.ErrInvalidInput, name, err)
+	}
+	return []byte(data), nil
+}
+
+// ReadCorpus reads every corpus entry in dir, in file name order
+func ReadCorpus(dir string) ([][]byte, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	corpus := make([][]byte, 0, len(names))
+	for _, name := range names {
+		data, err := ReadCorpusFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		corpus = append(corpus, data)
+	}
+	return corpus, nil
+}
@@ -0,0 +1,118 @@
+package clipperfuzz
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	clipper "github.com/go-clipper/clipper2/port"
+)
+
+// FuzzBoolean runs the seed corpus with go test and fuzzes with
+// go test -fuzz=FuzzBoolean ./clipperfuzz
+func FuzzBoolean(f *testing.F) {
+	Fuzz(f)
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		expected Case
+	}{
+		{"Empty", nil, Case{}},
+		{
+			"Steps and duplicate",
+			[]byte{1, opStep, 5, 5, opDuplicate, opStep, 251, 0},
+			Case{Subjects: clipper.Paths64{{{X: 5, Y: 5}, {X: 5, Y: 5}, {X: 0, Y: 5}}}, FillRule: clipper.NonZero},
+		},
+		{
+			"Collinear run",
+			[]byte{0, opStep, 0, 0, opStep, 2, 1, opCollinear, 1},
+			Case{Subjects: clipper.Paths64{{{X: 0, Y: 0}, {X: 2, Y: 1}, {X: 4, Y: 2}, {X: 6, Y: 3}}}},
+		},
+		{
+			"Spike and near-parallel edge into a clip",
+			[]byte{2, opClip, opStep, 10, 0, opSpike, 10, opNearParallel},
+			Case{Clips: clipper.Paths64{{{X: 10, Y: 0}, {X: 10, Y: 16}, {X: 10, Y: 0}, {X: 11, Y: -16}}}, FillRule: clipper.Positive},
+		},
+		{
+			"Truncated operation",
+			[]byte{0, opStep, 1, 1, opSubject, opStep, 1},
+			Case{Subjects: clipper.Paths64{{{X: 1, Y: 1}}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Decode(tt.data); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	c := Case{
+		Subjects: clipper.Paths64{{{X: -1 << 40, Y: 3}, {X: 7, Y: 1 << 40}, {X: 0, Y: 0}}},
+		Clips:    clipper.Paths64{{{X: 1, Y: 2}}},
+		FillRule: clipper.Negative,
+	}
+	if got := Decode(Encode(c)); !reflect.DeepEqual(got, c) {
+		t.Errorf("expected %v to round trip, got %v", c, got)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	for i, seed := range Seeds() {
+		if err := Check(Decode(seed)); err != nil {
+			t.Errorf("seed %d: unexpected error: %v", i, err)
+		}
+	}
+
+	// Out-of-range coordinates are rejected by the library, not reported
+	huge := Case{Subjects: clipper.Paths64{{{X: 0, Y: 0}, {X: 1 << 62, Y: 0}, {X: 0, Y: 1 << 62}}}}
+	if err := Check(huge); err != nil {
+		t.Errorf("expected out-of-range input to pass, got %v", err)
+	}
+}
+
+func TestCorpusFiles(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "testdata", "fuzz", "FuzzBoolean")
+	inputs := [][]byte{{0, opStep, 1, 2}, []byte("\x00\xff\"quoted\"\n")}
+	for _, data := range inputs {
+		name, err := WriteCorpusFile(dir, data)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got, err := ReadCorpusFile(name)
+		if err != nil || !reflect.DeepEqual(got, data) {
+			t.Errorf("expected %q, got %q (err %v)", data, got, err)
+		}
+	}
+
+	corpus, err := ReadCorpus(dir)
+	if err != nil || len(corpus) != len(inputs) {
+		t.Errorf("expected %d entries, got %q (err %v)", len(inputs), corpus, err)
+	}
+
+	// A file go test wrote for a failing input reads back the same way
+	name := filepath.Join(t.TempDir(), "failing")
+	writeFile(t, name, "go test fuzz v1\n[]byte(\"\\x01\\x03\\x05\\x05\")\n")
+	if got, err := ReadCorpusFile(name); err != nil || string(got) != "\x01\x03\x05\x05" {
+		t.Errorf("expected the go test entry, got %q (err %v)", got, err)
+	}
+
+	writeFile(t, name, "go test fuzz v1\nstring(\"x\")\n")
+	if _, err := ReadCorpusFile(name); !errors.Is(err, clipper.ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for a string entry, got %v", err)
+	}
+}
+
+// writeFile writes content to name or fails the test
+func writeFile(t *testing.T, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
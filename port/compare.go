@@ -0,0 +1,85 @@
+package clipper
+
+// ==============================================================================
+// Approximate Path Comparison
+// ==============================================================================
+
+// ApproxEqualPaths64 reports whether a and b describe the same rings up to a
+// per-vertex deviation of tol units in X and Y. Rings may appear in any
+// order, start at any vertex and run in either direction, so results from
+// engines that round differently (such as the pure Go engine and the C++
+// oracle, which may differ by ±1 unit) can be compared meaningfully. Matched
+// rings must have the same number of vertices. A negative tol never matches.
+func ApproxEqualPaths64(a, b Paths64, tol int64) bool {
+	if tol < 0 || len(a) != len(b) {
+		return false
+	}
+
+	// Candidate pairs, then a maximum bipartite matching so that a ring close
+	// to several others cannot take the partner another ring needs
+	candidates := make([][]int, len(a))
+	for i, p := range a {
+		for j, q := range b {
+			if approxEqualRing(p, q, tol) {
+				candidates[i] = append(candidates[i], j)
+			}
+		}
+		if len(candidates[i]) == 0 {
+			return false
+		}
+	}
+
+	matchedTo := make([]int, len(b))
+	for j := range matchedTo {
+		matchedTo[j] = -1
+	}
+	var augment func(i int, seen []bool) bool
+	augment = func(i int, seen []bool) bool {
+		for _, j := range candidates[i] {
+			if seen[j] {
+				continue
+			}
+			seen[j] = true
+			if matchedTo[j] < 0 || augment(matchedTo[j], seen) {
+				matchedTo[j] = i
+				return true
+			}
+		}
+		return false
+	}
+	for i := range a {
+		if !augment(i, make([]bool, len(b))) {
+			return false
+		}
+	}
+	return true
+}
+
+// approxEqualRing reports whether q is p, rotated and possibly reversed, with
+// every vertex within tol in X and Y
+func approxEqualRing(p, q Path64, tol int64) bool {
+	if len(p) != len(q) {
+		return false
+	}
+	n := len(p)
+	if n == 0 {
+		return true
+	}
+	near := func(u, v Point64) bool {
+		return abs64(u.X-v.X) <= tol && abs64(u.Y-v.Y) <= tol
+	}
+	for start := 0; start < n; start++ {
+		if !near(p[0], q[start]) {
+			continue
+		}
+		forward, backward := true, true
+		for k := 1; k < n && (forward || backward); k++ {
+			forward = forward && near(p[k], q[(start+k)%n])
+			backward = backward && near(p[k], q[(start-k+n)%n])
+		}
+		if forward || backward {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package clipper
+
+import "testing"
+
+func TestApproxEqualPaths64(t *testing.T) {
+	square := Path64{{0, 0}, {100, 0}, {100, 100}, {0, 100}}
+	triangle := Path64{{200, 0}, {300, 0}, {250, 80}}
+
+	tests := []struct {
+		name     string
+		a, b     Paths64
+		tol      int64
+		expected bool
+	}{
+		{"identical", Paths64{square, triangle}, Paths64{square, triangle}, 0, true},
+		{"ring order", Paths64{square, triangle}, Paths64{triangle, square}, 0, true},
+		{"rotated", Paths64{square}, Paths64{{{100, 100}, {0, 100}, {0, 0}, {100, 0}}}, 0, true},
+		{"reversed", Paths64{square}, Paths64{Reverse64(square)}, 0, true},
+		{"off by one", Paths64{square}, Paths64{{{1, -1}, {100, 1}, {99, 101}, {0, 100}}}, 1, true},
+		{"off by one, no tolerance", Paths64{square}, Paths64{{{1, -1}, {100, 1}, {99, 101}, {0, 100}}}, 0, false},
+		{"off by two", Paths64{square}, Paths64{{{0, 0}, {102, 0}, {100, 100}, {0, 100}}}, 1, false},
+		{"extra vertex", Paths64{square}, Paths64{{{0, 0}, {50, 0}, {100, 0}, {100, 100}, {0, 100}}}, 1, false},
+		{"missing ring", Paths64{square, triangle}, Paths64{square}, 1, false},
+		{"duplicate ring", Paths64{square, square}, Paths64{square, triangle}, 1, false},
+		{"negative tolerance", Paths64{square}, Paths64{square}, -1, false},
+		{"both empty", nil, Paths64{}, 0, true},
+	}
+	for _, tt := range tests {
+		if got := ApproxEqualPaths64(tt.a, tt.b, tt.tol); got != tt.expected {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, got)
+		}
+	}
+}
+
+func TestApproxEqualPaths64Matching(t *testing.T) {
+	// a[0] is close to both rings of b, a[1] only to b[0]: a greedy pairing
+	// of a[0] with b[0] would wrongly fail
+	a := Paths64{
+		{{0, 0}, {10, 0}, {10, 10}, {0, 10}},
+		{{0, 0}, {12, 0}, {12, 10}, {0, 10}},
+	}
+	b := Paths64{
+		{{0, 0}, {11, 0}, {11, 10}, {0, 10}},
+		{{0, 0}, {9, 0}, {9, 10}, {0, 10}},
+	}
+	if !ApproxEqualPaths64(a, b, 1) {
+		t.Error("expected rings to be matched one to one")
+	}
+}
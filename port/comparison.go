@@ -0,0 +1,155 @@
+package clipper
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"text/tabwriter"
+	"time"
+)
+
+// ==============================================================================
+// Pure Go vs Oracle Performance Comparison
+// ==============================================================================
+
+// Workload is a named boolean operation timed by RunComparison
+type Workload struct {
+	Name     string
+	ClipType ClipType
+	FillRule FillRule
+	Subjects Paths64
+	Clips    Paths64
+}
+
+// ComparisonResult holds the mean time per operation of one workload
+type ComparisonResult struct {
+	Name   string
+	PureGo time.Duration // pure Go engine
+	Oracle time.Duration // C++ oracle (0 unless built with clipper_cgo)
+	Ratio  float64       // PureGo / Oracle (0 without the oracle)
+}
+
+// StandardWorkloads returns the fixed, deterministic workloads used by the
+// package benchmarks, so numbers from different machines and versions can be
+// compared
+func StandardWorkloads() []Workload {
+	r := rand.New(rand.NewSource(1))
+
+	var grid Paths64
+	for row := int64(0); row < 20; row++ {
+		for col := int64(0); col < 20; col++ {
+			x, y := col*75, row*75
+			grid = append(grid, Path64{{x, y}, {x + 100, y}, {x + 100, y + 100}, {x, y + 100}})
+		}
+	}
+
+	var circles Paths64
+	for i := 0; i < 50; i++ {
+		center := Point64{X: r.Int63n(10000), Y: r.Int63n(10000)}
+		circles = append(circles, RegularPolygon64(center, 500+float64(r.Intn(1000)), 64, 0))
+	}
+
+	randomPolygon := func(n int) Path64 {
+		path := make(Path64, n)
+		for i := range path {
+			path[i] = Point64{X: r.Int63n(10000), Y: r.Int63n(10000)}
+		}
+		return path
+	}
+
+	return []Workload{
+		{Name: "grid-union", ClipType: Union, FillRule: NonZero, Subjects: grid},
+		{
+			Name: "circles-intersection", ClipType: Intersection, FillRule: NonZero,
+			Subjects: circles[:25], Clips: circles[25:],
+		},
+		{
+			Name: "star-difference", ClipType: Difference, FillRule: NonZero,
+			Subjects: Paths64{RegularPolygon64(Point64{5000, 5000}, 5000, 500, 0)},
+			Clips:    Paths64{starPolygon(Point64{5000, 5000}, 4800, 1500, 200)},
+		},
+		{
+			Name: "random-xor", ClipType: Xor, FillRule: EvenOdd,
+			Subjects: Paths64{randomPolygon(100)}, Clips: Paths64{randomPolygon(100)},
+		},
+	}
+}
+
+// starPolygon returns a star with the given number of points, alternating
+// between the outer and inner radius
+func starPolygon(center Point64, outer, inner float64, points int) Path64 {
+	big := RegularPolygon64(center, outer, points, 0)
+	small := RegularPolygon64(center, inner, points, math.Pi/float64(points))
+	star := make(Path64, 0, 2*points)
+	for i := range big {
+		star = append(star, big[i], small[i])
+	}
+	return star
+}
+
+// RunComparison times every workload iterations times with the pure Go
+// engine and, when the package is built with the clipper_cgo tag, with the
+// C++ oracle. Without the oracle only the pure Go column is filled in.
+func RunComparison(workloads []Workload, iterations int) ([]ComparisonResult, error) {
+	if iterations <= 0 {
+		return nil, ErrInvalidInput
+	}
+
+	results := make([]ComparisonResult, len(workloads))
+	for i, w := range workloads {
+		results[i].Name = w.Name
+
+		pure, err := timeWorkload(w, iterations, func(w Workload) error {
+			_, _, err := NewVattiEngine(w.ClipType, w.FillRule).ExecuteClipping(w.Subjects, nil, w.Clips)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", w.Name, err)
+		}
+		results[i].PureGo = pure
+
+		oracle, err := timeWorkload(w, iterations, func(w Workload) error {
+			_, _, err := oracleBooleanOp64Impl(w.ClipType, w.FillRule, w.Subjects, nil, w.Clips)
+			return err
+		})
+		switch {
+		case errors.Is(err, ErrNotImplemented):
+			continue
+		case err != nil:
+			return nil, fmt.Errorf("%s: %w", w.Name, err)
+		}
+		results[i].Oracle = oracle
+		if oracle > 0 {
+			results[i].Ratio = float64(pure) / float64(oracle)
+		}
+	}
+	return results, nil
+}
+
+// timeWorkload returns the mean duration of run over iterations calls
+func timeWorkload(w Workload, iterations int, run func(Workload) error) (time.Duration, error) {
+	start := time.Now()
+	for i := 0; i < iterations; i++ {
+		if err := run(w); err != nil {
+			return 0, err
+		}
+	}
+	return time.Since(start) / time.Duration(iterations), nil
+}
+
+// WriteComparisonTable writes results as an aligned text table
+func WriteComparisonTable(w io.Writer, results []ComparisonResult) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "workload\tpure Go\toracle\tratio\t")
+	for _, r := range results {
+		oracle, ratio := "n/a", "n/a"
+		if r.Oracle > 0 {
+			oracle = r.Oracle.String()
+			ratio = fmt.Sprintf("%.2fx", r.Ratio)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t\n", r.Name, r.PureGo, oracle, ratio)
+	}
+	return tw.Flush()
+}
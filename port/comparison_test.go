@@ -0,0 +1,79 @@
+package clipper
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRunComparison(t *testing.T) {
+	workloads := StandardWorkloads()
+	results, err := RunComparison(workloads, 1)
+	if err != nil {
+		t.Fatalf("RunComparison failed: %v", err)
+	}
+	if len(results) != len(workloads) {
+		t.Fatalf("expected %d results, got %d", len(workloads), len(results))
+	}
+	for i, r := range results {
+		if r.Name != workloads[i].Name || r.PureGo <= 0 {
+			t.Errorf("expected a timed result for %s, got %+v", workloads[i].Name, r)
+		}
+		if (r.Oracle == 0) != (r.Ratio == 0) {
+			t.Errorf("expected a ratio exactly when the oracle ran, got %+v", r)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := WriteComparisonTable(&buf, results); err != nil {
+		t.Fatalf("WriteComparisonTable failed: %v", err)
+	}
+	if lines := strings.Count(buf.String(), "\n"); lines != len(results)+1 {
+		t.Errorf("expected a header and %d rows, got:\n%s", len(results), buf.String())
+	}
+
+	if _, err := RunComparison(workloads, 0); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for zero iterations, got %v", err)
+	}
+}
+
+// BenchmarkComparison runs the standard workloads with both implementations:
+// go test -bench Comparison ./port (add -tags=clipper_cgo for the oracle)
+func BenchmarkComparison(b *testing.B) {
+	for _, w := range StandardWorkloads() {
+		b.Run(w.Name+"/pure", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				NewVattiEngine(w.ClipType, w.FillRule).ExecuteClipping(w.Subjects, nil, w.Clips)
+			}
+		})
+		b.Run(w.Name+"/oracle", func(b *testing.B) {
+			if _, _, err := oracleBooleanOp64Impl(w.ClipType, w.FillRule, w.Subjects, nil, w.Clips); errors.Is(err, ErrNotImplemented) {
+				b.Skip("oracle requires -tags=clipper_cgo")
+			}
+			for i := 0; i < b.N; i++ {
+				oracleBooleanOp64Impl(w.ClipType, w.FillRule, w.Subjects, nil, w.Clips)
+			}
+		})
+	}
+}
+
+// BenchmarkAreaOfBooleanOp64 compares the area-only sweep with building the
+// solution and summing its area
+func BenchmarkAreaOfBooleanOp64(b *testing.B) {
+	for _, w := range StandardWorkloads() {
+		b.Run(w.Name+"/area", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				NewVattiEngine(w.ClipType, w.FillRule).ExecuteArea(w.Subjects, w.Clips)
+			}
+		})
+		b.Run(w.Name+"/solution", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				solution, _, _ := NewVattiEngine(w.ClipType, w.FillRule).ExecuteClipping(w.Subjects, nil, w.Clips)
+				for _, path := range solution {
+					Area64(path)
+				}
+			}
+		})
+	}
+}
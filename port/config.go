@@ -0,0 +1,48 @@
+package clipper
+
+// ==============================================================================
+// Configurable Defaults
+// ==============================================================================
+
+// Package defaults, matching Clipper2
+const (
+	DefaultMiterLimit   = 2.0  // miter joins longer than this multiple of delta are squared off
+	DefaultArcTolerance = 0.25 // largest deviation of rounded joins from a true arc
+)
+
+// Config collects the defaults the package otherwise applies itself.
+// Embedders wanting different defaults without touching global state build a
+// Config once and derive per-call options from it, so concurrent callers with
+// different settings never interfere (SetDefaultOptions changes them for all
+// callers instead).
+type Config struct {
+	MiterLimit      float64 // miter limit for offsetting (DefaultMiterLimit)
+	ArcTolerance    float64 // arc tolerance for offsetting (DefaultArcTolerance)
+	MaxCornerPoints int     // vertex cap per rounded corner, 0 for none
+}
+
+// DefaultConfig returns the package defaults
+func DefaultConfig() Config {
+	return Config{
+		MiterLimit:   DefaultMiterLimit,
+		ArcTolerance: DefaultArcTolerance,
+	}
+}
+
+// OffsetOptions returns offsetting options carrying the configured defaults
+func (c Config) OffsetOptions() OffsetOptions {
+	return OffsetOptions{
+		MiterLimit:      c.MiterLimit,
+		ArcTolerance:    c.ArcTolerance,
+		MaxCornerPoints: c.MaxCornerPoints,
+	}
+}
+
+// effectiveMiterLimit returns the miter limit actually applied: limits of 1
+// or less fall back to the default, as in Clipper2
+func effectiveMiterLimit(miterLimit float64) float64 {
+	if miterLimit <= 1 {
+		return DefaultMiterLimit
+	}
+	return miterLimit
+}
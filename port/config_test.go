@@ -0,0 +1,39 @@
+package clipper
+
+import "testing"
+
+// TestDefaultConfig tests the package defaults and their use as options
+func TestDefaultConfig(t *testing.T) {
+	cfg := DefaultConfig()
+	if cfg.MiterLimit != DefaultMiterLimit || cfg.ArcTolerance != DefaultArcTolerance || cfg.MaxCornerPoints != 0 {
+		t.Errorf("Unexpected defaults: %+v", cfg)
+	}
+
+	cfg.MiterLimit = 4
+	cfg.MaxCornerPoints = 8
+	expected := OffsetOptions{MiterLimit: 4, ArcTolerance: DefaultArcTolerance, MaxCornerPoints: 8}
+	if got := cfg.OffsetOptions(); got != expected {
+		t.Errorf("OffsetOptions() = %+v, expected %+v", got, expected)
+	}
+	if DefaultConfig().MiterLimit != DefaultMiterLimit {
+		t.Error("Modifying a Config changed the package defaults")
+	}
+}
+
+// TestEffectiveMiterLimit tests the fallback for miter limits of 1 or less
+func TestEffectiveMiterLimit(t *testing.T) {
+	tests := []struct {
+		limit, expected float64
+	}{
+		{0, DefaultMiterLimit},
+		{1, DefaultMiterLimit},
+		{1.5, 1.5},
+		{10, 10},
+	}
+
+	for _, tt := range tests {
+		if got := effectiveMiterLimit(tt.limit); got != tt.expected {
+			t.Errorf("effectiveMiterLimit(%v) = %v, expected %v", tt.limit, got, tt.expected)
+		}
+	}
+}
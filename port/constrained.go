@@ -0,0 +1,101 @@
+package clipper
+
+import "sort"
+
+// ==============================================================================
+// Constrained Edge Export (CDT / Meshing Input)
+// ==============================================================================
+
+// ConstrainedEdges is a planar straight-line graph: a table of unique
+// vertices and the undirected edges between them, referenced by index. This
+// is the input constrained Delaunay triangulators and mesh generators expect.
+type ConstrainedEdges struct {
+	Vertices []Point64
+	Edges    [][2]int // vertex indices, smaller index first, sorted
+}
+
+// ExportConstrainedEdges64 converts closed rings, typically clipping
+// results, into a vertex table and a constraint edge list. Wherever a vertex
+// touches another edge, that edge is split so rings that touch share the
+// vertex; repeated vertices and edges (such as the common boundary of
+// adjacent regions) appear once, and zero-length edges are dropped.
+// Edges that cross each other cannot be represented and make the export fail
+// with ErrInvalidInput.
+func ExportConstrainedEdges64(paths Paths64) (*ConstrainedEdges, error) {
+	result := &ConstrainedEdges{}
+	index := make(map[Point64]int)
+	vertexIndex := func(pt Point64) int {
+		if i, ok := index[pt]; ok {
+			return i
+		}
+		index[pt] = len(result.Vertices)
+		result.Vertices = append(result.Vertices, pt)
+		return index[pt]
+	}
+
+	seen := make(map[[2]int]bool)
+	for _, ring := range insertTouchingVertices(paths) {
+		if len(ring) < 2 {
+			continue
+		}
+		for i, a := range ring {
+			b := ring[(i+1)%len(ring)]
+			if a == b {
+				continue
+			}
+			edge := [2]int{vertexIndex(a), vertexIndex(b)}
+			if edge[0] > edge[1] {
+				edge[0], edge[1] = edge[1], edge[0]
+			}
+			if !seen[edge] {
+				seen[edge] = true
+				result.Edges = append(result.Edges, edge)
+			}
+		}
+	}
+
+	sort.Slice(result.Edges, func(i, j int) bool {
+		if result.Edges[i][0] != result.Edges[j][0] {
+			return result.Edges[i][0] < result.Edges[j][0]
+		}
+		return result.Edges[i][1] < result.Edges[j][1]
+	})
+	if result.hasCrossing() {
+		return nil, ErrInvalidInput
+	}
+	return result, nil
+}
+
+// hasCrossing reports whether any two edges cross at a point interior to both
+func (c *ConstrainedEdges) hasCrossing() bool {
+	type span struct {
+		edge       [2]int
+		minY, maxY int64
+	}
+	spans := make([]span, len(c.Edges))
+	for i, e := range c.Edges {
+		a, b := c.Vertices[e[0]], c.Vertices[e[1]]
+		spans[i] = span{e, min64(a.Y, b.Y), max64(a.Y, b.Y)}
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].minY < spans[j].minY })
+
+	var active []span
+	for _, s := range spans {
+		kept := active[:0]
+		for _, o := range active {
+			if o.maxY >= s.minY {
+				kept = append(kept, o)
+			}
+		}
+		active = kept
+
+		a1, a2 := c.Vertices[s.edge[0]], c.Vertices[s.edge[1]]
+		for _, o := range active {
+			if segmentsIntersectStrict(a1, a2, c.Vertices[o.edge[0]], c.Vertices[o.edge[1]]) {
+				return true
+			}
+		}
+		active = append(active, s)
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package clipper
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestExportConstrainedEdges64 tests vertex deduplication and edge splitting
+func TestExportConstrainedEdges64(t *testing.T) {
+	t.Run("Adjacent regions share vertices and edges", func(t *testing.T) {
+		a := Path64{{0, 0}, {10, 0}, {10, 10}, {0, 10}}
+		b := Path64{{10, 0}, {20, 0}, {20, 5}, {10, 5}}
+
+		result, err := ExportConstrainedEdges64(Paths64{a, b})
+		if err != nil {
+			t.Fatalf("ExportConstrainedEdges64 failed: %v", err)
+		}
+		if len(result.Vertices) != 7 {
+			t.Errorf("Expected 7 vertices, got %d: %v", len(result.Vertices), result.Vertices)
+		}
+		if len(result.Edges) != 8 {
+			t.Errorf("Expected 8 edges, got %d: %v", len(result.Edges), result.Edges)
+		}
+
+		// The shared vertex (10,5) must split square a's right edge
+		hasEdge := func(p, q Point64) bool {
+			for _, e := range result.Edges {
+				u, v := result.Vertices[e[0]], result.Vertices[e[1]]
+				if (u == p && v == q) || (u == q && v == p) {
+					return true
+				}
+			}
+			return false
+		}
+		if !hasEdge(Point64{10, 0}, Point64{10, 5}) || !hasEdge(Point64{10, 5}, Point64{10, 10}) {
+			t.Errorf("Expected the edge (10,0)-(10,10) to be split at (10,5): %v", result.Edges)
+		}
+		if hasEdge(Point64{10, 0}, Point64{10, 10}) {
+			t.Error("Unsplit edge (10,0)-(10,10) was kept")
+		}
+		for _, e := range result.Edges {
+			if e[0] >= e[1] {
+				t.Errorf("Edge %v is not ordered", e)
+			}
+		}
+	})
+
+	t.Run("Repeated points are dropped", func(t *testing.T) {
+		ring := Path64{{0, 0}, {0, 0}, {10, 0}, {10, 10}, {0, 0}}
+		result, err := ExportConstrainedEdges64(Paths64{ring})
+		if err != nil {
+			t.Fatalf("ExportConstrainedEdges64 failed: %v", err)
+		}
+		if len(result.Vertices) != 3 || len(result.Edges) != 3 {
+			t.Errorf("Expected a triangle, got %v %v", result.Vertices, result.Edges)
+		}
+	})
+
+	t.Run("Crossing edges are rejected", func(t *testing.T) {
+		a := Path64{{0, 0}, {10, 0}, {10, 10}, {0, 10}}
+		b := Path64{{5, 5}, {15, 5}, {15, 15}, {5, 15}}
+		if _, err := ExportConstrainedEdges64(Paths64{a, b}); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("Expected ErrInvalidInput, got %v", err)
+		}
+	})
+
+	t.Run("Boolean results export cleanly", func(t *testing.T) {
+		subject := Paths64{{{0, 0}, {100, 0}, {100, 100}, {0, 100}}}
+		clip := Paths64{{{50, -10}, {150, 50}, {50, 110}}}
+		difference, remainder, err := DifferenceWithRemainder64(subject, clip, NonZero)
+		if err != nil {
+			t.Fatalf("DifferenceWithRemainder64 failed: %v", err)
+		}
+		if _, err := ExportConstrainedEdges64(append(difference, remainder...)); err != nil {
+			t.Errorf("ExportConstrainedEdges64 failed: %v", err)
+		}
+	})
+}
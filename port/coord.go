@@ -0,0 +1,134 @@
+package clipper
+
+// ==============================================================================
+// 32-bit Coordinates
+// ==============================================================================
+
+// The 32-bit API covers path helpers and conversions only: the clipping
+// engine, the offsetter and every other algorithm run on 64-bit coordinates,
+// so 32-bit paths are widened to run them.
+
+// MaxCoord32 is the largest coordinate magnitude of the 32-bit API. Within
+// this range coordinate differences fit in 31 bits, so cross products of
+// 32-bit points are exact in int64 arithmetic; points beyond it, which the
+// int32 fields still admit, take the 128-bit kernel.
+const MaxCoord32 = 1<<30 - 1
+
+// inRange32 reports whether both coordinates of pt lie within ±MaxCoord32
+func inRange32(pt Point32) bool {
+	return pt.X >= -MaxCoord32 && pt.X <= MaxCoord32 && pt.Y >= -MaxCoord32 && pt.Y <= MaxCoord32
+}
+
+// toPoint64 widens a 32-bit point
+func toPoint64(pt Point32) Point64 {
+	return Point64{X: int64(pt.X), Y: int64(pt.Y)}
+}
+
+// crossSign32 returns the sign (-1, 0 or +1) of the cross product of vectors
+// (p2-p1) and (p3-p1)
+func crossSign32(p1, p2, p3 Point32) int {
+	if !inRange32(p1) || !inRange32(p2) || !inRange32(p3) {
+		return CrossProduct128(toPoint64(p1), toPoint64(p2), toPoint64(p3)).Cmp(Int128{})
+	}
+	cross := (int64(p2.X)-int64(p1.X))*(int64(p3.Y)-int64(p1.Y)) -
+		(int64(p2.Y)-int64(p1.Y))*(int64(p3.X)-int64(p1.X))
+	switch {
+	case cross > 0:
+		return 1
+	case cross < 0:
+		return -1
+	}
+	return 0
+}
+
+// doubleArea32 returns twice the signed area of a 32-bit path, exactly
+func doubleArea32(path Path32) Int128 {
+	if len(path) < 3 {
+		return Int128{}
+	}
+
+	// Products of int32 coordinates fit in int64, but their difference only
+	// within ±MaxCoord32, so the products are summed in 128 bits separately
+	var area Int128
+	prev := path[len(path)-1]
+	for _, pt := range path {
+		area = area.Add(NewInt128(int64(prev.X) * int64(pt.Y))).Sub(NewInt128(int64(pt.X) * int64(prev.Y)))
+		prev = pt
+	}
+	return area
+}
+
+// ==============================================================================
+// 32-bit API
+// ==============================================================================
+
+// Area32 returns the signed area of a 32-bit path (positive if
+// counter-clockwise), computed natively
+func Area32(path Path32) float64 {
+	return doubleArea32(path).ToFloat64() / 2
+}
+
+// IsPositive32 returns true if the path has positive orientation (counter-clockwise)
+func IsPositive32(path Path32) bool {
+	area := doubleArea32(path)
+	return !area.IsNegative() && !area.IsZero()
+}
+
+// IsCollinear32 checks if three 32-bit points are collinear
+func IsCollinear32(p1, p2, p3 Point32) bool {
+	return crossSign32(p1, p2, p3) == 0
+}
+
+// Reverse32 reverses the order of points in a 32-bit path
+func Reverse32(path Path32) Path32 {
+	result := make(Path32, len(path))
+	for i, j := 0, len(path)-1; i < len(path); i, j = i+1, j-1 {
+		result[i] = path[j]
+	}
+	return result
+}
+
+// Path32To64 widens a 32-bit path to 64-bit coordinates
+func Path32To64(path Path32) Path64 {
+	result := make(Path64, len(path))
+	for i, pt := range path {
+		result[i] = toPoint64(pt)
+	}
+	return result
+}
+
+// Paths32To64 widens 32-bit paths to 64-bit coordinates
+func Paths32To64(paths Paths32) Paths64 {
+	result := make(Paths64, len(paths))
+	for i, path := range paths {
+		result[i] = Path32To64(path)
+	}
+	return result
+}
+
+// Path64To32 narrows a path to 32-bit coordinates. It returns ErrInvalidInput
+// if any coordinate lies outside ±MaxCoord32.
+func Path64To32(path Path64) (Path32, error) {
+	result := make(Path32, len(path))
+	for i, pt := range path {
+		if pt.X < -MaxCoord32 || pt.X > MaxCoord32 || pt.Y < -MaxCoord32 || pt.Y > MaxCoord32 {
+			return nil, ErrInvalidInput
+		}
+		result[i] = Point32{X: int32(pt.X), Y: int32(pt.Y)}
+	}
+	return result, nil
+}
+
+// Paths64To32 narrows paths to 32-bit coordinates. It returns ErrInvalidInput
+// if any coordinate lies outside ±MaxCoord32.
+func Paths64To32(paths Paths64) (Paths32, error) {
+	result := make(Paths32, len(paths))
+	for i, path := range paths {
+		narrowed, err := Path64To32(path)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = narrowed
+	}
+	return result, nil
+}
@@ -0,0 +1,94 @@
+package clipper
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// TestCrossSign32 tests the 32-bit cross product sign against the 128-bit kernel
+func TestCrossSign32(t *testing.T) {
+	tests := []struct {
+		name       string
+		p1, p2, p3 Point64
+	}{
+		{"Left turn", Point64{0, 0}, Point64{10, 0}, Point64{10, 10}},
+		{"Right turn", Point64{0, 0}, Point64{10, 0}, Point64{10, -10}},
+		{"Collinear", Point64{0, 0}, Point64{5, 5}, Point64{10, 10}},
+		{"Extreme range left", Point64{-MaxCoord32, -MaxCoord32}, Point64{MaxCoord32, -MaxCoord32}, Point64{MaxCoord32, MaxCoord32}},
+		{"Extreme range right", Point64{-MaxCoord32, MaxCoord32}, Point64{MaxCoord32, MaxCoord32}, Point64{MaxCoord32, -MaxCoord32}},
+		{"Nearly collinear", Point64{-MaxCoord32, -MaxCoord32}, Point64{MaxCoord32, MaxCoord32 - 1}, Point64{MaxCoord32 - 1, MaxCoord32 - 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := CrossProduct128(tt.p1, tt.p2, tt.p3).Cmp(Int128{})
+			path, err := Path64To32(Path64{tt.p1, tt.p2, tt.p3})
+			if err != nil {
+				t.Fatalf("Path64To32 failed: %v", err)
+			}
+			if got := crossSign32(path[0], path[1], path[2]); got != want {
+				t.Errorf("crossSign32 = %d, expected %d", got, want)
+			}
+		})
+	}
+
+	// int32 coordinates beyond ±MaxCoord32 take the 128-bit kernel
+	for _, pts := range [][3]Point32{
+		{{math.MinInt32, math.MinInt32}, {math.MaxInt32, math.MinInt32}, {math.MaxInt32, math.MaxInt32}},
+		{{math.MinInt32, math.MaxInt32}, {math.MaxInt32, math.MaxInt32}, {math.MaxInt32, math.MinInt32}},
+		{{math.MinInt32, math.MinInt32}, {math.MaxInt32, math.MaxInt32}, {0, 0}},
+	} {
+		want := CrossProduct128(toPoint64(pts[0]), toPoint64(pts[1]), toPoint64(pts[2])).Cmp(Int128{})
+		if got := crossSign32(pts[0], pts[1], pts[2]); got != want {
+			t.Errorf("crossSign32%v = %d, expected %d", pts, got, want)
+		}
+	}
+}
+
+// TestArea32 tests that the native 32-bit area matches the 64-bit area
+func TestArea32(t *testing.T) {
+	tests := []struct {
+		name string
+		path Path32
+		want float64
+	}{
+		{"Counter-clockwise square", Path32{{0, 0}, {10, 0}, {10, 10}, {0, 10}}, 100},
+		{"Clockwise square", Path32{{0, 0}, {0, 10}, {10, 10}, {10, 0}}, -100},
+		{"Degenerate", Path32{{0, 0}, {10, 0}}, 0},
+		{"Full range", Path32{{-MaxCoord32, -MaxCoord32}, {MaxCoord32, -MaxCoord32}, {MaxCoord32, MaxCoord32}, {-MaxCoord32, MaxCoord32}}, 4 * float64(MaxCoord32) * float64(MaxCoord32)},
+		{"Beyond MaxCoord32", Path32{{math.MinInt32, math.MinInt32}, {math.MaxInt32, math.MinInt32}, {math.MaxInt32, math.MaxInt32}, {math.MinInt32, math.MaxInt32}}, float64(math.MaxUint32) * float64(math.MaxUint32)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Area32(tt.path); got != tt.want {
+				t.Errorf("Area32(%v) = %v, expected %v", tt.path, got, tt.want)
+			}
+			if got := Area64(Path32To64(tt.path)); got != tt.want {
+				t.Errorf("Area64 of widened path = %v, expected %v", got, tt.want)
+			}
+			if got := IsPositive32(tt.path); got != (tt.want > 0) {
+				t.Errorf("IsPositive32(%v) = %v", tt.path, got)
+			}
+		})
+	}
+}
+
+// TestPath64To32 tests narrowing and its range check
+func TestPath64To32(t *testing.T) {
+	path := Path64{{-MaxCoord32, 0}, {MaxCoord32, 5}, {0, -MaxCoord32}}
+	narrowed, err := Path64To32(path)
+	if err != nil {
+		t.Fatalf("Path64To32(%v) failed: %v", path, err)
+	}
+	if widened := Path32To64(narrowed); !identicalPath(widened, path) {
+		t.Errorf("Round trip gave %v, expected %v", widened, path)
+	}
+
+	for _, pt := range []Point64{{MaxCoord32 + 1, 0}, {0, -MaxCoord32 - 1}, {-1 << 63, 0}} {
+		if _, err := Paths64To32(Paths64{{{0, 0}, pt}}); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("Paths64To32 with %v: expected ErrInvalidInput, got %v", pt, err)
+		}
+	}
+}
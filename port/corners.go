@@ -0,0 +1,217 @@
+package clipper
+
+import (
+	"fmt"
+	"math"
+)
+
+// ==============================================================================
+// Corner Classification
+// ==============================================================================
+
+// CornerKind classifies the corner a path makes at a vertex
+type CornerKind uint8
+
+const (
+	CornerConvex     CornerKind = iota // turns towards the inside, interior angle below 180 degrees
+	CornerReflex                       // turns away from the inside, interior angle above 180 degrees
+	CornerCollinear                    // runs straight on, interior angle of exactly 180 degrees
+	CornerSpike                        // doubles back on itself, interior angle of 0 degrees
+	CornerEndpoint                     // first or last vertex of an open path
+	CornerDegenerate                   // coincides with a neighbouring vertex
+)
+
+// CornerInfo describes the corner at one vertex of a path
+type CornerInfo struct {
+	Kind CornerKind
+	// Turn is +1 for a left turn, -1 for a right turn and 0 when the edges
+	// are collinear, in the direction of travel along the path
+	Turn int
+	// Angle is the interior angle in radians, in [0, 2*pi]. The inside is
+	// the filled side of a closed path (left of a positive path, right of a
+	// negative one) and the left side of an open path. Endpoints and
+	// degenerate vertices report 0.
+	Angle float64
+}
+
+// ClassifyCorners64 reports the convexity, interior angle and collinearity
+// of path at every vertex, for custom offsetting or chamfering logic. Kinds
+// and turns come from the exact 128-bit predicates the engine uses, so they
+// never disagree with it; the angle is computed in floating point but always
+// matches the kind (below pi exactly when convex, and so on).
+func ClassifyCorners64(path Path64, isClosed bool) []CornerInfo {
+	n := len(path)
+	corners := make([]CornerInfo, n)
+	insideSign := 1
+	if isClosed && Area64(path) < 0 {
+		insideSign = -1
+	}
+	for i, pt := range path {
+		if !isClosed && (i == 0 || i == n-1) {
+			corners[i].Kind = CornerEndpoint
+			continue
+		}
+		prev, next := path[(i+n-1)%n], path[(i+1)%n]
+		if prev == pt || next == pt {
+			corners[i].Kind = CornerDegenerate
+			continue
+		}
+		corners[i] = classifyCorner(prev, pt, next, insideSign)
+	}
+	return corners
+}
+
+// classifyCorner classifies the corner at pt between distinct neighbours,
+// with the inside to the left for insideSign +1 and to the right for -1
+func classifyCorner(prev, pt, next Point64, insideSign int) CornerInfo {
+	cross := CrossProduct128(prev, pt, next)
+	if cross.IsZero() {
+		if pt.Sub(prev).Dot128(next.Sub(pt)).IsNegative() {
+			return CornerInfo{Kind: CornerSpike}
+		}
+		return CornerInfo{Kind: CornerCollinear, Angle: math.Pi}
+	}
+
+	turn := 1
+	if cross.IsNegative() {
+		turn = -1
+	}
+	// The turn angle is the exterior angle of a left turn
+	in, out := pt.Sub(prev), next.Sub(pt)
+	deflection := math.Abs(math.Atan2(
+		float64(in.X)*float64(out.Y)-float64(in.Y)*float64(out.X),
+		float64(in.X)*float64(out.X)+float64(in.Y)*float64(out.Y)))
+	if turn == insideSign {
+		angle := math.Min(math.Max(math.Pi-deflection, math.SmallestNonzeroFloat64), math.Nextafter(math.Pi, 0))
+		return CornerInfo{Kind: CornerConvex, Turn: turn, Angle: angle}
+	}
+	angle := math.Max(math.Min(math.Pi+deflection, 2*math.Pi), math.Nextafter(math.Pi, 4))
+	return CornerInfo{Kind: CornerReflex, Turn: turn, Angle: angle}
+}
+
+// ==============================================================================
+// Corner Editing
+// ==============================================================================
+
+// ChamferVertices64 cuts the corners of a closed path at the given vertex
+// indices, replacing each with a straight edge between the points dist away
+// along both adjacent edges. The rest of the outline is kept exactly, unlike
+// with a full offset. Collinear corners are left as they are; spikes,
+// duplicate vertices, out-of-range indices, a non-positive dist and cuts
+// overlapping along an edge return ErrInvalidInput.
+func ChamferVertices64(path Path64, indices []int, dist float64) (Path64, error) {
+	if !(dist > 0) || math.IsInf(dist, 1) {
+		return nil, ErrInvalidInput
+	}
+	return editCorners(path, indices,
+		func(float64) float64 { return dist },
+		func(_ Point64, _, _ PointD, t1, t2 PointD, _ float64) Path64 {
+			return Path64{RoundPointD(t1), RoundPointD(t2)}
+		})
+}
+
+// FilletVertices64 rounds the corners of a closed path at the given vertex
+// indices with circular arcs of the given radius, tangent to both adjacent
+// edges. Arcs are approximated within arcTol like the round joins of
+// InflatePaths64 (0 for the offsetter's default). Invalid input is rejected
+// as in ChamferVertices64, including radii too large for the adjacent edges.
+func FilletVertices64(path Path64, indices []int, radius, arcTol float64) (Path64, error) {
+	if !(radius > 0) || math.IsInf(radius, 1) || arcTol < 0 {
+		return nil, ErrInvalidInput
+	}
+	stepsPerRad := arcStepsPer360(radius, arcTol) / (2 * math.Pi)
+	return editCorners(path, indices,
+		func(theta float64) float64 { return radius / math.Tan(theta/2) },
+		func(pt Point64, u1, u2, t1, t2 PointD, theta float64) Path64 {
+			bis := PointD{X: u1.X + u2.X, Y: u1.Y + u2.Y}
+			bisLen := math.Hypot(bis.X, bis.Y)
+			d := radius / math.Sin(theta/2)
+			c := PointD{X: float64(pt.X) + bis.X/bisLen*d, Y: float64(pt.Y) + bis.Y/bisLen*d}
+
+			a1 := math.Atan2(t1.Y-c.Y, t1.X-c.X)
+			sweep := math.Atan2(t2.Y-c.Y, t2.X-c.X) - a1
+			if sweep > math.Pi {
+				sweep -= 2 * math.Pi
+			} else if sweep < -math.Pi {
+				sweep += 2 * math.Pi
+			}
+			steps := max(1, int(math.Ceil(math.Abs(sweep)*stepsPerRad)))
+			arc := make(Path64, 0, steps+1)
+			arc = append(arc, RoundPointD(t1))
+			for k := 1; k < steps; k++ {
+				a := a1 + sweep*float64(k)/float64(steps)
+				arc = append(arc, RoundPointD(PointD{X: c.X + radius*math.Cos(a), Y: c.Y + radius*math.Sin(a)}))
+			}
+			return append(arc, RoundPointD(t2))
+		})
+}
+
+// editCorners replaces the selected corners of a closed path. trim returns
+// how far along both edges a corner with edge angle theta (0 to pi) is cut
+// back; corner returns the replacement for the vertex pt, given the unit
+// vectors u1 and u2 towards its neighbours and the cut points t1 and t2.
+func editCorners(path Path64, indices []int, trim func(theta float64) float64,
+	corner func(pt Point64, u1, u2, t1, t2 PointD, theta float64) Path64) (Path64, error) {
+	n := len(path)
+	if n < 3 {
+		return nil, ErrInvalidInput
+	}
+	kinds := ClassifyCorners64(path, true)
+	cut := make([]float64, n)
+	selected := make([]bool, n)
+	for _, i := range indices {
+		if i < 0 || i >= n {
+			return nil, fmt.Errorf("%w: vertex index %d out of range", ErrInvalidInput, i)
+		}
+		switch kinds[i].Kind {
+		case CornerSpike, CornerDegenerate:
+			return nil, fmt.Errorf("%w: vertex %d has no corner to edit", ErrInvalidInput, i)
+		case CornerConvex, CornerReflex:
+			selected[i] = true
+		}
+	}
+
+	unit := func(from, to Point64) (PointD, float64) {
+		dx, dy := float64(to.X-from.X), float64(to.Y-from.Y)
+		l := math.Hypot(dx, dy)
+		return PointD{X: dx / l, Y: dy / l}, l
+	}
+	theta := make([]float64, n)
+	for i, pt := range path {
+		if !selected[i] {
+			continue
+		}
+		u1, _ := unit(pt, path[(i+n-1)%n])
+		u2, _ := unit(pt, path[(i+1)%n])
+		theta[i] = math.Atan2(math.Abs(u1.X*u2.Y-u1.Y*u2.X), u1.X*u2.X+u1.Y*u2.Y)
+		cut[i] = trim(theta[i])
+	}
+	for i, pt := range path {
+		j := (i + 1) % n
+		if _, l := unit(pt, path[j]); cut[i]+cut[j] > l*(1+1e-9) {
+			return nil, fmt.Errorf("%w: cuts at vertices %d and %d overlap", ErrInvalidInput, i, j)
+		}
+	}
+
+	result := make(Path64, 0, n)
+	for i, pt := range path {
+		if !selected[i] {
+			result = append(result, pt)
+			continue
+		}
+		u1, _ := unit(pt, path[(i+n-1)%n])
+		u2, _ := unit(pt, path[(i+1)%n])
+		p := PointD{X: float64(pt.X), Y: float64(pt.Y)}
+		t1 := PointD{X: p.X + u1.X*cut[i], Y: p.Y + u1.Y*cut[i]}
+		t2 := PointD{X: p.X + u2.X*cut[i], Y: p.Y + u2.Y*cut[i]}
+		for _, q := range corner(pt, u1, u2, t1, t2, theta[i]) {
+			if len(result) == 0 || result[len(result)-1] != q {
+				result = append(result, q)
+			}
+		}
+	}
+	for len(result) > 1 && result[len(result)-1] == result[0] {
+		result = result[:len(result)-1]
+	}
+	return result, nil
+}
@@ -0,0 +1,137 @@
+package clipper
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// TestClassifyCorners tests corner kinds, turns and angles on closed and open paths
+func TestClassifyCorners(t *testing.T) {
+	// An L-shaped ring, counter-clockwise, with one collinear vertex
+	ring := Path64{{0, 0}, {50, 0}, {100, 0}, {100, 50}, {50, 50}, {50, 100}, {0, 100}}
+	expected := []CornerKind{CornerConvex, CornerCollinear, CornerConvex, CornerConvex, CornerReflex, CornerConvex, CornerConvex}
+
+	check := func(name string, corners []CornerInfo, kinds []CornerKind, angles []float64) {
+		t.Helper()
+		if len(corners) != len(kinds) {
+			t.Fatalf("%s: expected %d corners, got %d", name, len(kinds), len(corners))
+		}
+		for i, c := range corners {
+			if c.Kind != kinds[i] {
+				t.Errorf("%s, vertex %d: expected kind %d, got %d", name, i, kinds[i], c.Kind)
+			}
+			if angles != nil && math.Abs(c.Angle-angles[i]) > 1e-12 {
+				t.Errorf("%s, vertex %d: expected angle %v, got %v", name, i, angles[i], c.Angle)
+			}
+		}
+	}
+	right, straight := math.Pi/2, math.Pi
+	check("ring", ClassifyCorners64(ring, true), expected,
+		[]float64{right, straight, right, right, 3 * right, right, right})
+
+	// Reversing the ring keeps the inside, so kinds and angles stay and turns flip
+	reversed := ClassifyCorners64(Reverse64(ring), true)
+	for i, c := range reversed {
+		orig := ClassifyCorners64(ring, true)[len(ring)-1-i]
+		if c.Kind != orig.Kind || c.Angle != orig.Angle || c.Turn != -orig.Turn {
+			t.Errorf("reversed vertex %d: expected %+v with the turn flipped, got %+v", i, orig, c)
+		}
+	}
+
+	// Open paths measure on the left and flag endpoints, spikes and duplicates
+	open := Path64{{0, 0}, {10, 0}, {10, 10}, {10, 10}, {10, 20}, {10, 5}, {0, 5}}
+	check("open", ClassifyCorners64(open, false),
+		[]CornerKind{CornerEndpoint, CornerConvex, CornerDegenerate, CornerDegenerate, CornerSpike, CornerReflex, CornerEndpoint},
+		[]float64{0, right, 0, 0, 0, 3 * right, 0})
+}
+
+// TestClassifyCornersNearlyStraight tests that kinds follow the exact predicates
+func TestClassifyCornersNearlyStraight(t *testing.T) {
+	// A left turn far too small for the float angle to resolve
+	const big = 1 << 60
+	corners := ClassifyCorners64(Path64{{0, 0}, {big, 1}, {2 * big, 3}}, false)
+	c := corners[1]
+	if c.Kind != CornerConvex || c.Turn != 1 || !(c.Angle < math.Pi) {
+		t.Errorf("expected a convex left turn below pi, got %+v", c)
+	}
+	corners = ClassifyCorners64(Path64{{0, 0}, {big, 1}, {2 * big, 1}}, false)
+	if c := corners[1]; c.Kind != CornerReflex || c.Turn != -1 || !(c.Angle > math.Pi) {
+		t.Errorf("expected a reflex right turn above pi, got %+v", c)
+	}
+}
+
+// TestChamferVertices tests cutting selected corners
+func TestChamferVertices(t *testing.T) {
+	square := Path64{{0, 0}, {100, 0}, {100, 100}, {0, 100}}
+	got, err := ChamferVertices64(square, []int{0, 2}, 10)
+	expected := Path64{{0, 10}, {10, 0}, {100, 0}, {100, 90}, {90, 100}, {0, 100}}
+	if err != nil || !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v (err %v)", expected, got, err)
+	}
+
+	// A collinear vertex has no corner and is kept
+	withMid := Path64{{0, 0}, {50, 0}, {100, 0}, {100, 100}, {0, 100}}
+	if got, err := ChamferVertices64(withMid, []int{1}, 10); err != nil || !reflect.DeepEqual(got, withMid) {
+		t.Errorf("expected the path unchanged, got %v (err %v)", got, err)
+	}
+
+	tests := []struct {
+		name    string
+		path    Path64
+		indices []int
+		dist    float64
+	}{
+		{"overlapping cuts", square, []int{0, 1}, 60},
+		{"cut past the edge", square, []int{0}, 101},
+		{"index out of range", square, []int{4}, 10},
+		{"spike", Path64{{0, 0}, {100, 0}, {50, 0}, {50, 50}}, []int{1}, 10},
+		{"zero distance", square, []int{0}, 0},
+		{"too few vertices", Path64{{0, 0}, {10, 0}}, []int{0}, 1},
+	}
+	for _, tt := range tests {
+		if _, err := ChamferVertices64(tt.path, tt.indices, tt.dist); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("%s: expected ErrInvalidInput, got %v", tt.name, err)
+		}
+	}
+}
+
+// TestFilletVertices tests rounding selected corners with tangent arcs
+func TestFilletVertices(t *testing.T) {
+	square := Path64{{0, 0}, {100, 0}, {100, 100}, {0, 100}}
+	got, err := FilletVertices64(square, []int{0, 1, 2, 3}, 10, 0.25)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Each corner loses the area between the square and the quarter circle;
+	// the chords approximating the arcs cut off a little more
+	expectedArea := 10000 - (4-math.Pi)*100
+	if area := Area64(got); area > expectedArea || area < expectedArea-20 {
+		t.Errorf("expected area near %v, got %v", expectedArea, area)
+	}
+	if got[0] != (Point64{0, 10}) || got[len(got)-1] != (Point64{0, 90}) {
+		t.Errorf("expected the arcs to start and end on the edges, got %v", got)
+	}
+	centers := []PointD{{10, 10}, {90, 10}, {90, 90}, {10, 90}}
+	for _, pt := range got {
+		near := math.Inf(1)
+		for _, c := range centers {
+			near = math.Min(near, math.Abs(math.Hypot(float64(pt.X)-c.X, float64(pt.Y)-c.Y)-10))
+		}
+		if near > 1 {
+			t.Errorf("vertex %v is not on any arc", pt)
+		}
+	}
+
+	// Filleting a reflex corner adds area
+	ell := Path64{{0, 0}, {100, 0}, {100, 50}, {50, 50}, {50, 100}, {0, 100}}
+	got, err = FilletVertices64(ell, []int{3}, 20, 0)
+	if err != nil || Area64(got) <= Area64(ell) || len(got) <= len(ell) {
+		t.Errorf("expected a larger outline with an arc, got %v (err %v)", got, err)
+	}
+
+	if _, err := FilletVertices64(square, []int{0, 1}, 60, 0.25); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for a radius too large, got %v", err)
+	}
+}
@@ -0,0 +1,118 @@
+package clipper
+
+// ==============================================================================
+// Coverage Difference Heatmaps
+// ==============================================================================
+
+// maxCoverageCells bounds the number of cells a coverage grid may have
+const maxCoverageCells = 1 << 24
+
+// CoverageGrid holds, for every cell of a square grid, the area covered by
+// one path set minus the area covered by another
+type CoverageGrid struct {
+	Origin     Point64     // lower-left corner of cell (0, 0)
+	CellSize   int64       // side length of each cell
+	Cols, Rows int         // grid dimensions
+	Diff       [][]float64 // Diff[row][col] = area(a ∩ cell) - area(b ∩ cell)
+}
+
+// Cell returns the rectangle covered by the cell at row, col
+func (g *CoverageGrid) Cell(row, col int) Rect64 {
+	left := g.Origin.X + int64(col)*g.CellSize
+	top := g.Origin.Y + int64(row)*g.CellSize
+	return Rect64{Left: left, Top: top, Right: left + g.CellSize, Bottom: top + g.CellSize}
+}
+
+// MaxAbsDiff returns the largest absolute cell difference and its cell
+func (g *CoverageGrid) MaxAbsDiff() (diff float64, row, col int) {
+	for r, cells := range g.Diff {
+		for c, d := range cells {
+			if d < 0 {
+				d = -d
+			}
+			if d > diff {
+				diff, row, col = d, r, c
+			}
+		}
+	}
+	return diff, row, col
+}
+
+// CoverageDiffGrid64 compares the regions filled by a and b cell by cell.
+// Both sets are first unioned under fillRule, so overlapping rings count
+// once, then clipped to each cell with RectClip64. The grid starts at the
+// lower-left corner of the combined bounds and covers them completely; a
+// positive cell means a covers more of it than b. This is handy for checking
+// that an upgrade or parameter change did not materially alter results.
+//
+// cellSize must be positive; grids of more than 2^24 cells fail with
+// ErrComplexityExceeded.
+func CoverageDiffGrid64(a, b Paths64, fillRule FillRule, cellSize int64) (*CoverageGrid, error) {
+	if cellSize <= 0 {
+		return nil, ErrInvalidInput
+	}
+
+	bounds := BoundsPaths64(a).UnionRect(BoundsPaths64(b))
+	grid := &CoverageGrid{CellSize: cellSize}
+	if !bounds.IsValid() {
+		return grid, nil
+	}
+
+	cols := (uint64(bounds.Width()) + uint64(cellSize) - 1) / uint64(cellSize)
+	rows := (uint64(bounds.Height()) + uint64(cellSize) - 1) / uint64(cellSize)
+	if cols == 0 {
+		cols = 1
+	}
+	if rows == 0 {
+		rows = 1
+	}
+	if cols > maxCoverageCells || rows > maxCoverageCells/cols {
+		return nil, ErrComplexityExceeded
+	}
+	grid.Origin = Point64{X: bounds.Left, Y: bounds.Top}
+	grid.Cols, grid.Rows = int(cols), int(rows)
+
+	filledA, err := Union64(a, nil, fillRule)
+	if err != nil {
+		return nil, err
+	}
+	filledB, err := Union64(b, nil, fillRule)
+	if err != nil {
+		return nil, err
+	}
+
+	grid.Diff = make([][]float64, grid.Rows)
+	for row := range grid.Diff {
+		grid.Diff[row] = make([]float64, grid.Cols)
+		for col := range grid.Diff[row] {
+			cell := grid.Cell(row, col)
+			areaA, err := clippedArea(filledA, cell)
+			if err != nil {
+				return nil, err
+			}
+			areaB, err := clippedArea(filledB, cell)
+			if err != nil {
+				return nil, err
+			}
+			grid.Diff[row][col] = areaA - areaB
+		}
+	}
+	return grid, nil
+}
+
+// clippedArea returns the signed area of paths lying inside rect
+func clippedArea(paths Paths64, rect Rect64) (float64, error) {
+	paths = FilterByRect(paths, rect)
+	if len(paths) == 0 {
+		return 0, nil
+	}
+	clipped, err := RectClipRect64(rect, paths)
+	if err != nil {
+		return 0, err
+	}
+	area := 0.0
+	for _, path := range clipped {
+		area += Area64(path)
+	}
+	return area, nil
+}
@@ -0,0 +1,64 @@
+package clipper
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// TestCoverageDiffGrid64 tests per-cell coverage differences
+func TestCoverageDiffGrid64(t *testing.T) {
+	a := Paths64{{{0, 0}, {20, 0}, {20, 20}, {0, 20}}}
+	b := Paths64{{{10, 0}, {30, 0}, {30, 20}, {10, 20}}}
+
+	grid, err := CoverageDiffGrid64(a, b, NonZero, 10)
+	if err != nil {
+		t.Fatalf("CoverageDiffGrid64 failed: %v", err)
+	}
+	if grid.Cols != 3 || grid.Rows != 2 || grid.Origin != (Point64{0, 0}) {
+		t.Fatalf("Unexpected grid layout: %d×%d at %v", grid.Cols, grid.Rows, grid.Origin)
+	}
+
+	expected := []float64{100, 0, -100}
+	for row := 0; row < grid.Rows; row++ {
+		for col, want := range expected {
+			if got := grid.Diff[row][col]; math.Abs(got-want) > 1e-9 {
+				t.Errorf("Diff[%d][%d] = %v, expected %v", row, col, got, want)
+			}
+		}
+	}
+	if got := grid.Cell(1, 2); got != (Rect64{20, 10, 30, 20}) {
+		t.Errorf("Cell(1, 2) = %v", got)
+	}
+	if diff, _, col := grid.MaxAbsDiff(); diff != 100 || col != 0 {
+		t.Errorf("MaxAbsDiff = %v at column %d", diff, col)
+	}
+}
+
+// TestCoverageDiffGrid64Overlaps tests that overlapping rings count once
+func TestCoverageDiffGrid64Overlaps(t *testing.T) {
+	square := Path64{{0, 0}, {10, 0}, {10, 10}, {0, 10}}
+	grid, err := CoverageDiffGrid64(Paths64{square, square}, Paths64{square}, NonZero, 5)
+	if err != nil {
+		t.Fatalf("CoverageDiffGrid64 failed: %v", err)
+	}
+	if diff, _, _ := grid.MaxAbsDiff(); diff != 0 {
+		t.Errorf("Expected identical coverage, got max difference %v", diff)
+	}
+}
+
+// TestCoverageDiffGrid64Errors tests argument validation
+func TestCoverageDiffGrid64Errors(t *testing.T) {
+	square := Paths64{{{0, 0}, {10, 0}, {10, 10}, {0, 10}}}
+	if _, err := CoverageDiffGrid64(square, nil, NonZero, 0); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Expected ErrInvalidInput for zero cell size, got %v", err)
+	}
+	huge := Paths64{{{0, 0}, {1 << 40, 0}, {1 << 40, 1 << 40}}}
+	if _, err := CoverageDiffGrid64(huge, nil, NonZero, 1); !errors.Is(err, ErrComplexityExceeded) {
+		t.Errorf("Expected ErrComplexityExceeded, got %v", err)
+	}
+	grid, err := CoverageDiffGrid64(nil, nil, NonZero, 10)
+	if err != nil || grid.Cols != 0 || grid.Rows != 0 {
+		t.Errorf("Expected empty grid, got %+v, %v", grid, err)
+	}
+}
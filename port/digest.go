@@ -0,0 +1,123 @@
+package clipper
+
+import (
+	"bytes"
+	"cmp"
+	"crypto/sha256"
+	"encoding/binary"
+	"slices"
+)
+
+// ==============================================================================
+// Canonical Serialization and Digests
+// ==============================================================================
+
+// The canonical form makes results that differ only in the order of their
+// rings, or in the vertex each ring starts at, compare equal: every ring is
+// rotated to start at its lowest point (smallest Y, then smallest X) and the
+// rings are sorted by their points. Orientation is kept, so an outer and a
+// hole with the same vertices stay different. The serialization is plain
+// big-endian integers, so digests are the same on every OS and architecture
+// and can replace stored golden geometry in regression tests.
+
+// Tags separating the serializations of paths and trees
+const (
+	canonicalPathsTag = 'P'
+	canonicalTreeTag  = 'T'
+)
+
+// CanonicalPaths64 returns closed paths in canonical form: each ring rotated
+// to start at its lowest point and the rings sorted. The input is not
+// modified.
+func CanonicalPaths64(paths Paths64) Paths64 {
+	result := make(Paths64, len(paths))
+	for i, path := range paths {
+		result[i] = canonicalRing(path)
+	}
+	slices.SortFunc(result, comparePaths)
+	return result
+}
+
+// canonicalRing returns a copy of ring rotated to start at its lowest point
+func canonicalRing(ring Path64) Path64 {
+	start := 0
+	for i, pt := range ring {
+		if pt.Y < ring[start].Y || (pt.Y == ring[start].Y && pt.X < ring[start].X) {
+			start = i
+		}
+	}
+	result := make(Path64, 0, len(ring))
+	return append(append(result, ring[start:]...), ring[:start]...)
+}
+
+// comparePaths orders paths point by point, a prefix before longer paths
+func comparePaths(a, b Path64) int {
+	return slices.CompareFunc(a, b, func(p, q Point64) int {
+		if p.Y != q.Y {
+			return cmp.Compare(p.Y, q.Y)
+		}
+		return cmp.Compare(p.X, q.X)
+	})
+}
+
+// CanonicalBytes64 serializes closed paths in canonical form: a tag byte,
+// the number of rings and, for every ring, its number of points followed by
+// their X and Y, all as big-endian 64-bit integers
+func CanonicalBytes64(paths Paths64) []byte {
+	buf := []byte{canonicalPathsTag}
+	buf = binary.BigEndian.AppendUint64(buf, uint64(len(paths)))
+	for _, ring := range CanonicalPaths64(paths) {
+		buf = appendRing(buf, ring)
+	}
+	return buf
+}
+
+// appendRing appends the point count and points of a canonical ring
+func appendRing(buf []byte, ring Path64) []byte {
+	buf = binary.BigEndian.AppendUint64(buf, uint64(len(ring)))
+	for _, pt := range ring {
+		buf = binary.BigEndian.AppendUint64(buf, uint64(pt.X))
+		buf = binary.BigEndian.AppendUint64(buf, uint64(pt.Y))
+	}
+	return buf
+}
+
+// DigestPaths64 returns the SHA-256 digest of the canonical serialization of
+// closed paths
+func DigestPaths64(paths Paths64) [sha256.Size]byte {
+	return sha256.Sum256(CanonicalBytes64(paths))
+}
+
+// CanonicalTreeBytes64 serializes a polygon tree in canonical form: a tag
+// byte followed by the root node, where every node is its canonical ring,
+// its number of children and the children, sorted by their own
+// serialization. Trees that differ only in the order of siblings serialize
+// alike.
+func CanonicalTreeBytes64(tree *PolyTree64) []byte {
+	buf := []byte{canonicalTreeTag}
+	if tree == nil {
+		return appendNode(buf, &PolyPath64{})
+	}
+	return appendNode(buf, tree)
+}
+
+// appendNode appends the canonical serialization of a tree node
+func appendNode(buf []byte, node *PolyPath64) []byte {
+	buf = appendRing(buf, canonicalRing(node.Path))
+	children := make([][]byte, len(node.Children))
+	for i, child := range node.Children {
+		children[i] = appendNode(nil, child)
+	}
+	slices.SortFunc(children, bytes.Compare)
+	buf = binary.BigEndian.AppendUint64(buf, uint64(len(children)))
+	for _, child := range children {
+		buf = append(buf, child...)
+	}
+	return buf
+}
+
+// DigestPolyTree64 returns the SHA-256 digest of the canonical serialization
+// of a polygon tree
+func DigestPolyTree64(tree *PolyTree64) [sha256.Size]byte {
+	return sha256.Sum256(CanonicalTreeBytes64(tree))
+}
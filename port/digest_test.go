@@ -0,0 +1,60 @@
+package clipper
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+// TestDigestPaths64 tests that digests ignore ring order and start vertex
+func TestDigestPaths64(t *testing.T) {
+	square := Path64{{0, 0}, {10, 0}, {10, 10}, {0, 10}}
+	hole := Path64{{2, 2}, {2, 8}, {8, 8}, {8, 2}}
+	digest := DigestPaths64(Paths64{square, hole})
+
+	// Pinned so the serialization never changes between releases or platforms
+	const expected = "67ad0836d381b5f4e701bc9c21901bb63458d4ae7ba644911d5135a37b34cdf1"
+	if got := hex.EncodeToString(digest[:]); got != expected {
+		t.Errorf("expected digest %s, got %s", expected, got)
+	}
+
+	tests := []struct {
+		name  string
+		paths Paths64
+		same  bool
+	}{
+		{"rings swapped", Paths64{hole, square}, true},
+		{"rotated start", Paths64{{{10, 10}, {0, 10}, {0, 0}, {10, 0}}, {{8, 2}, {2, 2}, {2, 8}, {8, 8}}}, true},
+		{"reversed ring", Paths64{Reverse64(square), hole}, false},
+		{"moved vertex", Paths64{{{0, 0}, {10, 0}, {10, 11}, {0, 10}}, hole}, false},
+		{"ring missing", Paths64{square}, false},
+	}
+	for _, tt := range tests {
+		if got := DigestPaths64(tt.paths); (got == digest) != tt.same {
+			t.Errorf("%s: expected equal digests %v", tt.name, tt.same)
+		}
+	}
+	if DigestPaths64(nil) == DigestPolyTree64(nil) {
+		t.Errorf("expected empty paths and an empty tree to have different digests")
+	}
+}
+
+// TestDigestPolyTree64 tests that tree digests ignore sibling order but not nesting
+func TestDigestPolyTree64(t *testing.T) {
+	a := Path64{{0, 0}, {10, 0}, {10, 10}, {0, 10}}
+	b := Path64{{20, 0}, {30, 0}, {30, 10}, {20, 10}}
+	hole := Path64{{2, 2}, {2, 8}, {8, 8}, {8, 2}}
+
+	build := func(first, second Path64, holeUnder int) *PolyTree64 {
+		tree := NewPolyTree64()
+		nodes := []*PolyPath64{tree.AddChild(first), tree.AddChild(second)}
+		nodes[holeUnder].AddChild(hole)
+		return tree
+	}
+	digest := DigestPolyTree64(build(a, b, 0))
+	if DigestPolyTree64(build(b, a, 1)) != digest {
+		t.Errorf("expected sibling order not to change the digest")
+	}
+	if DigestPolyTree64(build(a, b, 1)) == digest {
+		t.Errorf("expected the parent of the hole to change the digest")
+	}
+}
@@ -0,0 +1,186 @@
+package clipper
+
+import "sort"
+
+// ==============================================================================
+// Dissolve - Merging Polygons Along Shared Boundaries
+// ==============================================================================
+
+// DefaultDissolveSnapTolerance is the snapping distance used by Dissolve64
+// when no options are given: vertices off by one unit of rounding still snap
+const DefaultDissolveSnapTolerance = 2
+
+// DissolveOptions controls Dissolve64
+type DissolveOptions struct {
+	// SnapTolerance snaps vertices closer than this distance to a vertex or
+	// edge of another ring onto it, so near-exact shared boundaries match
+	// exactly (0: exact boundaries only)
+	SnapTolerance int64
+}
+
+// Dissolve64 merges polygons that overlap or share boundaries into larger
+// regions, the cartographic dissolve. Each polygon (a set of rings filled
+// under fillRule) is first snapped against the others so that boundaries
+// digitized or rounded slightly apart coincide, then all are unioned and
+// the boundaries they share are removed, leaving one ring per merged region
+// and its holes in the usual orientation (outers counter-clockwise).
+func Dissolve64(polygons []Paths64, fillRule FillRule, opts ...DissolveOptions) (Paths64, error) {
+	options := DissolveOptions{SnapTolerance: DefaultDissolveSnapTolerance}
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	if options.SnapTolerance < 0 {
+		return nil, ErrInvalidInput
+	}
+
+	// Flatten, remembering which polygon every ring belongs to
+	var rings Paths64
+	var owner []int
+	for i, polygon := range polygons {
+		for _, ring := range polygon {
+			rings = append(rings, ring)
+			owner = append(owner, i)
+		}
+	}
+	if options.SnapTolerance > 0 {
+		rings, _ = weldPaths(rings, nil, options.SnapTolerance)
+		rings = snapToEdges(rings, options.SnapTolerance)
+	}
+
+	// Resolve each polygon under its own fill rule; afterwards the regions
+	// are consistently oriented and combine under NonZero
+	var filled Paths64
+	for start := 0; start < len(rings); {
+		end := start
+		for end < len(rings) && owner[end] == owner[start] {
+			end++
+		}
+		region, err := Union64(rings[start:end], nil, fillRule)
+		if err != nil {
+			return nil, err
+		}
+		filled = append(filled, region...)
+		start = end
+	}
+	merged, err := Union64(filled, nil, NonZero)
+	if err != nil {
+		return nil, err
+	}
+
+	// Rings that only share an edge are not joined by the sweep; cancel the
+	// shared edges and relink what remains
+	return Union64(cancelSharedEdges(insertTouchingVertices(merged)), nil, NonZero)
+}
+
+// snapToEdges moves every vertex lying within tolerance of another ring's
+// edge onto that edge (welded vertices are already shared exactly)
+func snapToEdges(rings Paths64, tolerance int64) Paths64 {
+	type edge struct {
+		ring       int
+		a, b       Point64
+		minX, maxX int64
+	}
+	var edges []edge
+	for i, ring := range rings {
+		for j, a := range ring {
+			b := ring[(j+1)%len(ring)]
+			minX, maxX := minMax64(a.X, b.X)
+			edges = append(edges, edge{i, a, b, minX, maxX})
+		}
+	}
+	sort.Slice(edges, func(i, j int) bool { return edges[i].minX < edges[j].minX })
+
+	result := make(Paths64, len(rings))
+	for i, ring := range rings {
+		result[i] = ring.Clone()
+		for j, pt := range ring {
+			best, bestDist := pt, float64(tolerance)
+			for _, e := range edges {
+				if e.minX > pt.X+tolerance {
+					break
+				}
+				if e.ring == i || e.maxX < pt.X-tolerance || pt == e.a || pt == e.b {
+					continue
+				}
+				if d := perpendicularDistance(pt, e.a, e.b); d < bestDist {
+					if q := closestPointOnSegment(pt, e.a, e.b); pt.DistanceTo(q) < bestDist {
+						best, bestDist = q, pt.DistanceTo(q)
+					}
+				}
+			}
+			result[i][j] = best
+		}
+	}
+	return result
+}
+
+// cancelSharedEdges removes every edge traversed in both directions (the
+// common boundary of two adjacent rings) and links the remaining edges into
+// rings, taking the sharpest left turn where several continue from a vertex
+func cancelSharedEdges(rings Paths64) Paths64 {
+	outgoing := make(map[Point64][]Point64)
+	for e, n := range netEdges(rings) {
+		for ; n > 0; n-- {
+			outgoing[e[0]] = append(outgoing[e[0]], e[1])
+		}
+	}
+
+	starts := make([]Point64, 0, len(outgoing))
+	for v, nexts := range outgoing {
+		starts = append(starts, v)
+		sort.Slice(nexts, func(i, j int) bool { return angleLess(nexts[i].Sub(v), nexts[j].Sub(v)) })
+	}
+	sort.Slice(starts, func(i, j int) bool { return pointLess(starts[i], starts[j]) })
+
+	var result Paths64
+	for _, start := range starts {
+		for len(outgoing[start]) > 0 {
+			ring := Path64{start}
+			prev, v := start, outgoing[start][0]
+			outgoing[start] = outgoing[start][1:]
+			for v != start {
+				ring = append(ring, v)
+				prev, v = v, takeEdge(outgoing, v, prev)
+			}
+			result = append(result, ring)
+		}
+	}
+	return result
+}
+
+// netEdges counts the directed edges of rings, cancelling each against the
+// edges traversed in the opposite direction, and returns those left over
+func netEdges(rings Paths64) map[[2]Point64]int {
+	count := make(map[[2]Point64]int)
+	for _, ring := range rings {
+		for i, a := range ring {
+			if b := ring[(i+1)%len(ring)]; a != b {
+				count[[2]Point64{a, b}]++
+			}
+		}
+	}
+	net := make(map[[2]Point64]int)
+	for e, n := range count {
+		if n -= count[[2]Point64{e[1], e[0]}]; n > 0 {
+			net[e] = n
+		}
+	}
+	return net
+}
+
+// takeEdge removes and returns the edge leaving v that turns furthest left
+// when arriving from prev: the first one clockwise from the way back
+func takeEdge(outgoing map[Point64][]Point64, v, prev Point64) Point64 {
+	nexts := outgoing[v]
+	back := prev.Sub(v)
+	pick := len(nexts) - 1
+	for i, n := range nexts {
+		if !angleLess(n.Sub(v), back) {
+			pick = (i + len(nexts) - 1) % len(nexts)
+			break
+		}
+	}
+	next := nexts[pick]
+	outgoing[v] = append(nexts[:pick], nexts[pick+1:]...)
+	return next
+}
@@ -0,0 +1,122 @@
+package clipper
+
+import (
+	"errors"
+	"testing"
+)
+
+func square(x, y, size int64) Path64 {
+	return Path64{{x, y}, {x + size, y}, {x + size, y + size}, {x, y + size}}
+}
+
+func TestDissolve64(t *testing.T) {
+	tests := []struct {
+		name     string
+		polygons []Paths64
+		rings    int
+		area     float64
+	}{
+		{
+			name:     "exact shared edge",
+			polygons: []Paths64{{square(0, 0, 100)}, {square(100, 0, 100)}},
+			rings:    1,
+			area:     20000,
+		},
+		{
+			name:     "partially shared edge",
+			polygons: []Paths64{{square(0, 0, 100)}, {square(100, 50, 100)}},
+			rings:    1,
+			area:     20000,
+		},
+		{
+			name: "boundary one unit apart",
+			polygons: []Paths64{
+				{square(0, 0, 100)},
+				{{{101, 0}, {200, 0}, {200, 100}, {101, 100}}},
+			},
+			rings: 1,
+			area:  20000,
+		},
+		{
+			name: "vertices near an edge",
+			polygons: []Paths64{
+				{square(0, 0, 100)},
+				{{{101, 20}, {200, 0}, {200, 100}, {101, 80}}},
+			},
+			rings: 1,
+			area:  18000,
+		},
+		{
+			name: "ring of four around a hole",
+			polygons: []Paths64{
+				{{{0, 0}, {300, 0}, {300, 100}, {0, 100}}},
+				{{{0, 200}, {300, 200}, {300, 300}, {0, 300}}},
+				{{{0, 100}, {100, 100}, {100, 200}, {0, 200}}},
+				{{{200, 100}, {300, 100}, {300, 200}, {200, 200}}},
+			},
+			rings: 2,
+			area:  80000,
+		},
+		{
+			name:     "overlapping",
+			polygons: []Paths64{{square(0, 0, 100)}, {square(50, 50, 100)}},
+			rings:    1,
+			area:     17500,
+		},
+		{
+			name:     "disjoint",
+			polygons: []Paths64{{square(0, 0, 100)}, {square(300, 0, 100)}},
+			rings:    2,
+			area:     20000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Dissolve64(tt.polygons, NonZero)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			stats := Stats64(result)
+			if stats.Rings != tt.rings || stats.NetArea != tt.area {
+				t.Errorf("expected %d rings of area %v, got %d of %v: %v", tt.rings, tt.area, stats.Rings, stats.NetArea, result)
+			}
+		})
+	}
+}
+
+func TestDissolve64ExactOnly(t *testing.T) {
+	polygons := []Paths64{
+		{square(0, 0, 100)},
+		{{{101, 0}, {200, 0}, {200, 100}, {101, 100}}},
+	}
+	result, err := Dissolve64(polygons, NonZero, DissolveOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result) != 2 {
+		t.Errorf("expected the gap to remain without snapping, got %v", result)
+	}
+
+	if _, err := Dissolve64(polygons, NonZero, DissolveOptions{SnapTolerance: -1}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for negative tolerance, got %v", err)
+	}
+}
+
+func TestDissolve64Grid(t *testing.T) {
+	// a grid of cells, each its own polygon, dissolves into one square
+	var polygons []Paths64
+	for row := int64(0); row < 8; row++ {
+		for col := int64(0); col < 8; col++ {
+			polygons = append(polygons, Paths64{square(col*10, row*10, 10)})
+		}
+	}
+	result, err := Dissolve64(polygons, NonZero)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := Paths64{{{0, 0}, {80, 0}, {80, 80}, {0, 80}}}
+	if !sameRings(result, expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+}
@@ -0,0 +1,222 @@
+package clipper
+
+import (
+	"math"
+	"sort"
+)
+
+// ==============================================================================
+// Signed Distance Fields
+// ==============================================================================
+
+// DistanceField64 samples the signed distance to the boundary of the region
+// paths fill under NonZero at the centre of every cell of a square grid over
+// bounds: positive inside, negative outside, zero on the boundary. The
+// result is indexed [row][col] like CoverageGrid, row 0 and column 0 being
+// the cell at bounds.Left, bounds.Top, and covers bounds completely. The
+// paths are unioned first, so edges buried inside overlapping rings do not
+// count as boundary; an empty region gives -Inf everywhere. Distances come
+// from a bucket index of the boundary edges, so each sample visits only the
+// edges near it. This is enough for glow effects or inset previews without a
+// separate SDF library.
+//
+// cell must be positive and bounds valid, otherwise ErrInvalidInput is
+// returned; grids of more than 2^24 cells fail with ErrComplexityExceeded.
+func DistanceField64(paths Paths64, bounds Rect64, cell int64) ([][]float64, error) {
+	if cell <= 0 || !bounds.IsValid() {
+		return nil, ErrInvalidInput
+	}
+	cols := (uint64(bounds.Width()) + uint64(cell) - 1) / uint64(cell)
+	rows := (uint64(bounds.Height()) + uint64(cell) - 1) / uint64(cell)
+	if cols == 0 {
+		cols = 1
+	}
+	if rows == 0 {
+		rows = 1
+	}
+	if cols > maxCoverageCells || rows > maxCoverageCells/cols {
+		return nil, ErrComplexityExceeded
+	}
+
+	filled, err := Union64(paths, nil, NonZero)
+	if err != nil {
+		return nil, err
+	}
+	segs := appendAreaSegments(nil, filled, PathTypeSubject)
+	index := newEdgeIndex(appendPathEdges(nil, filled, true), int(rows*cols))
+
+	field := make([][]float64, rows)
+	for row := range field {
+		field[row] = make([]float64, cols)
+		y := float64(bounds.Top) + (float64(row)+0.5)*float64(cell)
+		inside := filledSpans(segs, y)
+		for col := range field[row] {
+			x := float64(bounds.Left) + (float64(col)+0.5)*float64(cell)
+			d, _ := index.nearest(x, y, nil)
+			for len(inside) > 0 && inside[1] < x {
+				inside = inside[2:]
+			}
+			if len(inside) == 0 || x < inside[0] {
+				d = -d
+			}
+			field[row][col] = d
+		}
+	}
+	return field, nil
+}
+
+// filledSpans returns the X ranges, as consecutive pairs, where the
+// horizontal line at y lies inside the region bounded by segs under NonZero
+func filledSpans(segs []areaSegment, y float64) []float64 {
+	type crossing struct {
+		x   float64
+		dir int
+	}
+	var crossings []crossing
+	for _, s := range segs {
+		if float64(s.bot.Y) <= y && y < float64(s.top.Y) {
+			crossings = append(crossings, crossing{segmentXAt(s, y), s.dir})
+		}
+	}
+	sort.Slice(crossings, func(i, j int) bool { return crossings[i].x < crossings[j].x })
+
+	var spans []float64
+	wind := 0
+	for _, c := range crossings {
+		wasFilled := wind != 0
+		wind -= c.dir
+		if isFilled := wind != 0; isFilled != wasFilled {
+			spans = append(spans, c.x)
+		}
+	}
+	return spans
+}
+
+// ==============================================================================
+// Nearest-Edge Index
+// ==============================================================================
+
+// edgeIndex buckets edges on a square grid, so the edge nearest to a point is
+// found by searching the buckets around it outwards
+type edgeIndex struct {
+	edges      [][2]PointD
+	origin     PointD
+	side       float64 // bucket side length
+	cols, rows int
+	buckets    [][]int // edge indices overlapping each bucket, row by row
+}
+
+// appendPathEdges appends the edges of paths, including the closing edge of
+// each when isClosed is set
+func appendPathEdges(edges [][2]PointD, paths Paths64, isClosed bool) [][2]PointD {
+	for _, path := range paths {
+		n := len(path)
+		if !isClosed {
+			n--
+		}
+		for i := 0; i < n; i++ {
+			a, b := path[i], path[(i+1)%len(path)]
+			edges = append(edges, [2]PointD{
+				{X: float64(a.X), Y: float64(a.Y)},
+				{X: float64(b.X), Y: float64(b.Y)},
+			})
+		}
+	}
+	return edges
+}
+
+// newEdgeIndex indexes edges with about one bucket per edge, but not many
+// more buckets than queries
+func newEdgeIndex(edges [][2]PointD, queries int) *edgeIndex {
+	index := &edgeIndex{edges: edges}
+	if len(edges) == 0 {
+		return index
+	}
+
+	lo, hi := edges[0][0], edges[0][0]
+	for _, e := range edges {
+		for _, pt := range e {
+			lo = PointD{X: math.Min(lo.X, pt.X), Y: math.Min(lo.Y, pt.Y)}
+			hi = PointD{X: math.Max(hi.X, pt.X), Y: math.Max(hi.Y, pt.Y)}
+		}
+	}
+	extent := math.Max(hi.X-lo.X, hi.Y-lo.Y)
+	perSide := math.Ceil(math.Sqrt(float64(min(len(edges), max(queries, 1)))))
+	index.side = math.Max(extent/perSide, 1)
+	index.origin = lo
+	index.cols = int((hi.X-lo.X)/index.side) + 1
+	index.rows = int((hi.Y-lo.Y)/index.side) + 1
+	index.buckets = make([][]int, index.cols*index.rows)
+
+	for i, e := range index.edges {
+		c0, r0 := index.bucketOf(math.Min(e[0].X, e[1].X), math.Min(e[0].Y, e[1].Y))
+		c1, r1 := index.bucketOf(math.Max(e[0].X, e[1].X), math.Max(e[0].Y, e[1].Y))
+		for r := r0; r <= r1; r++ {
+			for c := c0; c <= c1; c++ {
+				index.buckets[r*index.cols+c] = append(index.buckets[r*index.cols+c], i)
+			}
+		}
+	}
+	return index
+}
+
+// bucketOf returns the bucket containing x, y, clamped to the grid
+func (index *edgeIndex) bucketOf(x, y float64) (col, row int) {
+	col = int(math.Floor((x - index.origin.X) / index.side))
+	row = int(math.Floor((y - index.origin.Y) / index.side))
+	return min(max(col, 0), index.cols-1), min(max(row, 0), index.rows-1)
+}
+
+// nearest returns the distance from x, y to the nearest indexed edge not
+// skipped, and its index; without one it returns +Inf and -1. Rings of
+// buckets are searched until no edge in an unsearched bucket can be closer
+// than the best found.
+func (index *edgeIndex) nearest(x, y float64, skip func(i int) bool) (float64, int) {
+	best, bestIdx := math.Inf(1), -1
+	if len(index.edges) == 0 {
+		return best, bestIdx
+	}
+	col, row := index.bucketOf(x, y)
+
+	// Points outside the grid are at least this far from every bucket
+	outside := math.Max(math.Max(index.origin.X-x, x-index.origin.X-float64(index.cols)*index.side), 0)
+	outside = math.Max(outside, math.Max(index.origin.Y-y, y-index.origin.Y-float64(index.rows)*index.side))
+
+	for r := 0; ; r++ {
+		if r > 0 && best <= math.Max(outside, float64(r-1)*index.side) {
+			return best, bestIdx
+		}
+		if col-r < 0 && row-r < 0 && col+r >= index.cols && row+r >= index.rows {
+			return best, bestIdx
+		}
+		for br := row - r; br <= row+r; br++ {
+			if br < 0 || br >= index.rows {
+				continue
+			}
+			for bc := col - r; bc <= col+r; bc++ {
+				if bc < 0 || bc >= index.cols || (br != row-r && br != row+r && bc != col-r && bc != col+r) {
+					continue
+				}
+				for _, i := range index.buckets[br*index.cols+bc] {
+					if skip != nil && skip(i) {
+						continue
+					}
+					// Ties go to the lowest index, whatever the search order
+					if d := pointSegmentDistance(x, y, index.edges[i]); d < best || (d == best && i < bestIdx) {
+						best, bestIdx = d, i
+					}
+				}
+			}
+		}
+	}
+}
+
+// pointSegmentDistance returns the distance from x, y to the segment e
+func pointSegmentDistance(x, y float64, e [2]PointD) float64 {
+	dx, dy := e[1].X-e[0].X, e[1].Y-e[0].Y
+	t := 0.0
+	if lenSq := dx*dx + dy*dy; lenSq > 0 {
+		t = math.Max(0, math.Min(1, ((x-e[0].X)*dx+(y-e[0].Y)*dy)/lenSq))
+	}
+	return math.Hypot(x-e[0].X-t*dx, y-e[0].Y-t*dy)
+}
@@ -0,0 +1,83 @@
+package clipper
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// TestDistanceField64 tests signs and distances against a brute-force search
+func TestDistanceField64(t *testing.T) {
+	square := Paths64{{{0, 0}, {100, 0}, {100, 100}, {0, 100}}}
+	field, err := DistanceField64(square, Rect64{Left: -20, Top: -20, Right: 120, Bottom: 120}, 20)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(field) != 7 || len(field[0]) != 7 {
+		t.Fatalf("expected a 7x7 grid, got %dx%d", len(field), len(field[0]))
+	}
+	tests := []struct {
+		row, col int
+		expected float64
+	}{
+		{3, 3, 50},               // centre (50, 50)
+		{1, 1, 10},               // (10, 10), inside near a corner
+		{0, 3, -10},              // (50, -10), above the top edge
+		{0, 0, -10 * math.Sqrt2}, // (-10, -10), nearest to the corner
+		{6, 6, -10 * math.Sqrt2}, // (110, 110)
+		{3, 0, -10},              // (-10, 50)
+	}
+	for _, test := range tests {
+		if got := field[test.row][test.col]; math.Abs(got-test.expected) > 1e-9 {
+			t.Errorf("cell %d,%d: expected %v, got %v", test.row, test.col, test.expected, got)
+		}
+	}
+
+	// Overlapping rings and a hole, checked against every boundary edge
+	paths := Paths64{
+		{{0, 0}, {300, 0}, {300, 200}, {0, 200}},
+		{{250, 150}, {400, 120}, {350, 300}},
+		{{50, 50}, {50, 150}, {150, 150}, {150, 50}},
+	}
+	bounds := Rect64{Left: -50, Top: -50, Right: 450, Bottom: 350}
+	field, err = DistanceField64(paths, bounds, 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	union, _ := Union64(paths, nil, NonZero)
+	for row := range field {
+		for col := range field[row] {
+			x := float64(bounds.Left) + (float64(col)+0.5)*7
+			y := float64(bounds.Top) + (float64(row)+0.5)*7
+			expected := math.Inf(1)
+			for _, ring := range union {
+				for i, a := range ring {
+					b := ring[(i+1)%len(ring)]
+					expected = math.Min(expected, pointSegmentDistance(x, y, [2]PointD{{X: float64(a.X), Y: float64(a.Y)}, {X: float64(b.X), Y: float64(b.Y)}}))
+				}
+			}
+			pt := Point64{X: int64(math.Floor(x)), Y: int64(math.Floor(y))}
+			inside := false
+			for _, ring := range union {
+				inside = inside != (PointInPolygon(pt, ring, EvenOdd) == Inside)
+			}
+			if !inside {
+				expected = -expected
+			}
+			if math.Abs(expected) < 1 {
+				continue // the rounded point may lie on the other side
+			}
+			if math.Abs(field[row][col]-expected) > 1e-9 {
+				t.Fatalf("cell %d,%d at (%v, %v): expected %v, got %v", row, col, x, y, expected, field[row][col])
+			}
+		}
+	}
+
+	empty, err := DistanceField64(nil, Rect64{Left: 0, Top: 0, Right: 10, Bottom: 10}, 5)
+	if err != nil || !math.IsInf(empty[1][1], -1) {
+		t.Errorf("expected -Inf for an empty region, got %v (err %v)", empty, err)
+	}
+	if _, err := DistanceField64(square, Rect64{Left: 0, Top: 0, Right: 10, Bottom: 10}, 0); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for a zero cell, got %v", err)
+	}
+}
@@ -0,0 +1,206 @@
+package clipper
+
+import (
+	"errors"
+	"sync"
+)
+
+// ==============================================================================
+// Runtime Engine Selection
+// ==============================================================================
+
+// Engine selects the implementation behind BooleanOp64, AreaOfBooleanOp64
+// and InflatePaths64 at run time
+type Engine uint8
+
+const (
+	EngineDefault        Engine = iota // as compiled: the oracle with -tags=clipper_cgo, pure Go otherwise
+	EngineGo                           // the pure Go port
+	EngineCGO                          // the registered C++ backend
+	EngineGoWithFallback               // pure Go, retrying with the C++ backend when it fails
+)
+
+// String returns the name of the engine
+func (e Engine) String() string {
+	switch e {
+	case EngineDefault:
+		return "Default"
+	case EngineGo:
+		return "Go"
+	case EngineCGO:
+		return "CGO"
+	case EngineGoWithFallback:
+		return "GoWithFallback"
+	default:
+		return "Unknown"
+	}
+}
+
+// Backend is an alternative clipping implementation, normally the C++
+// Clipper2 library registered by importing the cgoengine package
+type Backend interface {
+	BooleanOp64(clipType ClipType, fillRule FillRule, subjects, subjectsOpen, clips Paths64) (solution, solutionOpen Paths64, err error)
+	InflatePaths64(paths Paths64, delta float64, joinType JoinType, endType EndType, opts OffsetOptions) (Paths64, error)
+}
+
+var (
+	engineMu      sync.RWMutex
+	currentEngine Engine
+	cgoBackend    Backend
+)
+
+// RegisterCGOBackend makes b available as EngineCGO. The cgoengine package
+// calls it from init; registering again replaces the previous backend.
+func RegisterCGOBackend(b Backend) {
+	engineMu.Lock()
+	defer engineMu.Unlock()
+	cgoBackend = b
+}
+
+// SetEngine selects the engine used by all subsequent operations, so an
+// application can A/B the pure Go port against the C++ library without
+// rebuilding. EngineCGO and EngineGoWithFallback fail with ErrNotImplemented
+// until a backend is registered; unknown engines fail with ErrInvalidInput.
+func SetEngine(e Engine) error {
+	engineMu.Lock()
+	defer engineMu.Unlock()
+	switch e {
+	case EngineDefault, EngineGo:
+	case EngineCGO, EngineGoWithFallback:
+		if cgoBackend == nil {
+			return ErrNotImplemented
+		}
+	default:
+		return ErrInvalidInput
+	}
+	currentEngine = e
+	return nil
+}
+
+// CurrentEngine returns the engine selected with SetEngine
+func CurrentEngine() Engine {
+	engineMu.RLock()
+	defer engineMu.RUnlock()
+	return currentEngine
+}
+
+// selectedEngine returns the current engine and backend together
+func selectedEngine() (Engine, Backend) {
+	engineMu.RLock()
+	defer engineMu.RUnlock()
+	return currentEngine, cgoBackend
+}
+
+// shouldFallBack reports whether an error of the pure Go port warrants
+// retrying with the backend: execution failures and missing features, not
+// problems with the input
+func shouldFallBack(err error) bool {
+	return errors.Is(err, ErrClipperExecution) || errors.Is(err, ErrNotImplemented)
+}
+
+// engineBooleanOp64 runs a boolean operation on the selected engine
+func engineBooleanOp64(clipType ClipType, fillRule FillRule, subjects, subjectsOpen, clips Paths64) (Paths64, Paths64, error) {
+	return engineBooleanOp64On(nil, clipType, fillRule, subjects, subjectsOpen, clips)
+}
+
+// engineBooleanOp64On is engineBooleanOp64 sweeping on ve, if not nil, when
+// the pure Go engine is selected
+func engineBooleanOp64On(ve *VattiEngine, clipType ClipType, fillRule FillRule, subjects, subjectsOpen, clips Paths64) (Paths64, Paths64, error) {
+	engine, backend := selectedEngine()
+	switch engine {
+	case EngineGo, EngineGoWithFallback:
+		solution, solutionOpen, err := renewVattiEngine(ve, clipType, fillRule).ExecuteClipping(subjects, subjectsOpen, clips)
+		if engine == EngineGoWithFallback && shouldFallBack(err) {
+			return backend.BooleanOp64(clipType, fillRule, subjects, subjectsOpen, clips)
+		}
+		return solution, solutionOpen, err
+	case EngineCGO:
+		return backend.BooleanOp64(clipType, fillRule, subjects, subjectsOpen, clips)
+	default:
+		if ve != nil && defaultEngineIsGo {
+			return renewVattiEngine(ve, clipType, fillRule).ExecuteClipping(subjects, subjectsOpen, clips)
+		}
+		return booleanOp64Impl(clipType, fillRule, subjects, subjectsOpen, clips)
+	}
+}
+
+// engineDifferenceWithRemainder64 splits subjects by clips on the selected
+// engine. The pure Go port does so in one layered sweep; backends have no
+// layered sweep, so they run a difference and an intersection.
+func engineDifferenceWithRemainder64(fillRule FillRule, subjects, clips Paths64) (difference, remainder Paths64, err error) {
+	engine, backend := selectedEngine()
+	switch engine {
+	case EngineGo, EngineGoWithFallback:
+		difference, remainder, err = NewVattiEngine(Difference, fillRule).executeDifferenceWithRemainder(subjects, clips)
+		if engine == EngineGoWithFallback && shouldFallBack(err) {
+			return differenceWithRemainderOf(backend.BooleanOp64, fillRule, subjects, clips)
+		}
+		return difference, remainder, err
+	case EngineCGO:
+		return differenceWithRemainderOf(backend.BooleanOp64, fillRule, subjects, clips)
+	default:
+		if defaultEngineIsGo {
+			return NewVattiEngine(Difference, fillRule).executeDifferenceWithRemainder(subjects, clips)
+		}
+		return differenceWithRemainderOf(booleanOp64Impl, fillRule, subjects, clips)
+	}
+}
+
+// differenceWithRemainderOf splits subjects by clips with two runs of a
+// boolean operation
+func differenceWithRemainderOf(booleanOp func(ClipType, FillRule, Paths64, Paths64, Paths64) (Paths64, Paths64, error),
+	fillRule FillRule, subjects, clips Paths64) (difference, remainder Paths64, err error) {
+	if difference, _, err = booleanOp(Difference, fillRule, subjects, nil, clips); err != nil {
+		return nil, nil, err
+	}
+	if remainder, _, err = booleanOp(Intersection, fillRule, subjects, nil, clips); err != nil {
+		return nil, nil, err
+	}
+	return difference, remainder, nil
+}
+
+// engineAreaOfBooleanOp64 computes the area of a boolean operation on the
+// selected engine; backends have no area-only entry point, so their
+// solution's signed area is summed
+func engineAreaOfBooleanOp64(clipType ClipType, fillRule FillRule, subjects, clips Paths64) (float64, error) {
+	engine, backend := selectedEngine()
+	backendArea := func() (float64, error) {
+		solution, _, err := backend.BooleanOp64(clipType, fillRule, subjects, nil, clips)
+		if err != nil {
+			return 0, err
+		}
+		area := 0.0
+		for _, path := range solution {
+			area += Area64(path)
+		}
+		return area, nil
+	}
+
+	switch engine {
+	case EngineGo, EngineGoWithFallback:
+		area, err := NewVattiEngine(clipType, fillRule).ExecuteArea(subjects, clips)
+		if engine == EngineGoWithFallback && shouldFallBack(err) {
+			return backendArea()
+		}
+		return area, err
+	case EngineCGO:
+		return backendArea()
+	default:
+		return areaOfBooleanOp64Impl(clipType, fillRule, subjects, clips)
+	}
+}
+
+// engineInflatePaths offsets paths on the selected engine. The pure Go port
+// does not implement offsetting yet, so EngineGo fails with ErrNotImplemented
+// and EngineGoWithFallback always uses the backend.
+func engineInflatePaths(paths Paths64, delta float64, joinType JoinType, endType EndType, opts OffsetOptions) (Paths64, error) {
+	engine, backend := selectedEngine()
+	switch engine {
+	case EngineGo:
+		return nil, ErrNotImplemented
+	case EngineCGO, EngineGoWithFallback:
+		return backend.InflatePaths64(paths, delta, joinType, endType, opts)
+	default:
+		return inflatePathsImpl(paths, delta, joinType, endType, opts)
+	}
+}
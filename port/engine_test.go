@@ -0,0 +1,91 @@
+package clipper
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// fakeBackend returns fixed results so tests can tell which engine ran
+type fakeBackend struct{}
+
+var fakeResult = Paths64{{{0, 0}, {4, 0}, {0, 4}}}
+
+func (fakeBackend) BooleanOp64(ClipType, FillRule, Paths64, Paths64, Paths64) (Paths64, Paths64, error) {
+	return fakeResult, nil, nil
+}
+
+func (fakeBackend) InflatePaths64(Paths64, float64, JoinType, EndType, OffsetOptions) (Paths64, error) {
+	return fakeResult, nil
+}
+
+// withBackend runs f with b registered and restores the engine state after
+func withBackend(t *testing.T, b Backend, f func()) {
+	t.Helper()
+	engineMu.Lock()
+	savedEngine, savedBackend := currentEngine, cgoBackend
+	cgoBackend = b
+	engineMu.Unlock()
+	defer func() {
+		engineMu.Lock()
+		currentEngine, cgoBackend = savedEngine, savedBackend
+		engineMu.Unlock()
+	}()
+	f()
+}
+
+func TestSetEngine(t *testing.T) {
+	withBackend(t, nil, func() {
+		for _, e := range []Engine{EngineCGO, EngineGoWithFallback} {
+			if err := SetEngine(e); !errors.Is(err, ErrNotImplemented) {
+				t.Errorf("%v without backend: expected ErrNotImplemented, got %v", e, err)
+			}
+		}
+		if err := SetEngine(Engine(99)); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("expected ErrInvalidInput for an unknown engine, got %v", err)
+		}
+		if err := SetEngine(EngineGo); err != nil || CurrentEngine() != EngineGo {
+			t.Errorf("expected EngineGo to be selected, got %v, %v", CurrentEngine(), err)
+		}
+	})
+}
+
+func TestEngineDispatch(t *testing.T) {
+	subjects := Paths64{{{0, 0}, {10, 0}, {10, 10}, {0, 10}}}
+	pure, _, err := NewVattiEngine(Union, NonZero).ExecuteClipping(subjects, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	withBackend(t, fakeBackend{}, func() {
+		tests := []struct {
+			engine  Engine
+			boolean Paths64
+			inflate Paths64
+			err     error
+		}{
+			{engine: EngineGo, boolean: pure, err: ErrNotImplemented},
+			{engine: EngineCGO, boolean: fakeResult, inflate: fakeResult},
+			{engine: EngineGoWithFallback, boolean: pure, inflate: fakeResult},
+		}
+		for _, tt := range tests {
+			if err := SetEngine(tt.engine); err != nil {
+				t.Fatalf("SetEngine(%v): %v", tt.engine, err)
+			}
+			if got, _ := Union64(subjects, nil, NonZero); !reflect.DeepEqual(got, tt.boolean) {
+				t.Errorf("%v: expected union %v, got %v", tt.engine, tt.boolean, got)
+			}
+			got, err := InflatePaths64(subjects, 1, Round, ClosedPolygon)
+			if !errors.Is(err, tt.err) || !reflect.DeepEqual(got, tt.inflate) {
+				t.Errorf("%v: expected inflate %v, %v; got %v, %v", tt.engine, tt.inflate, tt.err, got, err)
+			}
+		}
+
+		if err := SetEngine(EngineCGO); err != nil {
+			t.Fatalf("SetEngine: %v", err)
+		}
+		if area, err := AreaOfBooleanOp64(Union, NonZero, subjects, nil); err != nil || area != Area64(fakeResult[0]) {
+			t.Errorf("expected the backend's area %v, got %v, %v", Area64(fakeResult[0]), area, err)
+		}
+	})
+}
@@ -2,16 +2,108 @@ package clipper
 
 import "errors"
 
+// ErrorCode classifies library errors so callers can branch on the failure
+// class without matching messages. Codes are stable across versions; new
+// codes are only ever appended.
+type ErrorCode uint8
+
+const (
+	CodeNone               ErrorCode = iota // no error
+	CodeUnknown                             // error not produced by this package
+	CodeInvalidRectangle                    // rectangle argument is malformed
+	CodeNotImplemented                      // feature not available in this build
+	CodeInvalidInput                        // invalid input parameters
+	CodeExecution                           // clipping failed during execution
+	CodeInternalTopology                    // engine reached an inconsistent internal state
+	CodeComplexityExceeded                  // input or output exceeded a complexity limit
+	CodeStalled                             // sweep exhausted its work budget
+)
+
+// String returns the name of the error code
+func (c ErrorCode) String() string {
+	switch c {
+	case CodeNone:
+		return "None"
+	case CodeInvalidRectangle:
+		return "InvalidRectangle"
+	case CodeNotImplemented:
+		return "NotImplemented"
+	case CodeInvalidInput:
+		return "InvalidInput"
+	case CodeExecution:
+		return "Execution"
+	case CodeInternalTopology:
+		return "InternalTopology"
+	case CodeComplexityExceeded:
+		return "ComplexityExceeded"
+	case CodeStalled:
+		return "Stalled"
+	default:
+		return "Unknown"
+	}
+}
+
+// ClipError is the concrete type of every sentinel error in this package.
+// Errors returned by the API either are a sentinel or wrap one, so
+// errors.As(err, &clipErr) recovers the code and errors.Is matches by code.
+type ClipError struct {
+	Code    ErrorCode
+	Message string
+	Err     error // broader class this error belongs to, if any
+}
+
+// Error implements the error interface
+func (e *ClipError) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the broader error class, so for example an internal
+// topology failure also matches ErrClipperExecution
+func (e *ClipError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is a ClipError with the same code
+func (e *ClipError) Is(target error) bool {
+	t, ok := target.(*ClipError)
+	return ok && t.Code == e.Code
+}
+
+// ErrorCodeOf returns the most specific code carried by err: CodeNone for nil
+// and CodeUnknown for errors that do not originate from this package
+func ErrorCodeOf(err error) ErrorCode {
+	if err == nil {
+		return CodeNone
+	}
+	var clipErr *ClipError
+	if errors.As(err, &clipErr) {
+		return clipErr.Code
+	}
+	return CodeUnknown
+}
+
 var (
 	// ErrInvalidRectangle indicates an invalid rectangle was provided
-	ErrInvalidRectangle = errors.New("invalid rectangle: must have exactly 4 points")
+	ErrInvalidRectangle error = &ClipError{Code: CodeInvalidRectangle, Message: "invalid rectangle: must have exactly 4 points"}
 
 	// ErrNotImplemented indicates a feature is not yet implemented
-	ErrNotImplemented = errors.New("not implemented yet")
+	ErrNotImplemented error = &ClipError{Code: CodeNotImplemented, Message: "not implemented yet"}
 
 	// ErrInvalidInput indicates invalid input parameters
-	ErrInvalidInput = errors.New("invalid input parameters")
+	ErrInvalidInput error = &ClipError{Code: CodeInvalidInput, Message: "invalid input parameters"}
 
 	// ErrClipperExecution indicates the clipper algorithm failed during execution
-	ErrClipperExecution = errors.New("clipper execution failed")
+	ErrClipperExecution error = &ClipError{Code: CodeExecution, Message: "clipper execution failed"}
+
+	// ErrInternalTopology indicates the engine's internal edge or output
+	// structure became inconsistent (a bug or unsupported degenerate input)
+	ErrInternalTopology error = &ClipError{Code: CodeInternalTopology, Message: "internal topology error", Err: ErrClipperExecution}
+
+	// ErrComplexityExceeded indicates an operation was aborted because it
+	// exceeded a configured complexity limit
+	ErrComplexityExceeded error = &ClipError{Code: CodeComplexityExceeded, Message: "complexity limit exceeded", Err: ErrClipperExecution}
+
+	// ErrClipperStalled indicates the sweep exhausted its SweepBudget, which
+	// a valid sweep never does; the *StallError returned tells where
+	ErrClipperStalled error = &ClipError{Code: CodeStalled, Message: "clipper sweep stalled", Err: ErrClipperExecution}
 )
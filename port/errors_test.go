@@ -0,0 +1,55 @@
+package clipper
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// TestErrorCodes tests error classification through Is/As and ErrorCodeOf
+func TestErrorCodes(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code ErrorCode
+	}{
+		{"Nil", nil, CodeNone},
+		{"Foreign", errors.New("boom"), CodeUnknown},
+		{"Sentinel", ErrInvalidInput, CodeInvalidInput},
+		{"Wrapped sentinel", fmt.Errorf("loading: %w", ErrInvalidRectangle), CodeInvalidRectangle},
+		{"Most specific code wins", fmt.Errorf("%w: at Y=3", ErrInternalTopology), CodeInternalTopology},
+		{"Complexity", ErrComplexityExceeded, CodeComplexityExceeded},
+		{"Stalled", ErrClipperStalled, CodeStalled},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if code := ErrorCodeOf(test.err); code != test.code {
+				t.Errorf("Expected code %v, got %v", test.code, code)
+			}
+		})
+	}
+
+	t.Run("Internal failures are execution failures", func(t *testing.T) {
+		err := fmt.Errorf("%w: missing maxima pair", ErrInternalTopology)
+		if !errors.Is(err, ErrInternalTopology) || !errors.Is(err, ErrClipperExecution) {
+			t.Errorf("Expected %v to match both ErrInternalTopology and ErrClipperExecution", err)
+		}
+		if errors.Is(err, ErrInvalidInput) {
+			t.Error("Expected internal failure not to match ErrInvalidInput")
+		}
+	})
+
+	t.Run("Match by code", func(t *testing.T) {
+		if !errors.Is(ErrComplexityExceeded, &ClipError{Code: CodeComplexityExceeded}) {
+			t.Error("Expected errors.Is to match a ClipError with the same code")
+		}
+	})
+
+	t.Run("API errors carry codes", func(t *testing.T) {
+		_, err := RectClip64(Path64{{0, 0}, {1, 1}}, nil)
+		if ErrorCodeOf(err) != CodeInvalidRectangle {
+			t.Errorf("Expected CodeInvalidRectangle, got %v (%v)", ErrorCodeOf(err), err)
+		}
+	})
+}
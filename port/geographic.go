@@ -0,0 +1,153 @@
+package clipper
+
+import "math"
+
+// ==============================================================================
+// Geographic Buffering
+// ==============================================================================
+
+// earthRadius is the mean Earth radius in meters (IUGG)
+const earthRadius = 6371008.8
+
+// GeoBufferOptions controls BufferGeographic
+type GeoBufferOptions struct {
+	JoinType JoinType // corner style (default without options: Round)
+	EndType  EndType  // ClosedPolygon for areas, an open type for lines
+
+	// Resolution is the number of integer units per meter in the projected
+	// plane (default: 1000, i.e. millimeters)
+	Resolution float64
+
+	// ArcTolerance is the largest deviation of rounded corners from a true
+	// arc, in meters (default: 0.1% of the buffer distance)
+	ArcTolerance float64
+
+	MiterLimit float64 // as OffsetOptions.MiterLimit (default: DefaultMiterLimit)
+}
+
+// BufferGeographic buffers paths given in degrees (X = longitude, Y =
+// latitude) by a distance in meters. Buffering in degree space distorts
+// badly away from the equator, so the paths are projected onto an azimuthal
+// equidistant plane centered on their bounds, offset there with
+// InflatePaths64 and projected back. Distances are exact from the center and
+// stay accurate for extents of a few hundred kilometers. The offset in the
+// plane needs the C++ library, as InflatePaths64 documents.
+//
+// Latitudes must lie within [-90, 90] and all values must be finite,
+// otherwise ErrInvalidInput is returned.
+func BufferGeographic(paths PathsD, meters float64, opts ...GeoBufferOptions) (PathsD, error) {
+	options := GeoBufferOptions{JoinType: Round}
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	if options.Resolution <= 0 {
+		options.Resolution = 1000
+	}
+	if options.ArcTolerance <= 0 {
+		options.ArcTolerance = math.Abs(meters) / 1000
+	}
+	if options.MiterLimit == 0 {
+		options.MiterLimit = DefaultMiterLimit
+	}
+	if math.IsNaN(meters) || math.IsInf(meters, 0) {
+		return nil, ErrInvalidInput
+	}
+
+	minPt, maxPt, ok := geoBounds(paths)
+	if !ok {
+		return nil, ErrInvalidInput
+	}
+	if minPt.X > maxPt.X {
+		return PathsD{}, nil
+	}
+	proj := newAzimuthalEquidistant((minPt.X+maxPt.X)/2, (minPt.Y+maxPt.Y)/2)
+
+	projected := make(Paths64, len(paths))
+	for i, path := range paths {
+		projected[i] = make(Path64, len(path))
+		for j, pt := range path {
+			x, y := proj.forward(pt.X, pt.Y)
+			projected[i][j] = Point64{
+				X: RoundHalfAway(x * options.Resolution),
+				Y: RoundHalfAway(y * options.Resolution),
+			}
+		}
+	}
+
+	buffered, err := InflatePaths64(projected, meters*options.Resolution, options.JoinType, options.EndType,
+		OffsetOptions{
+			MiterLimit:   options.MiterLimit,
+			ArcTolerance: options.ArcTolerance * options.Resolution,
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(PathsD, len(buffered))
+	for i, path := range buffered {
+		result[i] = make(PathD, len(path))
+		for j, pt := range path {
+			lon, lat := proj.inverse(float64(pt.X)/options.Resolution, float64(pt.Y)/options.Resolution)
+			result[i][j] = PointD{X: lon, Y: lat}
+		}
+	}
+	return result, nil
+}
+
+// geoBounds returns the smallest and largest longitude/latitude of paths
+// (minPt.X > maxPt.X if empty); ok is false for non-finite values or
+// latitudes outside [-90, 90]
+func geoBounds(paths PathsD) (minPt, maxPt PointD, ok bool) {
+	minPt = PointD{X: math.Inf(1), Y: math.Inf(1)}
+	maxPt = PointD{X: math.Inf(-1), Y: math.Inf(-1)}
+	for _, path := range paths {
+		for _, pt := range path {
+			if math.IsNaN(pt.X) || math.IsInf(pt.X, 0) || math.IsNaN(pt.Y) || pt.Y < -90 || pt.Y > 90 {
+				return minPt, maxPt, false
+			}
+			minPt.X, minPt.Y = math.Min(minPt.X, pt.X), math.Min(minPt.Y, pt.Y)
+			maxPt.X, maxPt.Y = math.Max(maxPt.X, pt.X), math.Max(maxPt.Y, pt.Y)
+		}
+	}
+	return minPt, maxPt, true
+}
+
+// azimuthalEquidistant is a spherical azimuthal equidistant projection:
+// distances and directions from the center are preserved
+type azimuthalEquidistant struct {
+	lon0, sinLat0, cosLat0 float64
+}
+
+// newAzimuthalEquidistant centers the projection on lon0, lat0 (degrees)
+func newAzimuthalEquidistant(lon0, lat0 float64) azimuthalEquidistant {
+	lat := lat0 * math.Pi / 180
+	return azimuthalEquidistant{lon0: lon0 * math.Pi / 180, sinLat0: math.Sin(lat), cosLat0: math.Cos(lat)}
+}
+
+// forward projects degrees to meters east (x) and north (y) of the center
+func (p azimuthalEquidistant) forward(lon, lat float64) (x, y float64) {
+	phi, dLambda := lat*math.Pi/180, lon*math.Pi/180-p.lon0
+	sinPhi, cosPhi := math.Sin(phi), math.Cos(phi)
+	cosC := p.sinLat0*sinPhi + p.cosLat0*cosPhi*math.Cos(dLambda)
+	c := math.Acos(math.Max(-1, math.Min(1, cosC)))
+	k := 1.0
+	if c > 1e-12 {
+		k = c / math.Sin(c)
+	}
+	x = earthRadius * k * cosPhi * math.Sin(dLambda)
+	y = earthRadius * k * (p.cosLat0*sinPhi - p.sinLat0*cosPhi*math.Cos(dLambda))
+	return x, y
+}
+
+// inverse maps meters east and north of the center back to degrees
+func (p azimuthalEquidistant) inverse(x, y float64) (lon, lat float64) {
+	x, y = x/earthRadius, y/earthRadius
+	rho := math.Hypot(x, y)
+	if rho < 1e-15 {
+		return p.lon0 * 180 / math.Pi, math.Asin(p.sinLat0) * 180 / math.Pi
+	}
+	sinC, cosC := math.Sin(rho), math.Cos(rho)
+	phi := math.Asin(math.Max(-1, math.Min(1, cosC*p.sinLat0+y*sinC*p.cosLat0/rho)))
+	lambda := p.lon0 + math.Atan2(x*sinC, rho*p.cosLat0*cosC-y*p.sinLat0*sinC)
+	return lambda * 180 / math.Pi, phi * 180 / math.Pi
+}
@@ -0,0 +1,83 @@
+package clipper
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestAzimuthalEquidistantRoundTrip(t *testing.T) {
+	proj := newAzimuthalEquidistant(13.4, 52.5)
+	for _, pt := range []PointD{{13.4, 52.5}, {13.5, 52.6}, {12.9, 52.1}, {15.0, 54.0}} {
+		x, y := proj.forward(pt.X, pt.Y)
+		lon, lat := proj.inverse(x, y)
+		if math.Abs(lon-pt.X) > 1e-9 || math.Abs(lat-pt.Y) > 1e-9 {
+			t.Errorf("round trip of %v gave (%v, %v)", pt, lon, lat)
+		}
+	}
+}
+
+func TestAzimuthalEquidistantPreservesDistance(t *testing.T) {
+	// one degree of latitude along a meridian is R·π/180 meters at any latitude
+	expected := earthRadius * math.Pi / 180
+	for _, lat0 := range []float64{0, 45, 70} {
+		proj := newAzimuthalEquidistant(0, lat0)
+		x, y := proj.forward(0, lat0+1)
+		if math.Abs(x) > 1e-6 || math.Abs(y-expected) > 1e-6 {
+			t.Errorf("lat0 %v: expected (0, %v), got (%v, %v)", lat0, expected, x, y)
+		}
+	}
+
+	// east-west distances shrink with latitude, unlike degree space
+	proj := newAzimuthalEquidistant(0, 60)
+	x, _ := proj.forward(0.01, 60)
+	if math.Abs(x-expected*0.01*0.5) > 1 {
+		t.Errorf("expected ~%v meters east at 60°N, got %v", expected*0.01*0.5, x)
+	}
+}
+
+func TestBufferGeographicInvalidInput(t *testing.T) {
+	square := PathsD{{{10, 60}, {10.01, 60}, {10.01, 60.01}, {10, 60.01}}}
+	if _, err := BufferGeographic(square, math.NaN()); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for NaN distance, got %v", err)
+	}
+	bad := PathsD{{{10, 91}, {11, 91}, {11, 92}}}
+	if _, err := BufferGeographic(bad, 100); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for latitude > 90, got %v", err)
+	}
+	result, err := BufferGeographic(nil, 100)
+	if err != nil || len(result) != 0 {
+		t.Errorf("expected empty result for empty input, got %v, %v", result, err)
+	}
+}
+
+func TestBufferGeographicCircle(t *testing.T) {
+	point := PathsD{{{10, 60}}}
+	withOffsetBackend(t, func() {
+		result, err := BufferGeographic(point, 1000, GeoBufferOptions{JoinType: Round, EndType: OpenRound})
+		if err != nil {
+			t.Fatalf("BufferGeographic failed: %v", err)
+		}
+		if len(result) != 1 {
+			t.Fatalf("expected 1 path, got %d", len(result))
+		}
+
+		// every vertex lies ~1000 m from the center, so the ring spans about
+		// twice as many degrees of longitude as of latitude at 60°N
+		proj := newAzimuthalEquidistant(10, 60)
+		for _, pt := range result[0] {
+			x, y := proj.forward(pt.X, pt.Y)
+			if d := math.Hypot(x, y); math.Abs(d-1000) > 2 {
+				t.Errorf("vertex %v is %v meters from center, expected ~1000", pt, d)
+			}
+		}
+		minX, maxX, minY, maxY := math.Inf(1), math.Inf(-1), math.Inf(1), math.Inf(-1)
+		for _, pt := range result[0] {
+			minX, maxX = math.Min(minX, pt.X), math.Max(maxX, pt.X)
+			minY, maxY = math.Min(minY, pt.Y), math.Max(maxY, pt.Y)
+		}
+		if width, height := maxX-minX, maxY-minY; math.Abs(width/height-2) > 0.05 {
+			t.Errorf("expected the ring about twice as wide as high in degrees, got %v x %v", width, height)
+		}
+	})
+}
@@ -0,0 +1,279 @@
+// Package geojson converts between clipping paths and GeoJSON (RFC 7946)
+// Polygon and MultiPolygon geometries. Integer coordinates are fixed-point
+// with precision decimal places, as in the D functions of the clipper
+// package, so a precision of 7 stores 12.3456789° as 123456789.
+//
+// GeoJSON rings are closed by repeating their first position and follow the
+// right-hand rule: exteriors counter-clockwise, holes clockwise. That is the
+// orientation of boolean operation output, so encoding only has to close
+// the rings and decoding only has to open them again; rings that arrive
+// wound the other way are reversed in both directions.
+package geojson
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+
+	clipper "github.com/go-clipper/clipper2/port"
+)
+
+// position is a GeoJSON position; altitude and further values are not kept
+type position [2]float64
+
+// geometry is a GeoJSON geometry or the Feature and FeatureCollection
+// objects that wrap geometries
+type geometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates,omitempty"`
+	Geometry    json.RawMessage `json:"geometry,omitempty"`
+	Geometries  json.RawMessage `json:"geometries,omitempty"`
+	Features    json.RawMessage `json:"features,omitempty"`
+}
+
+// ==============================================================================
+// Encoding
+// ==============================================================================
+
+// MarshalPaths encodes closed paths as a Polygon if they form a single
+// polygon with its holes, otherwise as a MultiPolygon. Rings are nested by
+// containment rather than by orientation: each ring inside an odd number of
+// others is a hole of the smallest one containing it. No clipping is done,
+// so overlapping rings should be unioned first.
+func MarshalPaths(paths clipper.Paths64, precision int) ([]byte, error) {
+	return MarshalPolyTree(nest(paths), precision)
+}
+
+// MarshalPathsD encodes floating-point paths like MarshalPaths after
+// rounding them to precision decimal places
+func MarshalPathsD(paths clipper.PathsD, precision int) ([]byte, error) {
+	scaled, err := clipper.PathsDToPaths64(paths, precision)
+	if err != nil {
+		return nil, fmt.Errorf("%w: coordinates cannot be scaled to precision %d", clipper.ErrInvalidInput, precision)
+	}
+	return MarshalPaths(scaled, precision)
+}
+
+// MarshalPolyTree encodes a polygon tree, such as the result of
+// BooleanOp64Tree, as a Polygon or MultiPolygon. Each outer polygon becomes
+// a GeoJSON polygon with its holes; islands inside holes become polygons of
+// their own.
+func MarshalPolyTree(tree *clipper.PolyTree64, precision int) ([]byte, error) {
+	if precision < -clipper.MaxPrecisionD || precision > clipper.MaxPrecisionD {
+		return nil, fmt.Errorf("%w: precision %d out of range", clipper.ErrInvalidInput, precision)
+	}
+	scale := math.Pow10(precision)
+
+	var polygons [][][]position
+	var addOuters func(node *clipper.PolyPath64)
+	addOuters = func(node *clipper.PolyPath64) {
+		for _, outer := range node.Children {
+			polygon := [][]position{ring(outer.Path, true, scale)}
+			for _, hole := range outer.Children {
+				polygon = append(polygon, ring(hole.Path, false, scale))
+			}
+			polygons = append(polygons, polygon)
+			for _, hole := range outer.Children {
+				addOuters(hole)
+			}
+		}
+	}
+	if tree != nil {
+		addOuters(tree)
+	}
+
+	if len(polygons) == 1 {
+		return marshalGeometry("Polygon", polygons[0])
+	}
+	if polygons == nil {
+		polygons = [][][]position{}
+	}
+	return marshalGeometry("MultiPolygon", polygons)
+}
+
+// marshalGeometry encodes a geometry of the given type
+func marshalGeometry(kind string, coordinates any) ([]byte, error) {
+	raw, err := json.Marshal(coordinates)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(geometry{Type: kind, Coordinates: raw})
+}
+
+// ring converts path to a closed GeoJSON ring wound counter-clockwise for
+// an exterior and clockwise for a hole
+func ring(path clipper.Path64, exterior bool, scale float64) []position {
+	result := make([]position, 0, len(path)+1)
+	reverse := (clipper.Area64(path) < 0) == exterior
+	for i := range path {
+		pt := path[i]
+		if reverse {
+			pt = path[len(path)-1-i]
+		}
+		result = append(result, position{float64(pt.X) / scale, float64(pt.Y) / scale})
+	}
+	if len(result) > 0 {
+		result = append(result, result[0])
+	}
+	return result
+}
+
+// nest builds the containment tree of paths, largest rings first so every
+// ring is placed after the rings that may contain it
+func nest(paths clipper.Paths64) *clipper.PolyTree64 {
+	order := make([]int, 0, len(paths))
+	for i, path := range paths {
+		if len(path) >= 3 {
+			order = append(order, i)
+		}
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return math.Abs(clipper.Area64(paths[order[a]])) > math.Abs(clipper.Area64(paths[order[b]]))
+	})
+
+	tree := clipper.NewPolyTree64()
+	for _, i := range order {
+		parent := tree
+	descend:
+		for {
+			for _, child := range parent.Children {
+				if inside(paths[i], child.Path) {
+					parent = child
+					continue descend
+				}
+			}
+			break
+		}
+		parent.AddChild(paths[i])
+	}
+	return tree
+}
+
+// inside reports whether path lies inside outer, judged by its first vertex
+// not on the boundary of outer; a path entirely on that boundary is not
+func inside(path, outer clipper.Path64) bool {
+	for _, pt := range path {
+		if loc := clipper.PointInPolygon(pt, outer, clipper.NonZero); loc != clipper.OnBoundary {
+			return loc == clipper.Inside
+		}
+	}
+	return false
+}
+
+// ==============================================================================
+// Decoding
+// ==============================================================================
+
+// UnmarshalPaths decodes the polygons of a Polygon, MultiPolygon,
+// GeometryCollection, Feature or FeatureCollection into fixed-point paths
+// with precision decimal places. Features without a geometry are skipped;
+// any other geometry type fails with ErrInvalidInput. The closing position
+// of each ring is dropped, exteriors are wound counter-clockwise and holes
+// clockwise whatever their orientation in the input.
+func UnmarshalPaths(data []byte, precision int) (clipper.Paths64, error) {
+	if precision < -clipper.MaxPrecisionD || precision > clipper.MaxPrecisionD {
+		return nil, fmt.Errorf("%w: precision %d out of range", clipper.ErrInvalidInput, precision)
+	}
+	result := clipper.Paths64{}
+	if err := decode(data, precision, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UnmarshalPathsD decodes polygons like UnmarshalPaths, rounding their
+// coordinates to precision decimal places
+func UnmarshalPathsD(data []byte, precision int) (clipper.PathsD, error) {
+	paths, err := UnmarshalPaths(data, precision)
+	if err != nil {
+		return nil, err
+	}
+	return clipper.Paths64ToPathsD(paths, precision), nil
+}
+
+// decode appends the polygon rings of a GeoJSON object to result
+func decode(data []byte, precision int, result *clipper.Paths64) error {
+	var g geometry
+	if err := json.Unmarshal(data, &g); err != nil {
+		return fmt.Errorf("%w: %v", clipper.ErrInvalidInput, err)
+	}
+
+	switch g.Type {
+	case "Polygon":
+		var polygon [][][]float64
+		if err := json.Unmarshal(g.Coordinates, &polygon); err != nil {
+			return fmt.Errorf("%w: Polygon coordinates: %v", clipper.ErrInvalidInput, err)
+		}
+		return appendPolygon(polygon, precision, result)
+	case "MultiPolygon":
+		var polygons [][][][]float64
+		if err := json.Unmarshal(g.Coordinates, &polygons); err != nil {
+			return fmt.Errorf("%w: MultiPolygon coordinates: %v", clipper.ErrInvalidInput, err)
+		}
+		for _, polygon := range polygons {
+			if err := appendPolygon(polygon, precision, result); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "Feature":
+		if len(g.Geometry) == 0 || string(g.Geometry) == "null" {
+			return nil
+		}
+		return decode(g.Geometry, precision, result)
+	case "GeometryCollection", "FeatureCollection":
+		members := g.Geometries
+		if g.Type == "FeatureCollection" {
+			members = g.Features
+		}
+		var items []json.RawMessage
+		if err := json.Unmarshal(members, &items); err != nil {
+			return fmt.Errorf("%w: %s members: %v", clipper.ErrInvalidInput, g.Type, err)
+		}
+		for _, item := range items {
+			if err := decode(item, precision, result); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: unsupported GeoJSON type %q", clipper.ErrInvalidInput, g.Type)
+	}
+}
+
+// appendPolygon appends the rings of one GeoJSON polygon to result, the
+// first as an exterior and the others as holes
+func appendPolygon(polygon [][][]float64, precision int, result *clipper.Paths64) error {
+	for i, positions := range polygon {
+		if len(positions) > 1 && samePosition(positions[0], positions[len(positions)-1]) {
+			positions = positions[:len(positions)-1]
+		}
+		if len(positions) < 3 {
+			return fmt.Errorf("%w: ring with %d distinct positions", clipper.ErrInvalidInput, len(positions))
+		}
+
+		path := make(clipper.PathD, len(positions))
+		for j, pos := range positions {
+			if len(pos) < 2 {
+				return fmt.Errorf("%w: position with %d values", clipper.ErrInvalidInput, len(pos))
+			}
+			path[j] = clipper.PointD{X: pos[0], Y: pos[1]}
+		}
+		scaled, err := clipper.PathDToPath64(path, precision)
+		if err != nil {
+			return fmt.Errorf("%w: coordinates cannot be scaled to precision %d", clipper.ErrInvalidInput, precision)
+		}
+
+		if (clipper.Area64(scaled) < 0) == (i == 0) {
+			scaled = clipper.Reverse64(scaled)
+		}
+		*result = append(*result, scaled)
+	}
+	return nil
+}
+
+// samePosition reports whether two positions share their coordinates
+func samePosition(a, b []float64) bool {
+	return len(a) >= 2 && len(b) >= 2 && a[0] == b[0] && a[1] == b[1]
+}
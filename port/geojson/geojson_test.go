@@ -0,0 +1,154 @@
+package geojson
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	clipper "github.com/go-clipper/clipper2/port"
+)
+
+func TestMarshalPaths(t *testing.T) {
+	outer := clipper.Path64{{X: 0, Y: 0}, {X: 100, Y: 0}, {X: 100, Y: 100}, {X: 0, Y: 100}}
+	hole := clipper.Path64{{X: 25, Y: 25}, {X: 25, Y: 75}, {X: 75, Y: 75}, {X: 75, Y: 25}}
+	island := clipper.Path64{{X: 40, Y: 40}, {X: 60, Y: 40}, {X: 60, Y: 60}, {X: 40, Y: 60}}
+
+	tests := []struct {
+		name      string
+		paths     clipper.Paths64
+		precision int
+		expected  string
+	}{
+		{
+			"Polygon with hole",
+			clipper.Paths64{hole, outer},
+			1,
+			`{"type":"Polygon","coordinates":[[[0,0],[10,0],[10,10],[0,10],[0,0]],[[2.5,2.5],[2.5,7.5],[7.5,7.5],[7.5,2.5],[2.5,2.5]]]}`,
+		},
+		{
+			"Orientation corrected",
+			clipper.Paths64{clipper.Reverse64(outer), clipper.Reverse64(hole)},
+			2,
+			`{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]],[[0.25,0.25],[0.25,0.75],[0.75,0.75],[0.75,0.25],[0.25,0.25]]]}`,
+		},
+		{
+			"Island in hole",
+			clipper.Paths64{outer, hole, island},
+			0,
+			`{"type":"MultiPolygon","coordinates":[[[[0,0],[100,0],[100,100],[0,100],[0,0]],[[25,25],[25,75],[75,75],[75,25],[25,25]]],[[[40,40],[60,40],[60,60],[40,60],[40,40]]]]}`,
+		},
+		{"Empty", nil, 0, `{"type":"MultiPolygon","coordinates":[]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MarshalPaths(tt.paths, tt.precision)
+			if err != nil || string(got) != tt.expected {
+				t.Errorf("expected %s, got %s (err %v)", tt.expected, got, err)
+			}
+		})
+	}
+
+	if _, err := MarshalPaths(clipper.Paths64{outer}, clipper.MaxPrecisionD+1); !errors.Is(err, clipper.ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for precision out of range, got %v", err)
+	}
+}
+
+func TestMarshalPolyTree(t *testing.T) {
+	subjects := clipper.Paths64{{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}}}
+	clips := clipper.Paths64{{{X: 3, Y: 3}, {X: 7, Y: 3}, {X: 7, Y: 7}, {X: 3, Y: 7}}}
+	tree, _, err := clipper.BooleanOp64Tree(clipper.Difference, clipper.NonZero, subjects, nil, clips)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := MarshalPolyTree(tree, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	paths, err := UnmarshalPaths(got, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := clipper.PolyTreeToPaths64(tree); !reflect.DeepEqual(paths, expected) {
+		t.Errorf("expected %v, got %v", expected, paths)
+	}
+}
+
+func TestUnmarshalPaths(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		precision int
+		expected  clipper.Paths64
+	}{
+		{
+			"Polygon scaled and reoriented",
+			`{"type":"Polygon","coordinates":[[[0,0],[0,1.5],[1.5,1.5],[1.5,0],[0,0]],[[0.5,0.5],[1,0.5],[1,1],[0.5,0.5]]]}`,
+			1,
+			clipper.Paths64{
+				{{X: 15, Y: 0}, {X: 15, Y: 15}, {X: 0, Y: 15}, {X: 0, Y: 0}},
+				{{X: 10, Y: 10}, {X: 10, Y: 5}, {X: 5, Y: 5}},
+			},
+		},
+		{
+			"Unclosed ring with altitude",
+			`{"type":"Polygon","coordinates":[[[0,0,5],[4,0,5],[4,4,5]]]}`,
+			0,
+			clipper.Paths64{{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4}}},
+		},
+		{
+			"FeatureCollection",
+			`{"type":"FeatureCollection","features":[
+				{"type":"Feature","properties":{},"geometry":{"type":"MultiPolygon","coordinates":[[[[0,0],[1,0],[0,1],[0,0]]],[[[5,5],[6,5],[5,6],[5,5]]]]}},
+				{"type":"Feature","properties":{},"geometry":null},
+				{"type":"Feature","geometry":{"type":"GeometryCollection","geometries":[{"type":"Polygon","coordinates":[[[9,9],[10,9],[9,10],[9,9]]]}]}}
+			]}`,
+			0,
+			clipper.Paths64{
+				{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}},
+				{{X: 5, Y: 5}, {X: 6, Y: 5}, {X: 5, Y: 6}},
+				{{X: 9, Y: 9}, {X: 10, Y: 9}, {X: 9, Y: 10}},
+			},
+		},
+		{"Empty MultiPolygon", `{"type":"MultiPolygon","coordinates":[]}`, 0, clipper.Paths64{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnmarshalPaths([]byte(tt.data), tt.precision)
+			if err != nil || !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v (err %v)", tt.expected, got, err)
+			}
+		})
+	}
+
+	for _, bad := range []string{
+		`not json`,
+		`{"type":"LineString","coordinates":[[0,0],[1,1]]}`,
+		`{"type":"Polygon","coordinates":[[[0,0],[1,0],[0,0]]]}`,
+		`{"type":"Polygon","coordinates":[[[0],[1,0],[1,1],[0]]]}`,
+		`{"type":"Polygon","coordinates":[[[1e300,0],[1,0],[1,1],[1e300,0]]]}`,
+	} {
+		if _, err := UnmarshalPaths([]byte(bad), 7); !errors.Is(err, clipper.ErrInvalidInput) {
+			t.Errorf("%s: expected ErrInvalidInput, got %v", bad, err)
+		}
+	}
+}
+
+func TestPathsDRoundTrip(t *testing.T) {
+	paths := clipper.PathsD{{{X: 13.4050001, Y: 52.52}, {X: 13.41, Y: 52.52}, {X: 13.41, Y: 52.53}}}
+	data, err := MarshalPathsD(paths, 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `{"type":"Polygon","coordinates":[[[13.4050001,52.52],[13.41,52.52],[13.41,52.53],[13.4050001,52.52]]]}`
+	if string(data) != expected {
+		t.Errorf("expected %s, got %s", expected, data)
+	}
+
+	back, err := UnmarshalPathsD(data, 7)
+	if err != nil || !reflect.DeepEqual(back, paths) {
+		t.Errorf("expected round trip to %v, got %v (err %v)", paths, back, err)
+	}
+}
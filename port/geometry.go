@@ -67,17 +67,72 @@ func IsCollinear(p1, p2, p3 Point64) bool {
 	return CrossProduct128(p1, p2, p3).IsZero()
 }
 
+// TrimCollinear64 removes vertices lying exactly on the line through their
+// neighbours, judged by the 128-bit cross product with no distance
+// tolerance. Duplicate vertices and spikes that double back along the same
+// line count as collinear too. Open paths keep their end points; a closed
+// path that has no area left returns nil, as does an open path of a single
+// point repeated.
+func TrimCollinear64(path Path64, isOpen bool) Path64 {
+	n := len(path)
+	if n < 3 {
+		if !isOpen || n < 2 || path[0] == path[1] {
+			return nil
+		}
+		return path.Clone()
+	}
+
+	src, stop := 0, n-1
+	if !isOpen {
+		for src != stop && IsCollinear(path[stop], path[src], path[src+1]) {
+			src++
+		}
+		for src != stop && IsCollinear(path[stop-1], path[stop], path[src]) {
+			stop--
+		}
+		if src == stop {
+			return nil
+		}
+	}
+
+	prev := src
+	result := Path64{path[prev]}
+	for src++; src != stop; src++ {
+		if !IsCollinear(path[prev], path[src], path[src+1]) {
+			prev = src
+			result = append(result, path[prev])
+		}
+	}
+
+	switch {
+	case isOpen:
+		result = append(result, path[src])
+	case !IsCollinear(path[prev], path[stop], result[0]):
+		result = append(result, path[stop])
+	default:
+		for len(result) > 2 && IsCollinear(result[len(result)-1], result[len(result)-2], result[0]) {
+			result = result[:len(result)-1]
+		}
+		if len(result) < 3 {
+			return nil
+		}
+	}
+	return result
+}
+
 // IsParallel checks if two line segments are parallel
+// A zero-length segment has no direction and is only parallel to another zero-length segment
 func IsParallel(seg1a, seg1b, seg2a, seg2b Point64) bool {
 	// Calculate direction vectors
-	v1x := seg1b.X - seg1a.X
-	v1y := seg1b.Y - seg1a.Y
-	v2x := seg2b.X - seg2a.X
-	v2y := seg2b.Y - seg2a.Y
+	v1 := seg1b.Sub(seg1a)
+	v2 := seg2b.Sub(seg2a)
+
+	if (v1 == Point64{}) || (v2 == Point64{}) {
+		return v1 == v2
+	}
 
 	// Cross product of direction vectors
-	cross := NewInt128(v1x).Mul64(v2y).Sub(NewInt128(v1y).Mul64(v2x))
-	return cross.IsZero()
+	return v1.Cross128(v2).IsZero()
 }
 
 // PointInPolygon determines if a point is inside, outside, or on the boundary of a polygon
@@ -120,6 +175,29 @@ func PointInPolygon(point Point64, polygon Path64, fillRule FillRule) PolygonLoc
 	return Outside
 }
 
+// PointInPaths64 locates a point relative to the region paths fill under
+// fillRule, summing the winding numbers of all rings so holes and islands
+// count as they do in a boolean operation. A point on the edge of any ring is
+// OnBoundary.
+func PointInPaths64(point Point64, paths Paths64, fillRule FillRule) PolygonLocation {
+	wn := 0
+	for _, path := range paths {
+		if len(path) < 3 || !Bounds64(path).Contains(point) {
+			continue
+		}
+		for i := range path {
+			if isPointOnSegment(point, path[i], path[(i+1)%len(path)]) {
+				return OnBoundary
+			}
+		}
+		wn += WindingNumber(point, path)
+	}
+	if isFilledWinding(wn, fillRule) {
+		return Inside
+	}
+	return Outside
+}
+
 // WindingNumber calculates the winding number of a point with respect to a polygon
 // Uses robust 128-bit arithmetic to handle edge cases
 func WindingNumber(point Point64, polygon Path64) int {
@@ -168,7 +246,7 @@ func calculateIntersectionPoint(seg1a, seg1b, _seg2a, _seg2b Point64, d1, d2 Int
 	x := float64(seg1a.X) + t*float64(seg1b.X-seg1a.X)
 	y := float64(seg1a.Y) + t*float64(seg1b.Y-seg1a.Y)
 
-	return Point64{X: int64(x + 0.5), Y: int64(y + 0.5)}, nil
+	return Point64{X: RoundHalfAway(x), Y: RoundHalfAway(y)}, nil
 }
 
 // handleCollinearSegments handles intersection of collinear segments
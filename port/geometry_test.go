@@ -2,6 +2,7 @@ package clipper
 
 import (
 	"math"
+	"reflect"
 	"testing"
 )
 
@@ -225,6 +226,36 @@ func TestIsCollinearComprehensive(t *testing.T) {
 	}
 }
 
+// TestTrimCollinear64 tests removal of exactly collinear vertices
+func TestTrimCollinear64(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     Path64
+		isOpen   bool
+		expected Path64
+	}{
+		{"Closed midpoints", Path64{{0, 0}, {5, 0}, {10, 0}, {10, 5}, {10, 10}, {0, 10}}, false, Path64{{0, 0}, {10, 0}, {10, 10}, {0, 10}}},
+		{"Closed start on edge", Path64{{5, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}, false, Path64{{10, 0}, {10, 10}, {0, 10}, {0, 0}}},
+		{"Closed duplicates", Path64{{0, 0}, {0, 0}, {10, 0}, {10, 10}, {10, 10}, {0, 10}}, false, Path64{{0, 0}, {10, 0}, {10, 10}, {0, 10}}},
+		{"Closed spike", Path64{{0, 0}, {10, 0}, {20, 0}, {10, 0}, {10, 10}, {0, 10}}, false, Path64{{0, 0}, {10, 0}, {10, 10}, {0, 10}}},
+		{"Near collinear kept", Path64{{0, 0}, {500000, 1}, {1000000, 0}, {1000000, 10}}, false, Path64{{0, 0}, {500000, 1}, {1000000, 0}, {1000000, 10}}},
+		{"Closed line", Path64{{0, 0}, {5, 5}, {10, 10}, {5, 5}}, false, nil},
+		{"Open ends kept", Path64{{0, 0}, {5, 0}, {10, 0}, {10, 10}, {10, 20}}, true, Path64{{0, 0}, {10, 0}, {10, 20}}},
+		{"Open straight", Path64{{0, 0}, {5, 0}, {10, 0}}, true, Path64{{0, 0}, {10, 0}}},
+		{"Open segment", Path64{{0, 0}, {5, 0}}, true, Path64{{0, 0}, {5, 0}}},
+		{"Open point", Path64{{3, 3}, {3, 3}}, true, nil},
+		{"Closed too short", Path64{{0, 0}, {5, 0}}, false, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TrimCollinear64(tt.path, tt.isOpen); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
 // TestIsParallelComprehensive provides extensive testing for IsParallel
 func TestIsParallelComprehensive(t *testing.T) {
 	tests := []struct {
@@ -902,3 +933,43 @@ func BenchmarkUtilityFunctions(b *testing.B) {
 		}
 	})
 }
+
+// TestPointInPaths64 tests containment in regions with holes and overlaps
+func TestPointInPaths64(t *testing.T) {
+	nested := Paths64{
+		{{0, 0}, {100, 0}, {100, 100}, {0, 100}},
+		{{20, 20}, {20, 80}, {80, 80}, {80, 20}}, // hole
+		{{40, 40}, {60, 40}, {60, 60}, {40, 60}}, // island
+	}
+	overlapping := Paths64{
+		{{0, 0}, {60, 0}, {60, 60}, {0, 60}},
+		{{40, 40}, {100, 40}, {100, 100}, {40, 100}},
+	}
+
+	tests := []struct {
+		name     string
+		pt       Point64
+		paths    Paths64
+		fillRule FillRule
+		expected PolygonLocation
+	}{
+		{"Inside outer", Point64{10, 10}, nested, NonZero, Inside},
+		{"Inside hole", Point64{30, 30}, nested, NonZero, Outside},
+		{"Inside island", Point64{50, 50}, nested, NonZero, Inside},
+		{"On hole edge", Point64{20, 50}, nested, NonZero, OnBoundary},
+		{"Outside", Point64{150, 50}, nested, NonZero, Outside},
+		{"Overlap under NonZero", Point64{50, 50}, overlapping, NonZero, Inside},
+		{"Overlap under EvenOdd", Point64{50, 50}, overlapping, EvenOdd, Outside},
+		{"Single ring under EvenOdd", Point64{80, 80}, overlapping, EvenOdd, Inside},
+		{"Clockwise ring under Positive", Point64{30, 30}, Paths64{Reverse64(overlapping[0])}, Positive, Outside},
+		{"No paths", Point64{0, 0}, nil, NonZero, Outside},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PointInPaths64(tt.pt, tt.paths, tt.fillRule); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package clipper
+
+import "math"
+
+// ==============================================================================
+// Hole Removal
+// ==============================================================================
+
+// RemoveHoles drops every hole below pp, together with the islands nested in
+// those holes, which the filled outers now cover anyway. Only the outlines
+// remain, as needed for silhouettes. The tree is modified in place; Clone it
+// first to keep the original.
+func (pp *PolyPath64) RemoveHoles() {
+	pp.FillHolesSmallerThan(math.Inf(1))
+}
+
+// FillHolesSmallerThan drops the holes below pp whose absolute area is less
+// than minArea, together with everything nested in them. Larger holes and
+// the islands inside them are kept and processed the same way. The tree is
+// modified in place; bounds stay valid since holes lie inside their outers.
+func (pp *PolyPath64) FillHolesSmallerThan(minArea float64) {
+	if pp.Parent == nil || pp.IsHole() {
+		for _, child := range pp.Children {
+			child.FillHolesSmallerThan(minArea)
+		}
+		return
+	}
+	kept := pp.Children[:0]
+	for _, hole := range pp.Children {
+		if math.Abs(Area64(hole.Path)) >= minArea {
+			kept = append(kept, hole)
+			hole.FillHolesSmallerThan(minArea)
+		}
+	}
+	for i := len(kept); i < len(pp.Children); i++ {
+		pp.Children[i] = nil
+	}
+	pp.Children = kept
+}
+
+// RemoveHoles64 returns the outer rings of paths, dropping every hole and
+// the islands inside holes. Nesting is determined by containment, so the
+// orientation of the input rings does not matter; rings are returned as
+// given, outers before the rings nested in them.
+func RemoveHoles64(paths Paths64) Paths64 {
+	tree := buildPolyTree64(paths)
+	tree.RemoveHoles()
+	return flattenPolyTree64(tree)
+}
+
+// FillHolesSmallerThan64 returns paths without the holes whose absolute area
+// is less than minArea and without the islands inside those holes. Nesting
+// is determined by containment as in RemoveHoles64.
+func FillHolesSmallerThan64(paths Paths64, minArea float64) Paths64 {
+	tree := buildPolyTree64(paths)
+	tree.FillHolesSmallerThan(minArea)
+	return flattenPolyTree64(tree)
+}
+
+// flattenPolyTree64 returns the paths of pp and its descendants, each
+// parent before its children
+func flattenPolyTree64(pp *PolyPath64) Paths64 {
+	var result Paths64
+	var visit func(node *PolyPath64)
+	visit = func(node *PolyPath64) {
+		if len(node.Path) > 0 {
+			result = append(result, node.Path)
+		}
+		for _, child := range node.Children {
+			visit(child)
+		}
+	}
+	visit(pp)
+	return result
+}
@@ -0,0 +1,53 @@
+package clipper
+
+import "testing"
+
+func TestRemoveHoles64(t *testing.T) {
+	// side lengths 100, 80 (hole), 60, 40 (hole), 20
+	rings := concentricRings(5, 0, 10)
+	other := Path64{{200, 0}, {250, 0}, {250, 50}, {200, 50}}
+	otherHole := Path64{{210, 10}, {210, 20}, {220, 20}, {220, 10}}
+	paths := append(rings, other, otherHole)
+
+	tests := []struct {
+		name     string
+		got      Paths64
+		expected Paths64
+	}{
+		{"remove all", RemoveHoles64(paths), Paths64{rings[0], other}},
+		{"below 2000", FillHolesSmallerThan64(paths, 2000), Paths64{rings[0], rings[1], rings[2], other}},
+		{"below 100", FillHolesSmallerThan64(paths, 100), Paths64{rings[0], rings[1], rings[2], rings[3], rings[4], other, otherHole}},
+		{"below 101", FillHolesSmallerThan64(paths, 101), Paths64{rings[0], rings[1], rings[2], rings[3], rings[4], other}},
+		{"below 1601", FillHolesSmallerThan64(paths, 1601), Paths64{rings[0], rings[1], rings[2], other}},
+		{"below 0", FillHolesSmallerThan64(paths, 0), Paths64{rings[0], rings[1], rings[2], rings[3], rings[4], other, otherHole}},
+	}
+	for _, tt := range tests {
+		if !sameRings(tt.got, tt.expected) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, tt.got)
+		}
+	}
+}
+
+func TestPolyTreeRemoveHoles(t *testing.T) {
+	tree := buildPolyTree64(concentricRings(4, 0, 10))
+	copied := tree.Clone()
+
+	tree.FillHolesSmallerThan(1000)
+	if depth := treeDepth(tree); depth != 3 {
+		t.Errorf("expected depth 3 after dropping the 20x20 hole, got %d", depth)
+	}
+	tree.RemoveHoles()
+	if depth := treeDepth(tree); depth != 1 {
+		t.Errorf("expected depth 1 after removing holes, got %d", depth)
+	}
+	if depth := treeDepth(copied); depth != 4 {
+		t.Errorf("expected the clone to keep depth 4, got %d", depth)
+	}
+
+	// called on a hole node, only the islands inside it are affected
+	hole := copied.Children[0].Children[0]
+	hole.RemoveHoles()
+	if depth := treeDepth(copied); depth != 3 {
+		t.Errorf("expected depth 3 after removing holes below the hole, got %d", depth)
+	}
+}
@@ -0,0 +1,289 @@
+package clipper
+
+import (
+	"bytes"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+)
+
+// TestUnionIdempotence checks that a NonZero union of any boolean result
+// returns the same rings
+func TestUnionIdempotence(t *testing.T) {
+	t.Run("Regression cases", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			clipType ClipType
+			fillRule FillRule
+			subjects Paths64
+			clips    Paths64
+		}{
+			{
+				"Collinear spike after splitting",
+				Union, NonZero,
+				Paths64{{{1, 1}, {2, 3}, {2, 0}, {2, 1}, {3, 1}, {1, 0}, {2, 0}, {1, 3}, {3, 1}, {3, 2}, {1, 1}, {0, 1}, {3, 0}}},
+				Paths64{{{2, 0}, {1, 1}, {1, 1}, {3, 1}, {0, 0}, {1, 1}, {3, 3}, {2, 3}, {3, 2}}},
+			},
+			{
+				"Loop touching its own ring",
+				Xor, NonZero,
+				Paths64{{{2, 1}, {0, 2}, {1, 0}, {0, 0}, {2, 0}, {2, 0}, {3, 1}, {1, 3}, {3, 1}, {3, 3}}},
+				Paths64{{{2, 0}, {3, 2}, {0, 3}, {1, 2}, {2, 3}, {0, 1}, {1, 3}, {2, 0}, {1, 1}}},
+			},
+			{
+				"Thin sliver across a long edge",
+				Intersection, EvenOdd,
+				Paths64{{{615, 704}, {416, 634}, {0, 481}}},
+				Paths64{{{101, 903}, {873, 221}, {616, 122}, {166, 149}, {287, 417}, {1, 77}}},
+			},
+			{
+				"Crossings snapped onto a loop",
+				Intersection, EvenOdd,
+				Paths64{{{8, 8}, {0, 0}, {7, 9}, {2, 5}, {3, 4}}},
+				Paths64{{{1, 6}, {5, 3}, {0, 5}, {6, 8}, {9, 5}, {3, 7}, {5, 3}, {4, 7}, {6, 9}}},
+			},
+			{
+				"Edge rounding onto a vertex beside it",
+				Xor, EvenOdd,
+				Paths64{{{2, 2}, {1, 0}, {1, 1}}},
+				Paths64{{{3, 2}, {3, 1}, {2, 1}, {2, 2}, {2, 3}}},
+			},
+			{
+				"Edge leaving the end of a horizontal",
+				Xor, NonZero,
+				Paths64{{{5, 4}, {3, 4}, {7, 1}, {5, 5}, {5, 1}, {6, 0}, {1, 0}, {2, 3}}, {{7, 3}, {6, 1}, {2, 6}, {4, 3}}},
+				Paths64{{{3, 5}, {1, 0}, {5, 4}, {3, 0}, {3, 5}, {0, 1}, {7, 5}}},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				checkUnionIdempotent(t, tt.clipType, tt.fillRule, tt.subjects, tt.clips)
+			})
+		}
+	})
+
+	t.Run("Random inputs", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		spans := []int64{4, 8, 20, 1000, 1000000, 1 << 40}
+		for i := 0; i < 2000; i++ {
+			span := spans[r.Intn(len(spans))]
+			subjects := Paths64{randomPath(r, 3+r.Intn(12), span)}
+			if r.Intn(2) == 0 {
+				subjects = append(subjects, randomPath(r, 3+r.Intn(5), span))
+			}
+			clips := Paths64{randomPath(r, 3+r.Intn(8), span)}
+			clipType := ClipType(r.Intn(4))
+			fillRule := FillRule(r.Intn(4))
+			if !checkUnionIdempotent(t, clipType, fillRule, subjects, clips) {
+				return
+			}
+		}
+	})
+}
+
+// checkUnionIdempotent reports an error and returns false if the result of a
+// boolean operation fails ValidatePaths64 or Union64 changes it
+func checkUnionIdempotent(t *testing.T, clipType ClipType, fillRule FillRule, subjects, clips Paths64) bool {
+	t.Helper()
+	result, _, err := BooleanOp64(clipType, fillRule, subjects, nil, clips)
+	if err != nil {
+		t.Errorf("BooleanOp64(%v, %v, %v, %v) failed: %v", clipType, fillRule, subjects, clips, err)
+		return false
+	}
+	if report := ValidatePaths64(result); !report.Valid() {
+		t.Errorf("Result of %v %v %v %v is not valid: %v\n  result %v",
+			clipType, fillRule, subjects, clips, report.Issues, result)
+		return false
+	}
+	again, err := Union64(result, nil, NonZero)
+	if err != nil {
+		t.Errorf("Union64(%v) failed: %v", result, err)
+		return false
+	}
+	if !sameRings(result, again) {
+		t.Errorf("Union64 is not idempotent for %v %v %v %v:\n  result %v\n  union  %v",
+			clipType, fillRule, subjects, clips, result, again)
+		return false
+	}
+	return true
+}
+
+// TestSnapRoundedLoops checks that loops formed by crossings rounded to the
+// grid stay in the output. The exact areas are those of the input scaled by
+// 10⁶; on the unit grid snap rounding moves every edge by less than a unit.
+func TestSnapRoundedLoops(t *testing.T) {
+	subjects := Paths64{{{8, 8}, {0, 0}, {7, 9}, {2, 5}, {3, 4}}}
+	clips := Paths64{{{1, 6}, {5, 3}, {0, 5}, {6, 8}, {9, 5}, {3, 7}, {5, 3}, {4, 7}, {6, 9}}}
+	scale := func(paths Paths64, factor int64) Paths64 {
+		result := paths.Clone()
+		for _, path := range result {
+			for i := range path {
+				path[i] = Point64{X: path[i].X * factor, Y: path[i].Y * factor}
+			}
+		}
+		return result
+	}
+
+	tests := []struct {
+		name      string
+		clipType  ClipType
+		clips     Paths64
+		exact     float64
+		tolerance float64
+	}{
+		{"Union of a self-intersecting ring", Union, nil, 8.529, 0.1},
+		{"Intersection with a self-intersecting clip", Intersection, clips, 3.286, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, _, err := BooleanOp64(tt.clipType, EvenOdd, subjects, nil, tt.clips)
+			if err != nil {
+				t.Fatalf("BooleanOp64 failed: %v", err)
+			}
+			if area := AreaPaths64(result); math.Abs(area-tt.exact) > tt.tolerance {
+				t.Errorf("Area = %v, expected %v ± %v; result %v", area, tt.exact, tt.tolerance, result)
+			}
+			checkUnionIdempotent(t, tt.clipType, EvenOdd, subjects, tt.clips)
+
+			// A finer grid leaves less to snap
+			scaled, _, err := BooleanOp64(tt.clipType, EvenOdd, scale(subjects, 1000), nil, scale(tt.clips, 1000))
+			if err != nil {
+				t.Fatalf("BooleanOp64 failed: %v", err)
+			}
+			if area := AreaPaths64(scaled) / 1e6; math.Abs(area-tt.exact) > 0.01 {
+				t.Errorf("Area scaled by 1000 = %v, expected %v", area, tt.exact)
+			}
+		})
+	}
+}
+
+// TestIsSimpleSolution tests the check that lets SimplifySelfIntersections64
+// return simple disjoint rings without a sweep
+func TestIsSimpleSolution(t *testing.T) {
+	square := Path64{{0, 0}, {10, 0}, {10, 10}, {0, 10}}
+	hole := Path64{{2, 2}, {2, 8}, {8, 8}, {8, 2}}
+
+	tests := []struct {
+		name     string
+		solution Paths64
+		want     bool
+	}{
+		{"Empty", Paths64{}, true},
+		{"Single square", Paths64{square}, true},
+		{"Square with hole", Paths64{square, hole}, true},
+		{"Clockwise outer", Paths64{Reverse64(square)}, false},
+		{"Counter-clockwise hole", Paths64{square, Reverse64(hole)}, false},
+		{"Collinear vertex", Paths64{{{0, 0}, {5, 0}, {10, 0}, {10, 10}, {0, 10}}}, false},
+		{"Shared corner", Paths64{square, {{10, 10}, {20, 10}, {20, 20}}}, false},
+		{"Vertex on edge", Paths64{square, {{10, 5}, {20, 0}, {20, 10}}}, false},
+		{"Crossing rings", Paths64{square, {{5, 5}, {15, 5}, {15, 15}, {5, 15}}}, false},
+		{"Separate rings", Paths64{square, {{20, 0}, {30, 0}, {30, 10}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isSimpleSolution(tt.solution); got != tt.want {
+				t.Errorf("isSimpleSolution(%v) = %v, expected %v", tt.solution, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestDeterministicOutput runs the same operations 100 times, concurrently,
+// and checks that every run returns byte-identical output: the same rings in
+// the same order, starting at the same vertex
+func TestDeterministicOutput(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	subjects := Paths64{randomPath(r, 12, 200), randomPath(r, 9, 200)}
+	subjectsOpen := Paths64{randomPath(r, 6, 200)}
+	clips := Paths64{randomPath(r, 10, 200)}
+
+	run := func(clipType ClipType, fillRule FillRule) []byte {
+		solution, solutionOpen, err := BooleanOp64(clipType, fillRule, subjects, subjectsOpen, clips)
+		if err != nil {
+			return []byte(err.Error())
+		}
+		var buf []byte
+		for _, path := range append(solution, solutionOpen...) {
+			buf = appendRing(buf, path)
+		}
+		return buf
+	}
+
+	for _, clipType := range []ClipType{Intersection, Union, Difference, Xor} {
+		for _, fillRule := range []FillRule{EvenOdd, NonZero} {
+			expected := run(clipType, fillRule)
+			results := make([][]byte, 100)
+			var wg sync.WaitGroup
+			for i := range results {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					results[i] = run(clipType, fillRule)
+				}()
+			}
+			wg.Wait()
+			for i, got := range results {
+				if !bytes.Equal(got, expected) {
+					t.Errorf("%v %v: run %d differs from the first", clipType, fillRule, i)
+					break
+				}
+			}
+		}
+	}
+}
+
+// randomPath returns n random points in [0, span)²
+func randomPath(r *rand.Rand, n int, span int64) Path64 {
+	path := make(Path64, n)
+	for i := range path {
+		path[i] = Point64{X: r.Int63n(span), Y: r.Int63n(span)}
+	}
+	return path
+}
+
+// sameRings reports whether two solutions hold the same rings, ignoring ring
+// order and each ring's starting vertex
+func sameRings(a, b Paths64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	ka, kb := make([]Path64, len(a)), make([]Path64, len(b))
+	for i := range a {
+		ka[i], kb[i] = rotateToLowest(a[i]), rotateToLowest(b[i])
+	}
+	less := func(keys []Path64) func(i, j int) bool {
+		return func(i, j int) bool {
+			p, q := keys[i], keys[j]
+			for k := 0; k < len(p) && k < len(q); k++ {
+				if p[k] != q[k] {
+					return p[k].Y < q[k].Y || (p[k].Y == q[k].Y && p[k].X < q[k].X)
+				}
+			}
+			return len(p) < len(q)
+		}
+	}
+	sort.Slice(ka, less(ka))
+	sort.Slice(kb, less(kb))
+	for i := range ka {
+		if !identicalPath(ka[i], kb[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// rotateToLowest returns ring starting at its lowest, then leftmost vertex
+func rotateToLowest(ring Path64) Path64 {
+	start := 0
+	for i, pt := range ring {
+		if pt.Y < ring[start].Y || (pt.Y == ring[start].Y && pt.X < ring[start].X) {
+			start = i
+		}
+	}
+	return append(append(Path64{}, ring[start:]...), ring[:start]...)
+}
@@ -2,7 +2,11 @@
 
 package clipper
 
-import "github.com/go-clipper/clipper2/capi"
+import (
+	"fmt"
+
+	"github.com/go-clipper/clipper2/capi"
+)
 
 // convertToCAPI converts port types to capi types
 func pathsToCAPI(paths Paths64) capi.Paths64 {
@@ -30,6 +34,9 @@ func pathsFromCAPI(paths capi.Paths64) Paths64 {
 	return result
 }
 
+// defaultEngineIsGo reports whether EngineDefault runs the pure Go port
+const defaultEngineIsGo = false
+
 // booleanOp64Impl delegates to the CGO oracle implementation
 func booleanOp64Impl(clipType ClipType, fillRule FillRule, subjects, subjectsOpen, clips Paths64) (solution Paths64, solutionOpen Paths64, err error) {
 	capiSubjects := pathsToCAPI(subjects)
@@ -44,7 +51,7 @@ func booleanOp64Impl(clipType ClipType, fillRule FillRule, subjects, subjectsOpe
 		capiClips,
 	)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, fmt.Errorf("%w: %w", ErrClipperExecution, err)
 	}
 
 	solution = pathsFromCAPI(capiSolution)
@@ -52,12 +59,32 @@ func booleanOp64Impl(clipType ClipType, fillRule FillRule, subjects, subjectsOpe
 	return solution, solutionOpen, nil
 }
 
+// oracleBooleanOp64Impl runs the oracle explicitly, for comparisons with the
+// pure Go engine
+func oracleBooleanOp64Impl(clipType ClipType, fillRule FillRule, subjects, subjectsOpen, clips Paths64) (Paths64, Paths64, error) {
+	return booleanOp64Impl(clipType, fillRule, subjects, subjectsOpen, clips)
+}
+
+// areaOfBooleanOp64Impl sums the signed areas of the oracle's solution, since
+// the C API has no area-only entry point
+func areaOfBooleanOp64Impl(clipType ClipType, fillRule FillRule, subjects, clips Paths64) (float64, error) {
+	solution, _, err := booleanOp64Impl(clipType, fillRule, subjects, nil, clips)
+	if err != nil {
+		return 0, err
+	}
+	area := 0.0
+	for _, path := range solution {
+		area += Area64(path)
+	}
+	return area, nil
+}
+
 // inflatePathsImpl delegates to the CGO oracle implementation
 func inflatePathsImpl(paths Paths64, delta float64, joinType JoinType, endType EndType, opts OffsetOptions) (Paths64, error) {
 	capiPaths := pathsToCAPI(paths)
 	capiResult, err := capi.InflatePaths64(capiPaths, delta, uint8(joinType), uint8(endType), opts.MiterLimit, opts.ArcTolerance)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %w", ErrClipperExecution, err)
 	}
 	return pathsFromCAPI(capiResult), nil
 }
@@ -71,7 +98,7 @@ func rectClipImpl(rect Path64, paths Paths64) (Paths64, error) {
 	capiPaths := pathsToCAPI(paths)
 	capiResult, err := capi.RectClip64(capiRect, capiPaths)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %w", ErrClipperExecution, err)
 	}
 	return pathsFromCAPI(capiResult), nil
 }
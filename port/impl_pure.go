@@ -5,6 +5,9 @@ package clipper
 // This file contains the main implementation entry points for the pure Go version
 // Complex algorithm details are organized into separate files for better maintainability
 
+// defaultEngineIsGo reports whether EngineDefault runs the pure Go port
+const defaultEngineIsGo = true
+
 // booleanOp64Impl - now using proper Vatti scanline algorithm
 func booleanOp64Impl(clipType ClipType, fillRule FillRule, subjects, subjectsOpen, clips Paths64) (solution, solutionOpen Paths64, err error) {
 	// Create and execute Vatti engine
@@ -12,6 +15,17 @@ func booleanOp64Impl(clipType ClipType, fillRule FillRule, subjects, subjectsOpe
 	return engine.ExecuteClipping(subjects, subjectsOpen, clips)
 }
 
+// oracleBooleanOp64Impl is only available with the clipper_cgo build tag
+func oracleBooleanOp64Impl(_clipType ClipType, _fillRule FillRule, _subjects, _subjectsOpen, _clips Paths64) (Paths64, Paths64, error) {
+	return nil, nil, ErrNotImplemented
+}
+
+// areaOfBooleanOp64Impl sweeps the inputs in area-only mode
+func areaOfBooleanOp64Impl(clipType ClipType, fillRule FillRule, subjects, clips Paths64) (float64, error) {
+	engine := NewVattiEngine(clipType, fillRule)
+	return engine.ExecuteArea(subjects, clips)
+}
+
 // inflatePathsImpl pure Go implementation (not yet implemented)
 func inflatePathsImpl(_paths Paths64, _delta float64, _joinType JoinType, _endType EndType, _opts OffsetOptions) (Paths64, error) {
 	return nil, ErrNotImplemented
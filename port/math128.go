@@ -89,7 +89,7 @@ func (i Int128) Cmp(other Int128) int {
 // ToFloat64 converts Int128 to float64 (may lose precision for large values)
 func (i Int128) ToFloat64() float64 {
 	// For values that fit in int64 range, use direct conversion to avoid precision loss
-	if (i.Hi == 0) || (i.Hi == -1 && i.Lo >= 1<<63) {
+	if (i.Hi == 0 && i.Lo < 1<<63) || (i.Hi == -1 && i.Lo >= 1<<63) {
 		// Value fits in int64 range
 		// Both cases can use the same conversion since they fit in int64 range
 		return float64(int64(i.Lo))
@@ -167,18 +167,9 @@ func (i Int128) Mul64(val int64) Int128 {
 // CrossProduct128 calculates the cross product of vectors (p2-p1) and (p3-p1)
 // using 128-bit intermediate calculations to prevent overflow
 func CrossProduct128(p1, p2, p3 Point64) Int128 {
-	// Calculate vectors
-	v1x := p2.X - p1.X
-	v1y := p2.Y - p1.Y
-	v2x := p3.X - p1.X
-	v2y := p3.Y - p1.Y
-
 	// Cross product: v1x * v2y - v1y * v2x
 	// Use 128-bit multiplication to avoid overflow
-	term1 := NewInt128(v1x).Mul64(v2y)
-	term2 := NewInt128(v1y).Mul64(v2x)
-
-	return term1.Sub(term2)
+	return p2.Sub(p1).Cross128(p3.Sub(p1))
 }
 
 // Area128 calculates the signed area of a polygon using 128-bit precision
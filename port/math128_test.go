@@ -233,6 +233,8 @@ func TestInt128_ToFloat64(t *testing.T) {
 		{"large_negative", Int128{Hi: -1, Lo: 0}, -math.Pow(2, 64)},
 		{"max_int64", NewInt128(math.MaxInt64), float64(math.MaxInt64)},
 		{"min_int64", NewInt128(math.MinInt64), float64(math.MinInt64)},
+		{"beyond_max_int64", Int128{Hi: 0, Lo: 1 << 63}, math.Pow(2, 63)},
+		{"below_min_int64", Int128{Hi: -1, Lo: 1<<63 - 1}, -math.Pow(2, 63) - 1},
 	}
 
 	for _, tt := range tests {
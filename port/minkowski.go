@@ -0,0 +1,81 @@
+package clipper
+
+// ==============================================================================
+// Minkowski Sum and Difference
+// ==============================================================================
+
+// MinkowskiSum64 sweeps pattern along path, as Clipper2's MinkowskiSum: the
+// result is the union of pattern placed at every point of path, so a small
+// disc pattern dilates path like a round-jointed offset. Closed paths are
+// swept around their whole outline, including the edge back to the start;
+// their interior is not filled. The union uses NonZero filling.
+func MinkowskiSum64(pattern, path Path64, isClosed bool) (Paths64, error) {
+	return Union64(minkowskiQuads(pattern, path, true, isClosed, nil), nil, NonZero)
+}
+
+// MinkowskiDiff64 sweeps pattern subtracted from every point of path, the
+// union of path[i] - pattern[j], as Clipper2's MinkowskiDiff
+func MinkowskiDiff64(pattern, path Path64, isClosed bool) (Paths64, error) {
+	return Union64(minkowskiQuads(pattern, path, false, isClosed, nil), nil, NonZero)
+}
+
+// MinkowskiSumPaths64 is MinkowskiSum64 for many paths, such as the
+// obstacles of a map, with a single union over all of them
+func MinkowskiSumPaths64(pattern Path64, paths Paths64, isClosed bool) (Paths64, error) {
+	var quads Paths64
+	for _, path := range paths {
+		quads = minkowskiQuads(pattern, path, true, isClosed, quads)
+	}
+	return Union64(quads, nil, NonZero)
+}
+
+// MinkowskiSumPatterns64 sweeps every pattern along every path and unions
+// the results, so obstacles can be dilated by a robot footprint made of
+// several parts in one call
+func MinkowskiSumPatterns64(patterns, paths Paths64, isClosed bool) (Paths64, error) {
+	var quads Paths64
+	for _, pattern := range patterns {
+		for _, path := range paths {
+			quads = minkowskiQuads(pattern, path, true, isClosed, quads)
+		}
+	}
+	return Union64(quads, nil, NonZero)
+}
+
+// minkowskiQuads appends to dst the counter-clockwise quadrilaterals swept
+// by each pattern edge between consecutive points of path, pattern being
+// added to (isSum) or subtracted from every path point
+func minkowskiQuads(pattern, path Path64, isSum, isClosed bool, dst Paths64) Paths64 {
+	if len(pattern) == 0 || len(path) == 0 {
+		return dst
+	}
+	placed := make(Paths64, len(path))
+	for i, p := range path {
+		placed[i] = make(Path64, len(pattern))
+		for j, q := range pattern {
+			if isSum {
+				placed[i][j] = p.Add(q)
+			} else {
+				placed[i][j] = p.Sub(q)
+			}
+		}
+	}
+
+	start, g := 1, 0
+	if isClosed {
+		start, g = 0, len(path)-1
+	}
+	for i := start; i < len(path); i++ {
+		h := len(pattern) - 1
+		for j := range pattern {
+			quad := Path64{placed[g][h], placed[i][h], placed[i][j], placed[g][j]}
+			if Area64(quad) < 0 {
+				quad = Reverse64(quad)
+			}
+			dst = append(dst, quad)
+			h = j
+		}
+		g = i
+	}
+	return dst
+}
@@ -0,0 +1,67 @@
+package clipper
+
+import "testing"
+
+// TestMinkowskiSum64 tests sweeps along open and closed paths
+func TestMinkowskiSum64(t *testing.T) {
+	square := Path64{{-1, -1}, {1, -1}, {1, 1}, {-1, 1}}
+	tests := []struct {
+		name     string
+		path     Path64
+		isClosed bool
+		expected Paths64
+	}{
+		{"Open segment", Path64{{0, 0}, {10, 0}}, false, Paths64{{{-1, -1}, {11, -1}, {11, 1}, {-1, 1}}}},
+		{"Open corner", Path64{{0, 0}, {10, 0}, {10, 10}}, false, Paths64{{{-1, -1}, {11, -1}, {11, 11}, {9, 11}, {9, 1}, {-1, 1}}}},
+		{
+			"Closed square",
+			Path64{{0, 0}, {10, 0}, {10, 10}, {0, 10}},
+			true,
+			Paths64{{{-1, -1}, {11, -1}, {11, 11}, {-1, 11}}, {{1, 1}, {1, 9}, {9, 9}, {9, 1}}},
+		},
+		{"Empty path", nil, true, Paths64{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MinkowskiSum64(square, tt.path, tt.isClosed)
+			if err != nil || !sameRings(got, tt.expected) {
+				t.Errorf("expected %v, got %v (err %v)", tt.expected, got, err)
+			}
+		})
+	}
+
+	// The difference is the sum with the pattern mirrored through the origin
+	triangle := Path64{{0, 0}, {4, 0}, {0, 2}}
+	path := Path64{{0, 0}, {10, 5}, {20, 0}}
+	mirrored := Path64{{0, 0}, {-4, 0}, {0, -2}}
+	diff, err := MinkowskiDiff64(triangle, path, false)
+	expected, _ := MinkowskiSum64(mirrored, path, false)
+	if err != nil || !sameRings(diff, expected) {
+		t.Errorf("expected %v, got %v (err %v)", expected, diff, err)
+	}
+}
+
+// TestMinkowskiSumPaths64 tests sweeps over many paths and patterns
+func TestMinkowskiSumPaths64(t *testing.T) {
+	square := Path64{{-1, -1}, {1, -1}, {1, 1}, {-1, 1}}
+	paths := Paths64{{{0, 0}, {10, 0}}, {{10, 0}, {20, 0}}, {{0, 10}, {10, 10}}}
+
+	got, err := MinkowskiSumPaths64(square, paths, false)
+	expected := Paths64{{{-1, -1}, {21, -1}, {21, 1}, {-1, 1}}, {{-1, 9}, {11, 9}, {11, 11}, {-1, 11}}}
+	if err != nil || !sameRings(got, expected) {
+		t.Errorf("expected %v, got %v (err %v)", expected, got, err)
+	}
+
+	// A second pattern below the first widens the swept band downwards
+	patterns := Paths64{square, {{-1, -3}, {1, -3}, {1, -1}, {-1, -1}}}
+	got, err = MinkowskiSumPatterns64(patterns, paths[:1], false)
+	expected = Paths64{{{-1, -3}, {11, -3}, {11, 1}, {-1, 1}}}
+	if err != nil || !sameRings(got, expected) {
+		t.Errorf("expected %v, got %v (err %v)", expected, got, err)
+	}
+
+	if got, err := MinkowskiSumPatterns64(nil, paths, true); err != nil || len(got) != 0 {
+		t.Errorf("expected no result without patterns, got %v (err %v)", got, err)
+	}
+}
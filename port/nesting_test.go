@@ -0,0 +1,312 @@
+package clipper
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// concentricRings returns levels nested squares, each inset by step from
+// the previous one, with alternating orientation: outer, hole, island, ...
+func concentricRings(levels int, origin, step int64) Paths64 {
+	size := 2 * int64(levels) * step
+	rings := make(Paths64, levels)
+	for i := range rings {
+		lo := origin + int64(i)*step
+		hi := origin + size - int64(i)*step
+		rings[i] = Path64{{lo, lo}, {hi, lo}, {hi, hi}, {lo, hi}}
+		if i%2 == 1 {
+			rings[i] = Reverse64(rings[i])
+		}
+	}
+	return rings
+}
+
+// nestedArea returns the filled area of concentricRings
+func nestedArea(levels int, step int64) float64 {
+	area := 0.0
+	for i := 0; i < levels; i++ {
+		side := float64(2 * int64(levels-i) * step)
+		if i%2 == 0 {
+			area += side * side
+		} else {
+			area -= side * side
+		}
+	}
+	return area
+}
+
+// treeDepth returns the depth of the deepest node below pp
+func treeDepth(pp *PolyPath64) int {
+	depth := 0
+	for _, child := range pp.Children {
+		depth = max(depth, 1+treeDepth(child))
+	}
+	return depth
+}
+
+// TestDifferenceDeepNesting tests that islands within holes survive a
+// Difference at every nesting depth, whether the nested hierarchy is the
+// subject or the clip
+func TestDifferenceDeepNesting(t *testing.T) {
+	const step = 10
+	for levels := 1; levels <= 8; levels++ {
+		nested := concentricRings(levels, 0, step)
+		size := int64(2 * levels * step)
+
+		for _, fillRule := range []FillRule{NonZero, EvenOdd, Positive} {
+			// Subtracting a far-away square leaves the hierarchy intact
+			far := Paths64{{{size + 10, 0}, {size + 20, 0}, {size + 20, 10}, {size + 10, 10}}}
+			tree, _, err := BooleanOp64Tree(Difference, fillRule, nested, nil, far)
+			if err != nil {
+				t.Fatalf("levels %d, %v: %v", levels, fillRule, err)
+			}
+			if got := tree.Stats().NetArea; got != nestedArea(levels, step) {
+				t.Errorf("levels %d, %v: expected area %v, got %v", levels, fillRule, nestedArea(levels, step), got)
+			}
+			if got := treeDepth(tree); got != levels {
+				t.Errorf("levels %d, %v: expected depth %d, got %d", levels, fillRule, levels, got)
+			}
+
+			// Subtracting the hierarchy from an enclosing square inverts it,
+			// adding one level
+			frame := Paths64{{{-step, -step}, {size + step, -step}, {size + step, size + step}, {-step, size + step}}}
+			tree, _, err = BooleanOp64Tree(Difference, fillRule, frame, nil, nested)
+			if err != nil {
+				t.Fatalf("levels %d, %v: %v", levels, fillRule, err)
+			}
+			expected := float64((size+2*step)*(size+2*step)) - nestedArea(levels, step)
+			if got := tree.Stats().NetArea; got != expected {
+				t.Errorf("levels %d, %v: expected inverted area %v, got %v", levels, fillRule, expected, got)
+			}
+			if got := treeDepth(tree); got != levels+1 {
+				t.Errorf("levels %d, %v: expected inverted depth %d, got %d", levels, fillRule, levels+1, got)
+			}
+		}
+	}
+}
+
+// TestDifferenceCutsThroughNesting tests a clip crossing every level
+func TestDifferenceCutsThroughNesting(t *testing.T) {
+	const levels, step = 6, 10
+	nested := concentricRings(levels, 0, step)
+	size := int64(2 * levels * step)
+
+	// Removing the right half of a symmetric hierarchy halves its area
+	rightHalf := Paths64{{{size / 2, -1}, {size + 1, -1}, {size + 1, size + 1}, {size / 2, size + 1}}}
+	for _, fillRule := range []FillRule{NonZero, EvenOdd} {
+		result, err := Difference64(nested, rightHalf, fillRule)
+		if err != nil {
+			t.Fatalf("%v: %v", fillRule, err)
+		}
+		if got := Stats64(result).NetArea; got != nestedArea(levels, step)/2 {
+			t.Errorf("%v: expected area %v, got %v", fillRule, nestedArea(levels, step)/2, got)
+		}
+		// every level is cut open into a U shape, so no holes remain
+		if holes := Stats64(result).Holes; holes != 0 {
+			t.Errorf("%v: expected no holes, got %d", fillRule, holes)
+		}
+	}
+}
+
+// TestOutersBeforeHoles tests that every outer ring is immediately followed
+// by its holes, and islands come after the hole containing them
+func TestOutersBeforeHoles(t *testing.T) {
+	var subjects Paths64
+	for i := int64(0); i < 3; i++ {
+		rings := concentricRings(5, i*200, 10)
+		// list the rings innermost first so the engine meets holes early
+		for j := len(rings) - 1; j >= 0; j-- {
+			subjects = append(subjects, rings[j])
+		}
+	}
+	subjects = append(subjects, Path64{{700, 0}, {750, 0}, {750, 50}, {700, 50}})
+
+	result, err := Union64(subjects, nil, NonZero)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result) != 16 {
+		t.Fatalf("expected 16 rings, got %d", len(result))
+	}
+
+	var outer Path64
+	placed := Paths64{}
+	for i, ring := range result {
+		if Area64(ring) > 0 {
+			outer = ring
+			// every ring containing an island, its hole included, comes first
+			if treeDepthOf(placed, ring)%2 != 0 {
+				t.Errorf("ring %d: island placed before its hole", i)
+			}
+		} else if outer == nil || !ringContainsRing(outer, ring) {
+			t.Errorf("ring %d: hole does not follow its outer ring", i)
+		}
+		placed = append(placed, ring)
+	}
+}
+
+// treeDepthOf counts the rings of placed that contain ring
+func treeDepthOf(placed Paths64, ring Path64) int {
+	depth := 0
+	for _, p := range placed {
+		if ringContainsRing(p, ring) {
+			depth++
+		}
+	}
+	return depth
+}
+
+// streamNested collects the rings of BooleanOp64Func, checking that every
+// ring follows its parent, which is the smallest ring containing it
+func streamNested(t *testing.T, clipType ClipType, fillRule FillRule, subjects, clips Paths64, opts ...Option) Paths64 {
+	t.Helper()
+	var rings Paths64
+	var reported []int
+	var holes []bool
+	err := BooleanOp64Func(clipType, fillRule, subjects, clips, func(ring Path64, isHole bool, parentIdx int) error {
+		if parentIdx >= len(rings) {
+			t.Errorf("ring %d: parent %d not emitted yet", len(rings), parentIdx)
+			parentIdx = -1
+		}
+		if isHole != (parentIdx >= 0 && !holes[parentIdx]) || isHole != (Area64(ring) < 0) {
+			t.Errorf("ring %d: unexpected isHole %v under parent %d", len(rings), isHole, parentIdx)
+		}
+		rings = append(rings, ring)
+		reported = append(reported, parentIdx)
+		holes = append(holes, isHole)
+		return nil
+	}, opts...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, parents := nestingParents(rings)
+	if !slices.Equal(reported, parents) {
+		t.Errorf("expected parents %v, got %v", parents, reported)
+	}
+	return rings
+}
+
+// TestBooleanOp64Func tests that streamed rings match BooleanOp64 and report their parents
+func TestBooleanOp64Func(t *testing.T) {
+	subjects := concentricRings(4, 0, 10)
+	subjects = append(subjects, Path64{{500, 0}, {550, 0}, {550, 50}, {500, 50}})
+	expected, _, err := BooleanOp64(Union, NonZero, subjects, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rings := streamNested(t, Union, NonZero, subjects, nil); !sameRings(rings, expected) {
+		t.Errorf("expected %v, got %v", expected, rings)
+	}
+
+	t.Run("During the sweep", func(t *testing.T) {
+		// Squares below a ring with a hole are emitted long before it closes
+		var subjects Paths64
+		for row := int64(0); row < 10; row++ {
+			for col := int64(0); col < 10; col++ {
+				x, y := col*20, row*20
+				subjects = append(subjects, Path64{{x, y}, {x + 10, y}, {x + 10, y + 10}, {x, y + 10}})
+			}
+		}
+		subjects = append(subjects, Path64{{0, 300}, {100, 300}, {100, 400}, {0, 400}}, Path64{{20, 320}, {20, 380}, {80, 380}, {80, 320}})
+		var lastY int64
+		emittedBefore := 0
+		err := BooleanOp64Func(Union, EvenOdd, subjects, nil, func(Path64, bool, int) error {
+			if lastY < 300 {
+				emittedBefore++
+			}
+			return nil
+		}, WithTracer(func(e TraceEvent) { lastY = e.Y }))
+		if err != nil || emittedBefore != 100 {
+			t.Errorf("expected the squares before the sweep reached the ring, got %d (err %v)", emittedBefore, err)
+		}
+	})
+
+	t.Run("Random", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1744))
+		for i := 0; i < 300; i++ {
+			var subjects, clips Paths64
+			for k := 0; k < 4; k++ {
+				subjects = append(subjects, randomPath(r, 3+r.Intn(8), 200))
+				clips = append(clips, randomPath(r, 3+r.Intn(8), 200))
+			}
+			clipType := ClipType(r.Intn(4))
+			fillRule := FillRule(r.Intn(4))
+			var expected Paths64
+			err := BooleanOp64Incremental(clipType, fillRule, subjects, nil, clips, func(path Path64, _ bool) error {
+				expected = append(expected, path)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if rings := streamNested(t, clipType, fillRule, subjects, clips); !sameRings(rings, expected) {
+				t.Fatalf("case %d: expected %v, got %v", i, expected, rings)
+			}
+		}
+	})
+
+	stop := errors.New("stop")
+	calls := 0
+	err = BooleanOp64Func(Union, NonZero, subjects, nil, func(Path64, bool, int) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Errorf("expected the callback error after one ring, got %v after %d", err, calls)
+	}
+}
+
+// TestBooleanOp64Incremental tests that rings are flushed during the sweep
+func TestBooleanOp64Incremental(t *testing.T) {
+	var subjects Paths64
+	for row := int64(0); row < 10; row++ {
+		for col := int64(0); col < 10; col++ {
+			x, y := col*20, row*20
+			subjects = append(subjects, Path64{{x, y}, {x + 10, y}, {x + 10, y + 10}, {x, y + 10}})
+		}
+	}
+	// A ring with a hole and an open path crossing the first row
+	subjects = append(subjects, Path64{{0, 300}, {100, 300}, {100, 400}, {0, 400}}, Path64{{20, 320}, {20, 380}, {80, 380}, {80, 320}})
+	subjectsOpen := Paths64{{{-5, 5}, {300, 5}}}
+	clips := Paths64{{{-10, -10}, {500, -10}, {500, 500}, {-10, 500}}}
+	expected, expectedOpen, err := BooleanOp64(Intersection, EvenOdd, subjects, subjectsOpen, clips)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var rings, open Paths64
+	var lastY int64
+	emittedBefore := 0 // paths emitted before the sweep reached the top
+	err = BooleanOp64Incremental(Intersection, EvenOdd, subjects, subjectsOpen, clips, func(path Path64, isOpen bool) error {
+		if lastY < 400 {
+			emittedBefore++
+		}
+		if isOpen {
+			open = append(open, path)
+		} else {
+			rings = append(rings, path)
+		}
+		return nil
+	}, WithTracer(func(e TraceEvent) { lastY = e.Y }))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !sameRings(rings, expected) || !sameRings(open, expectedOpen) {
+		t.Errorf("expected %v %v, got %v %v", expected, expectedOpen, rings, open)
+	}
+	if emittedBefore < 100 {
+		t.Errorf("expected the squares to be flushed before the sweep ended, got %d", emittedBefore)
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+	err = BooleanOp64Incremental(Union, NonZero, subjects, nil, nil, func(Path64, bool) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Errorf("expected the callback error after one path, got %v after %d", err, calls)
+	}
+}
@@ -0,0 +1,254 @@
+package clipper
+
+import "math"
+
+// ==============================================================================
+// Offsetting Helpers
+// ==============================================================================
+
+// maxCoord is the largest coordinate magnitude that can be safely scaled and
+// offset without intermediate products overflowing (matches Clipper2's MAX_COORD)
+const maxCoord = math.MaxInt64 >> 2
+
+// cornerLimitedArcTolerance returns the arc tolerance to use so that no
+// rounded corner needs more than opts.MaxCornerPoints vertices. Clipper2
+// approximates a full circle of radius |delta| with pi/acos(1-tol/|delta|)
+// steps; corners and end caps turn through at most half a circle, so that
+// count is kept at or below twice the cap.
+func cornerLimitedArcTolerance(delta float64, opts OffsetOptions) float64 {
+	absDelta := math.Abs(delta)
+	if opts.MaxCornerPoints <= 0 || absDelta == 0 {
+		return opts.ArcTolerance
+	}
+	minTolerance := absDelta * (1 - math.Cos(math.Pi/float64(2*opts.MaxCornerPoints)))
+	return math.Max(opts.ArcTolerance, minTolerance)
+}
+
+// arcStepsPer360 returns the number of steps the offsetter uses to
+// approximate a full circle of the given radius within arcTolerance; a
+// tolerance of 0 grows with the radius, as in Clipper2
+func arcStepsPer360(radius, arcTolerance float64) float64 {
+	tol := math.Log10(2+radius) * DefaultArcTolerance
+	if arcTolerance > 1e-12 {
+		tol = math.Min(radius, arcTolerance)
+	}
+	return math.Min(math.Pi/math.Acos(1-tol/radius), radius*math.Pi)
+}
+
+// InflatePathsXY64 offsets paths by different distances along the X and Y axes
+// (an elliptical rather than circular offset), which is useful when the two axes
+// use different units such as longitude/latitude degrees. deltaX and deltaY must
+// share the same sign. The paths are stretched along the axis with the smaller
+// delta so both distances become equal, offset with InflatePaths64 and then
+// compressed back, so round joins become elliptical arcs. Offsetting needs
+// the C++ library like InflatePaths64 does.
+func InflatePathsXY64(paths Paths64, deltaX, deltaY float64, joinType JoinType, endType EndType, opts ...Option) (Paths64, error) {
+	switch {
+	case math.IsNaN(deltaX) || math.IsNaN(deltaY) || math.IsInf(deltaX, 0) || math.IsInf(deltaY, 0):
+		return nil, ErrInvalidInput
+	case deltaX == deltaY:
+		return InflatePaths64(paths, deltaX, joinType, endType, opts...)
+	case deltaX == 0 || deltaY == 0 || (deltaX < 0) != (deltaY < 0):
+		// A zero or opposite-signed axis cannot be reached by scaling
+		return nil, ErrInvalidInput
+	}
+
+	// Stretch the axis with the smaller delta so no precision is lost to rounding
+	stretchX := math.Abs(deltaX) < math.Abs(deltaY)
+	var scale, delta float64
+	if stretchX {
+		scale, delta = deltaY/deltaX, deltaY
+	} else {
+		scale, delta = deltaX/deltaY, deltaX
+	}
+
+	stretched, ok := scalePathsAxis(paths, scale, stretchX)
+	if !ok {
+		return nil, ErrInvalidInput
+	}
+
+	result, err := InflatePaths64(stretched, delta, joinType, endType, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	restored, _ := scalePathsAxis(result, 1/scale, stretchX)
+	return restored, nil
+}
+
+// OffsetBand64 returns the band between closed polygons and their offset by
+// delta as a PolyTree64: the offset outline minus the polygons for a positive
+// delta (a frame around them), the polygons minus their inset for a negative
+// one (a border inside them). Contours are always oriented by nesting depth,
+// as with OffsetOptions.OrientByContainment, and both differences are taken
+// with NonZero filling, so the fill rule of the input does not matter. opts
+// apply to the offset and to the difference; a fill rule among them is
+// ignored. A zero delta yields an empty tree; any other needs the C++
+// library for the offset, see InflatePaths64.
+func OffsetBand64(paths Paths64, delta float64, joinType JoinType, opts ...Option) (*PolyTree64, error) {
+	if math.IsNaN(delta) || math.IsInf(delta, 0) {
+		return nil, ErrInvalidInput
+	}
+	paths, err := applyRingClosure(resolveOptions(opts).closure, "input", paths)
+	if err != nil {
+		return nil, err
+	}
+	if delta == 0 {
+		return NewPolyTree64(), nil
+	}
+	original := orientByContainment(paths)
+	offset, err := InflatePaths64(original, delta, joinType, ClosedPolygon, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	opts = append(opts[:len(opts):len(opts)], WithFillRule(NonZero))
+	var band *PolyTree64
+	if delta > 0 {
+		band, _, err = BooleanOp64Tree(Difference, NonZero, offset, nil, original, opts...)
+	} else {
+		band, _, err = BooleanOp64Tree(Difference, NonZero, original, nil, offset, opts...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return band, nil
+}
+
+// scalePathsAxis scales one coordinate axis of every point (X when onX is true,
+// otherwise Y). Returns false if any scaled coordinate would exceed maxCoord.
+func scalePathsAxis(paths Paths64, scale float64, onX bool) (Paths64, bool) {
+	result := make(Paths64, len(paths))
+	for i, path := range paths {
+		scaled := make(Path64, len(path))
+		for j, pt := range path {
+			if onX {
+				v := float64(pt.X) * scale
+				if math.Abs(v) > maxCoord {
+					return nil, false
+				}
+				scaled[j] = Point64{X: RoundHalfAway(v), Y: pt.Y}
+			} else {
+				v := float64(pt.Y) * scale
+				if math.Abs(v) > maxCoord {
+					return nil, false
+				}
+				scaled[j] = Point64{X: pt.X, Y: RoundHalfAway(v)}
+			}
+		}
+		result[i] = scaled
+	}
+	return result, true
+}
+
+// EstimateInflatedBounds64 returns a rectangle guaranteed to contain the
+// result of InflatePaths64(paths, delta, joinType, ...) for any end type,
+// without computing the offset. No offset vertex lies further from the input
+// than |delta|·√2 for round and square joins, the corners of square end caps
+// reaching that far whatever the join, or |delta|·miterLimit for miter joins
+// (a miterLimit of 1 or less means the default of 2), plus one unit for
+// rounding. Shrinking closed polygons stays within the input bounds, which
+// the estimate also contains. Empty input yields InvalidRect64. It needs no
+// backend, so it works in the pure Go build, where InflatePaths64 does not.
+func EstimateInflatedBounds64(paths Paths64, delta float64, joinType JoinType, miterLimit float64) Rect64 {
+	bounds := BoundsPaths64(paths)
+	if !bounds.IsValid() {
+		return bounds
+	}
+
+	reach := math.Sqrt2
+	if joinType == Miter {
+		reach = math.Max(effectiveMiterLimit(miterLimit), math.Sqrt2)
+	}
+	grow := math.Ceil(math.Abs(delta)*reach) + 1
+	if math.IsNaN(grow) || grow > maxCoord {
+		grow = maxCoord
+	}
+
+	g := int64(grow)
+	return Rect64{
+		Left:   saturatingAdd64(bounds.Left, -g),
+		Top:    saturatingAdd64(bounds.Top, -g),
+		Right:  saturatingAdd64(bounds.Right, g),
+		Bottom: saturatingAdd64(bounds.Bottom, g),
+	}
+}
+
+// saturatingAdd64 returns a+b clamped to the int64 range
+func saturatingAdd64(a, b int64) int64 {
+	sum := a + b
+	switch {
+	case b > 0 && sum < a:
+		return math.MaxInt64
+	case b < 0 && sum > a:
+		return math.MinInt64
+	}
+	return sum
+}
+
+// orientByContainment returns closed contours reoriented by nesting depth:
+// contours inside an even number of others become counter-clockwise outers
+// and those inside an odd number become clockwise holes. Offsetting a group
+// reverses it as a whole based on its lowest contour, which inverts holes of
+// glyph-like input whose nested contours do not alternate consistently;
+// OffsetOptions.OrientByContainment orients each contour by containment
+// parity first to avoid that.
+func orientByContainment(paths Paths64) Paths64 {
+	areas := make([]float64, len(paths))
+	for i, path := range paths {
+		areas[i] = Area64(path)
+	}
+	depths := make([]int, len(paths))
+	sweepContainment(paths, BoundsEach64(paths), func(inner, _ int) bool {
+		return areas[inner] != 0
+	}, func(inner, _ int) {
+		depths[inner]++
+	})
+
+	result := make(Paths64, len(paths))
+	changed := false
+	for i, path := range paths {
+		result[i] = path
+		area, depth := areas[i], depths[i]
+		if area == 0 {
+			continue
+		}
+		if (area > 0) != (depth%2 == 0) {
+			result[i] = Reverse64(path)
+			changed = true
+		}
+	}
+	if !changed {
+		return paths
+	}
+	return result
+}
+
+// ringContainsRing reports whether inner lies within outer, decided by the
+// first vertex of inner not on outer's boundary, or failing that by the first
+// such edge midpoint. Rings touching at all of those are not nested.
+func ringContainsRing(outer, inner Path64) bool {
+	for _, pt := range inner {
+		switch PointInPolygon(pt, outer, NonZero) {
+		case Inside:
+			return true
+		case Outside:
+			return false
+		}
+	}
+
+	// Midpoints are compared at twice the scale to keep them on the grid
+	doubled := make(Path64, len(outer))
+	for i, pt := range outer {
+		doubled[i] = pt.Add(pt)
+	}
+	for i, pt := range inner {
+		switch PointInPolygon(pt.Add(inner[(i+1)%len(inner)]), doubled, NonZero) {
+		case Inside:
+			return true
+		case Outside:
+			return false
+		}
+	}
+	return false
+}
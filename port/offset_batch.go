@@ -0,0 +1,77 @@
+package clipper
+
+import (
+	"runtime"
+	"sync"
+)
+
+// ==============================================================================
+// Concurrent Batch Offsetting
+// ==============================================================================
+
+// OffsetJob is one InflatePaths64 call of a batch
+type OffsetJob struct {
+	Paths    Paths64
+	Delta    float64
+	JoinType JoinType
+	EndType  EndType
+	Options  *OffsetOptions // nil for DefaultConfig().OffsetOptions()
+}
+
+// OffsetResult is the outcome of one OffsetJob
+type OffsetResult struct {
+	Paths Paths64
+	Err   error
+}
+
+// InflateBatch64 runs many independent offsets, such as stroking thousands of
+// polylines for map rendering, on a pool of worker goroutines (GOMAXPROCS
+// when workers <= 0). Results are returned in job order, and a failing job
+// only sets its own Err. Jobs share no state, so the batch is safe to run
+// concurrently with other calls; input paths are not modified. Every job is
+// an InflatePaths64 call, so without the C++ library each Err is
+// ErrNotImplemented.
+func InflateBatch64(jobs []OffsetJob, workers int) []OffsetResult {
+	results := make([]OffsetResult, len(jobs))
+	runPool(len(jobs), workers, func() func(int) {
+		return func(i int) {
+			job := jobs[i]
+			var opts []Option
+			if job.Options != nil {
+				opts = append(opts, *job.Options)
+			}
+			paths, err := InflatePaths64(job.Paths, job.Delta, job.JoinType, job.EndType, opts...)
+			results[i] = OffsetResult{Paths: paths, Err: err}
+		}
+	})
+	return results
+}
+
+// runPool calls work(i) for every i in [0, n) on a pool of workers
+// goroutines (GOMAXPROCS when workers <= 0) and waits for them. Each
+// goroutine calls start once for its work function, so it can keep state
+// such as an engine from job to job.
+func runPool(n, workers int, start func() func(i int)) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, n)
+
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			work := start()
+			for i := range next {
+				work(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+}
@@ -0,0 +1,61 @@
+package clipper
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestInflateBatch64(t *testing.T) {
+	var jobs []OffsetJob
+	for i := int64(0); i < 50; i++ {
+		jobs = append(jobs, OffsetJob{
+			Paths:    Paths64{{{i * 100, 0}, {i*100 + 50, 20}, {i*100 + 80, 0}}},
+			Delta:    float64(1 + i%5),
+			JoinType: Round,
+			EndType:  OpenRound,
+		})
+	}
+	jobs[7].Options = &OffsetOptions{MiterLimit: 4, ArcTolerance: 0.5}
+	jobs[7].JoinType = Miter
+	jobs[13].EndType = ClosedPolygon
+
+	withOffsetBackend(t, func() {
+		for _, workers := range []int{0, 1, 4, 100} {
+			results := InflateBatch64(jobs, workers)
+			if len(results) != len(jobs) {
+				t.Fatalf("workers %d: expected %d results, got %d", workers, len(jobs), len(results))
+			}
+			for i, job := range jobs {
+				var opts []Option
+				if job.Options != nil {
+					opts = append(opts, *job.Options)
+				}
+				expected, err := InflatePaths64(job.Paths, job.Delta, job.JoinType, job.EndType, opts...)
+				if err != nil || len(expected) == 0 {
+					t.Fatalf("job %d: expected an offset, got %v, %v", i, expected, err)
+				}
+				if results[i].Err != nil || !reflect.DeepEqual(results[i].Paths, expected) {
+					t.Errorf("workers %d, job %d: expected %v; got %v, %v", workers, i, expected, results[i].Paths, results[i].Err)
+				}
+			}
+		}
+	})
+
+	// Failures stay with their jobs
+	withBackend(t, nil, func() {
+		if err := SetEngine(EngineGo); err != nil {
+			t.Fatalf("SetEngine: %v", err)
+		}
+		results := InflateBatch64(jobs[:3], 2)
+		for i, result := range results {
+			if !errors.Is(result.Err, ErrNotImplemented) || result.Paths != nil {
+				t.Errorf("job %d: expected ErrNotImplemented from EngineGo, got %v, %v", i, result.Paths, result.Err)
+			}
+		}
+	})
+
+	if results := InflateBatch64(nil, 4); len(results) != 0 {
+		t.Errorf("expected no results for no jobs, got %v", results)
+	}
+}
@@ -0,0 +1,34 @@
+//go:build clipper_cgo
+
+package clipper
+
+import (
+	"math"
+	"testing"
+)
+
+// withOffsetBackend runs f on the C++ library linked by this build, so the
+// offset tests check the real backend rather than offsetBackend
+func withOffsetBackend(t *testing.T, f func()) {
+	t.Helper()
+	withBackend(t, nil, func() {
+		if err := SetEngine(EngineDefault); err != nil {
+			t.Fatalf("SetEngine: %v", err)
+		}
+		f()
+	})
+}
+
+// TestInflatePaths64Oracle tests that offsetting runs on the C++ library
+func TestInflatePaths64Oracle(t *testing.T) {
+	square := Paths64{{{0, 0}, {100, 0}, {100, 100}, {0, 100}}}
+	withOffsetBackend(t, func() {
+		result, err := InflatePaths64(square, 10, Miter, ClosedPolygon)
+		if err != nil {
+			t.Fatalf("InflatePaths64 failed: %v", err)
+		}
+		if area := AreaPaths64(result); math.Abs(area-120*120) > 1 {
+			t.Errorf("Expected area %v, got %v (result %v)", 120*120, area, result)
+		}
+	})
+}
@@ -0,0 +1,227 @@
+//go:build !clipper_cgo
+
+package clipper
+
+import (
+	"math"
+	"testing"
+)
+
+// offsetBackend is a Backend whose InflatePaths64 offsets in pure Go the way
+// Clipper2's ClipperOffset does, so offset tests run without the C++
+// library: every path is offset into a raw ring with joins at its vertices
+// and the rings are unioned with the Positive fill rule. Boolean operations
+// run on the pure Go engine.
+type offsetBackend struct{}
+
+func (offsetBackend) BooleanOp64(clipType ClipType, fillRule FillRule, subjects, subjectsOpen, clips Paths64) (Paths64, Paths64, error) {
+	return NewVattiEngine(clipType, fillRule).ExecuteClipping(subjects, subjectsOpen, clips)
+}
+
+func (offsetBackend) InflatePaths64(paths Paths64, delta float64, joinType JoinType, endType EndType, opts OffsetOptions) (Paths64, error) {
+	if endType != ClosedPolygon {
+		delta = math.Abs(delta)
+	}
+	if delta == 0 {
+		return paths.Clone(), nil
+	}
+	if endType == ClosedPolygon && lowestPathArea(paths) < 0 {
+		// Clipper2 takes the orientation of the outer holding the lowest point
+		reversed := make(Paths64, len(paths))
+		for i, path := range paths {
+			reversed[i] = Reverse64(path)
+		}
+		paths = reversed
+	}
+	o := stubOffsetter{delta: delta, joinType: joinType, cap: endType, opts: opts}
+	var raw Paths64
+	for _, path := range paths {
+		path = dedupRing(path, endType == ClosedPolygon || endType == ClosedLine)
+		switch {
+		case len(path) == 0:
+		case len(path) == 1:
+			raw = append(raw, o.dot(path[0], joinType == Round || endType == OpenRound))
+		case endType == ClosedPolygon:
+			raw = append(raw, o.ring(path))
+		case endType == ClosedLine:
+			raw = append(raw, o.ring(path), o.ring(Reverse64(path)))
+		default:
+			// An open path offsets as the closed path running out and back;
+			// its two reversals are the end caps
+			back := Reverse64(path[1 : len(path)-1])
+			raw = append(raw, o.ring(append(path.Clone(), back...)))
+		}
+	}
+	solution, _, err := NewVattiEngine(Union, Positive).ExecuteClipping(raw, nil, nil)
+	return solution, err
+}
+
+// withOffsetBackend runs f with offsetBackend serving offsets under
+// EngineGoWithFallback. With -tags=clipper_cgo offset_cgo_test.go runs f on
+// the C++ library instead.
+func withOffsetBackend(t *testing.T, f func()) {
+	t.Helper()
+	withBackend(t, offsetBackend{}, func() {
+		if err := SetEngine(EngineGoWithFallback); err != nil {
+			t.Fatalf("SetEngine: %v", err)
+		}
+		f()
+	})
+}
+
+// lowestPathArea returns the signed area of the path holding the point with
+// the largest Y
+func lowestPathArea(paths Paths64) float64 {
+	lowest := -1
+	var lowY int64
+	for i, path := range paths {
+		for _, pt := range path {
+			if lowest < 0 || pt.Y > lowY {
+				lowest, lowY = i, pt.Y
+			}
+		}
+	}
+	if lowest < 0 {
+		return 0
+	}
+	return Area64(paths[lowest])
+}
+
+// dedupRing drops consecutive duplicate points, and a closing duplicate of
+// the first point if closed
+func dedupRing(path Path64, closed bool) Path64 {
+	var out Path64
+	for _, pt := range path {
+		if len(out) == 0 || out[len(out)-1] != pt {
+			out = append(out, pt)
+		}
+	}
+	for closed && len(out) > 1 && out[0] == out[len(out)-1] {
+		out = out[:len(out)-1]
+	}
+	return out
+}
+
+// stubOffsetter builds the raw offset rings of offsetBackend
+type stubOffsetter struct {
+	delta    float64
+	joinType JoinType
+	opts     OffsetOptions
+	cap      EndType // end type, giving the join at the reversals of open paths
+	out      Path64
+}
+
+type vec struct{ x, y float64 }
+
+func (a vec) add(b vec) vec           { return vec{a.x + b.x, a.y + b.y} }
+func (a vec) scale(f float64) vec     { return vec{a.x * f, a.y * f} }
+func (a vec) dot(b vec) float64       { return a.x*b.x + a.y*b.y }
+func (a vec) cross(b vec) float64     { return a.x*b.y - a.y*b.x }
+func (a vec) rotate(s, c float64) vec { return vec{a.x*c - a.y*s, a.x*s + a.y*c} }
+
+// emit appends pt + v·delta, rounded to the grid
+func (o *stubOffsetter) emit(pt Point64, v vec) {
+	o.out = append(o.out, Point64{
+		X: pt.X + int64(math.Round(v.x*o.delta)),
+		Y: pt.Y + int64(math.Round(v.y*o.delta)),
+	})
+}
+
+// ring offsets the closed path to the right of its edges, which is outwards
+// for a positive path
+func (o *stubOffsetter) ring(path Path64) Path64 {
+	n := len(path)
+	dirs := make([]vec, n)
+	for i, a := range path {
+		b := path[(i+1)%n]
+		dx, dy := float64(b.X-a.X), float64(b.Y-a.Y)
+		l := math.Hypot(dx, dy)
+		dirs[i] = vec{dx / l, dy / l}
+	}
+	o.out = nil
+	for j := range path {
+		o.join(path[j], dirs[(j+n-1)%n], dirs[j])
+	}
+	return o.out
+}
+
+// join emits the offset points at vertex v between the edge arriving along
+// dk and the one leaving along dj
+func (o *stubOffsetter) join(v Point64, dk, dj vec) {
+	nk, nj := vec{dk.y, -dk.x}, vec{dj.y, -dj.x}
+	sinA, cosA := dk.cross(dj), dk.dot(dj)
+	reversal := cosA < -0.99
+	angle := math.Atan2(sinA, cosA)
+	if sinA == 0 && cosA < 0 {
+		// Turn back around the side being offset
+		angle = math.Copysign(math.Pi, o.delta)
+	}
+	switch {
+	case !reversal && sinA*o.delta < 0:
+		// Concave: the offset edges cross and the union removes the loop
+		o.emit(v, nk)
+		o.out = append(o.out, v)
+		o.emit(v, nj)
+	case reversal && o.cap == OpenButt:
+		o.emit(v, nk)
+		o.emit(v, nj)
+	case reversal && o.cap == OpenRound, !reversal && o.joinType == Round:
+		o.round(v, nk, angle)
+	case cosA > 0.999, !reversal && o.joinType == Miter && cosA+1 > o.miterBound():
+		o.emit(v, nk.add(nj).scale(1/(1+cosA)))
+	default:
+		o.square(v, nk, nj, dk, dj)
+	}
+}
+
+// miterBound returns the 1+cos below which Clipper2 squares a miter join
+func (o *stubOffsetter) miterBound() float64 {
+	if o.opts.MiterLimit <= 1 {
+		return 2
+	}
+	return 2 / (o.opts.MiterLimit * o.opts.MiterLimit)
+}
+
+// square cuts the corner at distance delta from v, across the bisector of
+// the normals, or straight ahead at a reversal
+func (o *stubOffsetter) square(v Point64, nk, nj, dk, dj vec) {
+	u := nk.add(nj)
+	if l := math.Hypot(u.x, u.y); l > 1e-9 {
+		u = u.scale(1 / l)
+	} else {
+		u = dk.scale(math.Copysign(1, o.delta))
+	}
+	o.emit(v, nk.add(dk.scale((1-nk.dot(u))/dk.dot(u))))
+	o.emit(v, nj.add(dj.scale((1-nj.dot(u))/dj.dot(u))))
+}
+
+// round emits an arc around v from normal nk through angle
+func (o *stubOffsetter) round(v Point64, nk vec, angle float64) {
+	steps := math.Ceil(arcStepsPer360(math.Abs(o.delta), o.opts.ArcTolerance) * math.Abs(angle) / (2 * math.Pi))
+	steps = math.Max(steps, 1)
+	s, c := math.Sincos(angle / steps)
+	n := nk
+	for i := 0; i <= int(steps); i++ {
+		o.emit(v, n)
+		n = n.rotate(s, c)
+	}
+}
+
+// dot returns the offset of a single point: a circle or a square
+func (o *stubOffsetter) dot(pt Point64, round bool) Path64 {
+	o.out = nil
+	if !round {
+		for _, v := range []vec{{-1, -1}, {1, -1}, {1, 1}, {-1, 1}} {
+			o.emit(pt, v)
+		}
+		return o.out
+	}
+	steps := math.Ceil(arcStepsPer360(math.Abs(o.delta), o.opts.ArcTolerance))
+	s, c := math.Sincos(2 * math.Pi / steps)
+	n := vec{1, 0}
+	for i := 0; i < int(steps); i++ {
+		o.emit(pt, n)
+		n = n.rotate(s, c)
+	}
+	return o.out
+}
@@ -0,0 +1,312 @@
+package clipper
+
+import (
+	"math"
+	"testing"
+)
+
+// TestInflatePathsXY64 tests anisotropic offsetting
+func TestInflatePathsXY64(t *testing.T) {
+	square := Paths64{{{0, 0}, {100, 0}, {100, 100}, {0, 100}}}
+
+	withOffsetBackend(t, func() {
+		result, err := InflatePathsXY64(square, 10, 20, Miter, ClosedPolygon)
+		if err != nil {
+			t.Fatalf("InflatePathsXY64 failed: %v", err)
+		}
+		if len(result) == 0 {
+			t.Fatal("Expected non-empty result from anisotropic inflate")
+		}
+
+		bounds := BoundsPaths64(result)
+		expected := Rect64{Left: -10, Top: -20, Right: 110, Bottom: 120}
+		if bounds != expected {
+			t.Errorf("Expected bounds %v, got %v", expected, bounds)
+		}
+	})
+}
+
+// TestInflatePathsXY64InvalidDeltas tests rejection of deltas that cannot be mapped
+func TestInflatePathsXY64InvalidDeltas(t *testing.T) {
+	square := Paths64{{{0, 0}, {100, 0}, {100, 100}, {0, 100}}}
+
+	tests := []struct {
+		name           string
+		deltaX, deltaY float64
+	}{
+		{"Opposite signs", 10, -10},
+		{"Zero X", 0, 10},
+		{"Zero Y", -5, 0},
+		{"NaN", math.NaN(), 10},
+		{"Infinite", 10, math.Inf(1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := InflatePathsXY64(square, tt.deltaX, tt.deltaY, Round, ClosedPolygon); err != ErrInvalidInput {
+				t.Errorf("Expected ErrInvalidInput, got %v", err)
+			}
+		})
+	}
+}
+
+// TestScalePathsAxis tests the per-axis scaling used by anisotropic offsetting
+func TestScalePathsAxis(t *testing.T) {
+	paths := Paths64{{{10, 10}, {-3, 7}}}
+
+	scaled, ok := scalePathsAxis(paths, 2.5, false)
+	if !ok {
+		t.Fatal("Unexpected overflow")
+	}
+	expected := Path64{{10, 25}, {-3, 18}}
+	for i, pt := range scaled[0] {
+		if pt != expected[i] {
+			t.Errorf("Point %d: expected %v, got %v", i, expected[i], pt)
+		}
+	}
+
+	if _, ok := scalePathsAxis(Paths64{{{maxCoord, 0}}}, 2, true); ok {
+		t.Error("Expected overflow to be detected")
+	}
+}
+
+// TestCornerLimitedArcTolerance tests that MaxCornerPoints bounds the steps per corner
+func TestCornerLimitedArcTolerance(t *testing.T) {
+	for _, delta := range []float64{1, 10, 1000, -50000} {
+		for _, maxPoints := range []int{1, 4, 16} {
+			opts := OffsetOptions{ArcTolerance: 0.25, MaxCornerPoints: maxPoints}
+			tol := cornerLimitedArcTolerance(delta, opts)
+			if tol < opts.ArcTolerance {
+				t.Errorf("delta=%v max=%d: tolerance %v finer than requested", delta, maxPoints, tol)
+			}
+			// Steps per half circle using Clipper2's formula
+			absDelta := math.Abs(delta)
+			steps := math.Pi / math.Acos(1-math.Min(tol, absDelta)/absDelta) / 2
+			if steps > float64(maxPoints)+1e-9 {
+				t.Errorf("delta=%v max=%d: %v steps per corner", delta, maxPoints, steps)
+			}
+		}
+	}
+
+	opts := OffsetOptions{ArcTolerance: 0.25}
+	if tol := cornerLimitedArcTolerance(1000, opts); tol != 0.25 {
+		t.Errorf("Expected tolerance unchanged without a cap, got %v", tol)
+	}
+}
+
+// TestInflatePaths64MaxCornerPoints tests the vertex budget of rounded joins
+func TestInflatePaths64MaxCornerPoints(t *testing.T) {
+	square := Paths64{{{0, 0}, {100, 0}, {100, 100}, {0, 100}}}
+	opts := OffsetOptions{MiterLimit: 2, ArcTolerance: 0.25, MaxCornerPoints: 4}
+
+	withOffsetBackend(t, func() {
+		result, err := InflatePaths64(square, 10000, Round, ClosedPolygon, opts)
+		if err != nil {
+			t.Fatalf("InflatePaths64 failed: %v", err)
+		}
+		if len(result) != 1 {
+			t.Fatalf("Expected a single path, got %d", len(result))
+		}
+		// Four corners of at most MaxCornerPoints+1 vertices each
+		if limit := 4 * (opts.MaxCornerPoints + 1); len(result[0]) > limit {
+			t.Errorf("Expected at most %d vertices, got %d", limit, len(result[0]))
+		}
+
+		// Without the cap the same tolerance needs far more
+		uncapped, err := InflatePaths64(square, 10000, Round, ClosedPolygon, OffsetOptions{MiterLimit: 2, ArcTolerance: 0.25})
+		if err != nil {
+			t.Fatalf("InflatePaths64 failed: %v", err)
+		}
+		if limit := 4 * (opts.MaxCornerPoints + 1); len(uncapped) != 1 || len(uncapped[0]) <= limit {
+			t.Errorf("Expected more than %d vertices without a cap, got %v paths", limit, len(uncapped))
+		}
+	})
+}
+
+// TestEstimateInflatedBounds64 tests the analytic offset bounds
+func TestEstimateInflatedBounds64(t *testing.T) {
+	square := Paths64{{{0, 0}, {100, 0}, {100, 100}, {0, 100}}}
+
+	tests := []struct {
+		name       string
+		paths      Paths64
+		delta      float64
+		joinType   JoinType
+		miterLimit float64
+		expected   Rect64
+	}{
+		{"Round", square, 10, Round, 0, Rect64{-16, -16, 116, 116}},
+		{"Square", square, 10, Square, 0, Rect64{-16, -16, 116, 116}},
+		{"Miter default limit", square, 10, Miter, 0, Rect64{-21, -21, 121, 121}},
+		{"Miter limit 3", square, 10, Miter, 3, Rect64{-31, -31, 131, 131}},
+		{"Negative delta", square, -10, Round, 0, Rect64{-16, -16, 116, 116}},
+		{"Zero delta", square, 0, Miter, 0, Rect64{-1, -1, 101, 101}},
+		{"Empty", nil, 10, Round, 0, InvalidRect64},
+		{"Saturates", Paths64{{{math.MaxInt64 - 5, 0}}}, 100, Round, 0, Rect64{math.MaxInt64 - 148, -143, math.MaxInt64, 143}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EstimateInflatedBounds64(tt.paths, tt.delta, tt.joinType, tt.miterLimit)
+			if got != tt.expected {
+				t.Errorf("EstimateInflatedBounds64 = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestEstimateInflatedBounds64ContainsOffset checks the estimate against real
+// offsets
+func TestEstimateInflatedBounds64ContainsOffset(t *testing.T) {
+	paths := Paths64{{{0, 0}, {100, 0}, {50, 10}}}
+	withOffsetBackend(t, func() {
+		for _, joinType := range []JoinType{Square, Round, Miter} {
+			for _, endType := range []EndType{ClosedPolygon, OpenSquare, OpenRound} {
+				result, err := InflatePaths64(paths, 25, joinType, endType, OffsetOptions{MiterLimit: 4})
+				if err != nil {
+					t.Fatalf("InflatePaths64 failed: %v", err)
+				}
+				estimate := EstimateInflatedBounds64(paths, 25, joinType, 4)
+				actual := BoundsPaths64(result)
+				if !actual.IsValid() || !estimate.ContainsRect(actual) {
+					t.Errorf("join %v end %v: estimate %v does not contain %v", joinType, endType, estimate, actual)
+				}
+			}
+		}
+	})
+}
+
+// TestOrientByContainment tests per-contour orientation by nesting parity
+func TestOrientByContainment(t *testing.T) {
+	square := func(lo, hi int64) Path64 {
+		return Path64{{lo, lo}, {hi, lo}, {hi, hi}, {lo, hi}}
+	}
+
+	tests := []struct {
+		name     string
+		paths    Paths64
+		positive []bool
+	}{
+		{"Already oriented", Paths64{square(0, 100), Reverse64(square(10, 90))}, []bool{true, false}},
+		{"All clockwise", Paths64{Reverse64(square(0, 100)), Reverse64(square(10, 90))}, []bool{true, false}},
+		{"All counter-clockwise", Paths64{square(0, 100), square(10, 90)}, []bool{true, false}},
+		{"Island in hole", Paths64{square(40, 60), square(10, 90), square(0, 100)}, []bool{true, false, true}},
+		{"Separate glyphs", Paths64{Reverse64(square(0, 10)), square(20, 30)}, []bool{true, true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := orientByContainment(tt.paths)
+			for i, want := range tt.positive {
+				if got := IsPositive64(result[i]); got != want {
+					t.Errorf("Contour %d: positive = %v, expected %v", i, got, want)
+				}
+				if math.Abs(Area64(result[i])) != math.Abs(Area64(tt.paths[i])) {
+					t.Errorf("Contour %d changed shape", i)
+				}
+			}
+		})
+	}
+}
+
+// TestInflatePaths64NestedGlyph checks that a glyph-like "O" with
+// inconsistently oriented contours is emboldened on both sides when oriented
+// by containment, and offset by its winding otherwise
+func TestInflatePaths64NestedGlyph(t *testing.T) {
+	outer := Reverse64(Path64{{0, 0}, {100, 0}, {100, 100}, {0, 100}})
+	inner := Reverse64(Path64{{30, 30}, {70, 30}, {70, 70}, {30, 70}})
+
+	tests := []struct {
+		name     string
+		orient   bool
+		expected float64
+	}{
+		// The outline grows outwards and the counter shrinks
+		{"Oriented by containment", true, 110*110 - 30*30},
+		// Both contours wind alike, so the counter is filled
+		{"Oriented by winding", false, 110 * 110},
+	}
+	withOffsetBackend(t, func() {
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				result, err := InflatePaths64(Paths64{outer, inner}, 5, Miter, ClosedPolygon, WithOrientByContainment(tt.orient))
+				if err != nil {
+					t.Fatalf("InflatePaths64 failed: %v", err)
+				}
+				if area := totalArea(result); math.Abs(area-tt.expected) > 1 {
+					t.Errorf("Expected area %v, got %v (%v)", tt.expected, area, result)
+				}
+			})
+		}
+	})
+}
+
+// TestOffsetBand64 tests frames around and borders inside a polygon
+func TestOffsetBand64(t *testing.T) {
+	// Clockwise input: the band must not depend on the orientation
+	square := Paths64{Reverse64(Path64{{0, 0}, {100, 0}, {100, 100}, {0, 100}})}
+
+	tests := []struct {
+		name         string
+		delta        float64
+		outer, inner Path64
+	}{
+		{"frame", 10, Path64{{-10, -10}, {110, -10}, {110, 110}, {-10, 110}}, square[0]},
+		{"border", -10, square[0], Path64{{10, 10}, {90, 10}, {90, 90}, {10, 90}}},
+	}
+	withOffsetBackend(t, func() {
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				band, err := OffsetBand64(square, tt.delta, Miter, WithFillRule(EvenOdd))
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if len(band.Children) != 1 || len(band.Children[0].Children) != 1 {
+					t.Fatalf("expected one outer with one hole, got %v", flattenPolyTree64(band))
+				}
+				outer, hole := band.Children[0].Path, band.Children[0].Children[0].Path
+				if math.Abs(Area64(outer)-math.Abs(Area64(tt.outer))) > 1 {
+					t.Errorf("expected outer area %v, got %v", math.Abs(Area64(tt.outer)), Area64(outer))
+				}
+				if math.Abs(Area64(hole)+math.Abs(Area64(tt.inner))) > 1 {
+					t.Errorf("expected hole area %v, got %v", -math.Abs(Area64(tt.inner)), Area64(hole))
+				}
+			})
+		}
+	})
+
+	if band, err := OffsetBand64(square, 0, Miter); err != nil || len(band.Children) != 0 {
+		t.Errorf("expected an empty band for a zero delta, got %v (err %v)", band, err)
+	}
+	if _, err := OffsetBand64(square, math.NaN(), Miter); err != ErrInvalidInput {
+		t.Errorf("expected ErrInvalidInput for a NaN delta, got %v", err)
+	}
+}
+
+// TestInflatePaths64Tree tests that offset holes stay nested in their outers
+func TestInflatePaths64Tree(t *testing.T) {
+	frame := Paths64{
+		{{0, 0}, {100, 0}, {100, 100}, {0, 100}},
+		{{30, 30}, {30, 70}, {70, 70}, {70, 30}},
+	}
+
+	withOffsetBackend(t, func() {
+		tree, err := InflatePaths64Tree(frame, 5, Miter, ClosedPolygon)
+		if err != nil {
+			t.Fatalf("InflatePaths64Tree failed: %v", err)
+		}
+		if tree.Count() != 1 || tree.Child(0).Count() != 1 {
+			t.Fatalf("expected one outer with one hole, got %v", tree)
+		}
+		outer, hole := tree.Child(0), tree.Child(0).Child(0)
+		if outer.IsHole() || !hole.IsHole() {
+			t.Errorf("expected an outer and a hole, got hole flags %v and %v", outer.IsHole(), hole.IsHole())
+		}
+		if got := Bounds64(outer.Polygon()); got != (Rect64{-5, -5, 105, 105}) {
+			t.Errorf("expected the outer grown to {-5 -5 105 105}, got %v", got)
+		}
+		if got := Bounds64(hole.Polygon()); got != (Rect64{35, 35, 65, 65}) {
+			t.Errorf("expected the hole shrunk to {35 35 65 65}, got %v", got)
+		}
+	})
+}
@@ -0,0 +1,82 @@
+package clipper
+
+import (
+	"fmt"
+	"math"
+)
+
+// ==============================================================================
+// Variable-Width Offsetting
+// ==============================================================================
+
+// InflatePathsVariable64 offsets every path by its own distance, deltas[i]
+// for paths[i], as needed for tapered toolpaths or strokes of varying width.
+// Each path is offset on its own and the results are merged. For
+// ClosedPolygon, a clockwise contour is a hole and is offset against its own
+// delta, growing where its polygon shrinks; nested contours keep cancelling
+// one another, so an island inside a hole is offset as a polygon of its own.
+// With OffsetOptions.OrientByContainment, contours are first oriented by
+// nesting depth as in InflatePaths64. Deltas must be finite and match
+// the paths one to one, otherwise ErrInvalidInput is returned. Equal deltas
+// make it a plain InflatePaths64 call.
+//
+// The backend offsets whole paths, so deltas cannot vary along a path. Like
+// InflatePaths64 it needs the C++ library and otherwise returns
+// ErrNotImplemented.
+func InflatePathsVariable64(paths Paths64, deltas []float64, joinType JoinType, endType EndType, opts ...Option) (Paths64, error) {
+	if len(deltas) != len(paths) {
+		return nil, fmt.Errorf("%w: %d deltas for %d paths", ErrInvalidInput, len(deltas), len(paths))
+	}
+	uniform := true
+	for i, delta := range deltas {
+		if math.IsNaN(delta) || math.IsInf(delta, 0) {
+			return nil, fmt.Errorf("%w: delta %d is not finite", ErrInvalidInput, i)
+		}
+		uniform = uniform && delta == deltas[0]
+	}
+	if len(paths) == 0 || uniform {
+		delta := 0.0
+		if len(deltas) > 0 {
+			delta = deltas[0]
+		}
+		return InflatePaths64(paths, delta, joinType, endType, opts...)
+	}
+
+	s := resolveOptions(opts)
+	if endType == ClosedPolygon {
+		var err error
+		if paths, err = applyRingClosure(s.closure, "input", paths); err != nil {
+			return nil, err
+		}
+		if s.offset.OrientByContainment {
+			paths = orientByContainment(paths)
+		}
+	}
+
+	// Offsets of holes are reversed, so every contribution winds +1 or -1
+	// and nested contours cancel under the Positive fill rule
+	var contributions Paths64
+	for i, path := range paths {
+		delta := deltas[i]
+		hole := endType == ClosedPolygon && Area64(path) < 0
+		if hole {
+			path, delta = Reverse64(path), -delta
+		}
+		offset, err := InflatePaths64(Paths64{path}, delta, joinType, endType, opts...)
+		if err != nil {
+			return nil, err
+		}
+		if hole {
+			for j, ring := range offset {
+				offset[j] = Reverse64(ring)
+			}
+		}
+		contributions = append(contributions, offset...)
+	}
+
+	solution, _, err := BooleanOp64(Union, Positive, contributions, nil, nil, WithContext(s.ctx))
+	if err != nil {
+		return nil, err
+	}
+	return solution, nil
+}
@@ -0,0 +1,55 @@
+package clipper
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// TestInflatePathsVariable64 tests per-path deltas and their validation
+func TestInflatePathsVariable64(t *testing.T) {
+	outer := Path64{{0, 0}, {100, 0}, {100, 100}, {0, 100}}
+	hole := Path64{{40, 40}, {40, 60}, {60, 60}, {60, 40}}
+	apart := Path64{{200, 0}, {220, 0}, {220, 20}, {200, 20}}
+
+	invalid := []struct {
+		name   string
+		paths  Paths64
+		deltas []float64
+	}{
+		{"Too few deltas", Paths64{outer, apart}, []float64{1}},
+		{"Too many deltas", Paths64{outer}, []float64{1, 2}},
+		{"NaN", Paths64{outer, apart}, []float64{1, math.NaN()}},
+		{"Infinite", Paths64{outer, apart}, []float64{math.Inf(-1), 1}},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := InflatePathsVariable64(tt.paths, tt.deltas, Miter, ClosedPolygon); !errors.Is(err, ErrInvalidInput) {
+				t.Errorf("expected ErrInvalidInput, got %v", err)
+			}
+		})
+	}
+
+	withOffsetBackend(t, func() {
+		result, err := InflatePathsVariable64(Paths64{outer, hole, apart}, []float64{10, 5, 2}, Miter, ClosedPolygon)
+		if err != nil {
+			t.Fatalf("InflatePathsVariable64 failed: %v", err)
+		}
+		// 120x120 around a hole shrunk to 10x10, and 24x24 around the apart square
+		expectedArea := 120.0*120 - 10*10 + 24*24
+		if area := totalArea(result); area != expectedArea {
+			t.Errorf("expected area %v, got %v (%v)", expectedArea, area, result)
+		}
+		if bounds := BoundsPaths64(result); bounds != (Rect64{-10, -10, 222, 110}) {
+			t.Errorf("expected bounds {-10 -10 222 110}, got %v", bounds)
+		}
+
+		// Equal deltas are a plain InflatePaths64 call
+		uniform, err := InflatePathsVariable64(Paths64{outer, apart}, []float64{3, 3}, Miter, ClosedPolygon)
+		expected, _ := InflatePaths64(Paths64{outer, apart}, 3, Miter, ClosedPolygon)
+		if err != nil || !reflect.DeepEqual(uniform, expected) {
+			t.Errorf("expected %v for equal deltas, got %v, %v", expected, uniform, err)
+		}
+	})
+}
@@ -0,0 +1,256 @@
+package clipper
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// ==============================================================================
+// Functional Options
+// ==============================================================================
+
+// Option configures a call of BooleanOp64, InflatePaths64 or RectClip64 (and
+// the functions built on them). ClipperOptions and OffsetOptions are options
+// themselves and replace all fields of their kind at once; the With*
+// functions change a single setting, so new switches never need new function
+// variants. Options are applied in order, later ones winning. Settings that
+// do not concern an operation are ignored by it.
+type Option interface {
+	applyOption(s *settings)
+}
+
+// settings collects the effect of all options of one call
+type settings struct {
+	clipper  ClipperOptions
+	offset   OffsetOptions
+	fillRule *FillRule // overrides the fill rule argument when set
+	closure  RingClosure
+	ctx      context.Context
+	tracer   func(TraceEvent)
+	budget   SweepBudget
+}
+
+// TraceEvent describes the sweep after one scanbeam, as reported to the
+// function passed to WithTracer
+type TraceEvent struct {
+	Y           int64 // scanline the sweep has reached
+	ActiveEdges int   // edges crossing the scanline
+	OutRecs     int   // output rings started so far
+}
+
+// optionFunc adapts a function to the Option interface
+type optionFunc func(s *settings)
+
+func (f optionFunc) applyOption(s *settings) { f(s) }
+
+// applyOption implements Option by replacing all boolean operation settings
+func (o ClipperOptions) applyOption(s *settings) { s.clipper = o }
+
+// applyOption implements Option by replacing all offsetting settings
+func (o OffsetOptions) applyOption(s *settings) { s.offset = o }
+
+// WithFillRule overrides the fill rule argument of a boolean operation
+func WithFillRule(fillRule FillRule) Option {
+	return optionFunc(func(s *settings) { s.fillRule = &fillRule })
+}
+
+// WithContext lets ctx cancel the operation. Boolean operations check it
+// after every scanbeam and then run on the pure Go engine, since backends
+// cannot be interrupted; other operations check it before they start. A
+// cancelled operation fails with an error matching both ErrClipperExecution
+// and ctx.Err().
+func WithContext(ctx context.Context) Option {
+	return optionFunc(func(s *settings) { s.ctx = ctx })
+}
+
+// WithTracer calls fn after every scanbeam of a boolean operation, for
+// progress reporting or profiling. Traced operations run on the pure Go
+// engine. fn is called on the calling goroutine and must not block.
+func WithTracer(fn func(TraceEvent)) Option {
+	return optionFunc(func(s *settings) { s.tracer = fn })
+}
+
+// WithSweepBudget limits the work of the sweep of boolean operations. An
+// operation exceeding it fails with a *StallError matching ErrClipperStalled
+// instead of running on; limited operations run on the pure Go engine. Zero
+// fields keep their default, sized from the input.
+func WithSweepBudget(b SweepBudget) Option {
+	return optionFunc(func(s *settings) { s.budget = b })
+}
+
+// WithRingClosure sets how closed input rings repeating their first point at
+// the end are handled: the subjects and clips of boolean operations, the
+// paths of RectClip64 and the paths InflatePaths64 offsets as closed
+// (default: RingClosureImplicit)
+func WithRingClosure(policy RingClosure) Option {
+	return optionFunc(func(s *settings) { s.closure = policy })
+}
+
+// WithKeepTouchingPoints sets ClipperOptions.KeepTouchingPointsAsVertices
+func WithKeepTouchingPoints(keep bool) Option {
+	return optionFunc(func(s *settings) { s.clipper.KeepTouchingPointsAsVertices = keep })
+}
+
+// WithZeroAreaRings sets ClipperOptions.ZeroAreaRings
+func WithZeroAreaRings(policy ZeroAreaPolicy) Option {
+	return optionFunc(func(s *settings) { s.clipper.ZeroAreaRings = policy })
+}
+
+// WithPartialResults sets ClipperOptions.PartialResults
+func WithPartialResults(partial bool) Option {
+	return optionFunc(func(s *settings) { s.clipper.PartialResults = partial })
+}
+
+// WithWeldTolerance sets ClipperOptions.WeldTolerance
+func WithWeldTolerance(tolerance int64) Option {
+	return optionFunc(func(s *settings) { s.clipper.WeldTolerance = tolerance })
+}
+
+// WithCleaning sets ClipperOptions.MinArea and MinEdgeLength
+func WithCleaning(minArea float64, minEdgeLength int64) Option {
+	return optionFunc(func(s *settings) {
+		s.clipper.MinArea = minArea
+		s.clipper.MinEdgeLength = minEdgeLength
+	})
+}
+
+// WithPreNodeSelfIntersections sets ClipperOptions.PreNodeSelfIntersections
+func WithPreNodeSelfIntersections(preNode bool) Option {
+	return optionFunc(func(s *settings) { s.clipper.PreNodeSelfIntersections = preNode })
+}
+
+// WithSharedEdges sets ClipperOptions.SharedEdges
+func WithSharedEdges(policy SharedEdgePolicy) Option {
+	return optionFunc(func(s *settings) { s.clipper.SharedEdges = policy })
+}
+
+// WithOutputBudget sets ClipperOptions.MaxOutputVertices and OutputBudget
+func WithOutputBudget(maxVertices int, policy OutputBudgetPolicy) Option {
+	return optionFunc(func(s *settings) {
+		s.clipper.MaxOutputVertices = maxVertices
+		s.clipper.OutputBudget = policy
+	})
+}
+
+// WithRounding sets ClipperOptions.Rounding
+func WithRounding(rounding RoundingStrategy) Option {
+	return optionFunc(func(s *settings) { s.clipper.Rounding = rounding })
+}
+
+// WithPreserveCollinear sets ClipperOptions.PreserveCollinear
+func WithPreserveCollinear(preserve bool) Option {
+	return optionFunc(func(s *settings) { s.clipper.PreserveCollinear = preserve })
+}
+
+// WithReverseSolution sets ClipperOptions.ReverseSolution
+func WithReverseSolution(reverse bool) Option {
+	return optionFunc(func(s *settings) { s.clipper.ReverseSolution = reverse })
+}
+
+// WithSkipSanitize sets ClipperOptions.SkipSanitize
+func WithSkipSanitize(skip bool) Option {
+	return optionFunc(func(s *settings) { s.clipper.SkipSanitize = skip })
+}
+
+// WithMiterLimit sets OffsetOptions.MiterLimit
+func WithMiterLimit(miterLimit float64) Option {
+	return optionFunc(func(s *settings) { s.offset.MiterLimit = miterLimit })
+}
+
+// WithArcTolerance sets OffsetOptions.ArcTolerance
+func WithArcTolerance(arcTolerance float64) Option {
+	return optionFunc(func(s *settings) { s.offset.ArcTolerance = arcTolerance })
+}
+
+// WithMaxCornerPoints sets OffsetOptions.MaxCornerPoints
+func WithMaxCornerPoints(maxPoints int) Option {
+	return optionFunc(func(s *settings) { s.offset.MaxCornerPoints = maxPoints })
+}
+
+// WithOrientByContainment sets OffsetOptions.OrientByContainment
+func WithOrientByContainment(orient bool) Option {
+	return optionFunc(func(s *settings) { s.offset.OrientByContainment = orient })
+}
+
+// ==============================================================================
+// Global Defaults
+// ==============================================================================
+
+var (
+	defaultsMu sync.RWMutex
+	defaults   []Option
+)
+
+// SetDefaultOptions replaces the options applied before the per-call options
+// of every subsequent operation, including operations the package runs
+// internally. It is safe to call concurrently with running operations, which
+// keep the defaults they started with. Calling it without options restores
+// the package defaults. WithFillRule and WithContext only make sense for a
+// single call and are ignored here.
+func SetDefaultOptions(opts ...Option) {
+	defaultsMu.Lock()
+	defer defaultsMu.Unlock()
+	defaults = append([]Option(nil), opts...)
+}
+
+// DefaultOptions returns the options set with SetDefaultOptions
+func DefaultOptions() []Option {
+	defaultsMu.RLock()
+	defer defaultsMu.RUnlock()
+	return append([]Option(nil), defaults...)
+}
+
+// resolveOptions applies the package defaults, the global defaults and opts
+func resolveOptions(opts []Option) settings {
+	s := settings{offset: DefaultConfig().OffsetOptions()}
+	defaultsMu.RLock()
+	for _, opt := range defaults {
+		if opt != nil {
+			opt.applyOption(&s)
+		}
+	}
+	defaultsMu.RUnlock()
+	s.fillRule, s.ctx = nil, nil
+	for _, opt := range opts {
+		if opt != nil {
+			opt.applyOption(&s)
+		}
+	}
+	return s
+}
+
+// checkContext returns the error for a cancelled ctx, or nil
+func checkContext(ctx context.Context) error {
+	if ctx == nil {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("%w: %w", ErrClipperExecution, err)
+	}
+	return nil
+}
+
+// attach configures the engine with the output options it honors itself and
+// connects it to WithContext, WithTracer and WithSweepBudget
+func (s *settings) attach(ve *VattiEngine) {
+	ve.rounding = s.clipper.Rounding
+	ve.preserveCollinear = s.clipper.PreserveCollinear
+	ve.reverseSolution = s.clipper.ReverseSolution
+	ve.SetSweepBudget(s.budget)
+	if s.tracer != nil {
+		ve.observer = s
+	}
+	if s.ctx != nil {
+		ve.SetCancelCheck(s.ctx.Err)
+	}
+}
+
+// observeScanbeam implements scanbeamObserver for WithTracer
+func (s *settings) observeScanbeam(ve *VattiEngine, y int64) {
+	event := TraceEvent{Y: y, OutRecs: len(ve.outRecords)}
+	for e := ve.activeEdges; e != nil; e = e.NextInAEL {
+		event.ActiveEdges++
+	}
+	s.tracer(event)
+}
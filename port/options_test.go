@@ -0,0 +1,255 @@
+package clipper
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+// TestFunctionalOptions tests that With* options match the equivalent struct options
+func TestFunctionalOptions(t *testing.T) {
+	a := Paths64{{{0, 0}, {100, 0}, {100, 100}, {0, 100}}}
+	b := Paths64{{{50, 50}, {150, 50}, {150, 150}, {50, 150}}}
+
+	expected, _, err := BooleanOp64(Union, NonZero, a, nil, b, ClipperOptions{KeepTouchingPointsAsVertices: true, WeldTolerance: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, _, err := BooleanOp64(Union, NonZero, a, nil, b, WithKeepTouchingPoints(true), WithWeldTolerance(2))
+	if err != nil || !sameRings(got, expected) {
+		t.Errorf("expected %v, got %v (err %v)", expected, got, err)
+	}
+
+	// Later options win, so a struct option resets earlier With* options
+	_, _, err = BooleanOp64(Union, NonZero, a, nil, b, WithWeldTolerance(-1), ClipperOptions{})
+	if err != nil {
+		t.Errorf("expected the struct option to replace the weld tolerance, got %v", err)
+	}
+}
+
+// TestWithFillRule tests that WithFillRule overrides the fill rule argument
+func TestWithFillRule(t *testing.T) {
+	// Two overlapping squares in one operand: EvenOdd leaves a hole
+	overlap := Paths64{
+		{{0, 0}, {100, 0}, {100, 100}, {0, 100}},
+		{{50, 50}, {150, 50}, {150, 150}, {50, 150}},
+	}
+	expected, _, _ := BooleanOp64(Union, EvenOdd, overlap, nil, nil)
+	got, _, err := BooleanOp64(Union, NonZero, overlap, nil, nil, WithFillRule(EvenOdd))
+	if err != nil || !sameRings(got, expected) {
+		t.Errorf("expected %v, got %v (err %v)", expected, got, err)
+	}
+}
+
+// TestWithContext tests cancellation of boolean operations, offsetting and rect clipping
+func TestWithContext(t *testing.T) {
+	square := Paths64{{{0, 0}, {100, 0}, {100, 100}, {0, 100}}}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := BooleanOp64(Union, NonZero, square, nil, nil, WithContext(ctx))
+	if !errors.Is(err, context.Canceled) || !errors.Is(err, ErrClipperExecution) {
+		t.Errorf("BooleanOp64: expected a cancellation error, got %v", err)
+	}
+	_, _, err = DifferenceWithRemainder64(square, square, NonZero, WithContext(ctx))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("DifferenceWithRemainder64: expected a cancellation error, got %v", err)
+	}
+	_, err = InflatePaths64(square, 10, Round, ClosedPolygon, WithContext(ctx))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("InflatePaths64: expected a cancellation error, got %v", err)
+	}
+	_, err = RectClip64(Path64{{0, 0}, {50, 0}, {50, 50}, {0, 50}}, square, WithContext(ctx))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("RectClip64: expected a cancellation error, got %v", err)
+	}
+
+	// Cancelling during the sweep stops it at the next scanbeam
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	stripes := Paths64{}
+	for i := int64(0); i < 10; i++ {
+		stripes = append(stripes, Path64{{0, i * 20}, {100, i * 20}, {100, i*20 + 10}, {0, i*20 + 10}})
+	}
+	scanbeams := 0
+	_, _, err = BooleanOp64(Union, NonZero, stripes, nil, nil, WithContext(ctx), WithTracer(func(TraceEvent) {
+		scanbeams++
+		if scanbeams == 3 {
+			cancel()
+		}
+	}))
+	if !errors.Is(err, context.Canceled) || scanbeams != 3 {
+		t.Errorf("expected cancellation after 3 scanbeams, got %d scanbeams and %v", scanbeams, err)
+	}
+}
+
+// TestBooleanOp64Ctx tests timeouts and the engine's cancel check
+func TestBooleanOp64Ctx(t *testing.T) {
+	a := Paths64{{{0, 0}, {100, 0}, {100, 100}, {0, 100}}}
+	b := Paths64{{{50, 50}, {150, 50}, {150, 150}, {50, 150}}}
+
+	expected, _, _ := BooleanOp64(Union, NonZero, a, nil, b)
+	got, _, err := BooleanOp64Ctx(context.Background(), Union, NonZero, a, nil, b)
+	if err != nil || !sameRings(got, expected) {
+		t.Errorf("expected %v, got %v (err %v)", expected, got, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	if _, _, err := BooleanOp64Ctx(ctx, Union, NonZero, a, nil, b); !errors.Is(err, context.DeadlineExceeded) || !errors.Is(err, ErrClipperExecution) {
+		t.Errorf("expected a deadline error, got %v", err)
+	}
+	if _, _, err := BooleanOp64TreeCtx(ctx, Union, NonZero, a, nil, b); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected a deadline error from the tree variant, got %v", err)
+	}
+
+	errStop := errors.New("stop")
+	checks := 0
+	ve := NewVattiEngine(Union, NonZero)
+	ve.SetCancelCheck(func() error {
+		checks++
+		if checks == 2 {
+			return errStop
+		}
+		return nil
+	})
+	if _, _, err := ve.ExecuteClipping(a, nil, b); !errors.Is(err, errStop) || !errors.Is(err, ErrClipperExecution) || checks != 2 {
+		t.Errorf("expected the sweep to stop at the second check, got %v after %d checks", err, checks)
+	}
+}
+
+// TestWithTracer tests that the tracer sees every scanbeam in order
+func TestWithTracer(t *testing.T) {
+	a := Paths64{{{0, 0}, {100, 0}, {100, 100}, {0, 100}}}
+	b := Paths64{{{50, 50}, {150, 50}, {150, 150}, {50, 150}}}
+
+	var events []TraceEvent
+	got, _, err := BooleanOp64(Intersection, NonZero, a, nil, b, WithTracer(func(e TraceEvent) {
+		events = append(events, e)
+	}))
+	expected, _, _ := BooleanOp64(Intersection, NonZero, a, nil, b)
+	if err != nil || !sameRings(got, expected) {
+		t.Errorf("expected tracing not to change the result %v, got %v (err %v)", expected, got, err)
+	}
+
+	ys := []int64{0, 50, 100, 150}
+	if len(events) != len(ys) {
+		t.Fatalf("expected %d events, got %v", len(ys), events)
+	}
+	for i, e := range events {
+		if e.Y != ys[i] {
+			t.Errorf("event %d: expected Y %d, got %d", i, ys[i], e.Y)
+		}
+	}
+	if events[1].ActiveEdges != 4 {
+		t.Errorf("expected 4 active edges at Y=50, got %d", events[1].ActiveEdges)
+	}
+}
+
+// TestOffsetOptionFunctions tests the offsetting With* options
+func TestOffsetOptionFunctions(t *testing.T) {
+	square := Paths64{{{0, 0}, {100, 0}, {100, 100}, {0, 100}}}
+	expected, err := InflatePaths64(square, 10, Round, ClosedPolygon, OffsetOptions{MiterLimit: DefaultMiterLimit, ArcTolerance: 2})
+	if err != nil {
+		t.Skipf("offsetting unavailable: %v", err)
+	}
+	got, err := InflatePaths64(square, 10, Round, ClosedPolygon, WithArcTolerance(2))
+	if err != nil || !sameRings(got, expected) {
+		t.Errorf("expected %v, got %v (err %v)", expected, got, err)
+	}
+}
+
+// TestDefaultOptions tests that global defaults apply before per-call options
+func TestDefaultOptions(t *testing.T) {
+	saved := DefaultOptions()
+	defer SetDefaultOptions(saved...)
+
+	a := Paths64{{{0, 0}, {100, 0}, {100, 100}, {0, 100}}}
+	b := Paths64{{{100, 20}, {200, 20}, {200, 80}, {100, 80}}}
+
+	SetDefaultOptions(WithOutputBudget(3, OutputBudgetAbort), WithFillRule(EvenOdd))
+	if _, _, err := BooleanOp64(Union, NonZero, a, nil, b); !errors.Is(err, ErrComplexityExceeded) {
+		t.Errorf("expected the default budget to apply, got %v", err)
+	}
+	if _, _, err := BooleanOp64(Union, NonZero, a, nil, b, WithOutputBudget(0, OutputBudgetAbort)); err != nil {
+		t.Errorf("expected the per-call option to override the default, got %v", err)
+	}
+	if got := DefaultOptions(); len(got) != 2 {
+		t.Errorf("expected 2 default options, got %d", len(got))
+	}
+
+	SetDefaultOptions()
+	if _, _, err := BooleanOp64(Union, NonZero, a, nil, b); err != nil {
+		t.Errorf("expected reset defaults, got %v", err)
+	}
+}
+
+// TestDefaultOptionsConcurrent tests that defaults can change while operations run
+func TestDefaultOptionsConcurrent(t *testing.T) {
+	saved := DefaultOptions()
+	defer SetDefaultOptions(saved...)
+
+	square := Paths64{{{0, 0}, {100, 0}, {100, 100}, {0, 100}}}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				if i%2 == 0 {
+					SetDefaultOptions(WithWeldTolerance(int64(j % 3)))
+					continue
+				}
+				if _, _, err := BooleanOp64(Union, NonZero, square, nil, nil); err != nil {
+					t.Errorf("unexpected error: %v", err)
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+// TestPreserveCollinearAndReverseSolution tests the output orientation and
+// collinear vertex options
+func TestPreserveCollinearAndReverseSolution(t *testing.T) {
+	square := Paths64{{{0, 0}, {50, 0}, {100, 0}, {100, 50}, {100, 100}, {50, 100}, {0, 100}, {0, 50}}}
+	hole := Paths64{{{25, 25}, {25, 75}, {75, 75}, {75, 25}}}
+
+	got, _, err := BooleanOp64(Union, NonZero, square, nil, nil)
+	if err != nil || len(got) != 1 || len(got[0]) != 4 {
+		t.Errorf("expected collinear vertices removed by default, got %v (err %v)", got, err)
+	}
+	got, _, err = BooleanOp64(Union, NonZero, square, nil, nil, WithPreserveCollinear(true))
+	if err != nil || !sameRings(got, square) {
+		t.Errorf("expected %v, got %v (err %v)", square, got, err)
+	}
+
+	expected, _, _ := BooleanOp64(Difference, NonZero, square, nil, hole)
+	got, _, err = BooleanOp64(Difference, NonZero, square, nil, hole, WithReverseSolution(true))
+	if err != nil || len(got) != len(expected) {
+		t.Fatalf("expected %d rings, got %v (err %v)", len(expected), got, err)
+	}
+	for i := range got {
+		if Area64(got[i]) != -Area64(expected[i]) || !sameRings(Paths64{Reverse64(got[i])}, Paths64{expected[i]}) {
+			t.Errorf("ring %d: expected %v reversed, got %v", i, expected[i], got[i])
+		}
+	}
+
+	c := NewClipper64()
+	_ = c.AddSubject(square)
+	got, _, err = c.Execute(Union, NonZero, WithPreserveCollinear(true), WithReverseSolution(true))
+	if err != nil || len(got) != 1 || len(got[0]) != 8 || Area64(got[0]) >= 0 {
+		t.Errorf("expected a clockwise ring of 8 vertices, got %v (err %v)", got, err)
+	}
+
+	var emitted Paths64
+	err = BooleanOp64Incremental(Union, NonZero, square, nil, nil, func(path Path64, _ bool) error {
+		emitted = append(emitted, path)
+		return nil
+	}, WithPreserveCollinear(true), WithReverseSolution(true))
+	if err != nil || len(emitted) != 1 || len(emitted[0]) != 8 || Area64(emitted[0]) >= 0 {
+		t.Errorf("expected a clockwise ring of 8 vertices, got %v (err %v)", emitted, err)
+	}
+}
@@ -0,0 +1,67 @@
+package clipper
+
+// ==============================================================================
+// Vertex Origins - Attribute Pass-Through Without Z
+// ==============================================================================
+
+// VertexOrigin tells where an output vertex came from, so per-vertex
+// attributes kept in slices parallel to the input paths can follow it
+type VertexOrigin struct {
+	PathType PathType // PathTypeSubject or PathTypeClip
+	Open     bool     // true for a vertex of an open subject path
+	Path     int      // index of the input path within its set
+	Vertex   int      // index of the vertex within the path, or Synthesized
+}
+
+// Synthesized marks an output vertex created at an intersection: it has no
+// input vertex and its attributes must be interpolated
+const Synthesized = -1
+
+// IsSynthesized reports whether the vertex was created at an intersection
+func (o VertexOrigin) IsSynthesized() bool {
+	return o.Vertex == Synthesized
+}
+
+// BooleanOp64WithOrigins performs a boolean operation like BooleanOp64 and
+// also returns, for every vertex of the closed and open solution, the input
+// vertex it originates from. Output vertices are always either input
+// vertices or intersection points, so each one is matched to the first input
+// vertex at the same position (closed subjects, then open subjects, then
+// clips); vertices matching none are reported as Synthesized. An
+// intersection that lands exactly on an input vertex reports that vertex.
+func BooleanOp64WithOrigins(clipType ClipType, fillRule FillRule, subjects, subjectsOpen, clips Paths64, opts ...Option) (solution, solutionOpen Paths64, origins, originsOpen [][]VertexOrigin, err error) {
+	solution, solutionOpen, err = BooleanOp64(clipType, fillRule, subjects, subjectsOpen, clips, opts...)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	index := make(map[Point64]VertexOrigin)
+	addSet := func(paths Paths64, pathType PathType, open bool) {
+		for i, path := range paths {
+			for j, pt := range path {
+				if _, ok := index[pt]; !ok {
+					index[pt] = VertexOrigin{PathType: pathType, Open: open, Path: i, Vertex: j}
+				}
+			}
+		}
+	}
+	addSet(subjects, PathTypeSubject, false)
+	addSet(subjectsOpen, PathTypeSubject, true)
+	addSet(clips, PathTypeClip, false)
+
+	lookup := func(paths Paths64) [][]VertexOrigin {
+		result := make([][]VertexOrigin, len(paths))
+		for i, path := range paths {
+			result[i] = make([]VertexOrigin, len(path))
+			for j, pt := range path {
+				origin, ok := index[pt]
+				if !ok {
+					origin = VertexOrigin{Path: -1, Vertex: Synthesized}
+				}
+				result[i][j] = origin
+			}
+		}
+		return result
+	}
+	return solution, solutionOpen, lookup(solution), lookup(solutionOpen), nil
+}
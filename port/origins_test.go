@@ -0,0 +1,67 @@
+package clipper
+
+import "testing"
+
+func TestBooleanOp64WithOrigins(t *testing.T) {
+	subjects := Paths64{{{0, 0}, {10, 0}, {10, 10}, {0, 10}}}
+	clips := Paths64{{{5, 5}, {15, 5}, {15, 15}, {5, 15}}}
+
+	solution, _, origins, _, err := BooleanOp64WithOrigins(Union, NonZero, subjects, nil, clips)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(origins) != len(solution) {
+		t.Fatalf("expected origins parallel to %d paths, got %d", len(solution), len(origins))
+	}
+
+	synthesized := 0
+	for i, path := range solution {
+		if len(origins[i]) != len(path) {
+			t.Fatalf("expected %d origins for path %d, got %d", len(path), i, len(origins[i]))
+		}
+		for j, pt := range path {
+			o := origins[i][j]
+			if o.IsSynthesized() {
+				synthesized++
+				continue
+			}
+			input := subjects
+			if o.PathType == PathTypeClip {
+				input = clips
+			}
+			if input[o.Path][o.Vertex] != pt {
+				t.Errorf("origin %+v of %v points at %v", o, pt, input[o.Path][o.Vertex])
+			}
+		}
+	}
+	// the union outline crosses itself at (10,5) and (5,10)
+	if synthesized != 2 {
+		t.Errorf("expected 2 synthesized vertices, got %d", synthesized)
+	}
+}
+
+func TestBooleanOp64WithOriginsOpen(t *testing.T) {
+	open := Paths64{{{-5, 5}, {5, 5}, {15, 5}}}
+	clips := Paths64{{{0, 0}, {10, 0}, {10, 10}, {0, 10}}}
+
+	_, solutionOpen, _, originsOpen, err := BooleanOp64WithOrigins(Intersection, NonZero, nil, open, clips)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(solutionOpen) != 1 || len(originsOpen[0]) != len(solutionOpen[0]) {
+		t.Fatalf("expected one clipped line with origins, got %v, %v", solutionOpen, originsOpen)
+	}
+	for j, pt := range solutionOpen[0] {
+		o := originsOpen[0][j]
+		switch pt {
+		case Point64{5, 5}:
+			if !o.Open || o.Path != 0 || o.Vertex != 1 {
+				t.Errorf("expected (5,5) to come from open path 0 vertex 1, got %+v", o)
+			}
+		default:
+			if !o.IsSynthesized() {
+				t.Errorf("expected clipped end %v to be synthesized, got %+v", pt, o)
+			}
+		}
+	}
+}
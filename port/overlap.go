@@ -0,0 +1,127 @@
+package clipper
+
+import (
+	"math"
+	"sort"
+)
+
+// ==============================================================================
+// Overlap Ratios
+// ==============================================================================
+
+// DenomMode selects the area an overlap is measured against
+type DenomMode uint8
+
+const (
+	DenomA     DenomMode = iota // area of a: fraction of a covered by b
+	DenomB                      // area of b: fraction of b covered by a
+	DenomUnion                  // area of a ∪ b: intersection over union (IoU)
+	DenomMin                    // smaller of the two areas
+	DenomMax                    // larger of the two areas
+)
+
+// OverlapRatio64 returns area(a ∩ b) divided by the denominator selected by
+// denom, in the range [0, 1]. Inputs are cleaned first (repeated and collinear
+// vertices removed, degenerate rings dropped) and both sets are filled with
+// NonZero, so self-overlapping inputs are not counted twice. An empty
+// denominator yields 0. No output polygons are built.
+//
+// opts apply to every area computation. To bound the time spent on
+// pathological inputs pass WithContext with a deadline: the sweep checks it
+// after every scanbeam and stops with an error matching both
+// ErrClipperExecution and context.DeadlineExceeded. A WithFillRule option is
+// ignored.
+func OverlapRatio64(a, b Paths64, denom DenomMode, opts ...Option) (float64, error) {
+	s := resolveOptions(opts)
+	a, b = cleanRings(a), cleanRings(b)
+	area := func(clipType ClipType, subjects, clips Paths64) (float64, error) {
+		return areaOfBooleanOp64(s, clipType, NonZero, subjects, clips)
+	}
+
+	common, err := area(Intersection, a, b)
+	if err != nil {
+		return 0, err
+	}
+
+	var total float64
+	switch denom {
+	case DenomUnion:
+		total, err = area(Union, a, b)
+	case DenomA:
+		total, err = area(Union, a, nil)
+	case DenomB:
+		total, err = area(Union, b, nil)
+	case DenomMin, DenomMax:
+		var areaA, areaB float64
+		if areaA, err = area(Union, a, nil); err != nil {
+			return 0, err
+		}
+		if areaB, err = area(Union, b, nil); err != nil {
+			return 0, err
+		}
+		if denom == DenomMin {
+			total = math.Min(areaA, areaB)
+		} else {
+			total = math.Max(areaA, areaB)
+		}
+	default:
+		return 0, ErrInvalidInput
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if total <= 0 || common <= 0 {
+		return 0, nil
+	}
+	return math.Min(common/total, 1), nil
+}
+
+// OverlapMatrix64 returns the intersection area of every subject set with
+// every clip set: matrix[i][j] = area(subjects[i] ∩ clips[j]). Pairs whose
+// bounds do not overlap are skipped without clipping, and the remaining pairs
+// are measured by AreaOfBooleanOp64, so their input is prepared as for
+// BooleanOp64 and no output polygons are built. This serves spatial joins
+// such as finding the clip polygon each subject overlaps most.
+func OverlapMatrix64(subjects, clips []Paths64, fillRule FillRule) ([][]float64, error) {
+	subjectBounds := make([]Rect64, len(subjects))
+	for i, paths := range subjects {
+		subjectBounds[i] = BoundsPaths64(paths)
+	}
+
+	// Visit clips in order of their left edge so each subject can stop at the
+	// first clip starting to its right
+	order := make([]int, 0, len(clips))
+	clipBounds := make([]Rect64, len(clips))
+	for j, paths := range clips {
+		clipBounds[j] = BoundsPaths64(paths)
+		if clipBounds[j].IsValid() {
+			order = append(order, j)
+		}
+	}
+	sort.Slice(order, func(a, b int) bool { return clipBounds[order[a]].Left < clipBounds[order[b]].Left })
+
+	s := resolveOptions(nil)
+	matrix := make([][]float64, len(subjects))
+	for i, paths := range subjects {
+		matrix[i] = make([]float64, len(clips))
+		bounds := subjectBounds[i]
+		if !bounds.IsValid() {
+			continue
+		}
+		for _, j := range order {
+			if clipBounds[j].Left > bounds.Right {
+				break
+			}
+			if !bounds.Intersects(clipBounds[j]) {
+				continue
+			}
+			area, err := areaOfBooleanOp64(s, Intersection, fillRule, FilterByRect(paths, clipBounds[j]), FilterByRect(clips[j], bounds))
+			if err != nil {
+				return nil, err
+			}
+			matrix[i][j] = area
+		}
+	}
+	return matrix, nil
+}
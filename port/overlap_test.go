@@ -0,0 +1,142 @@
+package clipper
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// TestOverlapRatio64 tests overlap ratios for each denominator mode
+func TestOverlapRatio64(t *testing.T) {
+	a := Paths64{{{0, 0}, {10, 0}, {10, 10}, {0, 10}}}
+	// Repeated and collinear vertices are cleaned before the ratio is computed
+	b := Paths64{{{5, 0}, {10, 0}, {20, 0}, {20, 0}, {20, 20}, {5, 20}}}
+
+	tests := []struct {
+		name     string
+		denom    DenomMode
+		expected float64
+	}{
+		{"DenomA", DenomA, 50.0 / 100},
+		{"DenomB", DenomB, 50.0 / 300},
+		{"DenomUnion", DenomUnion, 50.0 / 350},
+		{"DenomMin", DenomMin, 50.0 / 100},
+		{"DenomMax", DenomMax, 50.0 / 300},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ratio, err := OverlapRatio64(a, b, test.denom)
+			if err != nil {
+				t.Fatalf("OverlapRatio64 failed: %v", err)
+			}
+			if math.Abs(ratio-test.expected) > 1e-12 {
+				t.Errorf("Expected ratio %v, got %v", test.expected, ratio)
+			}
+		})
+	}
+
+	t.Run("Self-overlapping input counted once", func(t *testing.T) {
+		ratio, err := OverlapRatio64(append(a, a[0]), a, DenomUnion)
+		if err != nil || ratio != 1 {
+			t.Errorf("Expected ratio 1, got %v (%v)", ratio, err)
+		}
+	})
+
+	t.Run("Empty denominator", func(t *testing.T) {
+		ratio, err := OverlapRatio64(nil, a, DenomA)
+		if err != nil || ratio != 0 {
+			t.Errorf("Expected ratio 0, got %v (%v)", ratio, err)
+		}
+	})
+
+	t.Run("Invalid mode", func(t *testing.T) {
+		if _, err := OverlapRatio64(a, b, DenomMode(99)); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("Expected ErrInvalidInput, got %v", err)
+		}
+	})
+
+	t.Run("With deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		ratio, err := OverlapRatio64(a, b, DenomA, WithContext(ctx))
+		if err != nil || ratio != 0.5 {
+			t.Errorf("Expected ratio 0.5, got %v (%v)", ratio, err)
+		}
+
+		expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+		defer cancel()
+		if _, err := OverlapRatio64(a, b, DenomA, WithContext(expired)); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+		}
+
+		// a context cancelled during the sweep stops it at the next scanbeam
+		ctx, cancel = context.WithCancel(context.Background())
+		defer cancel()
+		_, err = OverlapRatio64(a, b, DenomA, WithContext(ctx), WithTracer(func(TraceEvent) { cancel() }))
+		if !errors.Is(err, context.Canceled) || !errors.Is(err, ErrClipperExecution) {
+			t.Errorf("Expected the sweep to stop with context.Canceled, got %v", err)
+		}
+	})
+}
+
+// TestCleanRing tests removal of repeated and collinear vertices
+func TestCleanRing(t *testing.T) {
+	ring := Path64{{0, 0}, {0, 0}, {5, 0}, {10, 0}, {10, 10}, {5, 5}, {0, 0}}
+	expected := Path64{{0, 0}, {10, 0}, {10, 10}}
+	if got := cleanRing(ring); !identicalPath(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+	if got := cleanRing(Path64{{0, 0}, {5, 5}, {10, 10}}); got != nil {
+		t.Errorf("Expected collinear ring to be removed, got %v", got)
+	}
+}
+
+// TestOverlapMatrix64 tests pairwise intersection areas
+func TestOverlapMatrix64(t *testing.T) {
+	square := func(x, y, size int64) Path64 {
+		return Path64{{x, y}, {x + size, y}, {x + size, y + size}, {x, y + size}}
+	}
+	subjects := []Paths64{
+		{square(0, 0, 10)},
+		{square(20, 0, 10), square(100, 100, 5)},
+		nil,
+	}
+	clips := []Paths64{
+		{square(5, 5, 10)},
+		{square(25, 0, 10)},
+		{square(-50, -50, 200), square(-40, -40, 180)}, // frame that leaves the middle empty
+	}
+
+	matrix, err := OverlapMatrix64(subjects, clips, EvenOdd)
+	if err != nil {
+		t.Fatalf("OverlapMatrix64 failed: %v", err)
+	}
+	expected := [][]float64{
+		{25, 0, 0},
+		{0, 50, 0},
+		{0, 0, 0},
+	}
+	for i := range expected {
+		for j := range expected[i] {
+			if math.Abs(matrix[i][j]-expected[i][j]) > 1e-9 {
+				t.Errorf("matrix[%d][%d] = %v, expected %v", i, j, matrix[i][j], expected[i][j])
+			}
+		}
+	}
+
+	// Every entry must agree with a direct area computation
+	for i := range subjects {
+		for j := range clips {
+			want, err := AreaOfBooleanOp64(Intersection, EvenOdd, subjects[i], clips[j])
+			if err != nil {
+				t.Fatalf("AreaOfBooleanOp64 failed: %v", err)
+			}
+			if math.Abs(matrix[i][j]-want) > 1e-9 {
+				t.Errorf("matrix[%d][%d] = %v, direct area %v", i, j, matrix[i][j], want)
+			}
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package clipper
+
+// ==============================================================================
+// Copying and Appending Paths
+// ==============================================================================
+
+// Clone returns a deep copy of the path. A nil path stays nil.
+func (p Path64) Clone() Path64 {
+	if p == nil {
+		return nil
+	}
+	result := make(Path64, len(p))
+	copy(result, p)
+	return result
+}
+
+// Clone returns a deep copy of the paths, so modifying the copy never affects
+// the original (or a result that feeds a later operation). A nil slice stays nil.
+func (ps Paths64) Clone() Paths64 {
+	if ps == nil {
+		return nil
+	}
+	return AppendPaths(make(Paths64, 0, len(ps)), ps)
+}
+
+// Clone returns a deep copy of the node and all of its descendants. The copy
+// is detached: its Parent is nil even when pp is not a root.
+func (pp *PolyPath64) Clone() *PolyPath64 {
+	if pp == nil {
+		return nil
+	}
+	return pp.cloneInto(nil)
+}
+
+// cloneInto deep-copies pp below parent
+func (pp *PolyPath64) cloneInto(parent *PolyPath64) *PolyPath64 {
+	node := &PolyPath64{
+		Path:      pp.Path.Clone(),
+		Parent:    parent,
+		bounds:    pp.bounds,
+		hasBounds: pp.hasBounds,
+	}
+	if len(pp.Children) > 0 {
+		node.Children = make([]*PolyPath64, len(pp.Children))
+		for i, child := range pp.Children {
+			node.Children[i] = child.cloneInto(node)
+		}
+	}
+	return node
+}
+
+// AppendPaths appends deep copies of every path in srcs to dst and returns the
+// extended slice. dst is grown at most once, and all copied points share a
+// single backing allocation, so building large inputs stays cheap.
+func AppendPaths(dst Paths64, srcs ...Paths64) Paths64 {
+	pathCount, pointCount := 0, 0
+	for _, src := range srcs {
+		pathCount += len(src)
+		for _, path := range src {
+			pointCount += len(path)
+		}
+	}
+	if pathCount == 0 {
+		return dst
+	}
+
+	if free := cap(dst) - len(dst); free < pathCount {
+		grown := make(Paths64, len(dst), len(dst)+pathCount)
+		copy(grown, dst)
+		dst = grown
+	}
+
+	points := make(Path64, 0, pointCount)
+	for _, src := range srcs {
+		for _, path := range src {
+			if path == nil {
+				dst = append(dst, nil)
+				continue
+			}
+			start := len(points)
+			points = append(points, path...)
+			// Full slice expression so appending to one path cannot clobber the next
+			dst = append(dst, points[start:len(points):len(points)])
+		}
+	}
+	return dst
+}
@@ -0,0 +1,83 @@
+package clipper
+
+import "testing"
+
+// TestPathsClone tests that clones never share memory with the original
+func TestPathsClone(t *testing.T) {
+	t.Run("Path64", func(t *testing.T) {
+		path := Path64{{0, 0}, {10, 0}, {10, 10}}
+		clone := path.Clone()
+		clone[0] = Point64{99, 99}
+		if path[0] != (Point64{0, 0}) {
+			t.Errorf("Modifying the clone changed the original: %v", path)
+		}
+		if Path64(nil).Clone() != nil {
+			t.Error("Expected clone of nil path to be nil")
+		}
+	})
+
+	t.Run("Paths64", func(t *testing.T) {
+		paths := Paths64{{{0, 0}, {1, 1}}, nil, {{2, 2}}}
+		clone := paths.Clone()
+		if len(clone) != len(paths) || clone[1] != nil {
+			t.Fatalf("Expected structure to be preserved, got %v", clone)
+		}
+		clone[0][0] = Point64{99, 99}
+		clone[0] = append(clone[0], Point64{5, 5})
+		if paths[0][0] != (Point64{0, 0}) || len(paths[0]) != 2 {
+			t.Errorf("Modifying the clone changed the original: %v", paths)
+		}
+		if clone[2][0] != (Point64{2, 2}) {
+			t.Errorf("Appending to one cloned path clobbered the next: %v", clone)
+		}
+	})
+
+	t.Run("PolyTree64", func(t *testing.T) {
+		tree := NewPolyTree64()
+		outer := tree.AddChild(Path64{{0, 0}, {100, 0}, {100, 100}, {0, 100}})
+		outer.AddChild(Path64{{10, 10}, {10, 90}, {90, 90}, {90, 10}})
+
+		clone := tree.Clone()
+		if len(clone.Children) != 1 || len(clone.Children[0].Children) != 1 {
+			t.Fatalf("Expected hierarchy to be preserved, got %+v", clone)
+		}
+		hole := clone.Children[0].Children[0]
+		if hole.Parent != clone.Children[0] || !hole.IsHole() {
+			t.Error("Expected cloned hole to point at its cloned parent")
+		}
+		if clone.Bounds() != tree.Bounds() {
+			t.Errorf("Expected bounds %v, got %v", tree.Bounds(), clone.Bounds())
+		}
+		hole.Path[0] = Point64{50, 50}
+		if outer.Children[0].Path[0] != (Point64{10, 10}) {
+			t.Error("Modifying the cloned tree changed the original")
+		}
+	})
+}
+
+// TestAppendPaths tests deep-copying append with preallocation
+func TestAppendPaths(t *testing.T) {
+	a := Paths64{{{0, 0}, {1, 0}, {1, 1}}}
+	b := Paths64{{{5, 5}, {6, 5}}, {{7, 7}}}
+
+	result := AppendPaths(nil, a, b)
+	if len(result) != 3 {
+		t.Fatalf("Expected 3 paths, got %d", len(result))
+	}
+	if cap(result) != 3 {
+		t.Errorf("Expected exact capacity 3, got %d", cap(result))
+	}
+	result[1][0] = Point64{-1, -1}
+	if b[0][0] != (Point64{5, 5}) {
+		t.Error("Modifying appended paths changed the source")
+	}
+
+	dst := make(Paths64, 0, 8)
+	dst = AppendPaths(dst, a)
+	if cap(dst) != 8 {
+		t.Errorf("Expected existing capacity to be reused, got cap %d", cap(dst))
+	}
+	if got := AppendPaths(dst); len(got) != 1 {
+		t.Errorf("Expected no-op append to keep 1 path, got %d", len(got))
+	}
+}
@@ -0,0 +1,312 @@
+package clipper
+
+import "math"
+
+// ==============================================================================
+// Floating-Point API
+// ==============================================================================
+
+// The D functions accept floating-point paths and a precision, the number of
+// decimal places kept, like the PathsD layer of Clipper2. Coordinates are
+// scaled by 10^precision, rounded to int64, processed by the 64-bit function
+// and scaled back, so results are exact to within half a unit of the last
+// kept decimal place. A negative precision rounds to tens, hundreds and so on.
+
+// MaxPrecisionD is the largest number of decimal places the D functions keep;
+// precisions outside ±MaxPrecisionD fail with ErrInvalidInput, as in Clipper2
+const MaxPrecisionD = 8
+
+// precisionScale returns 10^precision, or false if precision is out of range
+func precisionScale(precision int) (float64, bool) {
+	if precision < -MaxPrecisionD || precision > MaxPrecisionD {
+		return 0, false
+	}
+	return math.Pow10(precision), true
+}
+
+// PathDToPath64 scales a floating-point path by 10^precision and rounds it to
+// integer coordinates. It returns ErrInvalidInput for a precision out of
+// range or a coordinate that is not finite or too large once scaled.
+func PathDToPath64(path PathD, precision int) (Path64, error) {
+	scale, ok := precisionScale(precision)
+	if !ok {
+		return nil, ErrInvalidInput
+	}
+	return scalePathD(path, scale)
+}
+
+// PathsDToPaths64 scales floating-point paths like PathDToPath64
+func PathsDToPaths64(paths PathsD, precision int) (Paths64, error) {
+	scale, ok := precisionScale(precision)
+	if !ok {
+		return nil, ErrInvalidInput
+	}
+	return scalePathsD(paths, scale)
+}
+
+// Path64ToPathD scales an integer path back by 10^-precision
+func Path64ToPathD(path Path64, precision int) PathD {
+	return unscalePath64(path, math.Pow10(precision))
+}
+
+// Paths64ToPathsD scales integer paths back by 10^-precision
+func Paths64ToPathsD(paths Paths64, precision int) PathsD {
+	return unscalePaths64(paths, math.Pow10(precision))
+}
+
+// scalePathD multiplies every coordinate by scale and rounds it
+func scalePathD(path PathD, scale float64) (Path64, error) {
+	result := make(Path64, len(path))
+	for i, pt := range path {
+		x, y := pt.X*scale, pt.Y*scale
+		if math.IsNaN(x) || math.IsNaN(y) || math.Abs(x) > maxCoord || math.Abs(y) > maxCoord {
+			return nil, ErrInvalidInput
+		}
+		result[i] = Point64{X: RoundHalfAway(x), Y: RoundHalfAway(y)}
+	}
+	return result, nil
+}
+
+// scalePathsD multiplies every coordinate of paths by scale and rounds it
+func scalePathsD(paths PathsD, scale float64) (Paths64, error) {
+	result := make(Paths64, len(paths))
+	for i, path := range paths {
+		scaled, err := scalePathD(path, scale)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = scaled
+	}
+	return result, nil
+}
+
+// unscalePath64 divides every coordinate by scale
+func unscalePath64(path Path64, scale float64) PathD {
+	result := make(PathD, len(path))
+	for i, pt := range path {
+		result[i] = PointD{X: float64(pt.X) / scale, Y: float64(pt.Y) / scale}
+	}
+	return result
+}
+
+// unscalePaths64 divides every coordinate of paths by scale
+func unscalePaths64(paths Paths64, scale float64) PathsD {
+	result := make(PathsD, len(paths))
+	for i, path := range paths {
+		result[i] = unscalePath64(path, scale)
+	}
+	return result
+}
+
+// UnionD returns the union of floating-point subjects and clips, keeping
+// precision decimal places
+func UnionD(subjects, clips PathsD, fillRule FillRule, precision int) (PathsD, error) {
+	solution, _, err := BooleanOpD(Union, fillRule, subjects, nil, clips, precision)
+	return solution, err
+}
+
+// IntersectD returns the intersection of floating-point subjects and clips
+func IntersectD(subjects, clips PathsD, fillRule FillRule, precision int) (PathsD, error) {
+	solution, _, err := BooleanOpD(Intersection, fillRule, subjects, nil, clips, precision)
+	return solution, err
+}
+
+// DifferenceD returns floating-point subjects minus clips
+func DifferenceD(subjects, clips PathsD, fillRule FillRule, precision int) (PathsD, error) {
+	solution, _, err := BooleanOpD(Difference, fillRule, subjects, nil, clips, precision)
+	return solution, err
+}
+
+// XorD returns the symmetric difference of floating-point subjects and clips
+func XorD(subjects, clips PathsD, fillRule FillRule, precision int) (PathsD, error) {
+	solution, _, err := BooleanOpD(Xor, fillRule, subjects, nil, clips, precision)
+	return solution, err
+}
+
+// BooleanOpD is BooleanOp64 on floating-point paths, keeping precision
+// decimal places. Distances among opts, such as WithWeldTolerance, are in
+// scaled integer units.
+func BooleanOpD(clipType ClipType, fillRule FillRule, subjects, subjectsOpen, clips PathsD, precision int, opts ...Option) (solution, solutionOpen PathsD, err error) {
+	scale, ok := precisionScale(precision)
+	if !ok {
+		return nil, nil, ErrInvalidInput
+	}
+	subjects64, err := scalePathsD(subjects, scale)
+	if err != nil {
+		return nil, nil, err
+	}
+	subjectsOpen64, err := scalePathsD(subjectsOpen, scale)
+	if err != nil {
+		return nil, nil, err
+	}
+	clips64, err := scalePathsD(clips, scale)
+	if err != nil {
+		return nil, nil, err
+	}
+	solution64, solutionOpen64, err := BooleanOp64(clipType, fillRule, subjects64, subjectsOpen64, clips64, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return unscalePaths64(solution64, scale), unscalePaths64(solutionOpen64, scale), nil
+}
+
+// InflatePathsD is InflatePaths64 on floating-point paths, keeping precision
+// decimal places. delta and the arc tolerance are in the units of paths.
+func InflatePathsD(paths PathsD, delta float64, joinType JoinType, endType EndType, precision int, opts ...Option) (PathsD, error) {
+	scale, ok := precisionScale(precision)
+	if !ok || math.IsNaN(delta) || math.IsInf(delta, 0) {
+		return nil, ErrInvalidInput
+	}
+	paths64, err := scalePathsD(paths, scale)
+	if err != nil {
+		return nil, err
+	}
+	offset := resolveOptions(opts).offset
+	offset.ArcTolerance *= scale
+	solution, err := InflatePaths64(paths64, delta*scale, joinType, endType, append(opts[:len(opts):len(opts)], offset)...)
+	if err != nil {
+		return nil, err
+	}
+	return unscalePaths64(solution, scale), nil
+}
+
+// RectClipD is RectClip64 on floating-point paths, keeping precision decimal
+// places
+func RectClipD(rect PathD, paths PathsD, precision int, opts ...Option) (PathsD, error) {
+	scale, ok := precisionScale(precision)
+	if !ok {
+		return nil, ErrInvalidInput
+	}
+	rect64, err := scalePathD(rect, scale)
+	if err != nil {
+		return nil, err
+	}
+	paths64, err := scalePathsD(paths, scale)
+	if err != nil {
+		return nil, err
+	}
+	solution, err := RectClip64(rect64, paths64, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return unscalePaths64(solution, scale), nil
+}
+
+// ==============================================================================
+// Scaling With Error Tracking
+// ==============================================================================
+
+// ScalePaths64WithError multiplies floating-point paths by scale and rounds
+// them to integer coordinates, like Clipper2's ScalePaths, and also returns
+// the largest rounding error of any coordinate in the units of paths. It
+// returns ErrInvalidInput for a scale that is not positive and finite, or a
+// coordinate that is not finite or too large once scaled.
+func ScalePaths64WithError(paths PathsD, scale float64) (Paths64, float64, error) {
+	if !(scale > 0) || math.IsInf(scale, 0) {
+		return nil, 0, ErrInvalidInput
+	}
+	result, err := scalePathsD(paths, scale)
+	if err != nil {
+		return nil, 0, err
+	}
+	maxError := 0.0
+	for i, path := range paths {
+		for j, pt := range path {
+			q := result[i][j]
+			maxError = max(maxError, math.Abs(float64(q.X)-pt.X*scale), math.Abs(float64(q.Y)-pt.Y*scale))
+		}
+	}
+	return result, maxError / scale, nil
+}
+
+// ScalePathsDWithError multiplies integer paths by scale, the inverse of
+// ScalePaths64WithError when given 1/scale, and also returns the largest
+// floating-point error of any coordinate. The error is zero for a
+// power-of-two scale and coordinates within ±2^53. It returns
+// ErrInvalidInput for a scale that is not finite.
+func ScalePathsDWithError(paths Paths64, scale float64) (PathsD, float64, error) {
+	if math.IsNaN(scale) || math.IsInf(scale, 0) {
+		return nil, 0, ErrInvalidInput
+	}
+	result := make(PathsD, len(paths))
+	maxError := 0.0
+	for i, path := range paths {
+		result[i] = make(PathD, len(path))
+		for j, pt := range path {
+			x, errX := scaleInt64(pt.X, scale)
+			y, errY := scaleInt64(pt.Y, scale)
+			result[i][j] = PointD{X: x, Y: y}
+			maxError = max(maxError, errX, errY)
+		}
+	}
+	return result, maxError, nil
+}
+
+// scaleInt64 returns v*scale in float64 and the error of that result: the
+// error of converting v, scaled, plus the rounding of the product, which
+// math.FMA recovers exactly
+func scaleInt64(v int64, scale float64) (float64, float64) {
+	f := float64(v)
+	var convErr float64
+	if f >= 1<<63 {
+		convErr = float64(uint64(1<<63) - uint64(v)) // v rounded up past MaxInt64
+	} else {
+		convErr = math.Abs(float64(int64(f) - v))
+	}
+	p := f * scale
+	return p, convErr*math.Abs(scale) + math.Abs(math.FMA(f, scale, -p))
+}
+
+// OptimalScaleD returns the largest power of two that keeps every
+// coordinate of paths within ±2^53 once scaled, the scale ExecuteScaled
+// uses. Powers of two scale floating-point values exactly, and up to 2^53
+// integers are exact in float64, so the precision kept is the most the
+// inputs' mantissas allow. Paths without a nonzero coordinate get a scale
+// of 1; non-finite coordinates fail with ErrInvalidInput.
+func OptimalScaleD(paths ...PathsD) (float64, error) {
+	maxAbs := 0.0
+	for _, group := range paths {
+		for _, path := range group {
+			for _, pt := range path {
+				maxAbs = max(maxAbs, math.Abs(pt.X), math.Abs(pt.Y))
+			}
+		}
+	}
+	switch {
+	case math.IsNaN(maxAbs) || math.IsInf(maxAbs, 0):
+		return 0, ErrInvalidInput
+	case maxAbs == 0:
+		return 1, nil
+	}
+	_, exp := math.Frexp(maxAbs) // maxAbs < 2^exp
+	return math.Ldexp(1, min(53-exp, 1023)), nil
+}
+
+// ExecuteScaled is BooleanOp64 on floating-point paths at the scale
+// OptimalScaleD picks for all of them, rather than a fixed number of decimal
+// places as in BooleanOpD, and also returns that scale; one unit of the
+// integer result is 1/scale. Distances among opts are in scaled units, so
+// options taking distances are easier to use with BooleanOpD.
+func ExecuteScaled(clipType ClipType, fillRule FillRule, subjects, subjectsOpen, clips PathsD, opts ...Option) (solution, solutionOpen PathsD, scale float64, err error) {
+	scale, err = OptimalScaleD(subjects, subjectsOpen, clips)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	subjects64, err := scalePathsD(subjects, scale)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	subjectsOpen64, err := scalePathsD(subjectsOpen, scale)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	clips64, err := scalePathsD(clips, scale)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	solution64, solutionOpen64, err := BooleanOp64(clipType, fillRule, subjects64, subjectsOpen64, clips64, opts...)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	return unscalePaths64(solution64, scale), unscalePaths64(solutionOpen64, scale), scale, nil
+}
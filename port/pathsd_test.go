@@ -0,0 +1,176 @@
+package clipper
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+// TestPathsDConversion tests scaling floating-point paths to and from int64
+func TestPathsDConversion(t *testing.T) {
+	paths := PathsD{{{0.125, -1.5}, {2.004, 3.996}, {-0.005, 0}}}
+	scaled, err := PathsDToPaths64(paths, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := Path64{{13, -150}, {200, 400}, {-1, 0}}
+	for i, pt := range scaled[0] {
+		if pt != expected[i] {
+			t.Errorf("point %d: expected %v, got %v", i, expected[i], pt)
+		}
+	}
+	back := Paths64ToPathsD(scaled, 2)
+	if back[0][1] != (PointD{X: 2, Y: 4}) {
+		t.Errorf("expected (2, 4), got %v", back[0][1])
+	}
+
+	if got, _ := PathDToPath64(PathD{{1234, 5678}}, -2); got[0] != (Point64{12, 57}) {
+		t.Errorf("expected rounding to hundreds, got %v", got)
+	}
+
+	for _, tt := range []struct {
+		name      string
+		path      PathD
+		precision int
+	}{
+		{"precision too high", PathD{{1, 1}}, MaxPrecisionD + 1},
+		{"precision too low", PathD{{1, 1}}, -MaxPrecisionD - 1},
+		{"NaN", PathD{{math.NaN(), 1}}, 2},
+		{"overflow", PathD{{1e18, 1}}, 2},
+		{"infinite", PathD{{1, math.Inf(-1)}}, 0},
+	} {
+		if _, err := PathDToPath64(tt.path, tt.precision); err != ErrInvalidInput {
+			t.Errorf("%s: expected ErrInvalidInput, got %v", tt.name, err)
+		}
+	}
+}
+
+// TestBooleanOpsD tests the floating-point boolean operations
+func TestBooleanOpsD(t *testing.T) {
+	a := PathsD{{{0, 0}, {1.5, 0}, {1.5, 1.5}, {0, 1.5}}}
+	b := PathsD{{{0.75, 0.75}, {2.25, 0.75}, {2.25, 2.25}, {0.75, 2.25}}}
+	areaD := func(paths PathsD) float64 {
+		scaled, _ := PathsDToPaths64(paths, 4)
+		total := 0.0
+		for _, path := range scaled {
+			total += Area64(path)
+		}
+		return total / 1e8
+	}
+
+	tests := []struct {
+		name     string
+		op       func(subjects, clips PathsD, fillRule FillRule, precision int) (PathsD, error)
+		expected float64
+	}{
+		{"UnionD", UnionD, 2*2.25 - 0.5625},
+		{"IntersectD", IntersectD, 0.5625},
+		{"DifferenceD", DifferenceD, 2.25 - 0.5625},
+		{"XorD", XorD, 2 * (2.25 - 0.5625)},
+	}
+	for _, tt := range tests {
+		got, err := tt.op(a, b, NonZero, 3)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		if math.Abs(areaD(got)-tt.expected) > 1e-9 {
+			t.Errorf("%s: expected area %v, got %v", tt.name, tt.expected, areaD(got))
+		}
+	}
+
+	if _, err := UnionD(a, b, NonZero, 9); err != ErrInvalidInput {
+		t.Errorf("expected ErrInvalidInput for precision 9, got %v", err)
+	}
+}
+
+// TestRectClipD tests floating-point rectangle clipping
+func TestRectClipD(t *testing.T) {
+	rect := PathD{{0.5, 0.5}, {1.5, 0.5}, {1.5, 1.5}, {0.5, 1.5}}
+	paths := PathsD{{{0, 0}, {1, 0}, {1, 1}, {0, 1}}}
+	got, err := RectClipD(rect, paths, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	scaled, _ := PathsDToPaths64(got, 2)
+	if len(scaled) != 1 || math.Abs(Area64(scaled[0])) != 50*50 {
+		t.Errorf("expected a 0.5 x 0.5 square, got %v", got)
+	}
+}
+
+// TestInflatePathsD tests floating-point offsetting with scaled delta
+func TestInflatePathsD(t *testing.T) {
+	square := PathsD{{{0, 0}, {1, 0}, {1, 1}, {0, 1}}}
+	got, err := InflatePathsD(square, 0.25, Miter, ClosedPolygon, 2)
+	if err == ErrNotImplemented {
+		t.Skip("InflatePaths64 not yet implemented in pure Go")
+	}
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	scaled, _ := PathsDToPaths64(got, 2)
+	if len(scaled) != 1 || Area64(scaled[0]) != 150*150 {
+		t.Errorf("expected a 1.5 x 1.5 square, got %v", got)
+	}
+}
+
+func TestScalePathsWithError(t *testing.T) {
+	paths := PathsD{{{0.25, -1.5}, {2.004, 3}, {1e-9, 7.5}}}
+	scaled, maxError, err := ScalePaths64WithError(paths, 100)
+	expected := Paths64{{{25, -150}, {200, 300}, {0, 750}}}
+	if err != nil || !reflect.DeepEqual(scaled, expected) {
+		t.Fatalf("expected %v, got %v (err %v)", expected, scaled, err)
+	}
+	if math.Abs(maxError-0.004) > 1e-12 {
+		t.Errorf("expected a max error of 0.004 from 2.004, got %v", maxError)
+	}
+
+	for _, scale := range []float64{0, -1, math.NaN(), math.Inf(1)} {
+		if _, _, err := ScalePaths64WithError(paths, scale); err != ErrInvalidInput {
+			t.Errorf("scale %v: expected ErrInvalidInput, got %v", scale, err)
+		}
+	}
+
+	back, maxError, err := ScalePathsDWithError(expected, 1.0/128)
+	if err != nil || maxError != 0 || back[0][1] != (PointD{X: 200.0 / 128, Y: 300.0 / 128}) {
+		t.Errorf("expected an exact power-of-two scale, got %v, error %v (err %v)", back, maxError, err)
+	}
+	if _, maxError, _ = ScalePathsDWithError(expected, 0.01); maxError == 0 || maxError > 1e-15 {
+		t.Errorf("expected a tiny nonzero error for a scale of 0.01, got %v", maxError)
+	}
+	if _, maxError, _ = ScalePathsDWithError(Paths64{{{1<<62 + 1, 0}}}, 1); maxError != 1 {
+		t.Errorf("expected the conversion error of 2^62+1, got %v", maxError)
+	}
+}
+
+func TestExecuteScaled(t *testing.T) {
+	tests := []struct {
+		name     string
+		paths    PathsD
+		expected float64
+	}{
+		{"unit square", PathsD{{{0, 0}, {1, 0}, {1, 1}}}, 1 << 52},
+		{"large coordinates", PathsD{{{-3e6, 0}, {1, 0}}}, 1 << 31},
+		{"empty", nil, 1},
+	}
+	for _, tt := range tests {
+		if got, err := OptimalScaleD(tt.paths); err != nil || got != tt.expected {
+			t.Errorf("%s: expected scale %v, got %v (err %v)", tt.name, tt.expected, got, err)
+		}
+	}
+	if _, err := OptimalScaleD(PathsD{{{math.Inf(-1), 0}}}); err != ErrInvalidInput {
+		t.Errorf("expected ErrInvalidInput for an infinite coordinate, got %v", err)
+	}
+
+	// Micro-scale geometry survives intact where two decimal places would
+	// collapse it
+	subjects := PathsD{{{0, 0}, {3e-6, 0}, {3e-6, 3e-6}, {0, 3e-6}}}
+	clips := PathsD{{{1e-6, 1e-6}, {4e-6, 1e-6}, {4e-6, 4e-6}, {1e-6, 4e-6}}}
+	solution, _, scale, err := ExecuteScaled(Intersection, NonZero, subjects, nil, clips)
+	if err != nil || scale < 1e15 || len(solution) != 1 {
+		t.Fatalf("expected one intersection at a large scale, got %v at %v (err %v)", solution, scale, err)
+	}
+	scaled, _, _ := ScalePaths64WithError(solution, scale)
+	if area := AreaPaths64(scaled) / (scale * scale); math.Abs(area-4e-12) > 1e-24 {
+		t.Errorf("expected an area of 4e-12, got %v", area)
+	}
+}
@@ -0,0 +1,118 @@
+package clipper
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+)
+
+// ==============================================================================
+// PDF and PostScript Path Output
+// ==============================================================================
+
+// pathOperators names the path construction and painting operators of a page
+// description language
+type pathOperators struct {
+	begin          string // starts a new path ("" if implicit)
+	moveTo, lineTo string
+	closePath      string
+	fillNonZero    string
+	fillEvenOdd    string
+	stroke         string
+}
+
+var (
+	pdfOperators = pathOperators{
+		moveTo: "m", lineTo: "l", closePath: "h",
+		fillNonZero: "f", fillEvenOdd: "f*", stroke: "S",
+	}
+	postScriptOperators = pathOperators{
+		begin:  "newpath",
+		moveTo: "moveto", lineTo: "lineto", closePath: "closepath",
+		fillNonZero: "fill", fillEvenOdd: "eofill", stroke: "stroke",
+	}
+)
+
+// WritePDF writes paths as PDF content-stream operators: closed paths are
+// filled as one path with "f" (NonZero) or "f*" (EvenOdd), then open paths
+// are stroked with "S". PDF has no Positive or Negative fill, so for those
+// rules the closed paths are first unioned under the rule and filled with
+// NonZero. Coordinates are written as integers in the PDF user space, which
+// like this package has Y pointing up; set the graphics state (colors, line
+// width, a "cm" transform for scaling) before this output.
+func WritePDF(w io.Writer, closed, open Paths64, fillRule FillRule) error {
+	return writePathOperators(w, pdfOperators, closed, open, fillRule)
+}
+
+// WritePostScript writes paths as PostScript operators: closed paths are
+// filled with "fill" (NonZero) or "eofill" (EvenOdd) and open paths stroked
+// with "stroke". Positive and Negative fills are handled as in WritePDF.
+func WritePostScript(w io.Writer, closed, open Paths64, fillRule FillRule) error {
+	return writePathOperators(w, postScriptOperators, closed, open, fillRule)
+}
+
+// writePathOperators emits the fill and stroke sections for one language
+func writePathOperators(w io.Writer, ops pathOperators, closed, open Paths64, fillRule FillRule) error {
+	fill := ops.fillNonZero
+	switch fillRule {
+	case EvenOdd:
+		fill = ops.fillEvenOdd
+	case Positive, Negative:
+		filled, err := Union64(closed, nil, fillRule)
+		if err != nil {
+			return err
+		}
+		closed = filled
+	}
+
+	bw := bufio.NewWriter(w)
+	if writeSubpaths(bw, ops, closed, true, 3) {
+		bw.WriteString(fill)
+		bw.WriteByte('\n')
+	}
+	if writeSubpaths(bw, ops, open, false, 2) {
+		bw.WriteString(ops.stroke)
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
+
+// writeSubpaths writes every path with at least minPoints points as a
+// subpath and reports whether any was written
+func writeSubpaths(bw *bufio.Writer, ops pathOperators, paths Paths64, closePaths bool, minPoints int) bool {
+	written := false
+	for _, path := range paths {
+		if len(path) < minPoints {
+			continue
+		}
+		if !written && ops.begin != "" {
+			bw.WriteString(ops.begin)
+			bw.WriteByte('\n')
+		}
+		written = true
+		for i, pt := range path {
+			op := ops.lineTo
+			if i == 0 {
+				op = ops.moveTo
+			}
+			writeOperation(bw, pt, op)
+		}
+		if closePaths {
+			bw.WriteString(ops.closePath)
+			bw.WriteByte('\n')
+		}
+	}
+	return written
+}
+
+// writeOperation writes "x y op"
+func writeOperation(bw *bufio.Writer, pt Point64, op string) {
+	var buf [64]byte
+	b := strconv.AppendInt(buf[:0], pt.X, 10)
+	b = append(b, ' ')
+	b = strconv.AppendInt(b, pt.Y, 10)
+	b = append(b, ' ')
+	b = append(b, op...)
+	b = append(b, '\n')
+	bw.Write(b)
+}
@@ -0,0 +1,66 @@
+package clipper
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestWritePDF tests PDF path operators and fill rule selection
+func TestWritePDF(t *testing.T) {
+	closed := Paths64{{{0, 0}, {10, 0}, {10, 10}}, {{1, 1}}}
+	open := Paths64{{{0, 20}, {-5, 25}}}
+
+	tests := []struct {
+		name     string
+		fillRule FillRule
+		expected string
+	}{
+		{"NonZero", NonZero, "0 0 m\n10 0 l\n10 10 l\nh\nf\n0 20 m\n-5 25 l\nS\n"},
+		{"EvenOdd", EvenOdd, "0 0 m\n10 0 l\n10 10 l\nh\nf*\n0 20 m\n-5 25 l\nS\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sb strings.Builder
+			if err := WritePDF(&sb, closed, open, tt.fillRule); err != nil {
+				t.Fatalf("WritePDF failed: %v", err)
+			}
+			if sb.String() != tt.expected {
+				t.Errorf("Expected:\n%s\ngot:\n%s", tt.expected, sb.String())
+			}
+		})
+	}
+}
+
+// TestWritePostScript tests PostScript path operators
+func TestWritePostScript(t *testing.T) {
+	closed := Paths64{{{0, 0}, {10, 0}, {10, 10}}}
+	open := Paths64{{{0, 20}, {-5, 25}}}
+
+	var sb strings.Builder
+	if err := WritePostScript(&sb, closed, open, EvenOdd); err != nil {
+		t.Fatalf("WritePostScript failed: %v", err)
+	}
+	expected := "newpath\n0 0 moveto\n10 0 lineto\n10 10 lineto\nclosepath\neofill\n" +
+		"newpath\n0 20 moveto\n-5 25 lineto\nstroke\n"
+	if sb.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, sb.String())
+	}
+}
+
+// TestWritePDFPositiveFill tests that Positive fills are resolved by a union
+// before writing, since PDF has no such fill operator
+func TestWritePDFPositiveFill(t *testing.T) {
+	square := Path64{{0, 0}, {10, 0}, {10, 10}, {0, 10}}
+
+	var sb strings.Builder
+	if err := WritePDF(&sb, Paths64{square, square}, nil, Positive); err != nil {
+		t.Fatalf("WritePDF failed: %v", err)
+	}
+	if got := strings.Count(sb.String(), " m\n"); got != 1 {
+		t.Errorf("Expected one subpath, got %d:\n%s", got, sb.String())
+	}
+	if !strings.HasSuffix(sb.String(), "h\nf\n") {
+		t.Errorf("Expected a NonZero fill, got:\n%s", sb.String())
+	}
+}
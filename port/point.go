@@ -0,0 +1,131 @@
+package clipper
+
+import "math"
+
+// ==============================================================================
+// Point64 Vector Helpers
+// ==============================================================================
+
+// Add returns the vector sum p + q.
+// Like int64 addition it wraps around silently when a component overflows.
+func (p Point64) Add(q Point64) Point64 {
+	return Point64{X: p.X + q.X, Y: p.Y + q.Y}
+}
+
+// Sub returns the vector difference p - q.
+// Like int64 subtraction it wraps around silently when a component overflows,
+// which needs coordinates of opposite sign beyond ~2^62.
+func (p Point64) Sub(q Point64) Point64 {
+	return Point64{X: p.X - q.X, Y: p.Y - q.Y}
+}
+
+// Negate returns the point reflected through the origin.
+// A math.MinInt64 component has no positive counterpart and stays as it is.
+func (p Point64) Negate() Point64 {
+	return Point64{X: -p.X, Y: -p.Y}
+}
+
+// Dot returns the dot product of p and q treated as vectors.
+// The result overflows for components above ~2^31; use Dot128 for large coordinates.
+func (p Point64) Dot(q Point64) int64 {
+	return p.X*q.X + p.Y*q.Y
+}
+
+// Cross returns the z-component of the cross product of p and q treated as vectors.
+// The result overflows for components above ~2^31; use Cross128 for large coordinates.
+func (p Point64) Cross(q Point64) int64 {
+	return p.X*q.Y - p.Y*q.X
+}
+
+// Dot128 returns the dot product of p and q using 128-bit intermediates
+func (p Point64) Dot128(q Point64) Int128 {
+	return NewInt128(p.X).Mul64(q.X).Add(NewInt128(p.Y).Mul64(q.Y))
+}
+
+// Cross128 returns the cross product of p and q using 128-bit intermediates
+func (p Point64) Cross128(q Point64) Int128 {
+	return NewInt128(p.X).Mul64(q.Y).Sub(NewInt128(p.Y).Mul64(q.X))
+}
+
+// DistanceTo returns the Euclidean distance between p and q
+func (p Point64) DistanceTo(q Point64) float64 {
+	// Differences are taken in float64 so extreme coordinates cannot overflow
+	return math.Hypot(float64(q.X)-float64(p.X), float64(q.Y)-float64(p.Y))
+}
+
+// ==============================================================================
+// PointD Vector Helpers
+// ==============================================================================
+
+// Add returns the vector sum p + q
+func (p PointD) Add(q PointD) PointD {
+	return PointD{X: p.X + q.X, Y: p.Y + q.Y}
+}
+
+// Sub returns the vector difference p - q
+func (p PointD) Sub(q PointD) PointD {
+	return PointD{X: p.X - q.X, Y: p.Y - q.Y}
+}
+
+// Negate returns the point reflected through the origin
+func (p PointD) Negate() PointD {
+	return PointD{X: -p.X, Y: -p.Y}
+}
+
+// Dot returns the dot product of p and q treated as vectors
+func (p PointD) Dot(q PointD) float64 {
+	return p.X*q.X + p.Y*q.Y
+}
+
+// Cross returns the z-component of the cross product of p and q treated as vectors
+func (p PointD) Cross(q PointD) float64 {
+	return p.X*q.Y - p.Y*q.X
+}
+
+// DistanceTo returns the Euclidean distance between p and q
+func (p PointD) DistanceTo(q PointD) float64 {
+	return math.Hypot(q.X-p.X, q.Y-p.Y)
+}
+
+// ==============================================================================
+// Rounding Helpers
+// ==============================================================================
+
+// RoundHalfAway rounds v to the nearest integer, with halves rounded away from
+// zero (matching C++ std::round used by Clipper2). Values outside the int64
+// range saturate to math.MinInt64/math.MaxInt64 and NaN maps to 0.
+func RoundHalfAway(v float64) int64 {
+	switch {
+	case math.IsNaN(v):
+		return 0
+	case v >= math.MaxInt64:
+		return math.MaxInt64
+	case v <= math.MinInt64:
+		return math.MinInt64
+	}
+	return int64(math.Round(v))
+}
+
+// RoundPointD converts a floating-point point to Point64 using RoundHalfAway
+func RoundPointD(pt PointD) Point64 {
+	return Point64{X: RoundHalfAway(pt.X), Y: RoundHalfAway(pt.Y)}
+}
+
+// MidPoint64 returns the midpoint of a and b, truncated toward zero like
+// integer division in the reference implementation. Unlike (a+b)/2 it cannot
+// overflow for coordinates near the int64 limits.
+func MidPoint64(a, b Point64) Point64 {
+	return Point64{X: midInt64(a.X, b.X), Y: midInt64(a.Y, b.Y)}
+}
+
+// midInt64 returns (a+b)/2 truncated toward zero without intermediate overflow
+func midInt64(a, b int64) int64 {
+	// The 65-bit sum is formed in 128 bits and shifted right (floor division),
+	// then odd negative sums are bumped by one to truncate toward zero
+	sum := NewInt128(a).Add(NewInt128(b))
+	half := int64(sum.Lo>>1 | uint64(sum.Hi)<<63)
+	if sum.IsNegative() && sum.Lo&1 == 1 {
+		half++
+	}
+	return half
+}
@@ -0,0 +1,133 @@
+package clipper
+
+import (
+	"math"
+	"testing"
+)
+
+// TestPoint64Arithmetic tests the Point64 vector helpers
+func TestPoint64Arithmetic(t *testing.T) {
+	p := Point64{3, 4}
+	q := Point64{-1, 2}
+
+	if got := p.Add(q); got != (Point64{2, 6}) {
+		t.Errorf("Add = %v, expected {2 6}", got)
+	}
+	if got := p.Sub(q); got != (Point64{4, 2}) {
+		t.Errorf("Sub = %v, expected {4 2}", got)
+	}
+	if got := p.Negate(); got != (Point64{-3, -4}) {
+		t.Errorf("Negate = %v, expected {-3 -4}", got)
+	}
+	if got := p.Dot(q); got != 5 {
+		t.Errorf("Dot = %d, expected 5", got)
+	}
+	if got := p.Cross(q); got != 10 {
+		t.Errorf("Cross = %d, expected 10", got)
+	}
+	if got := p.DistanceTo(Point64{0, 0}); got != 5 {
+		t.Errorf("DistanceTo = %v, expected 5", got)
+	}
+}
+
+// TestPoint64Arithmetic128 tests that the 128-bit variants do not overflow
+func TestPoint64Arithmetic128(t *testing.T) {
+	big := Point64{math.MaxInt64 / 2, math.MaxInt64 / 2}
+	other := Point64{2, -2}
+
+	if cross := big.Cross128(other); cross.Cmp(NewInt128(math.MaxInt64/2).Mul64(-4)) != 0 {
+		t.Errorf("Cross128 = %+v, expected %+v", cross, NewInt128(math.MaxInt64/2).Mul64(-4))
+	}
+	if dot := big.Dot128(big); dot.IsNegative() || dot.IsZero() {
+		t.Errorf("Dot128 of large vector with itself should be positive, got %+v", dot)
+	}
+	if dot := big.Dot128(other); !dot.IsZero() {
+		t.Errorf("Dot128 of perpendicular vectors should be zero, got %+v", dot)
+	}
+
+	far := Point64{math.MinInt64 + 1, 0}
+	near := Point64{math.MaxInt64, 0}
+	if d := far.DistanceTo(near); d <= 0 || math.IsInf(d, 0) {
+		t.Errorf("DistanceTo with extreme coordinates = %v", d)
+	}
+}
+
+// TestPointDArithmetic tests the PointD vector helpers
+func TestPointDArithmetic(t *testing.T) {
+	p := PointD{1.5, 2}
+	q := PointD{0.5, -1}
+
+	if got := p.Add(q); got != (PointD{2, 1}) {
+		t.Errorf("Add = %v, expected {2 1}", got)
+	}
+	if got := p.Sub(q); got != (PointD{1, 3}) {
+		t.Errorf("Sub = %v, expected {1 3}", got)
+	}
+	if got := p.Negate(); got != (PointD{-1.5, -2}) {
+		t.Errorf("Negate = %v, expected {-1.5 -2}", got)
+	}
+	if got := p.Dot(q); got != -1.25 {
+		t.Errorf("Dot = %v, expected -1.25", got)
+	}
+	if got := p.Cross(q); got != -2.5 {
+		t.Errorf("Cross = %v, expected -2.5", got)
+	}
+	if got := (PointD{0, 0}).DistanceTo(PointD{3, 4}); got != 5 {
+		t.Errorf("DistanceTo = %v, expected 5", got)
+	}
+}
+
+// TestRoundHalfAway tests rounding with halves away from zero
+func TestRoundHalfAway(t *testing.T) {
+	tests := []struct {
+		input    float64
+		expected int64
+	}{
+		{0, 0},
+		{0.5, 1},
+		{-0.5, -1},
+		{1.4999, 1},
+		{-1.4999, -1},
+		{2.5, 3},
+		{-2.5, -3},
+		{1e30, math.MaxInt64},
+		{-1e30, math.MinInt64},
+		{math.NaN(), 0},
+	}
+
+	for _, tt := range tests {
+		if result := RoundHalfAway(tt.input); result != tt.expected {
+			t.Errorf("RoundHalfAway(%v) = %d, expected %d", tt.input, result, tt.expected)
+		}
+	}
+
+	if pt := RoundPointD(PointD{-1.5, 2.5}); pt != (Point64{-2, 3}) {
+		t.Errorf("RoundPointD = %v, expected {-2 3}", pt)
+	}
+}
+
+// TestMidPoint64 tests overflow-safe midpoint calculation
+func TestMidPoint64(t *testing.T) {
+	tests := []struct {
+		a, b     int64
+		expected int64
+	}{
+		{0, 10, 5},
+		{1, 2, 1},
+		{-1, -2, -1},
+		{3, -4, 0},
+		{-3, 4, 0},
+		{-5, 0, -2},
+		{math.MaxInt64, math.MaxInt64, math.MaxInt64},
+		{math.MinInt64, math.MinInt64, math.MinInt64},
+		{math.MaxInt64, math.MinInt64, 0},
+		{math.MaxInt64, math.MaxInt64 - 1, math.MaxInt64 - 1},
+	}
+
+	for _, tt := range tests {
+		mid := MidPoint64(Point64{tt.a, tt.b}, Point64{tt.b, tt.a})
+		if mid.X != tt.expected || mid.Y != tt.expected {
+			t.Errorf("MidPoint64(%d, %d) = %v, expected %d", tt.a, tt.b, mid, tt.expected)
+		}
+	}
+}
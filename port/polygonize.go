@@ -0,0 +1,361 @@
+package clipper
+
+import (
+	"math"
+	"sort"
+)
+
+// ==============================================================================
+// Polygonize - Faces Formed by Line Work
+// ==============================================================================
+
+// Polygonize64 nodes a set of lines, splitting them wherever they cross or
+// touch, and returns the closed faces the resulting line work encloses (the
+// equivalent of the GEOS Polygonizer). Line ends closer than tolerance are
+// welded together, so slightly overshooting or undershooting digitized lines
+// still close; pass 0 to only join identical points. Dangling lines and
+// bridges between faces enclose nothing and are ignored.
+//
+// Every face is returned as a counter-clockwise ring followed by clockwise
+// rings for the outlines of any line work nested inside it (its holes), so
+// the result fills correctly under NonZero. Faces sharing an edge are
+// returned separately; union them to get the covered region. A ring drawn
+// as one open path must repeat its first point at the end.
+func Polygonize64(lines Paths64, tolerance int64) (Paths64, error) {
+	if tolerance < 0 {
+		return nil, ErrInvalidInput
+	}
+
+	// Rounding crossing points to the integer grid can move edges enough to
+	// create new crossings, so noding repeats until the edges are stable
+	edges, crossed := nodeLines(lines, tolerance)
+	for pass := 1; crossed && pass < maxNodingPasses; pass++ {
+		segs := make(Paths64, len(edges))
+		for i, e := range edges {
+			segs[i] = Path64{e[0], e[1]}
+		}
+		edges, crossed = nodeLines(segs, tolerance)
+	}
+
+	graph := newPlanarGraph(edges)
+	var cycles Paths64
+	for {
+		graph.pruneDangles()
+		var bridges [][2]Point64
+		cycles, bridges = graph.traceCycles()
+		if len(bridges) == 0 {
+			break
+		}
+		for _, e := range bridges {
+			graph.removeEdge(e[0], e[1])
+		}
+	}
+	return assembleFaces(cycles, graph.components()), nil
+}
+
+// maxNodingPasses bounds how often Polygonize64 re-nodes its own edges
+const maxNodingPasses = 8
+
+// nodeLines splits every segment of lines at the points where it crosses or
+// touches another, welds points closer than tolerance and returns the unique
+// non-degenerate edges; crossed reports whether any segments crossed
+func nodeLines(lines Paths64, tolerance int64) (edges [][2]Point64, crossed bool) {
+	var segs [][2]Point64
+	for _, line := range lines {
+		for i := 0; i+1 < len(line); i++ {
+			if line[i] != line[i+1] {
+				segs = append(segs, [2]Point64{line[i], line[i+1]})
+			}
+		}
+	}
+
+	splits := segmentCrossings(segs)
+	var vertices []Point64
+	for i, s := range segs {
+		vertices = append(vertices, s[0], s[1])
+		if len(splits[i]) > 0 {
+			crossed = true
+			vertices = append(vertices, splits[i]...)
+		}
+	}
+	sort.Slice(vertices, func(i, j int) bool {
+		if vertices[i].X != vertices[j].X {
+			return vertices[i].X < vertices[j].X
+		}
+		return vertices[i].Y < vertices[j].Y
+	})
+
+	var weld map[Point64]Point64
+	if tolerance > 0 {
+		weld = weldMap(Paths64{vertices}, tolerance)
+	}
+	welded := func(pt Point64) Point64 {
+		if rep, ok := weld[pt]; ok {
+			return rep
+		}
+		return pt
+	}
+
+	seen := make(map[[2]Point64]bool)
+	for i, s := range segs {
+		// Split points ordered from s[0] towards s[1]
+		pts := append(splits[i], touchingPointsOnEdge(vertices, s[0], s[1])...)
+		dir := s[1].Sub(s[0])
+		sort.Slice(pts, func(a, b int) bool {
+			return pts[a].Sub(s[0]).Dot128(dir).Cmp(pts[b].Sub(s[0]).Dot128(dir)) < 0
+		})
+
+		prev := welded(s[0])
+		for _, pt := range append(pts, s[1]) {
+			pt = welded(pt)
+			if pt == prev {
+				continue
+			}
+			edge := [2]Point64{prev, pt}
+			if pointLess(pt, prev) {
+				edge = [2]Point64{pt, prev}
+			}
+			if !seen[edge] {
+				seen[edge] = true
+				edges = append(edges, edge)
+			}
+			prev = pt
+		}
+	}
+	return edges, crossed
+}
+
+// segmentCrossings returns, for every segment, the rounded points where it
+// crosses another segment at a point interior to both
+func segmentCrossings(segs [][2]Point64) [][]Point64 {
+	crossings := make([][]Point64, len(segs))
+	forEachCrossing(segs, func(i, j int) {
+		a, b := segs[i], segs[j]
+		if pt, ok := getSegmentIntersectPt(a[0], a[1], b[0], b[1]); ok {
+			crossings[i] = append(crossings[i], pt)
+			crossings[j] = append(crossings[j], pt)
+		}
+	})
+	return crossings
+}
+
+// forEachCrossing calls fn for every pair of segments crossing at a point
+// interior to both. Segments are scanned in order of their left end so each
+// only inspects those it can reach.
+func forEachCrossing(segs [][2]Point64, fn func(i, j int)) {
+	order := make([]int, len(segs))
+	for i := range order {
+		order[i] = i
+	}
+	minX := func(s [2]Point64) int64 { return min64(s[0].X, s[1].X) }
+	sort.Slice(order, func(i, j int) bool { return minX(segs[order[i]]) < minX(segs[order[j]]) })
+
+	for k, i := range order {
+		a := segs[i]
+		maxX := max64(a[0].X, a[1].X)
+		minY, maxY := minMax64(a[0].Y, a[1].Y)
+		for _, j := range order[k+1:] {
+			b := segs[j]
+			if minX(b) > maxX {
+				break
+			}
+			if max64(b[0].Y, b[1].Y) < minY || min64(b[0].Y, b[1].Y) > maxY {
+				continue
+			}
+			if segmentsIntersectStrict(a[0], a[1], b[0], b[1]) {
+				fn(i, j)
+			}
+		}
+	}
+}
+
+// pointLess orders points by X, then Y
+func pointLess(a, b Point64) bool {
+	return a.X < b.X || (a.X == b.X && a.Y < b.Y)
+}
+
+// planarGraph is an undirected graph of noded line work
+type planarGraph struct {
+	adj map[Point64][]Point64
+}
+
+// newPlanarGraph builds the graph of edges
+func newPlanarGraph(edges [][2]Point64) *planarGraph {
+	g := &planarGraph{adj: make(map[Point64][]Point64)}
+	for _, e := range edges {
+		g.adj[e[0]] = append(g.adj[e[0]], e[1])
+		g.adj[e[1]] = append(g.adj[e[1]], e[0])
+	}
+	return g
+}
+
+// removeEdge deletes the edge between a and b if present
+func (g *planarGraph) removeEdge(a, b Point64) {
+	remove := func(from, to Point64) {
+		nbrs := g.adj[from]
+		for i, n := range nbrs {
+			if n == to {
+				nbrs = append(nbrs[:i], nbrs[i+1:]...)
+				break
+			}
+		}
+		if len(nbrs) == 0 {
+			delete(g.adj, from)
+		} else {
+			g.adj[from] = nbrs
+		}
+	}
+	remove(a, b)
+	remove(b, a)
+}
+
+// pruneDangles repeatedly removes edges ending at a vertex of degree one
+func (g *planarGraph) pruneDangles() {
+	var queue []Point64
+	for v, nbrs := range g.adj {
+		if len(nbrs) == 1 {
+			queue = append(queue, v)
+		}
+	}
+	for len(queue) > 0 {
+		v := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+		if len(g.adj[v]) != 1 {
+			continue
+		}
+		w := g.adj[v][0]
+		g.removeEdge(v, w)
+		if len(g.adj[w]) == 1 {
+			queue = append(queue, w)
+		}
+	}
+}
+
+// sortedVertices returns the vertices in a deterministic order
+func (g *planarGraph) sortedVertices() []Point64 {
+	vertices := make([]Point64, 0, len(g.adj))
+	for v := range g.adj {
+		vertices = append(vertices, v)
+	}
+	sort.Slice(vertices, func(i, j int) bool { return pointLess(vertices[i], vertices[j]) })
+	return vertices
+}
+
+// traceCycles walks every directed edge once, always taking the sharpest
+// left turn, so each cycle has its face on the left: bounded faces come out
+// counter-clockwise and the outline of each connected component clockwise.
+// Edges walked in both directions by the same cycle are bridges.
+func (g *planarGraph) traceCycles() (cycles Paths64, bridges [][2]Point64) {
+	vertices := g.sortedVertices()
+	for _, v := range vertices {
+		nbrs := g.adj[v]
+		sort.Slice(nbrs, func(i, j int) bool { return angleLess(nbrs[i].Sub(v), nbrs[j].Sub(v)) })
+	}
+
+	visited := make(map[[2]Point64]bool)
+	for _, start := range vertices {
+		for _, first := range g.adj[start] {
+			if visited[[2]Point64{start, first}] {
+				continue
+			}
+			inCycle := make(map[[2]Point64]bool)
+			var cycle Path64
+			u, v := start, first
+			for !visited[[2]Point64{u, v}] {
+				visited[[2]Point64{u, v}] = true
+				inCycle[[2]Point64{u, v}] = true
+				if inCycle[[2]Point64{v, u}] {
+					bridges = append(bridges, [2]Point64{u, v})
+				}
+				cycle = append(cycle, u)
+				u, v = v, g.nextClockwise(v, u)
+			}
+			cycles = append(cycles, cycle)
+		}
+	}
+	return cycles, bridges
+}
+
+// nextClockwise returns the neighbour of v that follows from clockwise in
+// angular order around v
+func (g *planarGraph) nextClockwise(v, from Point64) Point64 {
+	nbrs := g.adj[v]
+	for i, n := range nbrs {
+		if n == from {
+			return nbrs[(i+len(nbrs)-1)%len(nbrs)]
+		}
+	}
+	return from
+}
+
+// components labels every vertex with the index of its connected component
+func (g *planarGraph) components() map[Point64]int {
+	label := make(map[Point64]int)
+	id := 0
+	for _, v := range g.sortedVertices() {
+		if _, ok := label[v]; ok {
+			continue
+		}
+		id++
+		stack := []Point64{v}
+		label[v] = id
+		for len(stack) > 0 {
+			u := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for _, w := range g.adj[u] {
+				if _, ok := label[w]; !ok {
+					label[w] = id
+					stack = append(stack, w)
+				}
+			}
+		}
+	}
+	return label
+}
+
+// angleLess orders direction vectors counter-clockwise, starting at the
+// positive X axis
+func angleLess(a, b Point64) bool {
+	upper := func(p Point64) bool { return p.Y > 0 || (p.Y == 0 && p.X > 0) }
+	if upper(a) != upper(b) {
+		return upper(a)
+	}
+	cross := a.Cross128(b)
+	return !cross.IsNegative() && !cross.IsZero()
+}
+
+// assembleFaces pairs every bounded face with the outlines of the components
+// nested directly inside it
+func assembleFaces(cycles Paths64, component map[Point64]int) Paths64 {
+	var faces, outlines Paths64
+	var faceAreas []float64
+	for _, cycle := range cycles {
+		switch area := Area64(cycle); {
+		case area > 0:
+			faces = append(faces, cycle)
+			faceAreas = append(faceAreas, area)
+		case area < 0:
+			outlines = append(outlines, cycle)
+		}
+	}
+
+	holes := make([]Paths64, len(faces))
+	for _, outline := range outlines {
+		best, bestArea := -1, math.Inf(1)
+		for i, face := range faces {
+			if component[face[0]] != component[outline[0]] && faceAreas[i] < bestArea && ringContainsRing(face, outline) {
+				best, bestArea = i, faceAreas[i]
+			}
+		}
+		if best >= 0 {
+			holes[best] = append(holes[best], outline)
+		}
+	}
+
+	result := make(Paths64, 0, len(faces)+len(outlines))
+	for i, face := range faces {
+		result = append(result, face)
+		result = append(result, holes[i]...)
+	}
+	return result
+}
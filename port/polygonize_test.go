@@ -0,0 +1,121 @@
+package clipper
+
+import (
+	"errors"
+	"testing"
+)
+
+// faceAreas returns the areas of the counter-clockwise rings of paths
+func faceAreas(paths Paths64) []float64 {
+	var areas []float64
+	for _, path := range paths {
+		if a := Area64(path); a > 0 {
+			areas = append(areas, a)
+		}
+	}
+	return areas
+}
+
+func TestPolygonize64(t *testing.T) {
+	tests := []struct {
+		name      string
+		lines     Paths64
+		tolerance int64
+		faces     []float64 // areas of the expected faces, in order
+		netArea   float64
+	}{
+		{
+			name:    "closed ring",
+			lines:   Paths64{{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}},
+			faces:   []float64{100},
+			netArea: 100,
+		},
+		{
+			name: "square from separate segments",
+			lines: Paths64{
+				{{0, 0}, {10, 0}}, {{10, 10}, {10, 0}}, {{10, 10}, {0, 10}}, {{0, 0}, {0, 10}},
+			},
+			faces:   []float64{100},
+			netArea: 100,
+		},
+		{
+			name: "crossing lines form a grid of four faces",
+			lines: Paths64{
+				{{0, 0}, {20, 0}, {20, 20}, {0, 20}, {0, 0}},
+				{{10, -5}, {10, 25}}, // overshoots, the dangling ends are dropped
+				{{-5, 10}, {25, 10}},
+			},
+			faces:   []float64{100, 100, 100, 100},
+			netArea: 400,
+		},
+		{
+			name: "T-junction splits a face",
+			lines: Paths64{
+				{{0, 0}, {20, 0}, {20, 10}, {0, 10}, {0, 0}},
+				{{10, 0}, {10, 10}},
+			},
+			faces:   []float64{100, 100},
+			netArea: 200,
+		},
+		{
+			name: "undershoot closed by tolerance",
+			lines: Paths64{
+				{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 1}},
+			},
+			tolerance: 2,
+			faces:     []float64{100},
+			netArea:   100,
+		},
+		{
+			name: "bridge between two faces",
+			lines: Paths64{
+				{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+				{{10, 5}, {20, 5}},
+				{{20, 0}, {30, 0}, {30, 10}, {20, 10}, {20, 0}},
+			},
+			faces:   []float64{100, 100},
+			netArea: 200,
+		},
+		{
+			name: "island inside a face becomes a hole",
+			lines: Paths64{
+				{{0, 0}, {30, 0}, {30, 30}, {0, 30}, {0, 0}},
+				{{10, 10}, {20, 10}, {20, 20}, {10, 20}, {10, 10}},
+			},
+			faces:   []float64{900, 100},
+			netArea: 900,
+		},
+		{
+			name:  "open line work",
+			lines: Paths64{{{0, 0}, {10, 0}, {10, 10}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Polygonize64(tt.lines, tt.tolerance)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			areas := faceAreas(result)
+			if len(areas) != len(tt.faces) {
+				t.Fatalf("expected faces %v, got %v (%v)", tt.faces, areas, result)
+			}
+			for i := range areas {
+				if areas[i] != tt.faces[i] {
+					t.Errorf("expected faces %v, got %v", tt.faces, areas)
+					break
+				}
+			}
+			if net := Stats64(result).NetArea; net != tt.netArea {
+				t.Errorf("expected net area %v, got %v", tt.netArea, net)
+			}
+		})
+	}
+}
+
+func TestPolygonize64NegativeTolerance(t *testing.T) {
+	if _, err := Polygonize64(nil, -1); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, got %v", err)
+	}
+}
@@ -0,0 +1,343 @@
+package clipper
+
+import (
+	"iter"
+	"math"
+	"sort"
+)
+
+// ==============================================================================
+// PolyTree64 - Hierarchical Polygon Output
+// ==============================================================================
+
+// PolyPath64 is a node in a polygon hierarchy. Outer polygons sit at odd
+// depths below the root and their holes at even depths, so a node's children
+// are the holes of an outer polygon or the islands inside a hole.
+type PolyPath64 struct {
+	Path     Path64        // the polygon (empty for the root node)
+	Children []*PolyPath64 // nested polygons contained by this one
+	Parent   *PolyPath64   // enclosing node (nil for the root)
+
+	bounds    Rect64 // bounds of this node's polygon and all descendants
+	hasBounds bool
+}
+
+// PolyTree64 is the root of a polygon hierarchy. The root itself holds no
+// polygon; its children are the outermost polygons.
+type PolyTree64 = PolyPath64
+
+// NewPolyTree64 returns an empty tree
+func NewPolyTree64() *PolyTree64 {
+	return &PolyTree64{}
+}
+
+// AddChild appends path as a child of pp and returns the new node.
+// Bounds of pp and all of its ancestors are extended incrementally.
+func (pp *PolyPath64) AddChild(path Path64) *PolyPath64 {
+	child := &PolyPath64{Path: path, Parent: pp}
+	if len(path) > 0 {
+		child.bounds = Bounds64(path)
+		child.hasBounds = true
+	}
+	pp.Children = append(pp.Children, child)
+
+	if child.hasBounds {
+		for node := pp; node != nil; node = node.Parent {
+			switch {
+			case !node.hasBounds:
+				node.bounds = child.bounds
+				node.hasBounds = true
+			case node.bounds.ContainsRect(child.bounds):
+				return child // ancestors already cover these bounds
+			default:
+				node.bounds = node.bounds.UnionRect(child.bounds)
+			}
+		}
+	}
+	return child
+}
+
+// IsHole returns true if the node represents a hole (even depth below the root)
+func (pp *PolyPath64) IsHole() bool {
+	depth := 0
+	for node := pp.Parent; node != nil; node = node.Parent {
+		depth++
+	}
+	return depth > 0 && depth%2 == 0
+}
+
+// Bounds returns the bounding rectangle of this node's polygon and all of its
+// descendants without flattening the tree. Empty trees return InvalidRect64.
+// Bounds are maintained by AddChild; modifying Path directly invalidates them.
+func (pp *PolyPath64) Bounds() Rect64 {
+	if !pp.hasBounds {
+		return InvalidRect64
+	}
+	return pp.bounds
+}
+
+// ==============================================================================
+// Navigation and Queries
+// ==============================================================================
+
+// Polygon returns the node's polygon (empty for the root), as in Clipper2
+func (pp *PolyPath64) Polygon() Path64 {
+	return pp.Path
+}
+
+// Count returns the number of direct children
+func (pp *PolyPath64) Count() int {
+	return len(pp.Children)
+}
+
+// Child returns the i-th direct child
+func (pp *PolyPath64) Child(i int) *PolyPath64 {
+	return pp.Children[i]
+}
+
+// Level returns the depth of the node: 0 for the root, 1 for outermost
+// polygons, 2 for their holes and so on
+func (pp *PolyPath64) Level() int {
+	level := 0
+	for node := pp.Parent; node != nil; node = node.Parent {
+		level++
+	}
+	return level
+}
+
+// Area returns the signed area of the node's polygon plus that of all its
+// descendants. With the orientation of boolean operation output (holes
+// clockwise) this is the net area covered by the node, holes subtracted and
+// islands added back; on the root it is the area of the whole tree.
+func (pp *PolyPath64) Area() float64 {
+	area := Area64(pp.Path)
+	for _, child := range pp.Children {
+		area += child.Area()
+	}
+	return area
+}
+
+// Centroid returns the area centroid of the node's polygon and all of its
+// descendants, as CentroidPaths64 of their paths
+func (pp *PolyPath64) Centroid() PointD {
+	paths := Paths64{pp.Path}
+	for node := range pp.Descendants() {
+		paths = append(paths, node.Path)
+	}
+	return CentroidPaths64(paths)
+}
+
+// Contains reports whether pt lies inside or on the boundary of the node's
+// own polygon; children are not consulted and the root contains nothing
+func (pp *PolyPath64) Contains(pt Point64) bool {
+	if len(pp.Path) < 3 || (pp.hasBounds && !pp.bounds.Contains(pt)) {
+		return false
+	}
+	return PointInPolygon(pt, pp.Path, NonZero) != Outside
+}
+
+// PointInPolyTree64 locates a point relative to the region of tree: it is
+// Inside if the deepest polygon containing it is an outer, Outside if that is
+// a hole or there is none, and OnBoundary on the edge of any polygon. Only
+// the branches whose bounds contain the point are searched.
+func PointInPolyTree64(pt Point64, tree *PolyTree64) PolygonLocation {
+	location := Outside
+	for node := tree; node != nil; {
+		var inside *PolyPath64
+		for _, child := range node.Children {
+			if !child.hasBounds || !child.bounds.Contains(pt) || len(child.Path) < 3 {
+				continue
+			}
+			switch PointInPolygon(pt, child.Path, NonZero) {
+			case OnBoundary:
+				return OnBoundary
+			case Inside:
+				inside = child
+			}
+			if inside != nil {
+				break // siblings do not overlap
+			}
+		}
+		if inside != nil {
+			location = Inside
+			if inside.IsHole() {
+				location = Outside
+			}
+		}
+		node = inside
+	}
+	return location
+}
+
+// Descendants iterates over every node below pp depth-first, each parent
+// before its children and siblings in order. Nodes must not be added or
+// removed while iterating.
+func (pp *PolyPath64) Descendants() iter.Seq[*PolyPath64] {
+	return func(yield func(*PolyPath64) bool) {
+		pp.walk(yield)
+	}
+}
+
+// walk yields the descendants of pp and reports whether to continue
+func (pp *PolyPath64) walk(yield func(*PolyPath64) bool) bool {
+	for _, child := range pp.Children {
+		if !yield(child) || !child.walk(yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// PolyTreeToPaths64 returns copies of the polygons of every node of tree in
+// depth-first order, each outer followed by its holes and their islands,
+// like the flat output of BooleanOp64
+func PolyTreeToPaths64(tree *PolyTree64) Paths64 {
+	result := Paths64{}
+	for node := range tree.Descendants() {
+		if len(node.Path) > 0 {
+			result = append(result, node.Path.Clone())
+		}
+	}
+	return result
+}
+
+// buildPolyTree64 arranges closed paths into a hierarchy by containment.
+// Paths are inserted from largest to smallest absolute area, so every parent
+// is already in the tree when its children are placed.
+func buildPolyTree64(paths Paths64) *PolyTree64 {
+	tree := NewPolyTree64()
+	rings := make(Paths64, 0, len(paths))
+	for _, path := range paths {
+		if len(path) >= 3 {
+			rings = append(rings, path)
+		}
+	}
+
+	order, parents := nestingParents(rings)
+	nodes := make([]*PolyPath64, len(rings))
+	for _, i := range order {
+		parent := tree
+		if parents[i] >= 0 {
+			parent = nodes[parents[i]]
+		}
+		nodes[i] = parent.AddChild(rings[i])
+	}
+	return tree
+}
+
+// ==============================================================================
+// Nesting
+// ==============================================================================
+
+// nestingParents returns the rings in order of decreasing absolute area,
+// stable for equal areas, and for every ring the index of the smallest ring
+// before it in that order containing it, or -1
+func nestingParents(rings Paths64) (order, parents []int) {
+	areas := make([]float64, len(rings))
+	order = make([]int, len(rings))
+	for i, ring := range rings {
+		areas[i] = math.Abs(Area64(ring))
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return areas[order[a]] > areas[order[b]] })
+	rank := make([]int, len(rings))
+	for k, i := range order {
+		rank[i] = k
+	}
+
+	parents = make([]int, len(rings))
+	for i := range parents {
+		parents[i] = -1
+	}
+	sweepContainment(rings, BoundsEach64(rings), func(inner, outer int) bool {
+		p := parents[inner]
+		return rank[outer] < rank[inner] && (p < 0 || rank[outer] > rank[p])
+	}, func(inner, outer int) {
+		parents[inner] = outer
+	})
+	return order, parents
+}
+
+// sweepContainment calls contains for every pair of rings where outer
+// contains inner. The rings are swept by the left edge of their bounds, so
+// only rings whose bounds are still open at a ring's left edge are candidates,
+// and a candidate is tested with ringContainsRing only if its bounds contain
+// the ring's and test, if not nil, accepts the pair.
+func sweepContainment(rings Paths64, bounds []Rect64, test func(inner, outer int) bool, contains func(inner, outer int)) {
+	// Bounds containing others sort before them
+	order := make([]int, len(rings))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool {
+		ra, rb := bounds[order[a]], bounds[order[b]]
+		switch {
+		case ra.Left != rb.Left:
+			return ra.Left < rb.Left
+		case ra.Right != rb.Right:
+			return ra.Right > rb.Right
+		case ra.Top != rb.Top:
+			return ra.Top < rb.Top
+		case ra.Bottom != rb.Bottom:
+			return ra.Bottom > rb.Bottom
+		}
+		return order[a] < order[b]
+	})
+
+	check := func(inner, outer int) {
+		if bounds[outer].ContainsRect(bounds[inner]) && (test == nil || test(inner, outer)) &&
+			ringContainsRing(rings[outer], rings[inner]) {
+			contains(inner, outer)
+		}
+	}
+	var active []int
+	for _, i := range order {
+		kept := active[:0]
+		for _, j := range active {
+			if bounds[j].Right < bounds[i].Left {
+				continue
+			}
+			kept = append(kept, j)
+			check(i, j)
+			if bounds[j] == bounds[i] {
+				check(j, i) // equal bounds contain each other
+			}
+		}
+		active = append(kept, i)
+	}
+}
+
+// ExtractOuters64 returns the path of every outer node of the tree, islands
+// inside holes included, oriented counter-clockwise. The paths are copies in
+// depth-first order, so they can be modified freely.
+func ExtractOuters64(tree *PolyTree64) Paths64 {
+	return extractNodes64(tree, false)
+}
+
+// ExtractHoles64 returns the path of every hole of the tree, oriented
+// clockwise as in the output contract (reverse them to treat holes as
+// polygons of their own). The paths are copies in depth-first order.
+func ExtractHoles64(tree *PolyTree64) Paths64 {
+	return extractNodes64(tree, true)
+}
+
+// extractNodes64 collects copies of the hole or non-hole node paths below
+// tree, normalized so outers are counter-clockwise and holes clockwise
+func extractNodes64(tree *PolyTree64, holes bool) Paths64 {
+	var result Paths64
+	var visit func(node *PolyPath64, hole bool)
+	visit = func(node *PolyPath64, hole bool) {
+		if node != tree && hole == holes && len(node.Path) > 0 {
+			if IsPositive64(node.Path) == hole {
+				result = append(result, Reverse64(node.Path))
+			} else {
+				result = append(result, node.Path.Clone())
+			}
+		}
+		for _, child := range node.Children {
+			visit(child, node != tree && !hole)
+		}
+	}
+	visit(tree, true)
+	return result
+}
@@ -0,0 +1,289 @@
+package clipper
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// TestPolyTree64Bounds tests that bounds are maintained while nodes are added
+func TestPolyTree64Bounds(t *testing.T) {
+	tree := NewPolyTree64()
+	if tree.Bounds() != InvalidRect64 {
+		t.Errorf("Expected empty tree bounds to be invalid, got %v", tree.Bounds())
+	}
+
+	outer := tree.AddChild(Path64{{0, 0}, {100, 0}, {100, 100}, {0, 100}})
+	hole := outer.AddChild(Path64{{10, 10}, {10, 90}, {90, 90}, {90, 10}})
+	tree.AddChild(Path64{{200, -50}, {300, -50}, {300, 50}, {200, 50}})
+
+	if got := tree.Bounds(); got != (Rect64{0, -50, 300, 100}) {
+		t.Errorf("Tree bounds = %v, expected {0 -50 300 100}", got)
+	}
+	if got := hole.Bounds(); got != (Rect64{10, 10, 90, 90}) {
+		t.Errorf("Hole bounds = %v, expected {10 10 90 90}", got)
+	}
+	if outer.IsHole() || !hole.IsHole() {
+		t.Error("Expected outer to be an outer polygon and hole to be a hole")
+	}
+}
+
+// TestBuildPolyTree64 tests containment-based hierarchy construction
+func TestBuildPolyTree64(t *testing.T) {
+	paths := Paths64{
+		{{40, 40}, {60, 40}, {60, 60}, {40, 60}},   // island
+		{{0, 0}, {100, 0}, {100, 100}, {0, 100}},   // outer
+		{{200, 0}, {250, 0}, {250, 50}, {200, 50}}, // separate outer
+		{{20, 20}, {20, 80}, {80, 80}, {80, 20}},   // hole
+	}
+
+	tree := buildPolyTree64(paths)
+	if len(tree.Children) != 2 {
+		t.Fatalf("Expected 2 outer polygons, got %d", len(tree.Children))
+	}
+
+	outer := tree.Children[0]
+	if len(outer.Children) != 1 {
+		t.Fatalf("Expected outer to have 1 hole, got %d", len(outer.Children))
+	}
+	hole := outer.Children[0]
+	if !hole.IsHole() {
+		t.Error("Expected second level to be a hole")
+	}
+	if len(hole.Children) != 1 || hole.Children[0].IsHole() {
+		t.Error("Expected hole to contain one island")
+	}
+	if got := tree.Bounds(); got != (Rect64{0, 0, 250, 100}) {
+		t.Errorf("Tree bounds = %v, expected {0 0 250 100}", got)
+	}
+}
+
+// TestNestingParents tests the bounds sweep against testing every pair of
+// rings, on nested solutions and on crossing random rings
+func TestNestingParents(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 300; i++ {
+		var squares Paths64
+		for j := 0; j < 2+r.Intn(30); j++ {
+			x, y, size := r.Int63n(100), r.Int63n(100), 1+r.Int63n(60)
+			squares = append(squares, Path64{{x, y}, {x + size, y}, {x + size, y + size}, {x, y + size}})
+		}
+		rings, _, err := BooleanOp64(Xor, EvenOdd, squares, nil, nil)
+		if err != nil {
+			t.Fatalf("BooleanOp64: %v", err)
+		}
+		if i%2 == 1 {
+			rings = Paths64{randomPath(r, 3+r.Intn(8), 20), randomPath(r, 3+r.Intn(8), 20), randomPath(r, 3+r.Intn(8), 20)}
+		}
+
+		order, parents := nestingParents(rings)
+		depths := make([]int, len(rings))
+		sweepContainment(rings, BoundsEach64(rings), nil, func(inner, _ int) { depths[inner]++ })
+		bounds := BoundsEach64(rings)
+		for k, inner := range order {
+			want := -1
+			for m := k - 1; m >= 0 && want < 0; m-- {
+				if outer := order[m]; bounds[outer].ContainsRect(bounds[inner]) && ringContainsRing(rings[outer], rings[inner]) {
+					want = outer
+				}
+			}
+			if parents[inner] != want {
+				t.Fatalf("%v: parent of ring %d is %d, expected %d", rings, inner, parents[inner], want)
+			}
+			depth := 0
+			for outer := range rings {
+				if outer != inner && bounds[outer].ContainsRect(bounds[inner]) && ringContainsRing(rings[outer], rings[inner]) {
+					depth++
+				}
+			}
+			if depths[inner] != depth {
+				t.Fatalf("%v: ring %d is inside %d rings, expected %d", rings, inner, depths[inner], depth)
+			}
+		}
+	}
+}
+
+// TestBooleanOp64Tree tests the tree-producing boolean operation
+func TestBooleanOp64Tree(t *testing.T) {
+	subject := Paths64{{{0, 0}, {10, 0}, {10, 10}, {0, 10}}}
+	clip := Paths64{{{5, 5}, {15, 5}, {15, 15}, {5, 15}}}
+
+	tree, _, err := BooleanOp64Tree(Intersection, NonZero, subject, nil, clip)
+	if err == ErrNotImplemented {
+		t.Skip("BooleanOp64Tree not yet implemented in pure Go")
+	}
+	if err != nil {
+		t.Fatalf("BooleanOp64Tree failed: %v", err)
+	}
+	if len(tree.Children) == 0 {
+		t.Fatal("Expected non-empty tree from intersection")
+	}
+	if !BoundsPaths64(subject).ContainsRect(tree.Bounds()) {
+		t.Errorf("Tree bounds %v should lie within subject bounds", tree.Bounds())
+	}
+	t.Logf("BooleanOp64Tree bounds: %v", tree.Bounds())
+}
+
+// TestExtractOutersAndHoles tests the outer/hole accessors and their
+// orientation normalization
+func TestExtractOutersAndHoles(t *testing.T) {
+	tree := NewPolyTree64()
+	outer := tree.AddChild(Reverse64(Path64{{0, 0}, {100, 0}, {100, 100}, {0, 100}})) // clockwise outer
+	hole := outer.AddChild(Path64{{20, 20}, {80, 20}, {80, 80}, {20, 80}})            // counter-clockwise hole
+	island := hole.AddChild(Path64{{40, 40}, {60, 40}, {60, 60}, {40, 60}})
+	island.AddChild(Path64{{45, 45}, {45, 55}, {55, 55}, {55, 45}})
+	tree.AddChild(Path64{{200, 0}, {250, 0}, {250, 50}, {200, 50}})
+
+	outers := ExtractOuters64(tree)
+	if len(outers) != 3 {
+		t.Fatalf("Expected 3 outers, got %d", len(outers))
+	}
+	for _, path := range outers {
+		if !IsPositive64(path) {
+			t.Errorf("Expected outer %v to be counter-clockwise", path)
+		}
+	}
+	holes := ExtractHoles64(tree)
+	if len(holes) != 2 {
+		t.Fatalf("Expected 2 holes, got %d", len(holes))
+	}
+	for _, path := range holes {
+		if IsPositive64(path) {
+			t.Errorf("Expected hole %v to be clockwise", path)
+		}
+	}
+
+	// results are copies
+	outers[0][0] = Point64{-1, -1}
+	if outer.Path[0] == (Point64{-1, -1}) || outer.Path[len(outer.Path)-1] == (Point64{-1, -1}) {
+		t.Error("Expected extracted paths not to share memory with the tree")
+	}
+	if len(ExtractOuters64(NewPolyTree64())) != 0 || len(ExtractHoles64(NewPolyTree64())) != 0 {
+		t.Error("Expected nothing from an empty tree")
+	}
+}
+
+// TestPolyTree64Navigation tests the Clipper2-style accessors and queries
+func TestPolyTree64Navigation(t *testing.T) {
+	paths := Paths64{
+		{{0, 0}, {100, 0}, {100, 100}, {0, 100}},   // outer
+		{{20, 20}, {20, 80}, {80, 80}, {80, 20}},   // hole
+		{{40, 40}, {60, 40}, {60, 60}, {40, 60}},   // island
+		{{200, 0}, {250, 0}, {250, 50}, {200, 50}}, // separate outer
+	}
+	tree := buildPolyTree64(paths)
+	if tree.Count() != 2 || tree.Level() != 0 || len(tree.Polygon()) != 0 {
+		t.Fatalf("expected a root with 2 children, got %d", tree.Count())
+	}
+	outer := tree.Child(0)
+	hole := outer.Child(0)
+	island := hole.Child(0)
+	levels := []int{outer.Level(), hole.Level(), island.Level()}
+	if levels[0] != 1 || levels[1] != 2 || levels[2] != 3 {
+		t.Errorf("expected levels 1, 2, 3, got %v", levels)
+	}
+
+	if c := island.Centroid(); c != (PointD{50, 50}) {
+		t.Errorf("expected island centroid {50 50}, got %v", c)
+	}
+	if c := outer.Centroid(); c != (PointD{50, 50}) {
+		t.Errorf("expected outer centroid {50 50}, got %v", c)
+	}
+
+	areas := []struct {
+		node     *PolyPath64
+		expected float64
+	}{
+		{tree, 10000 - 3600 + 400 + 2500},
+		{outer, 10000 - 3600 + 400},
+		{hole, -3600 + 400},
+		{island, 400},
+	}
+	for _, a := range areas {
+		if got := a.node.Area(); got != a.expected {
+			t.Errorf("level %d: expected area %v, got %v", a.node.Level(), a.expected, got)
+		}
+	}
+
+	contains := []struct {
+		node     *PolyPath64
+		pt       Point64
+		expected bool
+	}{
+		{outer, Point64{X: 10, Y: 10}, true},
+		{outer, Point64{X: 50, Y: 50}, true}, // children are not consulted
+		{outer, Point64{X: 100, Y: 50}, true},
+		{outer, Point64{X: 150, Y: 50}, false},
+		{hole, Point64{X: 10, Y: 10}, false},
+		{tree, Point64{X: 10, Y: 10}, false},
+	}
+	for _, c := range contains {
+		if got := c.node.Contains(c.pt); got != c.expected {
+			t.Errorf("level %d, %v: expected %v, got %v", c.node.Level(), c.pt, c.expected, got)
+		}
+	}
+
+	var visited []int
+	for node := range tree.Descendants() {
+		visited = append(visited, node.Level())
+		if node == hole {
+			break
+		}
+	}
+	if len(visited) < 2 || visited[len(visited)-1] != 2 || visited[len(visited)-2] != 1 {
+		t.Errorf("expected each parent before its children, got levels %v", visited)
+	}
+
+	flat := PolyTreeToPaths64(tree)
+	if !sameRings(flat, paths) {
+		t.Errorf("expected %v, got %v", paths, flat)
+	}
+	flat[0][0].X++
+	if !sameRings(PolyTreeToPaths64(tree), paths) {
+		t.Error("expected PolyTreeToPaths64 to return copies")
+	}
+}
+
+// TestPointInPolyTree64 tests containment by nesting depth
+func TestPointInPolyTree64(t *testing.T) {
+	tree := buildPolyTree64(Paths64{
+		{{0, 0}, {100, 0}, {100, 100}, {0, 100}},
+		{{20, 20}, {20, 80}, {80, 80}, {80, 20}},
+		{{40, 40}, {60, 40}, {60, 60}, {40, 60}},
+		{{200, 0}, {250, 0}, {250, 50}, {200, 50}},
+	})
+	tests := []struct {
+		pt       Point64
+		expected PolygonLocation
+	}{
+		{Point64{10, 10}, Inside},
+		{Point64{30, 30}, Outside},
+		{Point64{50, 50}, Inside},
+		{Point64{60, 50}, OnBoundary},
+		{Point64{225, 25}, Inside},
+		{Point64{150, 50}, Outside},
+	}
+	for _, tt := range tests {
+		if got := PointInPolyTree64(tt.pt, tree); got != tt.expected {
+			t.Errorf("%v: expected %v, got %v", tt.pt, tt.expected, got)
+		}
+	}
+	if got := PointInPolyTree64(Point64{0, 0}, NewPolyTree64()); got != Outside {
+		t.Errorf("expected Outside for an empty tree, got %v", got)
+	}
+
+	// The tree of a solution agrees with its flat paths
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		solution, err := Union64(Paths64{randomPath(r, 10, 100), randomPath(r, 10, 100)}, nil, EvenOdd)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tree := buildPolyTree64(solution)
+		for j := 0; j < 50; j++ {
+			pt := Point64{r.Int63n(100), r.Int63n(100)}
+			if got, expected := PointInPolyTree64(pt, tree), PointInPaths64(pt, solution, NonZero); got != expected {
+				t.Fatalf("%v in %v: expected %v, got %v", pt, solution, expected, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package clipper
+
+import "sort"
+
+// ==============================================================================
+// Pre-Noding of Self-Intersecting Input
+// ==============================================================================
+
+// nodeSelfIntersections returns a copy of the closed rings in paths with a
+// vertex inserted wherever two of their edges cross, whether in the same
+// ring or in different ones. The filled region is unchanged, but the sweep
+// then meets every crossing at an exact input vertex instead of a computed
+// intersection, which makes results for self-intersecting input agree with
+// upstream Clipper2 instead of depending on the order crossings are found.
+func nodeSelfIntersections(paths Paths64) Paths64 {
+	var segs [][2]Point64
+	for _, path := range paths {
+		for i, a := range path {
+			segs = append(segs, [2]Point64{a, path[(i+1)%len(path)]})
+		}
+	}
+	crossings := segmentCrossings(segs)
+
+	result := make(Paths64, len(paths))
+	k := 0
+	for i, path := range paths {
+		out := make(Path64, 0, len(path))
+		for range path {
+			a, b := segs[k][0], segs[k][1]
+			pts := crossings[k]
+			k++
+			out = append(out, a)
+			if len(pts) == 0 {
+				continue
+			}
+			dir := b.Sub(a)
+			sort.Slice(pts, func(i, j int) bool {
+				return pts[i].Sub(a).Dot128(dir).Cmp(pts[j].Sub(a).Dot128(dir)) < 0
+			})
+			for _, pt := range pts {
+				if pt != a && pt != b && pt != out[len(out)-1] {
+					out = append(out, pt)
+				}
+			}
+		}
+		result[i] = out
+	}
+	return result
+}
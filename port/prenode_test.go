@@ -0,0 +1,53 @@
+package clipper
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNodeSelfIntersections(t *testing.T) {
+	bowtie := Paths64{{{0, 0}, {10, 10}, {10, 0}, {0, 10}}}
+	expected := Paths64{{{0, 0}, {5, 5}, {10, 10}, {10, 0}, {5, 5}, {0, 10}}}
+	if got := nodeSelfIntersections(bowtie); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	// crossings between rings of the same set are noded too
+	rings := Paths64{{{0, 0}, {10, 0}, {10, 10}, {0, 10}}, {{5, -5}, {15, 5}, {5, 15}}}
+	noded := nodeSelfIntersections(rings)
+	if len(noded[0]) != 6 || len(noded[1]) != 5 {
+		t.Errorf("expected 2 crossings inserted into each ring, got %v", noded)
+	}
+
+	square := Paths64{{{0, 0}, {10, 0}, {10, 10}, {0, 10}}}
+	if got := nodeSelfIntersections(square); !reflect.DeepEqual(got, square) {
+		t.Errorf("expected a simple ring unchanged, got %v", got)
+	}
+}
+
+func TestBooleanOp64PreNodeSelfIntersections(t *testing.T) {
+	// a pentagram: the center is wound twice, the points once
+	star := Paths64{starPolygon(Point64{0, 0}, 1000, 400, 5)}
+	pentagram := Paths64{{star[0][0], star[0][4], star[0][8], star[0][2], star[0][6]}}
+	options := ClipperOptions{PreNodeSelfIntersections: true}
+
+	for _, fillRule := range []FillRule{EvenOdd, NonZero} {
+		plain, _, err := BooleanOp64(Union, fillRule, pentagram, nil, nil)
+		if err != nil {
+			t.Fatalf("%v: %v", fillRule, err)
+		}
+		noded, _, err := BooleanOp64(Union, fillRule, pentagram, nil, nil, options)
+		if err != nil {
+			t.Fatalf("%v: %v", fillRule, err)
+		}
+		if a, b := Stats64(plain).NetArea, Stats64(noded).NetArea; a == 0 || b < a*0.999 || b > a*1.001 {
+			t.Errorf("%v: expected matching areas, got %v and %v", fillRule, a, b)
+		}
+	}
+
+	evenOdd, _, _ := BooleanOp64(Union, EvenOdd, pentagram, nil, nil, options)
+	nonZero, _, _ := BooleanOp64(Union, NonZero, pentagram, nil, nil, options)
+	if Stats64(evenOdd).NetArea >= Stats64(nonZero).NetArea {
+		t.Errorf("expected EvenOdd to leave the center of the pentagram empty")
+	}
+}
@@ -0,0 +1,141 @@
+package clipper
+
+import "math"
+
+// ==============================================================================
+// Rect64 - Axis-Aligned Bounding Rectangles
+// ==============================================================================
+
+// Rect64 represents an axis-aligned rectangle with 64-bit integer coordinates
+type Rect64 struct {
+	Left, Top, Right, Bottom int64
+}
+
+// InvalidRect64 is an "inverted" rectangle that acts as the identity for Union.
+// Bounds of empty inputs are reported as InvalidRect64.
+var InvalidRect64 = Rect64{
+	Left:   math.MaxInt64,
+	Top:    math.MaxInt64,
+	Right:  math.MinInt64,
+	Bottom: math.MinInt64,
+}
+
+// IsValid returns false for inverted rectangles such as InvalidRect64
+func (r Rect64) IsValid() bool {
+	return r.Left <= r.Right && r.Top <= r.Bottom
+}
+
+// IsEmpty returns true if the rectangle encloses no area
+func (r Rect64) IsEmpty() bool {
+	return r.Right <= r.Left || r.Bottom <= r.Top
+}
+
+// Width returns the horizontal extent of the rectangle
+func (r Rect64) Width() int64 {
+	return r.Right - r.Left
+}
+
+// Height returns the vertical extent of the rectangle
+func (r Rect64) Height() int64 {
+	return r.Bottom - r.Top
+}
+
+// Contains returns true if pt lies inside or on the boundary of the rectangle
+func (r Rect64) Contains(pt Point64) bool {
+	return pt.X >= r.Left && pt.X <= r.Right && pt.Y >= r.Top && pt.Y <= r.Bottom
+}
+
+// ContainsRect returns true if other lies entirely within the rectangle
+func (r Rect64) ContainsRect(other Rect64) bool {
+	return other.Left >= r.Left && other.Right <= r.Right &&
+		other.Top >= r.Top && other.Bottom <= r.Bottom
+}
+
+// Intersects returns true if the two rectangles overlap or touch
+func (r Rect64) Intersects(other Rect64) bool {
+	return max64(r.Left, other.Left) <= min64(r.Right, other.Right) &&
+		max64(r.Top, other.Top) <= min64(r.Bottom, other.Bottom)
+}
+
+// Union returns the smallest rectangle containing both r and pt.
+// Starting from InvalidRect64, repeated calls build bounds incrementally.
+func (r Rect64) Union(pt Point64) Rect64 {
+	if !r.IsValid() {
+		return Rect64{Left: pt.X, Top: pt.Y, Right: pt.X, Bottom: pt.Y}
+	}
+	return Rect64{
+		Left:   min64(r.Left, pt.X),
+		Top:    min64(r.Top, pt.Y),
+		Right:  max64(r.Right, pt.X),
+		Bottom: max64(r.Bottom, pt.Y),
+	}
+}
+
+// UnionRect returns the smallest rectangle containing both r and other
+func (r Rect64) UnionRect(other Rect64) Rect64 {
+	switch {
+	case !other.IsValid():
+		return r
+	case !r.IsValid():
+		return other
+	}
+	return Rect64{
+		Left:   min64(r.Left, other.Left),
+		Top:    min64(r.Top, other.Top),
+		Right:  max64(r.Right, other.Right),
+		Bottom: max64(r.Bottom, other.Bottom),
+	}
+}
+
+// AsPath returns the rectangle as a 4-point path suitable for RectClip64
+func (r Rect64) AsPath() Path64 {
+	return Path64{
+		{r.Left, r.Top},
+		{r.Right, r.Top},
+		{r.Right, r.Bottom},
+		{r.Left, r.Bottom},
+	}
+}
+
+// Bounds64 returns the bounding rectangle of a path (InvalidRect64 if empty)
+func Bounds64(path Path64) Rect64 {
+	bounds := InvalidRect64
+	for _, pt := range path {
+		bounds = bounds.Union(pt)
+	}
+	return bounds
+}
+
+// BoundsPaths64 returns the bounding rectangle of all paths (InvalidRect64 if empty)
+func BoundsPaths64(paths Paths64) Rect64 {
+	bounds := InvalidRect64
+	for _, path := range paths {
+		for _, pt := range path {
+			bounds = bounds.Union(pt)
+		}
+	}
+	return bounds
+}
+
+// BoundsEach64 returns the bounding rectangle of every path, in input order.
+// Empty paths get InvalidRect64.
+func BoundsEach64(paths Paths64) []Rect64 {
+	result := make([]Rect64, len(paths))
+	for i, path := range paths {
+		result[i] = Bounds64(path)
+	}
+	return result
+}
+
+// FilterByRect returns the paths whose bounds intersect or touch rect, so
+// paths that cannot affect a clip against rect are culled before building the
+// job. The returned paths share memory with the input; use Clone to detach.
+func FilterByRect(paths Paths64, rect Rect64) Paths64 {
+	result := make(Paths64, 0, len(paths))
+	for _, path := range paths {
+		if bounds := Bounds64(path); bounds.IsValid() && bounds.Intersects(rect) {
+			result = append(result, path)
+		}
+	}
+	return result
+}
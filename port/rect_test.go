@@ -0,0 +1,90 @@
+package clipper
+
+import "testing"
+
+// TestRect64Union tests incremental bounds construction
+func TestRect64Union(t *testing.T) {
+	r := InvalidRect64
+	if r.IsValid() {
+		t.Fatal("InvalidRect64 should not be valid")
+	}
+
+	r = r.Union(Point64{5, 5})
+	if r != (Rect64{5, 5, 5, 5}) {
+		t.Errorf("Union with first point = %v, expected {5 5 5 5}", r)
+	}
+	if !r.IsEmpty() {
+		t.Error("Single-point rectangle should be empty")
+	}
+
+	r = r.Union(Point64{-3, 10}).Union(Point64{8, 0})
+	expected := Rect64{Left: -3, Top: 0, Right: 8, Bottom: 10}
+	if r != expected {
+		t.Errorf("Union = %v, expected %v", r, expected)
+	}
+	if r.Width() != 11 || r.Height() != 10 {
+		t.Errorf("Width/Height = %d/%d, expected 11/10", r.Width(), r.Height())
+	}
+}
+
+// TestRect64UnionRect tests merging of rectangles
+func TestRect64UnionRect(t *testing.T) {
+	a := Rect64{0, 0, 10, 10}
+	b := Rect64{5, -5, 20, 5}
+
+	if got := a.UnionRect(b); got != (Rect64{0, -5, 20, 10}) {
+		t.Errorf("UnionRect = %v, expected {0 -5 20 10}", got)
+	}
+	if got := a.UnionRect(InvalidRect64); got != a {
+		t.Errorf("UnionRect with invalid = %v, expected %v", got, a)
+	}
+	if got := InvalidRect64.UnionRect(a); got != a {
+		t.Errorf("Invalid UnionRect = %v, expected %v", got, a)
+	}
+	if !a.Intersects(b) || a.ContainsRect(b) {
+		t.Error("Expected rectangles to intersect without containment")
+	}
+}
+
+// TestBoundsPaths64 tests bounds of paths
+func TestBoundsPaths64(t *testing.T) {
+	paths := Paths64{
+		{{0, 0}, {10, 0}, {10, 10}},
+		{{-5, 20}, {3, 7}},
+	}
+	if got := BoundsPaths64(paths); got != (Rect64{-5, 0, 10, 20}) {
+		t.Errorf("BoundsPaths64 = %v, expected {-5 0 10 20}", got)
+	}
+	if got := Bounds64(nil); got != InvalidRect64 {
+		t.Errorf("Bounds64(nil) = %v, expected InvalidRect64", got)
+	}
+}
+
+// TestBoundsEach64 tests per-path bounds and rectangle culling
+func TestBoundsEach64(t *testing.T) {
+	paths := Paths64{
+		{{0, 0}, {10, 0}, {10, 10}},
+		nil,
+		{{50, 50}, {60, 70}},
+		{{10, 10}, {20, 10}, {20, 20}}, // touches the window corner
+	}
+
+	bounds := BoundsEach64(paths)
+	expected := []Rect64{{0, 0, 10, 10}, InvalidRect64, {50, 50, 60, 70}, {10, 10, 20, 20}}
+	if len(bounds) != len(expected) {
+		t.Fatalf("Expected %d bounds, got %d", len(expected), len(bounds))
+	}
+	for i := range expected {
+		if bounds[i] != expected[i] {
+			t.Errorf("Bounds %d: expected %v, got %v", i, expected[i], bounds[i])
+		}
+	}
+
+	kept := FilterByRect(paths, Rect64{-5, -5, 10, 10})
+	if len(kept) != 2 || kept[0][0] != (Point64{0, 0}) || kept[1][0] != (Point64{10, 10}) {
+		t.Errorf("Expected first and last paths to be kept, got %v", kept)
+	}
+	if kept := FilterByRect(paths, Rect64{100, 100, 200, 200}); len(kept) != 0 {
+		t.Errorf("Expected no paths for distant window, got %v", kept)
+	}
+}
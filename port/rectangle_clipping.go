@@ -2,8 +2,13 @@
 
 package clipper
 
+import "math"
+
 // This file contains rectangle clipping implementation using Sutherland-Hodgman algorithm
 // Separated for better code organization
+//
+// Each ring is clipped independently and keeps its orientation, so holes stay
+// holes (opposite orientation to their outer polygon) in the output.
 
 // rectClipImpl pure Go implementation using Sutherland-Hodgman style clipping
 func rectClipImpl(rect Path64, paths Paths64) (Paths64, error) {
@@ -45,17 +50,73 @@ func rectClipImpl(rect Path64, paths Paths64) (Paths64, error) {
 		bottom: bottom,
 	}
 
-	result := make(Paths64, 0, len(paths))
-	for _, path := range paths {
-		if len(path) < 2 {
-			continue // Skip degenerate paths
+	// The result aliases paths until the first path that is not kept as is,
+	// so input lying wholly inside the rectangle is returned without copying
+	var result Paths64
+	aliased := true
+	window := Rect64{Left: left, Top: top, Right: right, Bottom: bottom}
+	// Rings that clip to the whole rectangle add a constant winding inside it.
+	// They are merged so an outer polygon cancels against a hole that surrounds
+	// the rectangle instead of reappearing as a filled ring; what is left is
+	// emitted as one ring per unit of winding, which every fill rule reads the
+	// same way as the rings it replaces.
+	coverWinding := 0
+	coverIndex := -1
+	for i, path := range paths {
+		bounds := Bounds64(path)
+		if window.ContainsRect(bounds) && clipper.isKeptAsIs(path) {
+			if !aliased {
+				result = append(result, path)
+			}
+			continue
+		}
+		if aliased {
+			result = append(make(Paths64, 0, len(paths)), paths[:i]...)
+			aliased = false
+		}
+		if len(path) < 2 || !window.Intersects(bounds) {
+			continue // Skip degenerate paths and paths outside the rectangle
 		}
 
 		clipped := clipper.clipPath(path)
 		cleaned := cleanPath(clipped)
-		if len(cleaned) >= 2 { // Need at least 2 points for a valid path
-			result = append(result, cleaned)
+		if len(cleaned) < 2 { // Need at least 2 points for a valid path
+			continue
+		}
+
+		if len(cleaned) >= 3 {
+			area := Area64(cleaned)
+			if area == 0 && !clipper.enclosesArea(cleaned) {
+				continue // Ring collapsed onto a line or the rectangle's boundary
+			}
+			if clipper.coversRect(area) {
+				if coverIndex < 0 {
+					coverIndex = len(result)
+					result = append(result, nil) // placeholder keeps input order
+				}
+				if area > 0 {
+					coverWinding++
+				} else {
+					coverWinding--
+				}
+				continue
+			}
+		}
+		result = append(result, cleaned)
+	}
+
+	if aliased && len(paths) > 0 {
+		return paths[:len(paths):len(paths)], nil
+	}
+	if result == nil {
+		result = Paths64{}
+	}
+	if coverIndex >= 0 {
+		covers := make(Paths64, 0, max(coverWinding, -coverWinding))
+		for range cap(covers) {
+			covers = append(covers, clipper.rectPath(coverWinding > 0))
 		}
+		result = append(result[:coverIndex], append(covers, result[coverIndex+1:]...)...)
 	}
 
 	return result, nil
@@ -66,6 +127,60 @@ type rectClipper struct {
 	left, top, right, bottom int64
 }
 
+// coversRect reports whether a clipped ring of the given signed area fills the
+// whole rectangle. Clipped rings lie within the rectangle, so only a ring
+// tracing its boundary can match its area.
+func (rc *rectClipper) coversRect(area float64) bool {
+	return math.Abs(area) == float64(rc.right-rc.left)*float64(rc.bottom-rc.top)
+}
+
+// isKeptAsIs reports whether a ring inside the rectangle passes through
+// clipping unchanged: it has no repeated points, encloses some area and does
+// not trace the rectangle itself, which would be merged with other covers
+func (rc *rectClipper) isKeptAsIs(path Path64) bool {
+	if len(path) < 3 {
+		return false
+	}
+	prev := path[len(path)-1]
+	for _, pt := range path {
+		if pt == prev {
+			return false
+		}
+		prev = pt
+	}
+	area := Area64(path)
+	return area != 0 && !rc.coversRect(area)
+}
+
+// enclosesArea reports whether a clipped ring of zero signed area still
+// encloses some region, like a figure-8 whose loops cancel out. It does unless
+// all its points are collinear or all its edges run along the rectangle's
+// sides, which is what Sutherland-Hodgman leaves of a ring clipped away.
+func (rc *rectClipper) enclosesArea(path Path64) bool {
+	collinear, onBoundary := true, true
+	prev := path[len(path)-1]
+	for _, pt := range path {
+		if !CrossProduct128(path[0], path[1], pt).IsZero() {
+			collinear = false
+		}
+		if !(pt.X == prev.X && (pt.X == rc.left || pt.X == rc.right)) &&
+			!(pt.Y == prev.Y && (pt.Y == rc.top || pt.Y == rc.bottom)) {
+			onBoundary = false
+		}
+		prev = pt
+	}
+	return !collinear && !onBoundary
+}
+
+// rectPath returns the rectangle as a ring with positive or negative orientation
+func (rc *rectClipper) rectPath(positive bool) Path64 {
+	path := Rect64{Left: rc.left, Top: rc.top, Right: rc.right, Bottom: rc.bottom}.AsPath()
+	if (Area64(path) > 0) != positive {
+		return Reverse64(path)
+	}
+	return path
+}
+
 // location represents which side of the rectangle a point is on
 type location uint8
 
@@ -225,7 +340,7 @@ func (rc *rectClipper) intersectWithVerticalLine(p1, p2 Point64, lineX int64) (P
 	t := numerator.ToFloat64() / float64(denominator)
 	y := float64(p1.Y) + t
 
-	return Point64{X: lineX, Y: int64(y + 0.5)}, true // Round to nearest integer
+	return Point64{X: lineX, Y: RoundHalfAway(y)}, true // Round to nearest integer
 }
 
 // intersectWithHorizontalLine finds intersection with horizontal line y = lineY
@@ -247,7 +362,7 @@ func (rc *rectClipper) intersectWithHorizontalLine(p1, p2 Point64, lineY int64)
 	t := numerator.ToFloat64() / float64(denominator)
 	x := float64(p1.X) + t
 
-	return Point64{X: int64(x + 0.5), Y: lineY}, true // Round to nearest integer
+	return Point64{X: RoundHalfAway(x), Y: lineY}, true // Round to nearest integer
 }
 
 // cleanPath removes duplicate consecutive points and returns a clean path
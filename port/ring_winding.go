@@ -0,0 +1,239 @@
+package clipper
+
+import (
+	"math"
+	"sort"
+)
+
+// ==============================================================================
+// Winding Counts of Output Rings
+// ==============================================================================
+
+// RingWinding holds the subject and clip winding numbers of the region just
+// inside an output ring. Counter-clockwise input paths wind positively.
+type RingWinding struct {
+	Subject int // winding number of the subject paths
+	Clip    int // winding number of the clip paths
+}
+
+// RingWindings64 reports, for every ring of a solution, the winding numbers
+// of the input region the ring encloses, sampled just inside one of its edges.
+// After a NonZero union this distinguishes areas covered once from areas
+// where several inputs overlapped. Rings enclosing no area report zeros.
+func RingWindings64(solution, subjects, clips Paths64) []RingWinding {
+	var ys []int64
+	for _, group := range []Paths64{solution, subjects, clips} {
+		for _, path := range group {
+			for _, pt := range path {
+				ys = append(ys, pt.Y)
+			}
+		}
+	}
+	sort.Slice(ys, func(i, j int) bool { return ys[i] < ys[j] })
+
+	result := make([]RingWinding, len(solution))
+	for i, ring := range solution {
+		sample, ok := ringInteriorSample(ring, ys, solution, subjects, clips)
+		if !ok {
+			continue
+		}
+		result[i] = RingWinding{
+			Subject: windingNumberD(sample, subjects),
+			Clip:    windingNumberD(sample, clips),
+		}
+	}
+	return result
+}
+
+// ringInteriorSample returns a point just inside ring that lies on no input
+// or output edge. The sample sits on a horizontal line strictly between two
+// consecutive vertex Ys, halfway from a ring edge to the nearest other edge
+// crossing that line on the ring's interior side.
+func ringInteriorSample(ring Path64, ys []int64, groups ...Paths64) (PointD, bool) {
+	area := Area64(ring)
+	if area == 0 {
+		return PointD{}, false
+	}
+
+	for i, p := range ring {
+		q := ring[(i+1)%len(ring)]
+		if p.Y == q.Y {
+			continue
+		}
+		// Smallest vertex Y above the edge's bottom (at most the edge's top)
+		lo := min64(p.Y, q.Y)
+		k := sort.Search(len(ys), func(j int) bool { return ys[j] > lo })
+		y := (float64(lo) + float64(ys[k])) / 2
+
+		x := edgeXAtD(p, q, y)
+		// Counter-clockwise rings have their interior to the left of each edge
+		upward := q.Y > p.Y
+		towardsMinusX := upward == (area > 0)
+
+		nearest := math.Inf(1)
+		for _, group := range groups {
+			for _, path := range group {
+				for j, a := range path {
+					b := path[(j+1)%len(path)]
+					if (float64(a.Y) < y) == (float64(b.Y) < y) {
+						continue
+					}
+					d := edgeXAtD(a, b, y) - x
+					if towardsMinusX {
+						d = -d
+					}
+					if d > 0 && d < nearest {
+						nearest = d
+					}
+				}
+			}
+		}
+		if math.IsInf(nearest, 1) {
+			continue
+		}
+		if towardsMinusX {
+			nearest = -nearest
+		}
+		return PointD{X: x + nearest/2, Y: y}, true
+	}
+	return PointD{}, false
+}
+
+// windingNumberD returns the winding number of paths around a point that lies
+// on none of their edges or vertex scanlines
+func windingNumberD(pt PointD, paths Paths64) int {
+	wind := 0
+	for _, path := range paths {
+		for i, a := range path {
+			b := path[(i+1)%len(path)]
+			if (float64(a.Y) < pt.Y) == (float64(b.Y) < pt.Y) {
+				continue
+			}
+			if edgeXAtD(a, b, pt.Y) > pt.X {
+				if b.Y > a.Y {
+					wind++
+				} else {
+					wind--
+				}
+			}
+		}
+	}
+	return wind
+}
+
+// edgeXAtD returns the X coordinate where segment a-b crosses the line at y
+func edgeXAtD(a, b Point64, y float64) float64 {
+	t := (y - float64(a.Y)) / (float64(b.Y) - float64(a.Y))
+	return float64(a.X) + t*(float64(b.X)-float64(a.X))
+}
+
+// ==============================================================================
+// Winding of Input Rings
+// ==============================================================================
+
+// RingRole is the part an input ring plays in the region a fill rule fills
+type RingRole uint8
+
+const (
+	RingIgnored RingRole = iota // the fill does not change across the ring, so it adds no boundary
+	RingOuter                   // filled just inside the ring, not just outside
+	RingHole                    // filled just outside the ring, not just inside
+)
+
+// RingFill describes how an input ring bounds the region its path set fills
+type RingFill struct {
+	Orientation int      // +1 for a counter-clockwise ring, -1 for clockwise, 0 for no area
+	Inside      int      // winding number of all paths just inside the ring
+	Outside     int      // winding number just outside, across the same edge
+	Role        RingRole // role under the fill rule passed to AnalyzeWinding64
+}
+
+// RoleUnder returns the role of the ring under any fill rule
+func (f RingFill) RoleUnder(fillRule FillRule) RingRole {
+	in, out := isFilledWinding(f.Inside, fillRule), isFilledWinding(f.Outside, fillRule)
+	switch {
+	case f.Orientation == 0 || in == out:
+		return RingIgnored
+	case in:
+		return RingOuter
+	}
+	return RingHole
+}
+
+// AnalyzeWinding64 reports for every ring of paths its orientation, the
+// winding numbers on both sides and whether it acts as an outer, a hole or
+// not at all under fillRule (RoleUnder gives the other rules). A polygon
+// that comes out empty under Positive typically shows its outer as
+// clockwise and ignored; FixForFillRule repairs that. Windings are sampled
+// across one edge of each ring, so rings crossing others report the
+// neighbourhood of that edge.
+func AnalyzeWinding64(paths Paths64, fillRule FillRule) []RingFill {
+	var ys []int64
+	for _, path := range paths {
+		for _, pt := range path {
+			ys = append(ys, pt.Y)
+		}
+	}
+	sort.Slice(ys, func(i, j int) bool { return ys[i] < ys[j] })
+
+	result := make([]RingFill, len(paths))
+	for i, ring := range paths {
+		sample, ok := ringInteriorSample(ring, ys, paths)
+		if !ok {
+			continue
+		}
+		f := RingFill{Orientation: 1, Inside: windingNumberD(sample, paths)}
+		if Area64(ring) < 0 {
+			f.Orientation = -1
+		}
+		f.Outside = f.Inside - f.Orientation
+		f.Role = f.RoleUnder(fillRule)
+		result[i] = f
+	}
+	return result
+}
+
+// FixForFillRule reorients rings by nesting depth so fillRule fills the
+// region EvenOdd would: rings inside an even number of others become outers
+// and the rest holes. Outers wind counter-clockwise, except under Negative,
+// where all orientations are reversed.
+func FixForFillRule(paths Paths64, fillRule FillRule) Paths64 {
+	oriented := orientByContainment(paths)
+	if fillRule != Negative {
+		return oriented
+	}
+	result := make(Paths64, len(oriented))
+	for i, path := range oriented {
+		result[i] = Reverse64(path)
+	}
+	return result
+}
+
+// MakePositive64 returns a copy of paths with rings inside an even number of
+// others wound counter-clockwise and the rest clockwise, the orientation
+// boolean operations produce. Positive and NonZero then fill the region
+// EvenOdd fills. Rings enclosing no area are copied unchanged.
+func MakePositive64(paths Paths64) Paths64 {
+	return orientByContainment(paths).Clone()
+}
+
+// NormalizeOrientation64 returns the rings of paths that bound the region
+// fillRule fills, wound counter-clockwise around filled areas and clockwise
+// around holes, so every fill rule except Negative fills that same region.
+// Rings the fill rule ignores, such as a ring nested in another of the same
+// orientation under NonZero, or rings enclosing no area, are dropped. Roles
+// are judged as in AnalyzeWinding64, so rings are expected to nest rather
+// than cross.
+func NormalizeOrientation64(paths Paths64, fillRule FillRule) Paths64 {
+	result := make(Paths64, 0, len(paths))
+	for i, f := range AnalyzeWinding64(paths, fillRule) {
+		switch {
+		case f.Role == RingIgnored:
+		case (f.Role == RingOuter) == (f.Orientation > 0):
+			result = append(result, paths[i].Clone())
+		default:
+			result = append(result, Reverse64(paths[i]))
+		}
+	}
+	return result
+}
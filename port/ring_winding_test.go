@@ -0,0 +1,136 @@
+package clipper
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestRingWindings64 tests winding counts reported for output rings
+func TestRingWindings64(t *testing.T) {
+	a := Path64{{0, 0}, {10, 0}, {10, 10}, {0, 10}}
+	b := Path64{{5, 5}, {15, 5}, {15, 15}, {5, 15}}
+	overlap := Path64{{5, 5}, {10, 5}, {10, 10}, {5, 10}}
+	far := Path64{{100, 100}, {110, 100}, {110, 110}, {100, 110}}
+
+	tests := []struct {
+		name     string
+		solution Paths64
+		subjects Paths64
+		clips    Paths64
+		expected []RingWinding
+	}{
+		{"Overlap of two subjects", Paths64{overlap}, Paths64{a, b}, nil, []RingWinding{{2, 0}}},
+		{"Single coverage", Paths64{a}, Paths64{a}, Paths64{far}, []RingWinding{{1, 0}}},
+		{"Subject and clip", Paths64{overlap}, Paths64{a}, Paths64{b}, []RingWinding{{1, 1}}},
+		{"Clockwise clip", Paths64{Reverse64(overlap)}, Paths64{a}, Paths64{Reverse64(b)}, []RingWinding{{1, -1}}},
+		{"Hole ring", Annulus64(Point64{}, 100, 50, 4), Annulus64(Point64{}, 100, 50, 4), nil, []RingWinding{{1, 0}, {0, 0}}},
+		{"Degenerate ring", Paths64{{{0, 0}, {5, 0}, {10, 0}}}, Paths64{a}, nil, []RingWinding{{0, 0}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := RingWindings64(test.solution, test.subjects, test.clips)
+			if len(got) != len(test.expected) {
+				t.Fatalf("Expected %d windings, got %d", len(test.expected), len(got))
+			}
+			for i := range got {
+				if got[i] != test.expected[i] {
+					t.Errorf("Ring %d: expected %+v, got %+v", i, test.expected[i], got[i])
+				}
+			}
+		})
+	}
+}
+
+// TestAnalyzeWinding64 tests ring roles before and after FixForFillRule
+func TestAnalyzeWinding64(t *testing.T) {
+	// A clockwise outer with a counter-clockwise hole: filled under NonZero
+	// and EvenOdd, empty under Positive
+	outer := Reverse64(Path64{{0, 0}, {100, 0}, {100, 100}, {0, 100}})
+	hole := Path64{{20, 20}, {80, 20}, {80, 80}, {20, 80}}
+	paths := Paths64{outer, hole}
+
+	got := AnalyzeWinding64(paths, Positive)
+	expected := []RingFill{
+		{Orientation: -1, Inside: -1, Outside: 0, Role: RingIgnored},
+		{Orientation: 1, Inside: 0, Outside: -1, Role: RingIgnored},
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("ring %d: expected %+v, got %+v", i, expected[i], got[i])
+		}
+	}
+	for _, fillRule := range []FillRule{EvenOdd, NonZero, Negative} {
+		if got[0].RoleUnder(fillRule) != RingOuter || got[1].RoleUnder(fillRule) != RingHole {
+			t.Errorf("%v: expected outer and hole, got %v and %v", fillRule, got[0].RoleUnder(fillRule), got[1].RoleUnder(fillRule))
+		}
+	}
+
+	for _, fillRule := range []FillRule{EvenOdd, NonZero, Positive, Negative} {
+		fixed := AnalyzeWinding64(FixForFillRule(paths, fillRule), fillRule)
+		if fixed[0].Role != RingOuter || fixed[1].Role != RingHole {
+			t.Errorf("%v: expected outer and hole after fixing, got %+v", fillRule, fixed)
+		}
+	}
+
+	degenerate := AnalyzeWinding64(Paths64{{{0, 0}, {10, 0}, {20, 0}}}, NonZero)
+	if degenerate[0] != (RingFill{}) {
+		t.Errorf("expected a zero-area ring to be ignored, got %+v", degenerate[0])
+	}
+}
+
+func TestMakePositive64(t *testing.T) {
+	outer := Path64{{0, 0}, {0, 100}, {100, 100}, {100, 0}}
+	hole := Path64{{20, 20}, {80, 20}, {80, 80}, {20, 80}}
+	island := Path64{{40, 40}, {60, 40}, {60, 60}, {40, 60}}
+	paths := Paths64{hole, island, outer}
+
+	got := MakePositive64(paths)
+	expected := Paths64{Reverse64(hole), island, Reverse64(outer)}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	got[1][0] = Point64{-1, -1}
+	if paths[1][0] != (Point64{40, 40}) {
+		t.Error("expected MakePositive64 to copy rings it keeps")
+	}
+}
+
+func TestNormalizeOrientation64(t *testing.T) {
+	square := func(lo, hi int64) Path64 { return Path64{{lo, lo}, {hi, lo}, {hi, hi}, {lo, hi}} }
+	// Under NonZero the second ring only raises the winding to 2 and the
+	// third lowers it back to 1, so only the first and the last bound the
+	// filled region
+	paths := Paths64{square(0, 100), square(10, 90), Reverse64(square(20, 80)), Reverse64(square(30, 70))}
+
+	tests := []struct {
+		name     string
+		fillRule FillRule
+		expected Paths64
+		area     float64
+	}{
+		{"NonZero", NonZero, Paths64{square(0, 100), Reverse64(square(30, 70))}, 8400},
+		{"EvenOdd", EvenOdd, Paths64{square(0, 100), Reverse64(square(10, 90)), square(20, 80), Reverse64(square(30, 70))}, 5600},
+		{"Positive", Positive, Paths64{square(0, 100), Reverse64(square(30, 70))}, 8400},
+		{"Negative", Negative, Paths64{}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NormalizeOrientation64(paths, tt.fillRule)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+
+			for _, fillRule := range []FillRule{EvenOdd, NonZero, Positive} {
+				filled, err := Union64(got, nil, fillRule)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if AreaPaths64(filled) != tt.area {
+					t.Errorf("fill rule %d: expected area %v, got %v", fillRule, tt.area, AreaPaths64(filled))
+				}
+			}
+		})
+	}
+}
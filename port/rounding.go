@@ -0,0 +1,98 @@
+package clipper
+
+import (
+	"math"
+	"math/big"
+)
+
+// ==============================================================================
+// Rounding Strategies for Intersection Points
+// ==============================================================================
+
+// RoundingStrategy maps a point computed in floating point, such as the
+// crossing of two edges, onto the integer output grid. Implementations must
+// be monotone in each coordinate (a smaller x never rounds to a larger X),
+// which keeps the sweep's edge order consistent, and must map integers on
+// their grid to themselves.
+type RoundingStrategy interface {
+	Round(x, y float64) Point64
+}
+
+// NearestRounding rounds to the nearest integer, halves away from zero. It
+// is the engine's default.
+type NearestRounding struct{}
+
+// Round implements RoundingStrategy
+func (NearestRounding) Round(x, y float64) Point64 {
+	return Point64{X: RoundHalfAway(x), Y: RoundHalfAway(y)}
+}
+
+// FloorRounding rounds towards negative infinity
+type FloorRounding struct{}
+
+// Round implements RoundingStrategy
+func (FloorRounding) Round(x, y float64) Point64 {
+	return Point64{X: int64(math.Floor(x)), Y: int64(math.Floor(y))}
+}
+
+// GridRounding snaps to the nearest multiple of Spacing, for pipelines with
+// a fixed grid such as map tiles. Every input vertex must already lie on the
+// grid for the whole output to do so. Snapping moves intersection points by
+// up to half a cell, so output edges may deviate that far from the input.
+type GridRounding struct {
+	Spacing int64 // grid cell size, at least 1
+}
+
+// Round implements RoundingStrategy
+func (g GridRounding) Round(x, y float64) Point64 {
+	s := float64(g.Spacing)
+	return Point64{X: RoundHalfAway(x/s) * g.Spacing, Y: RoundHalfAway(y/s) * g.Spacing}
+}
+
+// intersectPt returns the crossing of the lines through two segments like
+// getSegmentIntersectPt, rounded with the engine's strategy
+func (ve *VattiEngine) intersectPt(ln1a, ln1b, ln2a, ln2b Point64) (Point64, bool) {
+	if ve.rounding == nil {
+		return getSegmentIntersectPt(ln1a, ln1b, ln2a, ln2b)
+	}
+	dx1 := float64(ln1b.X - ln1a.X)
+	dy1 := float64(ln1b.Y - ln1a.Y)
+	dx2 := float64(ln2b.X - ln2a.X)
+	dy2 := float64(ln2b.Y - ln2a.Y)
+
+	det := dy1*dx2 - dy2*dx1
+	if crossingNeedsExact(ln1a, ln2a, dx1, dy1, dx2, dy2, det) {
+		t, x, y, ok := exactCrossing(ln1a, ln1b, ln2a, ln2b)
+		switch {
+		case !ok:
+			return Point64{}, false
+		case t.Sign() <= 0:
+			return ln1a, true
+		case t.Cmp(big.NewRat(1, 1)) >= 0:
+			return ln1b, true
+		}
+		xf, _ := x.Float64()
+		yf, _ := y.Float64()
+		return ve.rounding.Round(xf, yf), true
+	}
+	if det == 0 {
+		return Point64{}, false
+	}
+	t := (float64(ln1a.X-ln2a.X)*dy2 - float64(ln1a.Y-ln2a.Y)*dx2) / det
+	switch {
+	case t <= 0:
+		return ln1a, true
+	case t >= 1:
+		return ln1b, true
+	}
+	return ve.rounding.Round(float64(ln1a.X)+t*dx1, float64(ln1a.Y)+t*dy1), true
+}
+
+// currX returns the X coordinate of an edge at scanline y like topX,
+// rounded with the engine's strategy
+func (ve *VattiEngine) currX(e *Edge, y int64) int64 {
+	if ve.rounding == nil || y == e.Top.Y || y == e.Bot.Y || e.Top.X == e.Bot.X {
+		return topX(e, y)
+	}
+	return ve.rounding.Round(float64(e.Bot.X)+e.Dx*float64(y-e.Bot.Y), float64(y)).X
+}
@@ -0,0 +1,92 @@
+package clipper
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestRoundingStrategies(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy RoundingStrategy
+		x, y     float64
+		expected Point64
+	}{
+		{"nearest", NearestRounding{}, 5.5, -1.5, Point64{6, -2}},
+		{"floor", FloorRounding{}, 5.5, -1.5, Point64{5, -2}},
+		{"grid", GridRounding{Spacing: 10}, 14.9, -15, Point64{10, -20}},
+		{"grid keeps grid points", GridRounding{Spacing: 10}, 30, 40, Point64{30, 40}},
+	}
+	for _, tt := range tests {
+		if got := tt.strategy.Round(tt.x, tt.y); got != tt.expected {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, got)
+		}
+	}
+}
+
+func TestEngineIntersectPtRounding(t *testing.T) {
+	// the diagonals of a 10x3 box cross at (5, 1.5)
+	a, b, c, d := Point64{0, 0}, Point64{10, 3}, Point64{0, 3}, Point64{10, 0}
+	for _, tt := range []struct {
+		strategy RoundingStrategy
+		expected Point64
+	}{
+		{nil, Point64{5, 2}},
+		{NearestRounding{}, Point64{5, 2}},
+		{FloorRounding{}, Point64{5, 1}},
+		{GridRounding{Spacing: 5}, Point64{5, 0}},
+	} {
+		ve := NewVattiEngine(Union, NonZero)
+		ve.rounding = tt.strategy
+		if got, ok := ve.intersectPt(a, b, c, d); !ok || got != tt.expected {
+			t.Errorf("%T: expected %v, got %v", tt.strategy, tt.expected, got)
+		}
+	}
+}
+
+func TestGridRoundingOutput(t *testing.T) {
+	const spacing = 10
+	r := rand.New(rand.NewSource(5))
+	onGrid := func(n int) Path64 {
+		path := randomPath(r, n, 100)
+		for i := range path {
+			path[i] = Point64{X: path[i].X * spacing, Y: path[i].Y * spacing}
+		}
+		return path
+	}
+
+	for trial := 0; trial < 30; trial++ {
+		subject, clip := Paths64{onGrid(6)}, Paths64{onGrid(6)}
+		for _, ct := range []ClipType{Intersection, Union, Difference, Xor} {
+			solution, _, err := BooleanOp64(ct, NonZero, subject, nil, clip, ClipperOptions{Rounding: GridRounding{Spacing: spacing}})
+			if err != nil {
+				t.Fatalf("trial %d, %v: unexpected error: %v", trial, ct, err)
+			}
+			for _, ring := range solution {
+				for _, pt := range ring {
+					if pt.X%spacing != 0 || pt.Y%spacing != 0 {
+						t.Fatalf("trial %d, %v: vertex %v is off the grid", trial, ct, pt)
+					}
+				}
+			}
+
+			// snapping moves edges by at most half a cell
+			exact, _, _ := BooleanOp64(ct, NonZero, subject, nil, clip)
+			var perimeter float64
+			for _, ring := range exact {
+				for i, pt := range ring {
+					perimeter += pt.DistanceTo(ring[(i+1)%len(ring)])
+				}
+			}
+			if diff := math.Abs(Stats64(solution).NetArea - Stats64(exact).NetArea); diff > perimeter*spacing {
+				t.Errorf("trial %d, %v: area differs by %v", trial, ct, diff)
+			}
+		}
+	}
+
+	if _, _, err := BooleanOp64(Union, NonZero, Paths64{onGrid(4)}, nil, nil, ClipperOptions{Rounding: GridRounding{}}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for a zero grid spacing, got %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package clipper
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSampleInterior64(t *testing.T) {
+	square := Path64{{0, 0}, {100, 0}, {100, 100}, {0, 100}}
+	hole := Path64{{30, 30}, {30, 70}, {70, 70}, {70, 30}}
+	overlap := Path64{{50, 0}, {150, 0}, {150, 100}, {50, 100}}
+
+	tests := []struct {
+		name     string
+		paths    Paths64
+		fillRule FillRule
+		spacing  int64
+		expected int
+	}{
+		{"square", Paths64{square}, NonZero, 10, 81},
+		{"square with hole", Paths64{square, hole}, NonZero, 10, 81 - 25},
+		{"triangle", Paths64{{{0, 0}, {100, 0}, {0, 100}}}, NonZero, 10, 36},
+		{"clockwise square", Paths64{Reverse64(square)}, NonZero, 10, 81},
+		{"clockwise square, positive", Paths64{Reverse64(square)}, Positive, 10, 0},
+		{"overlap nonzero", Paths64{square, overlap}, NonZero, 10, 14 * 9},
+		{"overlap evenodd", Paths64{square, overlap}, EvenOdd, 10, 4*9 + 4*9},
+		{"negative coordinates", Paths64{{{-25, -25}, {25, -25}, {25, 25}, {-25, 25}}}, NonZero, 10, 25},
+		{"empty", nil, NonZero, 10, 0},
+	}
+	for _, tt := range tests {
+		points, err := SampleInterior64(tt.paths, tt.fillRule, tt.spacing)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		if len(points) != tt.expected {
+			t.Errorf("%s: expected %d points, got %d: %v", tt.name, tt.expected, len(points), points)
+		}
+		for _, pt := range points {
+			if pt.X%tt.spacing != 0 || pt.Y%tt.spacing != 0 {
+				t.Errorf("%s: point %v is off the grid", tt.name, pt)
+			}
+		}
+	}
+
+	if _, err := SampleInterior64(Paths64{square}, NonZero, 0); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for zero spacing, got %v", err)
+	}
+}
+
+func TestSampleInterior64Order(t *testing.T) {
+	points, err := SampleInterior64(Paths64{{{0, 0}, {30, 0}, {30, 30}, {0, 30}}}, NonZero, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []Point64{{10, 10}, {20, 10}, {10, 20}, {20, 20}}
+	if !identicalPath(points, expected) {
+		t.Errorf("expected %v, got %v", expected, points)
+	}
+}
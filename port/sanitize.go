@@ -0,0 +1,287 @@
+package clipper
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ==============================================================================
+// Zero-Area Rings
+// ==============================================================================
+
+// isCollinearRing returns true if every vertex of a closed path lies on one
+// line (including rings whose vertices all coincide)
+func isCollinearRing(path Path64) bool {
+	origin := path[0]
+	var dir Point64
+	found := false
+	for _, pt := range path[1:] {
+		if !found {
+			if pt != origin {
+				dir, found = pt, true
+			}
+			continue
+		}
+		if !CrossProduct128(origin, dir, pt).IsZero() {
+			return false
+		}
+	}
+	return true
+}
+
+// applyZeroAreaPolicy removes collinear closed rings from subjects and clips
+// according to policy. ZeroAreaKeepOpen turns collinear subject rings into
+// open paths spanning the ring's extent; collinear clip rings are always
+// dropped since clip paths must be closed.
+func applyZeroAreaPolicy(policy ZeroAreaPolicy, subjects, subjectsOpen, clips Paths64) (Paths64, Paths64, Paths64, error) {
+	var err error
+	var converted Paths64
+	subjects, converted, err = filterZeroAreaRings(policy, "subject", subjects)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(converted) > 0 {
+		subjectsOpen = append(subjectsOpen[:len(subjectsOpen):len(subjectsOpen)], converted...)
+	}
+	clips, _, err = filterZeroAreaRings(policy, "clip", clips)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return subjects, subjectsOpen, clips, nil
+}
+
+// filterZeroAreaRings returns paths without their collinear rings plus, for
+// ZeroAreaKeepOpen, those rings as open segments. The input is only copied if
+// a ring has to be removed.
+func filterZeroAreaRings(policy ZeroAreaPolicy, role string, paths Paths64) (kept, open Paths64, err error) {
+	kept = paths
+	copied := false
+	for i, path := range paths {
+		if len(path) < 3 || !isCollinearRing(path) {
+			if copied {
+				kept = append(kept, path)
+			}
+			continue
+		}
+
+		switch policy {
+		case ZeroAreaError:
+			return nil, nil, fmt.Errorf("%w: %s ring %d has zero area (all vertices collinear)", ErrInvalidInput, role, i)
+		case ZeroAreaKeepOpen:
+			if segment := collinearExtent(path); segment != nil {
+				open = append(open, segment)
+			}
+		}
+		if !copied {
+			kept = append(make(Paths64, 0, len(paths)-1), paths[:i]...)
+			copied = true
+		}
+	}
+	return kept, open, nil
+}
+
+// collinearExtent returns the segment between the two extreme points of a
+// collinear ring, or nil if all of its points coincide
+func collinearExtent(path Path64) Path64 {
+	sorted := path.Clone()
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].X != sorted[j].X {
+			return sorted[i].X < sorted[j].X
+		}
+		return sorted[i].Y < sorted[j].Y
+	})
+	first, last := sorted[0], sorted[len(sorted)-1]
+	if first == last {
+		return nil
+	}
+	return Path64{first, last}
+}
+
+// ==============================================================================
+// Closing Vertices
+// ==============================================================================
+
+// applyRingClosure handles the closed rings of paths whose last point repeats
+// the first according to policy. The input is only copied if a ring has to
+// be shortened.
+func applyRingClosure(policy RingClosure, role string, paths Paths64) (Paths64, error) {
+	if policy == RingClosureImplicit {
+		return paths, nil
+	}
+	result := paths
+	copied := false
+	for i, path := range paths {
+		n := len(path)
+		for n > 1 && path[n-1] == path[0] {
+			n--
+		}
+		if n == len(path) {
+			continue
+		}
+		if policy == RingClosureStrict {
+			return nil, fmt.Errorf("%w: %s ring %d repeats its first point at the end", ErrInvalidInput, role, i)
+		}
+		if !copied {
+			result = append(make(Paths64, 0, len(paths)), paths...)
+			copied = true
+		}
+		result[i] = path[:n:n]
+	}
+	return result, nil
+}
+
+// ==============================================================================
+// Ring Cleaning
+// ==============================================================================
+
+// cleanRing returns a copy of a closed path without repeated points and
+// without vertices collinear with their neighbours. Rings reduced below three
+// vertices return nil.
+func cleanRing(path Path64) Path64 {
+	result := make(Path64, 0, len(path))
+	for _, pt := range path {
+		if len(result) > 0 && result[len(result)-1] == pt {
+			continue
+		}
+		result = append(result, pt)
+	}
+	for len(result) > 1 && result[0] == result[len(result)-1] {
+		result = result[:len(result)-1]
+	}
+
+	// Removing a vertex can make its neighbours collinear, so repeat until stable
+	for changed := true; changed && len(result) >= 3; {
+		changed = false
+		kept := result[:0]
+		n := len(result)
+		for i, pt := range result {
+			prev := result[(i+n-1)%n]
+			if len(kept) > 0 {
+				prev = kept[len(kept)-1]
+			}
+			next := result[(i+1)%n]
+			if CrossProduct128(prev, pt, next).IsZero() {
+				changed = true
+				continue
+			}
+			kept = append(kept, pt)
+		}
+		result = kept
+	}
+	if len(result) < 3 {
+		return nil
+	}
+	return result
+}
+
+// cleanRings applies cleanRing to every path and drops the empty results
+func cleanRings(paths Paths64) Paths64 {
+	result := make(Paths64, 0, len(paths))
+	for _, path := range paths {
+		if cleaned := cleanRing(path); cleaned != nil {
+			result = append(result, cleaned)
+		}
+	}
+	return result
+}
+
+// ==============================================================================
+// Input Sanitization
+// ==============================================================================
+
+// sanitizeRings prepares closed rings for the sweep unless skip is set: it
+// strips repeated points, removes spikes (vertices where the ring turns back
+// on itself) and splits rings that pass through the same point twice into
+// separate rings. None of this changes the winding number anywhere, so the
+// region every fill rule selects stays the same, but degenerate real-world
+// input no longer reaches the engine. The input is only copied if a ring
+// changes.
+func sanitizeRings(paths Paths64, skip bool) Paths64 {
+	if skip {
+		return paths
+	}
+	result := paths
+	copied := false
+	for i, path := range paths {
+		rings := sanitizeRing(path)
+		if len(rings) == 1 && len(rings[0]) == len(path) {
+			if copied {
+				result = append(result, path)
+			}
+			continue
+		}
+		if !copied {
+			result = append(make(Paths64, 0, len(paths)), paths[:i]...)
+			copied = true
+		}
+		result = append(result, rings...)
+	}
+	return result
+}
+
+// sanitizeRing returns the rings a closed path splits into once repeated
+// points and spikes are removed; rings with less than three vertices are
+// dropped. A ring needing no changes is returned as the only element.
+func sanitizeRing(path Path64) Paths64 {
+	ring := removeSpikes(path)
+	if len(ring) < 3 {
+		return nil
+	}
+
+	// Split at the first point visited twice; both loops may need it again
+	at := make(map[Point64]int, len(ring))
+	for j, pt := range ring {
+		i, seen := at[pt]
+		if !seen {
+			at[pt] = j
+			continue
+		}
+		loop := ring[i:j:j]
+		rest := append(ring[:i:i], ring[j:]...)
+		return append(sanitizeRing(loop), sanitizeRing(rest)...)
+	}
+	return Paths64{ring}
+}
+
+// removeSpikes returns path without repeated points and without vertices
+// where the path reverses along a line. Points collinear with their
+// neighbours but continuing in the same direction are kept. path is returned
+// unchanged if it has neither.
+func removeSpikes(path Path64) Path64 {
+	isSpike := func(prev, pt, next Point64) bool {
+		return prev == pt || (CrossProduct128(prev, pt, next).IsZero() && pt.Sub(prev).Dot128(next.Sub(pt)).IsNegative())
+	}
+	clean := true
+	for i, pt := range path {
+		n := len(path)
+		if isSpike(path[(i+n-1)%n], pt, path[(i+1)%n]) {
+			clean = false
+			break
+		}
+	}
+	if clean {
+		return path
+	}
+
+	// Removing a spike can expose another at its neighbour, so repeat until
+	// stable
+	result := path.Clone()
+	for changed := true; changed && len(result) >= 3; {
+		changed = false
+		kept := result[:0]
+		n := len(result)
+		for i, pt := range result {
+			prev := result[(i+n-1)%n]
+			if len(kept) > 0 {
+				prev = kept[len(kept)-1]
+			}
+			if isSpike(prev, pt, result[(i+1)%n]) {
+				changed = true
+				continue
+			}
+			kept = append(kept, pt)
+		}
+		result = kept
+	}
+	return result
+}
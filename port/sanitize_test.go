@@ -0,0 +1,220 @@
+package clipper
+
+import (
+	"errors"
+	"testing"
+)
+
+// collinear rings used by the zero-area regression tests
+var (
+	diagonalRing   = Path64{{0, 0}, {5, 5}, {10, 10}, {5, 5}}
+	horizontalRing = Path64{{0, 0}, {10, 0}, {5, 0}}
+	pointRing      = Path64{{3, 3}, {3, 3}, {3, 3}}
+)
+
+// TestIsCollinearRing tests zero-area ring detection
+func TestIsCollinearRing(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     Path64
+		expected bool
+	}{
+		{"Diagonal", diagonalRing, true},
+		{"Horizontal", horizontalRing, true},
+		{"Coincident points", pointRing, true},
+		{"Triangle", Path64{{0, 0}, {10, 0}, {5, 5}}, false},
+		// A symmetric bow-tie has zero signed area but is not collinear
+		{"Bow-tie", Path64{{0, 0}, {10, 10}, {10, 0}, {0, 10}}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := isCollinearRing(test.path); got != test.expected {
+				t.Errorf("isCollinearRing(%v) = %v, expected %v", test.path, got, test.expected)
+			}
+		})
+	}
+}
+
+// TestZeroAreaRings tests the policies for collinear input rings
+func TestZeroAreaRings(t *testing.T) {
+	square := Path64{{0, 0}, {20, 0}, {20, 20}, {0, 20}}
+	subjects := Paths64{square, diagonalRing, horizontalRing}
+
+	t.Run("Engine ignores collinear rings", func(t *testing.T) {
+		engine := NewVattiEngine(Union, NonZero)
+		result, _, err := engine.ExecuteClipping(Paths64{diagonalRing, horizontalRing, pointRing}, nil, nil)
+		if err != nil || len(result) != 0 {
+			t.Errorf("Expected empty result without error, got %v, %v", result, err)
+		}
+	})
+
+	t.Run("Drop", func(t *testing.T) {
+		area, err := AreaOfBooleanOp64(Union, NonZero, subjects, Paths64{diagonalRing})
+		if err != nil {
+			t.Fatalf("AreaOfBooleanOp64 failed: %v", err)
+		}
+		if area != 400 {
+			t.Errorf("Expected collinear rings not to change the area, got %v", area)
+		}
+
+		kept, open, clips, err := applyZeroAreaPolicy(ZeroAreaDrop, subjects, nil, Paths64{diagonalRing})
+		if err != nil || len(kept) != 1 || len(open) != 0 || len(clips) != 0 {
+			t.Errorf("Expected only the square to remain, got %v %v %v (%v)", kept, open, clips, err)
+		}
+		if len(subjects) != 3 {
+			t.Error("Input slice was modified")
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		_, _, err := BooleanOp64(Union, NonZero, subjects, nil, nil, ClipperOptions{ZeroAreaRings: ZeroAreaError})
+		if !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("Expected ErrInvalidInput, got %v", err)
+		}
+	})
+
+	t.Run("Keep as open paths", func(t *testing.T) {
+		existing := Paths64{{{-5, -5}, {-1, -1}}}
+		kept, open, _, err := applyZeroAreaPolicy(ZeroAreaKeepOpen, subjects, existing, nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(kept) != 1 {
+			t.Errorf("Expected collinear rings to leave the closed subjects, got %v", kept)
+		}
+		expected := Paths64{existing[0], {{0, 0}, {10, 10}}, {{0, 0}, {10, 0}}}
+		if len(open) != len(expected) {
+			t.Fatalf("Expected open paths %v, got %v", expected, open)
+		}
+		for i := range expected {
+			if !identicalPath(open[i], expected[i]) {
+				t.Errorf("Open path %d: expected %v, got %v", i, expected[i], open[i])
+			}
+		}
+		if len(existing) != 1 {
+			t.Error("Caller's open paths were modified")
+		}
+	})
+}
+
+// TestRingClosure tests both closing conventions across the entry points
+func TestRingClosure(t *testing.T) {
+	open := Paths64{{{0, 0}, {100, 0}, {100, 100}, {0, 100}}}
+	closed := Paths64{{{0, 0}, {100, 0}, {100, 100}, {0, 100}, {0, 0}}}
+	clip := Paths64{{{50, 50}, {150, 50}, {150, 150}, {50, 150}, {50, 50}}}
+	rect := Path64{{20, 20}, {80, 20}, {80, 80}, {20, 80}}
+
+	for _, policy := range []RingClosure{RingClosureImplicit, RingClosureAutoClose} {
+		for _, ct := range []ClipType{Union, Intersection, Difference, Xor} {
+			expected, _, err := BooleanOp64(ct, NonZero, open, nil, clip[:1:1], WithRingClosure(policy))
+			if err != nil {
+				t.Fatalf("policy %d, %v: unexpected error: %v", policy, ct, err)
+			}
+			got, _, err := BooleanOp64(ct, NonZero, closed, nil, clip, WithRingClosure(policy))
+			if err != nil || !sameRings(got, expected) {
+				t.Errorf("policy %d, %v: expected %v, got %v (err %v)", policy, ct, expected, got, err)
+			}
+		}
+		expected, _ := RectClip64(rect, open)
+		if got, err := RectClip64(rect, closed, WithRingClosure(policy)); err != nil || !sameRings(got, expected) {
+			t.Errorf("policy %d, RectClip64: expected %v, got %v (err %v)", policy, expected, got, err)
+		}
+		if expected, err := InflatePaths64(open, 10, Miter, ClosedPolygon); err == nil {
+			got, err := InflatePaths64(closed, 10, Miter, ClosedPolygon, WithRingClosure(policy))
+			if err != nil || !sameRings(got, expected) {
+				t.Errorf("policy %d, InflatePaths64: expected %v, got %v (err %v)", policy, expected, got, err)
+			}
+		}
+	}
+
+	// AutoClose strips the closing point before pre-noding sees it, without
+	// touching the caller's paths
+	got, _, err := BooleanOp64(Union, NonZero, closed, nil, nil,
+		ClipperOptions{PreNodeSelfIntersections: true}, WithRingClosure(RingClosureAutoClose))
+	if err != nil || !sameRings(got, open) || len(closed[0]) != 5 {
+		t.Errorf("expected %v with the input unchanged, got %v (err %v)", open, got, err)
+	}
+
+	// Strict rejects the repeated point everywhere
+	strict := WithRingClosure(RingClosureStrict)
+	if _, _, err := BooleanOp64(Union, NonZero, open, nil, clip, strict); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("BooleanOp64: expected ErrInvalidInput, got %v", err)
+	}
+	if _, err := RectClip64(rect, closed, strict); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("RectClip64: expected ErrInvalidInput, got %v", err)
+	}
+	if _, err := InflatePaths64(closed, 10, Miter, ClosedPolygon, strict); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("InflatePaths64: expected ErrInvalidInput, got %v", err)
+	}
+	if _, _, err := BooleanOp64(Union, NonZero, open, nil, nil, strict); err != nil {
+		t.Errorf("expected implicitly closed rings to pass, got %v", err)
+	}
+	// Open paths may end where they start
+	loop := Paths64{{{0, 0}, {10, 0}, {10, 10}, {0, 0}}}
+	if _, _, err := BooleanOp64(Intersection, NonZero, nil, loop, open, strict); err != nil {
+		t.Errorf("expected open paths to be exempt, got %v", err)
+	}
+}
+
+// TestSanitizeRing tests duplicate, spike and self-touch removal
+func TestSanitizeRing(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     Path64
+		expected Paths64
+	}{
+		{"Clean", Path64{{0, 0}, {10, 0}, {10, 10}}, Paths64{{{0, 0}, {10, 0}, {10, 10}}}},
+		{"Collinear kept", Path64{{0, 0}, {5, 0}, {10, 0}, {10, 10}}, Paths64{{{0, 0}, {5, 0}, {10, 0}, {10, 10}}}},
+		{"Duplicates", Path64{{0, 0}, {0, 0}, {10, 0}, {10, 10}, {0, 0}}, Paths64{{{0, 0}, {10, 0}, {10, 10}}}},
+		{"Spike", Path64{{0, 0}, {10, 0}, {20, 0}, {10, 0}, {10, 10}}, Paths64{{{0, 0}, {10, 0}, {10, 10}}}},
+		{
+			"Self-touching",
+			Path64{{0, 0}, {10, 0}, {10, 10}, {20, 10}, {20, 20}, {10, 20}, {10, 10}, {0, 10}},
+			Paths64{{{10, 10}, {20, 10}, {20, 20}, {10, 20}}, {{0, 0}, {10, 0}, {10, 10}, {0, 10}}},
+		},
+		{"Collinear ring", horizontalRing, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := sanitizeRing(test.path); !sameRings(got, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, got)
+			}
+		})
+	}
+
+	paths := Paths64{{{0, 0}, {10, 0}, {10, 10}}}
+	if got := sanitizeRings(paths, false); &got[0] != &paths[0] {
+		t.Error("expected clean input to be returned without copying")
+	}
+}
+
+// TestSanitizedBooleanOp tests that sanitization keeps the region and can be skipped
+func TestSanitizedBooleanOp(t *testing.T) {
+	subjects := Paths64{{
+		{0, 0}, {0, 0}, {50, 0}, {100, 0}, {120, 0}, {100, 0}, {100, 50}, {100, 100},
+		{150, 100}, {150, 150}, {100, 150}, {100, 100}, {0, 100}, {0, 100},
+	}}
+	clips := Paths64{{{50, 50}, {125, 50}, {125, 125}, {125, 50}, {125, 125}, {50, 125}}}
+
+	for _, fillRule := range []FillRule{EvenOdd, NonZero} {
+		sanitized, err := Intersect64(subjects, clips, fillRule)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		raw, _, err := BooleanOp64(Intersection, fillRule, subjects, nil, clips, WithSkipSanitize(true))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		area := func(paths Paths64) (sum float64) {
+			for _, path := range paths {
+				sum += Area64(path)
+			}
+			return sum
+		}
+		if a, b := area(sanitized), area(raw); a != b || a != 50*50+25*25 {
+			t.Errorf("%v: expected area %d either way, got %v sanitized and %v raw", fillRule, 50*50+25*25, a, b)
+		}
+	}
+}
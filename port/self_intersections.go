@@ -0,0 +1,154 @@
+package clipper
+
+import "sort"
+
+// ==============================================================================
+// Resolving Self-Intersections
+// ==============================================================================
+
+// SimplifySelfIntersections64 resolves self-intersecting and overlapping
+// polygons into simple ones covering the region paths fill under fillRule,
+// Clipper2's "union of one set" idiom: Union64(paths, nil, fillRule). A
+// figure-eight becomes its two lobes, spikes and doubled-back edges are
+// removed, and rings overlapping one another are merged. Output follows the
+// contract of every boolean operation: outer rings counter-clockwise, holes
+// clockwise, nothing touching or crossing.
+//
+// Under NonZero and EvenOdd, disjoint rings that are already simple and not
+// nested are returned as they are, counter-clockwise and in input order,
+// without running the sweep; options always run the full union.
+func SimplifySelfIntersections64(paths Paths64, fillRule FillRule, opts ...Option) (Paths64, error) {
+	if len(opts) == 0 && (fillRule == NonZero || fillRule == EvenOdd) {
+		if simple, ok := simpleDisjointRings(paths); ok {
+			return simple, nil
+		}
+	}
+	solution, _, err := BooleanOp64(Union, fillRule, paths, nil, nil, opts...)
+	return solution, err
+}
+
+// simpleDisjointRings returns paths turned counter-clockwise if they are
+// simple rings neither touching nor enclosing one another, which fill the
+// same region under NonZero and EvenOdd whatever their orientation. Nested
+// rings fail isSimpleSolution once turned, since holes must be clockwise.
+func simpleDisjointRings(paths Paths64) (Paths64, bool) {
+	if len(paths) == 0 {
+		return nil, false
+	}
+	result := make(Paths64, len(paths))
+	for i, path := range paths {
+		if Area64(path) < 0 {
+			result[i] = Reverse64(path)
+		} else {
+			result[i] = append(Path64(nil), path...)
+		}
+	}
+	if !isSimpleSolution(result) {
+		return nil, false
+	}
+	return result, true
+}
+
+// solutionEdge is a ring edge as seen by isSimpleSolution
+type solutionEdge struct {
+	a, b       Point64
+	ring, idx  int
+	minY, maxY int64
+}
+
+// isSimpleSolution reports whether rings are free of repeated vertices,
+// collinear vertices and edges that touch or cross one another, and whether
+// each ring's orientation matches its nesting: outer rings counter-clockwise,
+// holes clockwise inside exactly one outer ring. A NonZero union reproduces
+// such a solution unchanged, so simpleDisjointRings can skip the sweep.
+func isSimpleSolution(solution Paths64) bool {
+	var vertices []Point64
+	var edges []solutionEdge
+	for r, ring := range solution {
+		n := len(ring)
+		if n < 3 {
+			return false
+		}
+		for i, pt := range ring {
+			vertices = append(vertices, pt)
+			next := ring[(i+1)%n]
+			if IsCollinear(ring[(i+n-1)%n], pt, next) {
+				return false
+			}
+			edges = append(edges, solutionEdge{pt, next, r, i, min64(pt.Y, next.Y), max64(pt.Y, next.Y)})
+		}
+	}
+	sort.Slice(vertices, func(i, j int) bool { return pointLess(vertices[i], vertices[j]) })
+	for i := 1; i < len(vertices); i++ {
+		if vertices[i] == vertices[i-1] {
+			return false
+		}
+	}
+
+	// Sweep upwards, testing each edge against those whose Y range overlaps
+	sort.Slice(edges, func(i, j int) bool { return edges[i].minY < edges[j].minY })
+	var active []solutionEdge
+	for _, e := range edges {
+		kept := active[:0]
+		for _, o := range active {
+			if o.maxY >= e.minY {
+				kept = append(kept, o)
+			}
+		}
+		active = kept
+
+		for _, o := range active {
+			if o.ring == e.ring && ringEdgesAdjacent(o.idx, e.idx, len(solution[e.ring])) {
+				continue
+			}
+			if segmentsTouch(e.a, e.b, o.a, o.b) {
+				return false
+			}
+		}
+		active = append(active, e)
+	}
+	return ringsOrientedByNesting(solution)
+}
+
+// ringsOrientedByNesting reports whether every ring winds the way its
+// position requires. Rings must not touch, so any vertex of a ring tells
+// which other rings enclose it.
+func ringsOrientedByNesting(solution Paths64) bool {
+	bounds := BoundsEach64(solution)
+	for i, ring := range solution {
+		pt := ring[0]
+		wind := 0
+		for j, other := range solution {
+			if j != i && bounds[j].Contains(pt) {
+				wind += windingNumberD(PointD{X: float64(pt.X), Y: float64(pt.Y)}, Paths64{other})
+			}
+		}
+		want := 0
+		if Area64(ring) < 0 {
+			want = 1
+		}
+		if wind != want {
+			return false
+		}
+	}
+	return true
+}
+
+// ringEdgesAdjacent reports whether edges i and j of an n-gon share a vertex
+func ringEdgesAdjacent(i, j, n int) bool {
+	return (i+1)%n == j || (j+1)%n == i
+}
+
+// segmentsTouch reports whether two segments have any point in common
+func segmentsTouch(a1, a2, b1, b2 Point64) bool {
+	d1 := CrossProduct128(b1, b2, a1)
+	d2 := CrossProduct128(b1, b2, a2)
+	d3 := CrossProduct128(a1, a2, b1)
+	d4 := CrossProduct128(a1, a2, b2)
+	zero := Int128{}
+	if d1.Cmp(zero)*d2.Cmp(zero) < 0 && d3.Cmp(zero)*d4.Cmp(zero) < 0 {
+		return true
+	}
+	return isPointOnSegment(a1, b1, b2) || isPointOnSegment(a2, b1, b2) ||
+		isPointOnSegment(b1, a1, a2) || isPointOnSegment(b2, a1, a2)
+}
@@ -0,0 +1,91 @@
+package clipper
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// TestSimplifySelfIntersections64 tests figure-eights, spikes and the fast path
+func TestSimplifySelfIntersections64(t *testing.T) {
+	tests := []struct {
+		name     string
+		paths    Paths64
+		fillRule FillRule
+		expected Paths64
+	}{
+		{
+			"Figure-eight",
+			Paths64{{{0, 0}, {10, 10}, {10, 0}, {0, 10}}},
+			NonZero,
+			Paths64{{{0, 0}, {5, 5}, {0, 10}}, {{10, 0}, {10, 10}, {5, 5}}},
+		},
+		{
+			"Figure-eight under EvenOdd",
+			Paths64{{{0, 0}, {10, 10}, {10, 0}, {0, 10}}},
+			EvenOdd,
+			Paths64{{{0, 0}, {5, 5}, {0, 10}}, {{10, 0}, {10, 10}, {5, 5}}},
+		},
+		{
+			"Spike",
+			Paths64{{{0, 0}, {10, 0}, {10, 10}, {5, 10}, {5, 20}, {5, 10}, {0, 10}}},
+			NonZero,
+			Paths64{{{0, 0}, {10, 0}, {10, 10}, {0, 10}}},
+		},
+		{
+			"Clockwise ring",
+			Paths64{{{0, 10}, {10, 10}, {10, 0}, {0, 0}}},
+			NonZero,
+			Paths64{{{0, 0}, {10, 0}, {10, 10}, {0, 10}}},
+		},
+		{
+			"Disjoint simple rings",
+			Paths64{{{0, 0}, {10, 0}, {10, 10}}, {{20, 10}, {30, 10}, {20, 0}}},
+			EvenOdd,
+			Paths64{{{0, 0}, {10, 0}, {10, 10}}, {{20, 0}, {30, 10}, {20, 10}}},
+		},
+		{
+			"Ring traversed twice",
+			Paths64{{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}, {10, 0}, {10, 10}, {0, 10}}},
+			NonZero,
+			Paths64{{{0, 0}, {10, 0}, {10, 10}, {0, 10}}},
+		},
+		{
+			"Ring traversed twice under EvenOdd",
+			Paths64{{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}, {10, 0}, {10, 10}, {0, 10}}},
+			EvenOdd,
+			Paths64{},
+		},
+		{
+			"Self-overlapping loop",
+			Paths64{{{0, 0}, {20, 0}, {20, 20}, {0, 20}, {0, 5}, {15, 5}, {15, 15}, {5, 15}, {5, 0}}},
+			EvenOdd,
+			Paths64{{{0, 5}, {5, 5}, {5, 0}, {20, 0}, {20, 20}, {0, 20}}, {{5, 5}, {5, 15}, {15, 15}, {15, 5}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SimplifySelfIntersections64(tt.paths, tt.fillRule)
+			if err != nil || !sameRings(got, tt.expected) {
+				t.Errorf("expected %v, got %v (err %v)", tt.expected, got, err)
+			}
+		})
+	}
+
+	// Random rings take the fast path only when simple, and match a plain
+	// union either way
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		var paths Paths64
+		for n := r.Intn(3) + 1; n > 0; n-- {
+			paths = append(paths, randomPath(r, 3+r.Intn(6), 1000))
+		}
+		for _, fillRule := range []FillRule{NonZero, EvenOdd} {
+			got, err := SimplifySelfIntersections64(paths, fillRule)
+			expected, _ := Union64(paths, nil, fillRule)
+			if err != nil || !sameRings(got, expected) {
+				t.Fatalf("%v: expected %v for %v, got %v (err %v)", fillRule, expected, paths, got, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package clipper
+
+import "math"
+
+// ==============================================================================
+// Shape Generators
+// ==============================================================================
+
+// RegularPolygon64 returns a regular polygon with the given number of sides
+// inscribed in a circle of the given radius around center. The first vertex
+// lies at angle rotation (radians) and the result is counter-clockwise
+// (positive orientation). Returns nil when sides < 3 or radius <= 0.
+func RegularPolygon64(center Point64, radius float64, sides int, rotation float64) Path64 {
+	if sides < 3 || radius <= 0 {
+		return nil
+	}
+
+	result := make(Path64, 0, sides)
+	step := 2 * math.Pi / float64(sides)
+	for i := 0; i < sides; i++ {
+		angle := rotation + float64(i)*step
+		pt := Point64{
+			X: center.X + int64(math.Round(radius*math.Cos(angle))),
+			Y: center.Y + int64(math.Round(radius*math.Sin(angle))),
+		}
+		// Small radii can collapse neighbouring vertices onto the same point
+		if len(result) > 0 && result[len(result)-1] == pt {
+			continue
+		}
+		result = append(result, pt)
+	}
+	if len(result) > 1 && result[0] == result[len(result)-1] {
+		result = result[:len(result)-1]
+	}
+	if len(result) < 3 {
+		return nil
+	}
+	return result
+}
+
+// Annulus64 returns a ring (donut) centered on center as an outer boundary
+// followed by its hole. The outer path is counter-clockwise and the hole is
+// clockwise, so EvenOdd, NonZero and Positive fill the ring, while Negative,
+// which fills only clockwise winding, fills nothing.
+// steps is the number of vertices used to approximate each circle.
+// Returns nil when steps < 3, innerR <= 0 or innerR >= outerR.
+func Annulus64(center Point64, outerR, innerR float64, steps int) Paths64 {
+	if steps < 3 || innerR <= 0 || innerR >= outerR {
+		return nil
+	}
+
+	outer := RegularPolygon64(center, outerR, steps, 0)
+	inner := RegularPolygon64(center, innerR, steps, 0)
+	if outer == nil || inner == nil {
+		return nil
+	}
+	return Paths64{outer, Reverse64(inner)}
+}
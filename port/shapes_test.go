@@ -0,0 +1,83 @@
+package clipper
+
+import (
+	"math"
+	"testing"
+)
+
+// TestRegularPolygon64 tests regular polygon generation
+func TestRegularPolygon64(t *testing.T) {
+	t.Run("Square", func(t *testing.T) {
+		square := RegularPolygon64(Point64{0, 0}, 10, 4, 0)
+		expected := Path64{{10, 0}, {0, 10}, {-10, 0}, {0, -10}}
+		if len(square) != len(expected) {
+			t.Fatalf("Expected %d points, got %d: %v", len(expected), len(square), square)
+		}
+		for i, pt := range square {
+			if pt != expected[i] {
+				t.Errorf("Point %d: expected %v, got %v", i, expected[i], pt)
+			}
+		}
+	})
+
+	t.Run("Orientation and area", func(t *testing.T) {
+		hexagon := RegularPolygon64(Point64{100, 100}, 1000, 6, math.Pi/6)
+		if len(hexagon) != 6 {
+			t.Fatalf("Expected 6 points, got %d", len(hexagon))
+		}
+		if !IsPositive64(hexagon) {
+			t.Error("Expected regular polygon to be counter-clockwise")
+		}
+		expected := 1.5 * math.Sqrt(3) * 1000 * 1000
+		if area := Area64(hexagon); math.Abs(area-expected)/expected > 0.001 {
+			t.Errorf("Expected area ~%.0f, got %.0f", expected, area)
+		}
+	})
+
+	t.Run("Invalid parameters", func(t *testing.T) {
+		if p := RegularPolygon64(Point64{}, 10, 2, 0); p != nil {
+			t.Errorf("Expected nil for 2 sides, got %v", p)
+		}
+		if p := RegularPolygon64(Point64{}, 0, 5, 0); p != nil {
+			t.Errorf("Expected nil for zero radius, got %v", p)
+		}
+	})
+}
+
+// TestAnnulus64 tests ring generation
+func TestAnnulus64(t *testing.T) {
+	ring := Annulus64(Point64{0, 0}, 100, 50, 64)
+	if len(ring) != 2 {
+		t.Fatalf("Expected outer and hole paths, got %d paths", len(ring))
+	}
+	if !IsPositive64(ring[0]) {
+		t.Error("Expected outer path to be counter-clockwise")
+	}
+	if IsPositive64(ring[1]) {
+		t.Error("Expected hole path to be clockwise")
+	}
+
+	net := Area64(ring[0]) + Area64(ring[1])
+	expected := math.Pi * (100*100 - 50*50)
+	if math.Abs(net-expected)/expected > 0.01 {
+		t.Errorf("Expected net area ~%.0f, got %.0f", expected, net)
+	}
+
+	for _, fillRule := range []FillRule{EvenOdd, NonZero, Positive, Negative} {
+		filled, err := Union64(ring, nil, fillRule)
+		if err != nil {
+			t.Fatalf("Union64(%v): %v", fillRule, err)
+		}
+		want := net
+		if fillRule == Negative {
+			want = 0
+		}
+		if got := totalArea(filled); math.Abs(got-want) > 1 {
+			t.Errorf("%v: expected area %.0f, got %.0f", fillRule, want, got)
+		}
+	}
+
+	if r := Annulus64(Point64{}, 50, 100, 16); r != nil {
+		t.Errorf("Expected nil when inner radius exceeds outer, got %v", r)
+	}
+}
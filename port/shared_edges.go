@@ -0,0 +1,125 @@
+package clipper
+
+import "sort"
+
+// ==============================================================================
+// Shared Boundaries of Subject and Clip
+// ==============================================================================
+
+// sharedBoundaryLines returns the segments that the filled subject and clip
+// regions share without enclosing area in the result of clipType: for
+// Intersection the edges where they touch from opposite sides, for
+// Difference the edges where they overlap from the same side. Other clip
+// types have no such segments. Each maximal run is returned as one open path.
+func sharedBoundaryLines(clipType ClipType, fillRule FillRule, subjects, clips Paths64) (Paths64, error) {
+	if clipType != Intersection && clipType != Difference {
+		return nil, nil
+	}
+
+	// Resolve both operands first so every boundary has its region on the
+	// left, then split them at each other's vertices so overlapping edges
+	// become identical
+	subject, _, err := engineBooleanOp64(Union, fillRule, subjects, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	clip, _, err := engineBooleanOp64(Union, fillRule, clips, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(subject) == 0 || len(clip) == 0 {
+		return nil, nil
+	}
+	split := insertTouchingVertices(append(subject[:len(subject):len(subject)], clip...))
+	subjectEdges, clipEdges := netEdges(split[:len(subject)]), netEdges(split[len(subject):])
+
+	var shared [][2]Point64
+	for e := range subjectEdges {
+		match := e
+		if clipType == Intersection {
+			match = [2]Point64{e[1], e[0]}
+		}
+		if clipEdges[match] > 0 {
+			shared = append(shared, e)
+		}
+	}
+	return chainSegments(shared), nil
+}
+
+// chainSegments links segments meeting end to end into polylines, merging
+// collinear runs and breaking only where the chain branches. A chain forming
+// a loop repeats its first point at the end. The result does not depend on
+// the order or direction of the segments.
+func chainSegments(segs [][2]Point64) Paths64 {
+	adj := make(map[Point64][]Point64)
+	used := make(map[[2]Point64]bool)
+	key := func(a, b Point64) [2]Point64 {
+		if pointLess(b, a) {
+			return [2]Point64{b, a}
+		}
+		return [2]Point64{a, b}
+	}
+	for _, s := range segs {
+		k := key(s[0], s[1])
+		if s[0] == s[1] || used[k] {
+			continue
+		}
+		used[k] = true
+		adj[s[0]] = append(adj[s[0]], s[1])
+		adj[s[1]] = append(adj[s[1]], s[0])
+	}
+	vertices := make([]Point64, 0, len(adj))
+	for v, nbrs := range adj {
+		vertices = append(vertices, v)
+		sort.Slice(nbrs, func(i, j int) bool { return pointLess(nbrs[i], nbrs[j]) })
+	}
+	sort.Slice(vertices, func(i, j int) bool { return pointLess(vertices[i], vertices[j]) })
+
+	var result Paths64
+	walk := func(start, next Point64) {
+		line := Path64{start}
+		prev, v := start, next
+		for {
+			delete(used, key(prev, v))
+			if n := len(line); n >= 2 && CrossProduct128(line[n-2], line[n-1], v).IsZero() &&
+				!line[n-1].Sub(line[n-2]).Dot128(v.Sub(line[n-1])).IsNegative() {
+				line[n-1] = v
+			} else {
+				line = append(line, v)
+			}
+			if len(adj[v]) != 2 || v == start {
+				break
+			}
+			w := adj[v][0]
+			if w == prev {
+				w = adj[v][1]
+			}
+			if !used[key(v, w)] {
+				break
+			}
+			prev, v = v, w
+		}
+		result = append(result, line)
+	}
+
+	// Open chains start at their ends or branch points, the remaining
+	// segments form loops
+	for _, v := range vertices {
+		if len(adj[v]) == 2 {
+			continue
+		}
+		for _, w := range adj[v] {
+			if used[key(v, w)] {
+				walk(v, w)
+			}
+		}
+	}
+	for _, v := range vertices {
+		for _, w := range adj[v] {
+			if used[key(v, w)] {
+				walk(v, w)
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,87 @@
+package clipper
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSharedEdges(t *testing.T) {
+	square := Paths64{{{0, 0}, {100, 0}, {100, 100}, {0, 100}}}
+	right := Paths64{{{100, 0}, {200, 0}, {200, 100}, {100, 100}}}
+	notch := Paths64{{{100, 30}, {200, 30}, {200, 70}, {100, 70}}}
+	left := Paths64{{{0, 0}, {50, 0}, {50, 100}, {0, 100}}}
+
+	tests := []struct {
+		name          string
+		clipType      ClipType
+		clip          Paths64
+		expectedArea  float64
+		expectedLines Paths64 // with SharedEdgesInclude
+	}{
+		{"intersection touching along an edge", Intersection, right, 0, Paths64{{{100, 0}, {100, 100}}}},
+		{"intersection touching along part of an edge", Intersection, notch, 0, Paths64{{{100, 30}, {100, 70}}}},
+		{"intersection overlapping", Intersection, left, 5000, nil},
+		{"difference overlapping", Difference, left, 5000, Paths64{{{50, 0}, {0, 0}, {0, 100}, {50, 100}}}},
+		{"difference of identical polygons", Difference, square, 0, Paths64{{{0, 0}, {0, 100}, {100, 100}, {100, 0}, {0, 0}}}},
+		{"difference touching along an edge", Difference, right, 10000, nil},
+		{"union", Union, right, 20000, nil},
+	}
+	for _, tt := range tests {
+		for _, policy := range []SharedEdgePolicy{SharedEdgesExclude, SharedEdgesInclude} {
+			solution, open, err := BooleanOp64(tt.clipType, NonZero, square, nil, tt.clip, ClipperOptions{SharedEdges: policy})
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", tt.name, err)
+			}
+			area := 0.0
+			for _, ring := range solution {
+				area += Area64(ring)
+			}
+			if area != tt.expectedArea {
+				t.Errorf("%s (policy %d): expected area %v, got %v", tt.name, policy, tt.expectedArea, area)
+			}
+			expected := tt.expectedLines
+			if policy == SharedEdgesExclude {
+				expected = nil
+			}
+			if len(open) != len(expected) || (len(open) > 0 && !reflect.DeepEqual(open, expected)) {
+				t.Errorf("%s (policy %d): expected lines %v, got %v", tt.name, policy, expected, open)
+			}
+		}
+	}
+}
+
+func TestSharedEdgesDeterministic(t *testing.T) {
+	// The shared lines must not depend on where rings start, which way they
+	// run or which of several rings supplies a shared edge
+	square := Paths64{{{0, 0}, {100, 0}, {100, 100}, {0, 100}}}
+	variants := []Paths64{
+		{{{100, 0}, {200, 0}, {200, 100}, {100, 100}}},
+		{{{200, 100}, {100, 100}, {100, 0}, {200, 0}}},
+		{{{100, 100}, {200, 100}, {200, 0}, {100, 0}}},
+		{{{100, 0}, {150, 0}, {150, 100}, {100, 100}}, {{150, 0}, {200, 0}, {200, 100}, {150, 100}}},
+		{{{100, 0}, {200, 0}, {200, 50}, {100, 50}}, {{100, 50}, {200, 50}, {200, 100}, {100, 100}}},
+	}
+	expected := Paths64{{{100, 0}, {100, 100}}}
+	for i, clip := range variants {
+		_, open, err := BooleanOp64(Intersection, NonZero, square, nil, clip, ClipperOptions{SharedEdges: SharedEdgesInclude})
+		if err != nil {
+			t.Fatalf("variant %d: unexpected error: %v", i, err)
+		}
+		if !reflect.DeepEqual(open, expected) {
+			t.Errorf("variant %d: expected %v, got %v", i, expected, open)
+		}
+	}
+}
+
+func TestChainSegments(t *testing.T) {
+	segs := [][2]Point64{{{10, 0}, {0, 0}}, {{10, 0}, {20, 0}}, {{20, 0}, {20, 10}}, {{5, 5}, {5, 8}}}
+	expected := Paths64{{{0, 0}, {20, 0}, {20, 10}}, {{5, 5}, {5, 8}}}
+	if got := chainSegments(segs); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	// order and direction of the segments do not matter
+	reversed := [][2]Point64{{{5, 8}, {5, 5}}, {{20, 10}, {20, 0}}, {{20, 0}, {10, 0}}, {{0, 0}, {10, 0}}}
+	if got := chainSegments(reversed); !reflect.DeepEqual(got, expected) {
+		t.Errorf("reversed: expected %v, got %v", expected, got)
+	}
+}
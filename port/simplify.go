@@ -0,0 +1,264 @@
+package clipper
+
+import "math"
+
+// ==============================================================================
+// Topology-Preserving Polyline Simplification
+// ==============================================================================
+
+// SimplifyPreservingTopology64 simplifies open polylines so that no vertex
+// strays more than epsilon from the simplified line, while refusing any
+// shortcut that would move part of a line to the other side of a polygon
+// boundary. A run of vertices is replaced by a straight segment only if the
+// region between the run and the segment contains no polygon boundary, so
+// every segment crosses the boundaries exactly where the original did.
+// Endpoints are always kept.
+func SimplifyPreservingTopology64(lines Paths64, polygons Paths64, epsilon float64) Paths64 {
+	edges := polygonEdges(polygons)
+	result := make(Paths64, 0, len(lines))
+	for _, line := range lines {
+		if len(line) <= 2 || !(epsilon > 0) {
+			result = append(result, line.Clone())
+			continue
+		}
+
+		out := Path64{line[0]}
+		for a := 0; a < len(line)-1; {
+			b := a + 1
+			for b+1 < len(line) && canShortcut(line, a, b+1, epsilon, edges) {
+				b++
+			}
+			out = append(out, line[b])
+			a = b
+		}
+		result = append(result, out)
+	}
+	return result
+}
+
+// boundaryEdge is one edge of a reference polygon with its bounds
+type boundaryEdge struct {
+	p, q   Point64
+	bounds Rect64
+}
+
+// polygonEdges collects the closing edges of every polygon
+func polygonEdges(polygons Paths64) []boundaryEdge {
+	var edges []boundaryEdge
+	for _, poly := range polygons {
+		for i, p := range poly {
+			q := poly[(i+1)%len(poly)]
+			if p == q {
+				continue
+			}
+			edges = append(edges, boundaryEdge{p, q, Bounds64(Path64{p, q})})
+		}
+	}
+	return edges
+}
+
+// canShortcut reports whether line[a..b] may be replaced by the segment
+// line[a]-line[b]: every skipped vertex must lie within epsilon of it, and no
+// polygon boundary may lie in the region enclosed by the run and the segment
+func canShortcut(line Path64, a, b int, epsilon float64, edges []boundaryEdge) bool {
+	start, end := line[a], line[b]
+	for i := a + 1; i < b; i++ {
+		if perpendicularDistance(line[i], start, end) > epsilon {
+			return false
+		}
+	}
+
+	run := line[a : b+1]
+	span := Bounds64(run)
+	for _, e := range edges {
+		if !e.bounds.Intersects(span) {
+			continue
+		}
+
+		// A boundary edge that merely touches the shortcut or the run cannot
+		// be classified safely, so the vertex is kept
+		chordCross, chordTouch := segmentCrossing(start, end, e.p, e.q)
+		if chordTouch {
+			return false
+		}
+		runCrossings := 0
+		for i := 0; i+1 < len(run); i++ {
+			cross, touch := segmentCrossing(run[i], run[i+1], e.p, e.q)
+			if touch {
+				return false
+			}
+			if cross {
+				runCrossings++
+			}
+		}
+		// An edge passing through the enclosed region crosses its outline an
+		// even number of times; an odd count means it ends inside
+		if (runCrossings%2 == 1) != chordCross {
+			return false
+		}
+		if PointInPolygon(e.p, run, EvenOdd) != Outside {
+			return false
+		}
+	}
+	return true
+}
+
+// segmentCrossing classifies segments a-b and p-q: cross is true for a proper
+// crossing at interior points of both, touch for any other contact
+// (shared endpoints, an endpoint on the other segment, or collinear overlap)
+func segmentCrossing(a, b, p, q Point64) (cross, touch bool) {
+	d1 := CrossProduct128(a, b, p).Cmp(Int128{})
+	d2 := CrossProduct128(a, b, q).Cmp(Int128{})
+	d3 := CrossProduct128(p, q, a).Cmp(Int128{})
+	d4 := CrossProduct128(p, q, b).Cmp(Int128{})
+
+	if d1*d2 < 0 && d3*d4 < 0 {
+		return true, false
+	}
+	touch = (d1 == 0 && isPointOnSegment(p, a, b)) ||
+		(d2 == 0 && isPointOnSegment(q, a, b)) ||
+		(d3 == 0 && isPointOnSegment(a, p, q)) ||
+		(d4 == 0 && isPointOnSegment(b, p, q))
+	return false, touch
+}
+
+// perpendicularDistance returns the distance from pt to the line through a
+// and b (or to a itself when a and b coincide)
+func perpendicularDistance(pt, a, b Point64) float64 {
+	if a == b {
+		return pt.DistanceTo(a)
+	}
+	return math.Abs(CrossProduct128(a, b, pt).ToFloat64()) / a.DistanceTo(b)
+}
+
+// ==============================================================================
+// Vertex Reduction
+// ==============================================================================
+
+// SimplifyPath64 removes vertices lying within epsilon of the line through
+// their neighbours, as Clipper2's SimplifyPath: the vertex closest to its
+// neighbours' line goes first and its neighbours are re-measured, so the
+// test is always local. That strips noise aggressively, but the result can
+// stray further than epsilon from the vertices removed; RamerDouglasPeucker64
+// bounds that deviation instead. The ends of open paths are kept; paths of
+// fewer than four vertices are returned as a copy.
+func SimplifyPath64(path Path64, epsilon float64, isClosed bool) Path64 {
+	n := len(path)
+	if n < 4 || math.IsNaN(epsilon) {
+		return path.Clone()
+	}
+	high := n - 1
+	epsSqr := epsilon * epsilon
+	removed := make([]bool, n)
+	distSqr := make([]float64, n)
+	if isClosed {
+		distSqr[0] = perpendicularDistSqr(path[0], path[high], path[1])
+		distSqr[high] = perpendicularDistSqr(path[high], path[0], path[high-1])
+	} else {
+		distSqr[0], distSqr[high] = math.MaxFloat64, math.MaxFloat64
+	}
+	for i := 1; i < high; i++ {
+		distSqr[i] = perpendicularDistSqr(path[i], path[i-1], path[i+1])
+	}
+
+	next := func(i int) int {
+		for i++; i <= high && removed[i]; i++ {
+		}
+		if i <= high {
+			return i
+		}
+		for i = 0; removed[i]; i++ {
+		}
+		return i
+	}
+	prior := func(i int) int {
+		if i == 0 {
+			i = high
+		} else {
+			i--
+		}
+		for ; i > 0 && removed[i]; i-- {
+		}
+		if !removed[i] {
+			return i
+		}
+		for i = high; removed[i]; i-- {
+		}
+		return i
+	}
+
+	for curr := 0; ; {
+		if distSqr[curr] > epsSqr {
+			start := curr
+			for {
+				curr = next(curr)
+				if curr == start || distSqr[curr] <= epsSqr {
+					break
+				}
+			}
+			if curr == start {
+				break
+			}
+		}
+
+		prev, nxt := prior(curr), next(curr)
+		if nxt == prev {
+			break
+		}
+		var prev2 int
+		if distSqr[nxt] < distSqr[curr] {
+			prev2, prev, curr, nxt = prev, curr, nxt, next(nxt)
+		} else {
+			prev2 = prior(prev)
+		}
+
+		removed[curr] = true
+		curr, nxt = nxt, next(nxt)
+		if isClosed || (curr != high && curr != 0) {
+			distSqr[curr] = perpendicularDistSqr(path[curr], path[prev], path[nxt])
+		}
+		if isClosed || (prev != 0 && prev != high) {
+			distSqr[prev] = perpendicularDistSqr(path[prev], path[prev2], path[curr])
+		}
+	}
+
+	result := make(Path64, 0, n)
+	for i, pt := range path {
+		if !removed[i] {
+			result = append(result, pt)
+		}
+	}
+	return result
+}
+
+// perpendicularDistSqr returns the squared distance from pt to the line
+// through a and b, or 0 when a and b coincide, as Clipper2's
+// PerpendicDistFromLineSqrd
+func perpendicularDistSqr(pt, a, b Point64) float64 {
+	if a == b {
+		return 0
+	}
+	cross := CrossProduct128(a, b, pt).ToFloat64()
+	dx, dy := float64(b.X-a.X), float64(b.Y-a.Y)
+	return cross * cross / (dx*dx + dy*dy)
+}
+
+// RamerDouglasPeucker64 simplifies path with the Ramer-Douglas-Peucker
+// algorithm, as Clipper2's RamerDouglasPeucker: the vertex farthest from
+// the segment between the ends is kept if it lies more than epsilon away,
+// and both halves are simplified the same way. No removed vertex ends up
+// more than epsilon from the result, which makes it the choice when the
+// deviation must be bounded. Compared with SimplifyPath64 it usually keeps
+// fewer vertices on smooth curves, whose sag it measures over the whole
+// run, and more on noisy lines. Open paths keep their ends;
+// closed paths are split at their first vertex and the vertex farthest from
+// it, which both stay.
+func RamerDouglasPeucker64(path Path64, epsilon float64, isClosed bool) Path64 {
+	if math.IsNaN(epsilon) {
+		return path.Clone()
+	}
+	if isClosed {
+		return simplifyRing(path, epsilon)
+	}
+	return douglasPeucker(path, epsilon)
+}
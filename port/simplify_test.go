@@ -0,0 +1,162 @@
+package clipper
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// TestSimplifyPreservingTopology64 tests that shortcuts never jump over polygon boundaries
+func TestSimplifyPreservingTopology64(t *testing.T) {
+	// A line with a small bump around x=50
+	line := Path64{{0, 0}, {40, 0}, {50, 5}, {60, 0}, {100, 0}}
+
+	t.Run("No polygons", func(t *testing.T) {
+		result := SimplifyPreservingTopology64(Paths64{line}, nil, 6)
+		expected := Path64{{0, 0}, {100, 0}}
+		if len(result) != 1 || !identicalPath(result[0], expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+	})
+
+	t.Run("Epsilon too small", func(t *testing.T) {
+		result := SimplifyPreservingTopology64(Paths64{line}, nil, 3)
+		expected := Path64{{0, 0}, {40, 0}, {50, 5}, {60, 0}, {100, 0}}
+		if !identicalPath(result[0], expected) {
+			t.Errorf("Expected %v, got %v", expected, result[0])
+		}
+	})
+
+	t.Run("Polygon inside the bump", func(t *testing.T) {
+		// Flattening the bump would move the line to the other side of this polygon
+		poly := Paths64{{{48, 1}, {52, 1}, {52, 3}, {48, 3}}}
+		result := SimplifyPreservingTopology64(Paths64{line}, poly, 6)
+		for _, pt := range result[0] {
+			if pt == (Point64{50, 5}) {
+				return
+			}
+		}
+		t.Errorf("Expected bump vertex to be kept, got %v", result[0])
+	})
+
+	t.Run("Polygon boundary crossed by both", func(t *testing.T) {
+		// The boundary at x=45 passes through the bump and the shortcut alike
+		poly := Paths64{{{45, -10}, {200, -10}, {200, 10}, {45, 10}}}
+		result := SimplifyPreservingTopology64(Paths64{line}, poly, 6)
+		expected := Path64{{0, 0}, {100, 0}}
+		if !identicalPath(result[0], expected) {
+			t.Errorf("Expected %v, got %v", expected, result[0])
+		}
+	})
+
+	t.Run("Short lines unchanged", func(t *testing.T) {
+		short := Path64{{0, 0}, {10, 10}}
+		result := SimplifyPreservingTopology64(Paths64{short}, nil, 100)
+		if !identicalPath(result[0], short) {
+			t.Errorf("Expected %v, got %v", short, result[0])
+		}
+	})
+}
+
+func TestSimplifyPath64(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     Path64
+		epsilon  float64
+		isClosed bool
+		expected Path64
+	}{
+		{"Collinear vertices", Path64{{0, 0}, {5, 0}, {10, 0}, {10, 10}, {5, 10}, {0, 10}}, 1, true, Path64{{0, 0}, {10, 0}, {10, 10}, {0, 10}}},
+		{"Small bump", Path64{{0, 0}, {5, 1}, {10, 0}, {10, 10}, {0, 10}}, 2, true, Path64{{0, 0}, {10, 0}, {10, 10}, {0, 10}}},
+		{"Open ends kept", Path64{{0, 0}, {0, 1}, {10, 1}, {20, 0}}, 2, false, Path64{{0, 0}, {20, 0}}},
+		{"Large bump kept", Path64{{0, 0}, {5, 5}, {10, 0}, {10, 10}, {0, 10}}, 2, true, Path64{{0, 0}, {5, 5}, {10, 0}, {10, 10}, {0, 10}}},
+		{"Short path copied", Path64{{0, 0}, {5, 0}, {10, 0}}, 1, false, Path64{{0, 0}, {5, 0}, {10, 0}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SimplifyPath64(tt.path, tt.epsilon, tt.isClosed); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRamerDouglasPeucker64(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     Path64
+		epsilon  float64
+		isClosed bool
+		expected Path64
+	}{
+		{"Open ends kept", Path64{{0, 0}, {0, 1}, {10, 1}, {20, 0}}, 2, false, Path64{{0, 0}, {20, 0}}},
+		{"Farthest vertex kept", Path64{{0, 0}, {5, 1}, {10, 6}, {15, 1}, {20, 0}}, 2, false, Path64{{0, 0}, {10, 6}, {20, 0}}},
+		{"Closed", Path64{{0, 0}, {5, 1}, {10, 0}, {10, 10}, {5, 9}, {0, 10}}, 2, true, Path64{{0, 0}, {10, 0}, {10, 10}, {0, 10}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RamerDouglasPeucker64(tt.path, tt.epsilon, tt.isClosed); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestSimplifyComparison documents how the two simplifiers differ: on a
+// smooth arc Ramer-Douglas-Peucker keeps fewer vertices, on a noisy line
+// SimplifyPath64 does, and only RamerDouglasPeucker64 keeps every removed
+// vertex within epsilon
+func TestSimplifyComparison(t *testing.T) {
+	const epsilon = 5
+	var arc, noisy Path64
+	for i := 0; i <= 90; i++ {
+		a := float64(i) * math.Pi / 180
+		arc = append(arc, Point64{int64(math.Round(10000 * math.Cos(a))), int64(math.Round(10000 * math.Sin(a)))})
+	}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		noisy = append(noisy, Point64{int64(i * 10), int64(r.Intn(7) - 3)})
+	}
+
+	arcSimplified, arcRDP := SimplifyPath64(arc, epsilon, false), RamerDouglasPeucker64(arc, epsilon, false)
+	if len(arcRDP) >= len(arcSimplified) {
+		t.Errorf("arc: expected fewer vertices from RDP, got %d vs %d", len(arcRDP), len(arcSimplified))
+	}
+	noisySimplified, noisyRDP := SimplifyPath64(noisy, epsilon, false), RamerDouglasPeucker64(noisy, epsilon, false)
+	if len(noisySimplified) >= len(noisyRDP) {
+		t.Errorf("noisy: expected fewer vertices from SimplifyPath64, got %d vs %d", len(noisySimplified), len(noisyRDP))
+	}
+
+	for _, tt := range []struct {
+		name             string
+		path, simplified Path64
+		bounded          bool
+	}{
+		{"arc RDP", arc, arcRDP, true},
+		{"noisy RDP", noisy, noisyRDP, true},
+		{"noisy SimplifyPath64", noisy, noisySimplified, false},
+	} {
+		if d := maxDeviation(tt.path, tt.simplified); (d <= epsilon) != tt.bounded {
+			t.Errorf("%s: expected deviation within epsilon %v, got %v", tt.name, tt.bounded, d)
+		}
+	}
+}
+
+// maxDeviation returns the largest distance from a vertex of path to the
+// simplified polyline
+func maxDeviation(path, simplified Path64) float64 {
+	worst := 0.0
+	for _, pt := range path {
+		best := math.Inf(1)
+		for i := 0; i+1 < len(simplified); i++ {
+			a, b := simplified[i], simplified[i+1]
+			seg := [2]PointD{{X: float64(a.X), Y: float64(a.Y)}, {X: float64(b.X), Y: float64(b.Y)}}
+			best = math.Min(best, pointSegmentDistance(float64(pt.X), float64(pt.Y), seg))
+		}
+		worst = math.Max(worst, best)
+	}
+	return worst
+}
@@ -0,0 +1,54 @@
+package clipper
+
+// ==============================================================================
+// Vertex Snapping
+// ==============================================================================
+
+// SnapPaths64 rounds every vertex of closed paths to the nearest multiple of
+// gridSize, halves away from zero as GridRounding does, so data digitized
+// with tiny mismatches shares exact coordinates before clipping. Repeated
+// vertices are then removed and rings left without area dropped. A
+// gridSize below 2 returns a copy of paths.
+func SnapPaths64(paths Paths64, gridSize int64) Paths64 {
+	if gridSize < 2 {
+		return paths.Clone()
+	}
+	snapped := make(Paths64, len(paths))
+	for i, path := range paths {
+		snapped[i] = make(Path64, len(path))
+		for j, pt := range path {
+			snapped[i][j] = Point64{X: snapToGrid(pt.X, gridSize), Y: snapToGrid(pt.Y, gridSize)}
+		}
+	}
+	return weldRings(snapped, nil, true)
+}
+
+// snapToGrid rounds v to the nearest multiple of g in integer arithmetic
+func snapToGrid(v, g int64) int64 {
+	q, r := v/g, v%g
+	if r < 0 {
+		r = -r
+	}
+	if 2*r >= g {
+		if v < 0 {
+			q--
+		} else {
+			q++
+		}
+	}
+	return q * g
+}
+
+// SnapToNeighbors64 merges vertices of closed paths closer than tolerance,
+// within a path or across paths, the weld WithWeldTolerance applies to
+// output. Clusters are formed transitively and each is replaced by its
+// lowest member (smallest Y, then X), so neighbouring polygons that should
+// share a vertex end up sharing it exactly and their union leaves no
+// sliver. Repeated vertices are then removed and rings left without area
+// dropped. A tolerance below 1 returns a copy of paths.
+func SnapToNeighbors64(paths Paths64, tolerance int64) Paths64 {
+	if tolerance < 1 {
+		return paths.Clone()
+	}
+	return weldRings(paths, weldMap(paths, tolerance), true)
+}
@@ -0,0 +1,58 @@
+package clipper
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSnapPaths64(t *testing.T) {
+	tests := []struct {
+		name     string
+		paths    Paths64
+		gridSize int64
+		expected Paths64
+	}{
+		{
+			"Rounds halves away from zero",
+			Paths64{{{4, -5}, {105, -4}, {96, 94}, {-15, 106}}},
+			10,
+			Paths64{{{0, -10}, {110, 0}, {100, 90}, {-20, 110}}},
+		},
+		{
+			"Merges repeated vertices",
+			Paths64{{{0, 0}, {1, 1}, {100, 2}, {99, 101}, {2, 98}}},
+			10,
+			Paths64{{{0, 0}, {100, 0}, {100, 100}, {0, 100}}},
+		},
+		{"Drops collapsed rings", Paths64{{{0, 0}, {3, 0}, {3, 3}}, {{0, 0}, {30, 0}, {0, 30}}}, 10, Paths64{{{0, 0}, {30, 0}, {0, 30}}}},
+		{"Grid of one copies", Paths64{{{1, 2}, {3, 4}}}, 1, Paths64{{{1, 2}, {3, 4}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SnapPaths64(tt.paths, tt.gridSize); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSnapToNeighbors64(t *testing.T) {
+	// Two squares meant to share the edge x=100, digitized one unit apart
+	left := Path64{{0, 0}, {100, 0}, {100, 100}, {0, 100}}
+	right := Path64{{101, 1}, {200, 0}, {200, 100}, {99, 101}}
+	snapped := SnapToNeighbors64(Paths64{left, right}, 3)
+	expected := Paths64{left, {{100, 0}, {200, 0}, {200, 100}, {100, 100}}}
+	if !reflect.DeepEqual(snapped, expected) {
+		t.Fatalf("expected %v, got %v", expected, snapped)
+	}
+
+	union, err := Union64(snapped, nil, NonZero)
+	if err != nil || len(union) != 1 || Area64(union[0]) != 20000 {
+		t.Errorf("expected a single 200x100 rectangle, got %v (err %v)", union, err)
+	}
+
+	if got := SnapToNeighbors64(Paths64{left, right}, 0); !reflect.DeepEqual(got, Paths64{left, right}) {
+		t.Errorf("expected a copy for tolerance 0, got %v", got)
+	}
+}
@@ -0,0 +1,222 @@
+package clipper
+
+import (
+	"math"
+	"slices"
+	"sort"
+)
+
+// ==============================================================================
+// Snap Rounding of Crossing Input
+// ==============================================================================
+
+// The sweep rounds every crossing it computes to the integer grid. Rounding
+// moves the edges on both sides of a crossing by up to half a unit, and a
+// moved edge can then cross an edge it missed before, or miss one it crossed,
+// after the sweep has passed it: the output ring folds over itself and a
+// union of the output differs from the output. Snap rounding the input first
+// avoids this. The plane is split into pixels, the points rounding half away
+// from zero to the same grid point. Pixels holding a crossing or a vertex are
+// hot, and every edge passing through a hot pixel is bent through its grid
+// point, repeatedly, as the bent edges can pass through pixels the straight
+// one missed. Edges of the result meet only at vertices, so the sweep
+// computes no crossings and builds its output from exact input points.
+
+// snapRoundRings returns the closed rings of subjects and clips snap rounded
+// together, or both unchanged if no two of their edges cross. Changed rings
+// are sanitized again, as snapping can fold an edge onto its neighbour. No
+// point moves by more than a unit, and the winding number changes only
+// within a unit of a crossing.
+func snapRoundRings(subjects, clips Paths64) (Paths64, Paths64) {
+	segs := ringSegments(subjects, clips)
+	var hot []Point64
+	forEachCrossing(segs, func(i, j int) {
+		hot = append(hot, crossingPixel(segs[i], segs[j]))
+	})
+	if len(hot) == 0 {
+		return subjects, clips
+	}
+	for _, seg := range segs {
+		hot = append(hot, seg[0])
+	}
+	sort.Slice(hot, func(i, j int) bool { return pointLess(hot[i], hot[j]) })
+	px := hotPixels(slices.Compact(hot))
+	return px.snapRings(subjects), px.snapRings(clips)
+}
+
+// ringsCross reports whether two edges of the closed rings in paths cross,
+// so that snapRoundRings would change them
+func ringsCross(paths ...Paths64) bool {
+	crossed := false
+	forEachCrossing(ringSegments(paths...), func(int, int) { crossed = true })
+	return crossed
+}
+
+// ringSegments returns the edges of the closed rings in paths
+func ringSegments(paths ...Paths64) [][2]Point64 {
+	var segs [][2]Point64
+	for _, group := range paths {
+		for _, path := range group {
+			for i, a := range path {
+				segs = append(segs, [2]Point64{a, path[(i+1)%len(path)]})
+			}
+		}
+	}
+	return segs
+}
+
+// snapsCrossings reports whether the engine snap rounds crossing input
+// before a sweep with the given rounding strategy. Strategies other than
+// NearestRounding round to a grid the pixels would not match.
+func snapsCrossings(rounding RoundingStrategy) bool {
+	switch rounding.(type) {
+	case nil, NearestRounding:
+		return true
+	}
+	return false
+}
+
+// snapRound applies snapRoundRings to the closed paths of an operation if
+// the engine's rounding strategy allows it
+func (ve *VattiEngine) snapRound(subjects, clips Paths64) (Paths64, Paths64) {
+	if !snapsCrossings(ve.rounding) {
+		return subjects, clips
+	}
+	return snapRoundRings(subjects, clips)
+}
+
+// crossingPixel returns the pixel of the point where segments a and b
+// cross, which must be interior to both. The crossing is computed in
+// floating point unless its error bound reaches a pixel boundary.
+func crossingPixel(a, b [2]Point64) Point64 {
+	dx1 := float64(a[1].X - a[0].X)
+	dy1 := float64(a[1].Y - a[0].Y)
+	dx2 := float64(b[1].X - b[0].X)
+	dy2 := float64(b[1].Y - b[0].Y)
+	det := dy1*dx2 - dy2*dx1
+	num := float64(a[0].X-b[0].X)*dy2 - float64(a[0].Y-b[0].Y)*dx2
+
+	// The error bound of crossingNeedsExact, with a margin
+	const eps = 1.0 / (1 << 52)
+	detMag := math.Abs(dy1*dx2) + math.Abs(dy2*dx1)
+	numMag := math.Abs(float64(a[0].X-b[0].X)*dy2) + math.Abs(float64(a[0].Y-b[0].Y)*dx2)
+	bound := 4 * (numMag + detMag) * (math.Abs(dx1) + math.Abs(dy1)) * eps / math.Abs(det)
+
+	t := num / det
+	x, y := t*dx1, t*dy1
+	nearHalf := func(v float64) bool { return math.Abs(v-math.Floor(v)-0.5) <= bound }
+	if det == 0 || bound >= 0.5 || nearHalf(x) || nearHalf(y) {
+		_, ex, ey, _ := exactCrossing(a[0], a[1], b[0], b[1])
+		return Point64{X: roundRatHalfAway(ex), Y: roundRatHalfAway(ey)}
+	}
+	return Point64{X: a[0].X + int64(math.Floor(x+0.5)), Y: a[0].Y + int64(math.Floor(y+0.5))}
+}
+
+// hotPixels are the grid points of the hot pixels, sorted by X, then Y
+type hotPixels []Point64
+
+// snapRings snaps every ring of paths, only copying paths if a ring changes
+func (px hotPixels) snapRings(paths Paths64) Paths64 {
+	result := paths
+	copied := false
+	for i, path := range paths {
+		ring := px.snapRing(path)
+		if len(ring) == len(path) {
+			if copied {
+				result = append(result, path)
+			}
+			continue
+		}
+		if !copied {
+			result = append(make(Paths64, 0, len(paths)), paths[:i]...)
+			copied = true
+		}
+		result = append(result, sanitizeRing(ring)...)
+	}
+	return result
+}
+
+// snapRing returns path with every edge bent through the hot pixels it
+// passes. Snapping only inserts vertices, so an unchanged ring keeps its
+// length.
+func (px hotPixels) snapRing(path Path64) Path64 {
+	var out Path64
+	for i, a := range path {
+		out = px.snapLink(a, path[(i+1)%len(path)], append(out, a))
+	}
+	return out
+}
+
+// snapLink appends the grid points the link a-b is bent through, excluding
+// a and b, to dst
+func (px hotPixels) snapLink(a, b Point64, dst Path64) Path64 {
+	hits := px.touchedBy(a, b)
+	if len(hits) == 0 {
+		return dst
+	}
+	dir := b.Sub(a)
+	sort.Slice(hits, func(i, j int) bool {
+		return hits[i].Sub(a).Dot128(dir).Cmp(hits[j].Sub(a).Dot128(dir)) < 0
+	})
+	prev := a
+	for _, pt := range hits {
+		dst = px.snapLink(prev, pt, dst)
+		dst = append(dst, pt)
+		prev = pt
+	}
+	return px.snapLink(prev, b, dst)
+}
+
+// touchedBy returns the hot pixels other than those of a and b that the
+// segment a-b passes through. The pixel of grid point h is the unit square
+// around it; which of its sides it owns follows from rounding half away from
+// zero. A segment between grid points meets the closed square if it overlaps
+// the segment's bounds and the corners h±(½,½) do not all lie strictly on
+// one side of its line, which in doubled coordinates is
+// |2·cross(b-a, h-a)| ≤ |Δx|+|Δy|. Where the two are equal the line only
+// touches a corner, and the pixel counts if it owns that corner.
+func (px hotPixels) touchedBy(a, b Point64) []Point64 {
+	minX, maxX := minMax64(a.X, b.X)
+	minY, maxY := minMax64(a.Y, b.Y)
+	dir := b.Sub(a)
+	reach := NewInt128(abs64(dir.X)).Add(NewInt128(abs64(dir.Y)))
+	start := sort.Search(len(px), func(i int) bool { return px[i].X >= minX })
+
+	// owns reports whether the pixel of grid coordinate v includes v+s/2
+	owns := func(v, s int64) bool { return (s > 0 && v < 0) || (s < 0 && v > 0) }
+
+	var found []Point64
+	for _, h := range px[start:] {
+		if h.X > maxX {
+			break
+		}
+		if h.Y < minY || h.Y > maxY || h == a || h == b {
+			continue
+		}
+		side := dir.Cross128(h.Sub(a))
+		side = side.Add(side)
+		abs := side
+		if abs.IsNegative() {
+			abs = abs.Negate()
+		}
+		switch abs.Cmp(reach) {
+		case 1:
+			continue
+		case 0:
+			// The corner h+s/2 on the line has cross(dir, s) = -side
+			var sx, sy int64
+			for _, s := range [4][2]int64{{1, 1}, {1, -1}, {-1, 1}, {-1, -1}} {
+				if NewInt128(s[1]).Mul64(dir.X).Sub(NewInt128(s[0]).Mul64(dir.Y)).Add(side).IsZero() {
+					sx, sy = s[0], s[1]
+					break
+				}
+			}
+			onSegment := 2*h.X+sx >= 2*minX && 2*h.X+sx <= 2*maxX && 2*h.Y+sy >= 2*minY && 2*h.Y+sy <= 2*maxY
+			if !onSegment || !owns(h.X, sx) || !owns(h.Y, sy) {
+				continue
+			}
+		}
+		found = append(found, h)
+	}
+	return found
+}
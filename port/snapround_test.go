@@ -0,0 +1,93 @@
+package clipper
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestSnapRoundRings(t *testing.T) {
+	square := Paths64{{{0, 0}, {10, 0}, {10, 10}, {0, 10}}}
+	touching := Paths64{{{10, 10}, {20, 10}, {20, 20}, {10, 20}}}
+	subjects, clips := snapRoundRings(square, touching)
+	if !reflect.DeepEqual(subjects, square) || !reflect.DeepEqual(clips, touching) {
+		t.Errorf("expected rings meeting only at vertices unchanged, got %v and %v", subjects, clips)
+	}
+
+	tests := []struct {
+		name     string
+		subjects Paths64
+		expected Paths64
+	}{
+		{
+			"Crossing on the grid",
+			Paths64{{{0, 0}, {10, 10}, {10, 0}, {0, 10}}},
+			Paths64{{{5, 5}, {10, 10}, {10, 0}}, {{0, 0}, {5, 5}, {0, 10}}},
+		},
+		{
+			"Crossing rounded half away from zero",
+			Paths64{{{0, 0}, {3, 3}, {3, 0}, {0, 3}}},
+			Paths64{{{2, 2}, {3, 3}, {3, 0}}, {{0, 0}, {2, 2}, {0, 3}}},
+		},
+		{
+			"Negative crossing rounded half away from zero",
+			Paths64{{{0, 0}, {-3, -3}, {-3, 0}, {0, -3}}},
+			Paths64{{{-2, -2}, {-3, -3}, {-3, 0}}, {{0, 0}, {-2, -2}, {0, -3}}},
+		},
+		{
+			"Edge bent through a vertex it passes",
+			Paths64{{{0, 0}, {10, 1}, {10, 3}, {5, 1}, {0, 3}, {4, -1}}},
+			Paths64{{{5, 1}, {10, 1}, {10, 3}}, {{3, 0}, {5, 1}, {0, 3}}, {{0, 0}, {3, 0}, {4, -1}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := snapRoundRings(tt.subjects, nil)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+			if ringsCross(got) {
+				t.Errorf("snapped rings %v still cross", got)
+			}
+		})
+	}
+}
+
+func TestSnapRoundRingsRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		span := []int64{4, 20, 1000, 1 << 40}[r.Intn(4)]
+		subjects := Paths64{randomPath(r, 3+r.Intn(12), span)}
+		clips := Paths64{randomPath(r, 3+r.Intn(8), span)}
+		s, c := snapRoundRings(subjects, clips)
+		if ringsCross(s, c) {
+			t.Fatalf("snapped rings %v and %v of %v and %v still cross", s, c, subjects, clips)
+		}
+	}
+}
+
+func TestHotPixelsTouchedBy(t *testing.T) {
+	tests := []struct {
+		name     string
+		pixels   hotPixels
+		a, b     Point64
+		expected []Point64
+	}{
+		{"Pixel the edge passes through", hotPixels{{2, 1}}, Point64{0, 0}, Point64{5, 2}, []Point64{{2, 1}}},
+		{"Pixel the edge misses", hotPixels{{2, 2}}, Point64{0, 0}, Point64{5, 2}, nil},
+		{"End points excluded", hotPixels{{0, 0}, {5, 2}}, Point64{0, 0}, Point64{5, 2}, nil},
+		// (2.5, 2.5) rounds to (3, 3), so neither pixel beside it owns it
+		{"Corner owned by neither pixel", hotPixels{{2, 3}, {3, 2}}, Point64{2, 2}, Point64{3, 3}, nil},
+		{"Corner owned by the pixel", hotPixels{{2, 2}, {3, 3}}, Point64{3, 2}, Point64{2, 3}, []Point64{{3, 3}}},
+		{"Negative corner owned by the pixel", hotPixels{{-3, -3}, {-2, -2}}, Point64{-3, -2}, Point64{-2, -3}, []Point64{{-3, -3}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.pixels.touchedBy(tt.a, tt.b); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package clipper
+
+import (
+	"math"
+	"sort"
+)
+
+// ==============================================================================
+// Splitting Open Paths at Points
+// ==============================================================================
+
+// pathCut is a location along a path: the start of segment seg (t == 0) or a
+// point inside it (0 < t < 1)
+type pathCut struct {
+	seg  int
+	t    float64
+	pt   Point64
+	dist float64 // distance from the requested split point
+}
+
+// SplitPathAt64 cuts an open path into pieces at the given points, for
+// example the crossings of a line with polygon boundaries. A point within
+// tolerance of a vertex splits the path there; otherwise the path is split
+// at the nearest point of every segment passing within tolerance, inserting
+// that point as a new vertex. Points farther than tolerance from the path
+// and points at its ends are ignored.
+//
+// Pieces are returned in path order and share their cut vertices. The path
+// needs at least two points and tolerance must not be negative, otherwise
+// ErrInvalidInput is returned.
+func SplitPathAt64(path Path64, points []Point64, tolerance int64) (Paths64, error) {
+	if len(path) < 2 || tolerance < 0 {
+		return nil, ErrInvalidInput
+	}
+
+	var cuts []pathCut
+	for _, pt := range points {
+		cuts = append(cuts, findPathCuts(path, pt, float64(tolerance))...)
+	}
+	sort.Slice(cuts, func(i, j int) bool {
+		if cuts[i].seg != cuts[j].seg {
+			return cuts[i].seg < cuts[j].seg
+		}
+		return cuts[i].t < cuts[j].t
+	})
+
+	var result Paths64
+	current := Path64{}
+	appendPt := func(pt Point64) {
+		if len(current) == 0 || current[len(current)-1] != pt {
+			current = append(current, pt)
+		}
+	}
+	splitAt := func(pt Point64) {
+		if len(current) >= 2 {
+			result = append(result, current)
+		}
+		current = Path64{pt}
+	}
+
+	last := len(path) - 1
+	for i, pt := range path {
+		appendPt(pt)
+		for len(cuts) > 0 && cuts[0].seg == i {
+			cut := cuts[0]
+			cuts = cuts[1:]
+			if cut.t == 0 && (i == 0 || i == last) {
+				continue // the path already ends here
+			}
+			appendPt(cut.pt)
+			if len(current) >= 2 {
+				splitAt(cut.pt)
+			}
+		}
+	}
+	if len(current) >= 2 {
+		result = append(result, current)
+	}
+	return result, nil
+}
+
+// findPathCuts returns where pt splits path: at every vertex within
+// tolerance or, if there is none, at the nearest point of every segment
+// within tolerance. Of two such points on adjacent segments only the closer
+// is kept, so a point near a corner does not cut off a sliver.
+func findPathCuts(path Path64, pt Point64, tolerance float64) []pathCut {
+	var cuts []pathCut
+	for i, v := range path {
+		if pt.DistanceTo(v) <= tolerance {
+			cuts = append(cuts, pathCut{seg: i, pt: v})
+		}
+	}
+	if len(cuts) > 0 {
+		return cuts
+	}
+
+	for i := 0; i+1 < len(path); i++ {
+		a, b := path[i], path[i+1]
+		if a == b {
+			continue
+		}
+		dx, dy := float64(b.X-a.X), float64(b.Y-a.Y)
+		t := (float64(pt.X-a.X)*dx + float64(pt.Y-a.Y)*dy) / (dx*dx + dy*dy)
+		if t <= 0 || t >= 1 {
+			continue // nearest point is a vertex, which is out of tolerance
+		}
+		px, py := float64(a.X)+t*dx, float64(a.Y)+t*dy
+		dist := math.Hypot(float64(pt.X)-px, float64(pt.Y)-py)
+		if dist > tolerance {
+			continue
+		}
+		cut := pathCut{seg: i, t: t, pt: Point64{X: RoundHalfAway(px), Y: RoundHalfAway(py)}, dist: dist}
+		if n := len(cuts); n > 0 && cuts[n-1].seg == i-1 {
+			if cut.dist < cuts[n-1].dist {
+				cuts[n-1] = cut
+			}
+			continue
+		}
+		cuts = append(cuts, cut)
+	}
+	return cuts
+}
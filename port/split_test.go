@@ -0,0 +1,96 @@
+package clipper
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSplitPathAt64(t *testing.T) {
+	line := Path64{{0, 0}, {100, 0}, {100, 100}}
+
+	tests := []struct {
+		name      string
+		points    []Point64
+		tolerance int64
+		expected  Paths64
+	}{
+		{
+			name:     "no points",
+			expected: Paths64{line},
+		},
+		{
+			name:     "inside a segment",
+			points:   []Point64{{40, 0}},
+			expected: Paths64{{{0, 0}, {40, 0}}, {{40, 0}, {100, 0}, {100, 100}}},
+		},
+		{
+			name:      "snapped onto a segment",
+			points:    []Point64{{40, 3}},
+			tolerance: 5,
+			expected:  Paths64{{{0, 0}, {40, 0}}, {{40, 0}, {100, 0}, {100, 100}}},
+		},
+		{
+			name:      "out of tolerance",
+			points:    []Point64{{40, 6}},
+			tolerance: 5,
+			expected:  Paths64{line},
+		},
+		{
+			name:      "snapped to a vertex",
+			points:    []Point64{{98, 2}},
+			tolerance: 3,
+			expected:  Paths64{{{0, 0}, {100, 0}}, {{100, 0}, {100, 100}}},
+		},
+		{
+			name:      "near a corner, nearest segment wins",
+			points:    []Point64{{95, 3}},
+			tolerance: 5,
+			expected:  Paths64{{{0, 0}, {95, 0}}, {{95, 0}, {100, 0}, {100, 100}}},
+		},
+		{
+			name:     "several points, unordered and repeated",
+			points:   []Point64{{100, 50}, {20, 0}, {100, 50}, {60, 0}},
+			expected: Paths64{{{0, 0}, {20, 0}}, {{20, 0}, {60, 0}}, {{60, 0}, {100, 0}, {100, 50}}, {{100, 50}, {100, 100}}},
+		},
+		{
+			name:     "at the ends",
+			points:   []Point64{{0, 0}, {100, 100}},
+			expected: Paths64{line},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := SplitPathAt64(line, tt.points, tt.tolerance)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestSplitPathAt64SelfCrossing(t *testing.T) {
+	// the path passes the crossing point twice and is cut both times
+	path := Path64{{0, 0}, {100, 100}, {100, 0}, {0, 100}}
+	result, err := SplitPathAt64(path, []Point64{{50, 50}}, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := Paths64{{{0, 0}, {50, 50}}, {{50, 50}, {100, 100}, {100, 0}, {50, 50}}, {{50, 50}, {0, 100}}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+}
+
+func TestSplitPathAt64InvalidInput(t *testing.T) {
+	if _, err := SplitPathAt64(Path64{{0, 0}}, nil, 0); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for a single point, got %v", err)
+	}
+	if _, err := SplitPathAt64(Path64{{0, 0}, {1, 0}}, nil, -1); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for negative tolerance, got %v", err)
+	}
+}
@@ -0,0 +1,72 @@
+package clipper
+
+import "math"
+
+// ==============================================================================
+// Polygon Set Statistics
+// ==============================================================================
+
+// PathStats summarizes a polygon set for logging and telemetry
+type PathStats struct {
+	Rings     int     // number of rings with at least one vertex
+	Holes     int     // rings that are holes
+	Vertices  int     // total vertex count
+	Bounds    Rect64  // bounds of all vertices (InvalidRect64 if empty)
+	GrossArea float64 // sum of absolute ring areas
+	NetArea   float64 // filled area: outer areas minus hole areas
+}
+
+// Stats64 returns the statistics of a clipping solution in one pass. Holes
+// are recognized by orientation, which matches the output contract (outers
+// counter-clockwise, holes clockwise), so NetArea is the sum of signed ring
+// areas. For arbitrary input use PolyTree64.Stats, which relies on nesting.
+func Stats64(paths Paths64) PathStats {
+	stats := PathStats{Bounds: InvalidRect64}
+	for _, path := range paths {
+		area := stats.addRing(path)
+		if area < 0 {
+			stats.Holes++
+		}
+		stats.NetArea += area
+	}
+	return stats
+}
+
+// Stats returns the statistics of the hierarchy below pp in one traversal.
+// Holes are the nodes at even depth, so NetArea does not depend on ring
+// orientation.
+func (pp *PolyPath64) Stats() PathStats {
+	stats := PathStats{Bounds: InvalidRect64}
+	var visit func(node *PolyPath64)
+	visit = func(node *PolyPath64) {
+		if area := math.Abs(stats.addRing(node.Path)); node.IsHole() {
+			stats.Holes++
+			stats.NetArea -= area
+		} else {
+			stats.NetArea += area
+		}
+		for _, child := range node.Children {
+			visit(child)
+		}
+	}
+	visit(pp)
+	return stats
+}
+
+// addRing counts path and extends the bounds, returning its signed area
+func (s *PathStats) addRing(path Path64) float64 {
+	if len(path) == 0 {
+		return 0
+	}
+	s.Rings++
+	s.Vertices += len(path)
+	for _, pt := range path {
+		s.Bounds.Left = min64(s.Bounds.Left, pt.X)
+		s.Bounds.Top = min64(s.Bounds.Top, pt.Y)
+		s.Bounds.Right = max64(s.Bounds.Right, pt.X)
+		s.Bounds.Bottom = max64(s.Bounds.Bottom, pt.Y)
+	}
+	area := Area64(path)
+	s.GrossArea += math.Abs(area)
+	return area
+}
@@ -0,0 +1,49 @@
+package clipper
+
+import "testing"
+
+// TestStats64 tests single-pass statistics of an oriented solution
+func TestStats64(t *testing.T) {
+	paths := Paths64{
+		{{0, 0}, {100, 0}, {100, 100}, {0, 100}},   // outer, area 10000
+		{{20, 20}, {20, 80}, {80, 80}, {80, 20}},   // hole, area -3600
+		{{200, 0}, {250, 0}, {250, 50}, {200, 50}}, // outer, area 2500
+		{},
+	}
+
+	stats := Stats64(paths)
+	expected := PathStats{
+		Rings: 3, Holes: 1, Vertices: 12,
+		Bounds:    Rect64{0, 0, 250, 100},
+		GrossArea: 16100, NetArea: 8900,
+	}
+	if stats != expected {
+		t.Errorf("Stats64 = %+v, expected %+v", stats, expected)
+	}
+
+	if empty := Stats64(nil); empty != (PathStats{Bounds: InvalidRect64}) {
+		t.Errorf("expected zero stats for empty input, got %+v", empty)
+	}
+}
+
+// TestPolyTree64Stats tests that tree statistics use nesting, not orientation
+func TestPolyTree64Stats(t *testing.T) {
+	tree := NewPolyTree64()
+	outer := tree.AddChild(Path64{{0, 0}, {100, 0}, {100, 100}, {0, 100}})
+	hole := outer.AddChild(Path64{{20, 20}, {80, 20}, {80, 80}, {20, 80}}) // CCW
+	hole.AddChild(Path64{{40, 40}, {60, 40}, {60, 60}, {40, 60}})
+
+	stats := tree.Stats()
+	expected := PathStats{
+		Rings: 3, Holes: 1, Vertices: 12,
+		Bounds:    Rect64{0, 0, 100, 100},
+		GrossArea: 14000, NetArea: 6800,
+	}
+	if stats != expected {
+		t.Errorf("Stats = %+v, expected %+v", stats, expected)
+	}
+
+	if got := hole.Stats(); got.Rings != 2 || got.Holes != 1 || got.NetArea != -3200 {
+		t.Errorf("subtree Stats = %+v, expected 2 rings, 1 hole, net -3200", got)
+	}
+}
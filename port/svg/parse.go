@@ -0,0 +1,407 @@
+// Package svg reads SVG path data into clipping paths and writes debug SVG
+// images of clipping operations in the style of Clipper2's SvgUtils: the
+// subjects, clips and solution of an operation drawn as semi-transparent
+// layers with their fill rules, which is usually the quickest way to see
+// what went wrong with a reported geometry bug.
+//
+// Coordinates are used as they are, so the images show Y pointing down as
+// SVG does.
+package svg
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	clipper "github.com/go-clipper/clipper2/port"
+)
+
+// CurveSegments is the number of line segments each Bézier curve and each
+// quarter of an elliptical arc is flattened into
+const CurveSegments = 16
+
+// ==============================================================================
+// Path Data Parsing
+// ==============================================================================
+
+// ParsePathD parses the "d" attribute of an SVG path element into one path
+// per subpath. All commands are accepted in absolute and relative form:
+// M, L, H, V and Z for lines, and C, S, Q, T and A for curves, which are
+// flattened into CurveSegments segments. The point repeated by Z is not
+// kept, and subpaths with a single point are dropped. Malformed data fails
+// with clipper.ErrInvalidInput.
+func ParsePathD(d string) (clipper.PathsD, error) {
+	p := pathParser{scanner: scanner{s: d}}
+	if err := p.parse(); err != nil {
+		return nil, err
+	}
+	return p.paths, nil
+}
+
+// ParsePath parses path data like ParsePathD and scales it to integer
+// coordinates with precision decimal places
+func ParsePath(d string, precision int) (clipper.Paths64, error) {
+	paths, err := ParsePathD(d)
+	if err != nil {
+		return nil, err
+	}
+	scaled, err := clipper.PathsDToPaths64(paths, precision)
+	if err != nil {
+		return nil, fmt.Errorf("%w: coordinates cannot be scaled to precision %d", clipper.ErrInvalidInput, precision)
+	}
+	return scaled, nil
+}
+
+// pointCounts is the number of points each remaining command takes
+var pointCounts = map[byte]int{'M': 1, 'L': 1, 'C': 3, 'S': 2, 'Q': 2, 'T': 1}
+
+// pathParser holds the state of the path data interpreter
+type pathParser struct {
+	scanner
+	paths   clipper.PathsD
+	current clipper.PathD
+	pos     clipper.PointD // current point
+	start   clipper.PointD // first point of the current subpath
+	control clipper.PointD // last control point, for S and T
+}
+
+// parse interprets every command of the path data
+func (p *pathParser) parse() error {
+	var cmd, prev byte
+	for {
+		p.skip()
+		if p.done() {
+			break
+		}
+		c := p.s[p.i]
+		switch {
+		case isCommand(c):
+			cmd = c
+			p.i++
+		case cmd == 0 || cmd == 'Z' || cmd == 'z':
+			return fmt.Errorf("%w: expected a command at offset %d", clipper.ErrInvalidInput, p.i)
+		case cmd == 'M':
+			cmd = 'L' // coordinates after a moveto are implicit linetos
+		case cmd == 'm':
+			cmd = 'l'
+		}
+		if err := p.command(cmd, prev); err != nil {
+			return err
+		}
+		prev = cmd
+	}
+	p.endSubpath()
+	return nil
+}
+
+// command interprets one command with its parameters; prev is the previous
+// command, which decides whether S and T reflect a control point
+func (p *pathParser) command(cmd, prev byte) error {
+	relative := cmd >= 'a'
+	var origin clipper.PointD
+	if relative {
+		origin = p.pos
+	}
+	upper := cmd &^ 0x20
+
+	switch upper {
+	case 'Z':
+		p.close()
+		return nil
+	case 'H', 'V':
+		v, err := p.numbers(1)
+		if err != nil {
+			return err
+		}
+		pt := p.pos
+		if upper == 'H' {
+			pt.X = v[0]
+			if relative {
+				pt.X += origin.X
+			}
+		} else {
+			pt.Y = v[0]
+			if relative {
+				pt.Y += origin.Y
+			}
+		}
+		p.lineTo(pt)
+		return nil
+	case 'A':
+		v, err := p.arcParameters()
+		if err != nil {
+			return err
+		}
+		p.arcTo(v[0], v[1], v[2], v[3] != 0, v[4] != 0, clipper.PointD{X: origin.X + v[5], Y: origin.Y + v[6]})
+		return nil
+	}
+
+	v, err := p.numbers(2 * pointCounts[upper])
+	if err != nil {
+		return err
+	}
+	pts := make([]clipper.PointD, len(v)/2)
+	for i := range pts {
+		pts[i] = clipper.PointD{X: origin.X + v[2*i], Y: origin.Y + v[2*i+1]}
+	}
+
+	prevUpper := prev &^ 0x20
+	switch upper {
+	case 'M':
+		p.endSubpath()
+		p.pos, p.start = pts[0], pts[0]
+		p.current = clipper.PathD{pts[0]}
+	case 'L':
+		p.lineTo(pts[0])
+	case 'C':
+		p.cubicTo(pts[0], pts[1], pts[2])
+	case 'S':
+		p.cubicTo(p.reflected(prevUpper == 'C' || prevUpper == 'S'), pts[0], pts[1])
+	case 'Q':
+		p.quadraticTo(pts[0], pts[1])
+	case 'T':
+		p.quadraticTo(p.reflected(prevUpper == 'Q' || prevUpper == 'T'), pts[0])
+	}
+	return nil
+}
+
+// reflected returns the reflection of the last control point through the
+// current point, or the current point if the previous command was not a
+// curve of the same kind
+func (p *pathParser) reflected(ok bool) clipper.PointD {
+	if !ok {
+		return p.pos
+	}
+	return clipper.PointD{X: 2*p.pos.X - p.control.X, Y: 2*p.pos.Y - p.control.Y}
+}
+
+// lineTo appends pt to the current subpath, starting one at the current
+// point if a Z closed the previous one
+func (p *pathParser) lineTo(pt clipper.PointD) {
+	if p.current == nil {
+		p.current = clipper.PathD{p.pos}
+	}
+	if pt != p.current[len(p.current)-1] {
+		p.current = append(p.current, pt)
+	}
+	p.pos, p.control = pt, pt
+}
+
+// cubicTo flattens a cubic Bézier curve from the current point
+func (p *pathParser) cubicTo(c1, c2, end clipper.PointD) {
+	p0 := p.pos
+	for i := 1; i <= CurveSegments; i++ {
+		t := float64(i) / CurveSegments
+		a, b, c, d := (1-t)*(1-t)*(1-t), 3*(1-t)*(1-t)*t, 3*(1-t)*t*t, t*t*t
+		p.lineTo(clipper.PointD{
+			X: a*p0.X + b*c1.X + c*c2.X + d*end.X,
+			Y: a*p0.Y + b*c1.Y + c*c2.Y + d*end.Y,
+		})
+	}
+	p.pos, p.control = end, c2
+}
+
+// quadraticTo flattens a quadratic Bézier curve from the current point
+func (p *pathParser) quadraticTo(c1, end clipper.PointD) {
+	p0 := p.pos
+	for i := 1; i <= CurveSegments; i++ {
+		t := float64(i) / CurveSegments
+		a, b, c := (1-t)*(1-t), 2*(1-t)*t, t*t
+		p.lineTo(clipper.PointD{
+			X: a*p0.X + b*c1.X + c*end.X,
+			Y: a*p0.Y + b*c1.Y + c*end.Y,
+		})
+	}
+	p.pos, p.control = end, c1
+}
+
+// arcTo flattens an elliptical arc from the current point to end, converting
+// the endpoint parameterization to a center one as in the SVG specification
+// (appendix B.2.4)
+func (p *pathParser) arcTo(rx, ry, rotation float64, largeArc, sweep bool, end clipper.PointD) {
+	start := p.pos
+	rx, ry = math.Abs(rx), math.Abs(ry)
+	if start == end {
+		return
+	}
+	if rx == 0 || ry == 0 {
+		p.lineTo(end)
+		return
+	}
+
+	sinPhi, cosPhi := math.Sincos(rotation * math.Pi / 180)
+	dx, dy := (start.X-end.X)/2, (start.Y-end.Y)/2
+	x1 := cosPhi*dx + sinPhi*dy
+	y1 := -sinPhi*dx + cosPhi*dy
+
+	// Radii too small to reach end are scaled up until they just do
+	if lambda := x1*x1/(rx*rx) + y1*y1/(ry*ry); lambda > 1 {
+		rx, ry = rx*math.Sqrt(lambda), ry*math.Sqrt(lambda)
+	}
+	num := rx*rx*ry*ry - rx*rx*y1*y1 - ry*ry*x1*x1
+	den := rx*rx*y1*y1 + ry*ry*x1*x1
+	coef := math.Sqrt(math.Max(0, num/den))
+	if largeArc == sweep {
+		coef = -coef
+	}
+	cx1, cy1 := coef*rx*y1/ry, -coef*ry*x1/rx
+	cx := cosPhi*cx1 - sinPhi*cy1 + (start.X+end.X)/2
+	cy := sinPhi*cx1 + cosPhi*cy1 + (start.Y+end.Y)/2
+
+	theta := math.Atan2((y1-cy1)/ry, (x1-cx1)/rx)
+	delta := math.Atan2((-y1-cy1)/ry, (-x1-cx1)/rx) - theta
+	if sweep && delta < 0 {
+		delta += 2 * math.Pi
+	} else if !sweep && delta > 0 {
+		delta -= 2 * math.Pi
+	}
+
+	steps := max(1, int(math.Ceil(math.Abs(delta)/(math.Pi/2)*CurveSegments)))
+	for i := 1; i < steps; i++ {
+		sinA, cosA := math.Sincos(theta + delta*float64(i)/float64(steps))
+		p.lineTo(clipper.PointD{
+			X: cx + rx*cosA*cosPhi - ry*sinA*sinPhi,
+			Y: cy + rx*cosA*sinPhi + ry*sinA*cosPhi,
+		})
+	}
+	p.lineTo(end)
+}
+
+// close ends the current subpath as a closed path and returns to its start
+func (p *pathParser) close() {
+	if n := len(p.current); n > 1 && p.current[n-1] == p.current[0] {
+		p.current = p.current[:n-1]
+	}
+	p.endSubpath()
+	p.pos, p.control = p.start, p.start
+}
+
+// endSubpath keeps the current subpath if it has more than one point
+func (p *pathParser) endSubpath() {
+	if len(p.current) > 1 {
+		p.paths = append(p.paths, p.current)
+	}
+	p.current = nil
+}
+
+// ==============================================================================
+// Tokenizer
+// ==============================================================================
+
+// scanner splits path data into commands and numbers
+type scanner struct {
+	s string
+	i int
+}
+
+// isCommand reports whether c is a path command letter
+func isCommand(c byte) bool {
+	switch c &^ 0x20 {
+	case 'M', 'L', 'H', 'V', 'Z', 'C', 'S', 'Q', 'T', 'A':
+		return true
+	}
+	return false
+}
+
+// done reports whether the whole input has been read
+func (sc *scanner) done() bool {
+	return sc.i >= len(sc.s)
+}
+
+// skip advances past whitespace and commas
+func (sc *scanner) skip() {
+	for !sc.done() {
+		switch sc.s[sc.i] {
+		case ' ', '\t', '\n', '\r', '\f', ',':
+			sc.i++
+		default:
+			return
+		}
+	}
+}
+
+// numbers reads n numbers
+func (sc *scanner) numbers(n int) ([]float64, error) {
+	v := make([]float64, n)
+	for k := range v {
+		x, err := sc.number()
+		if err != nil {
+			return nil, err
+		}
+		v[k] = x
+	}
+	return v, nil
+}
+
+// number reads one number; "1.5.5" is read as 1.5 followed by .5 and
+// "1-2" as 1 followed by -2, as SVG allows
+func (sc *scanner) number() (float64, error) {
+	sc.skip()
+	begin := sc.i
+	if !sc.done() && (sc.s[sc.i] == '+' || sc.s[sc.i] == '-') {
+		sc.i++
+	}
+	digits := sc.digits()
+	if !sc.done() && sc.s[sc.i] == '.' {
+		sc.i++
+		digits += sc.digits()
+	}
+	if digits == 0 {
+		sc.i = begin
+		return 0, fmt.Errorf("%w: expected a number at offset %d", clipper.ErrInvalidInput, begin)
+	}
+	if !sc.done() && (sc.s[sc.i] == 'e' || sc.s[sc.i] == 'E') {
+		mark := sc.i
+		sc.i++
+		if !sc.done() && (sc.s[sc.i] == '+' || sc.s[sc.i] == '-') {
+			sc.i++
+		}
+		if sc.digits() == 0 {
+			sc.i = mark // an "e" without digits is not an exponent
+		}
+	}
+	v, err := strconv.ParseFloat(sc.s[begin:sc.i], 64)
+	if err != nil || math.IsInf(v, 0) {
+		return 0, fmt.Errorf("%w: number %q out of range", clipper.ErrInvalidInput, sc.s[begin:sc.i])
+	}
+	return v, nil
+}
+
+// digits advances past decimal digits and returns how many there were
+func (sc *scanner) digits() int {
+	begin := sc.i
+	for !sc.done() && sc.s[sc.i] >= '0' && sc.s[sc.i] <= '9' {
+		sc.i++
+	}
+	return sc.i - begin
+}
+
+// flag reads an arc flag, a single 0 or 1 that needs no separator
+func (sc *scanner) flag() (float64, error) {
+	sc.skip()
+	if !sc.done() && (sc.s[sc.i] == '0' || sc.s[sc.i] == '1') {
+		sc.i++
+		return float64(sc.s[sc.i-1] - '0'), nil
+	}
+	return 0, fmt.Errorf("%w: expected an arc flag at offset %d", clipper.ErrInvalidInput, sc.i)
+}
+
+// arcParameters reads rx ry rotation large-arc sweep x y
+func (sc *scanner) arcParameters() ([]float64, error) {
+	v, err := sc.numbers(3)
+	if err != nil {
+		return nil, err
+	}
+	for range 2 {
+		f, err := sc.flag()
+		if err != nil {
+			return nil, err
+		}
+		v = append(v, f)
+	}
+	end, err := sc.numbers(2)
+	if err != nil {
+		return nil, err
+	}
+	return append(v, end...), nil
+}
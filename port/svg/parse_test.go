@@ -0,0 +1,102 @@
+package svg
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	clipper "github.com/go-clipper/clipper2/port"
+)
+
+func TestParsePathD(t *testing.T) {
+	tests := []struct {
+		name     string
+		d        string
+		expected clipper.PathsD
+	}{
+		{"Absolute lines", "M 0 0 L 10 0 L 10 10 Z", clipper.PathsD{{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}}}},
+		{"Relative lines", "m1,1 h4 v4 h-4z", clipper.PathsD{{{X: 1, Y: 1}, {X: 5, Y: 1}, {X: 5, Y: 5}, {X: 1, Y: 5}}}},
+		{"Implicit linetos", "M0 0 10 0 10 10", clipper.PathsD{{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}}}},
+		{"Compact numbers", "M0-1.5.5-0L1e1,2E0", clipper.PathsD{{{X: 0, Y: -1.5}, {X: 0.5, Y: 0}, {X: 10, Y: 2}}}},
+		{"Closing point dropped", "M0 0H4V4H0V0Z", clipper.PathsD{{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4}, {X: 0, Y: 4}}}},
+		{
+			"Subpaths",
+			"M0 0L4 0L4 4Z M10 10L14 10L14 14Z",
+			clipper.PathsD{{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4}}, {{X: 10, Y: 10}, {X: 14, Y: 10}, {X: 14, Y: 14}}},
+		},
+		{"Subpath after Z starts at its start", "M1 1L5 1L5 5Zl0 4", clipper.PathsD{{{X: 1, Y: 1}, {X: 5, Y: 1}, {X: 5, Y: 5}}, {{X: 1, Y: 1}, {X: 1, Y: 5}}}},
+		{"Lone moveto dropped", "M5 5 M0 0L1 1", clipper.PathsD{{{X: 0, Y: 0}, {X: 1, Y: 1}}}},
+		{"Empty", "  ", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePathD(tt.d)
+			if err != nil || !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v (err %v)", tt.expected, got, err)
+			}
+		})
+	}
+
+	for _, bad := range []string{"10 10", "M0", "M0 0 L1 x", "M0 0Z 5 5", "M0 0A1 1 0 2 0 1 1", "M1e999 0"} {
+		if _, err := ParsePathD(bad); !errors.Is(err, clipper.ErrInvalidInput) {
+			t.Errorf("%q: expected ErrInvalidInput, got %v", bad, err)
+		}
+	}
+}
+
+// TestParseCurves tests that flattened curves end where they should and
+// stay on the curve
+func TestParseCurves(t *testing.T) {
+	tests := []struct {
+		name   string
+		d      string
+		end    clipper.PointD
+		radius float64 // distance of every point from the origin, if nonzero
+	}{
+		{"Cubic", "M0 0C0 10 10 10 10 0", clipper.PointD{X: 10, Y: 0}, 0},
+		{"Smooth cubic", "M0 0c0 10 10 10 10 0s10 -10 10 0", clipper.PointD{X: 20, Y: 0}, 0},
+		{"Quadratic", "M0 0Q5 10 10 0T20 0", clipper.PointD{X: 20, Y: 0}, 0},
+		{"Arc", "M10 0A10 10 0 0 1 -10 0", clipper.PointD{X: -10, Y: 0}, 10},
+		{"Arc with compact flags", "M10 0a10 10 0 01-20 0", clipper.PointD{X: -10, Y: 0}, 10},
+		{"Arc radii scaled up", "M10 0A1 1 0 0 1 -10 0", clipper.PointD{X: -10, Y: 0}, 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePathD(tt.d)
+			if err != nil || len(got) != 1 {
+				t.Fatalf("expected one path, got %v (err %v)", got, err)
+			}
+			path := got[0]
+			if len(path) <= CurveSegments || path[len(path)-1] != tt.end {
+				t.Errorf("expected a flattened curve ending at %v, got %v", tt.end, path)
+			}
+			for _, pt := range path {
+				if tt.radius != 0 && math.Abs(math.Hypot(pt.X, pt.Y)-tt.radius) > 1e-9 {
+					t.Errorf("expected %v on the circle of radius %v", pt, tt.radius)
+				}
+			}
+		})
+	}
+
+	// A sweep flag of 1 goes through positive Y here, 0 through negative Y
+	for flag, sign := range map[string]float64{"1": 1, "0": -1} {
+		path, _ := ParsePathD("M10 0A10 10 0 0 " + flag + " -10 0")
+		if mid := path[0][len(path[0])/2]; mid.Y*sign < 9.9 {
+			t.Errorf("sweep %s: expected the arc through y=%v, got %v", flag, 10*sign, mid)
+		}
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	got, err := ParsePath("M0 0L1.25 0L1.25 0.5Z", 2)
+	expected := clipper.Paths64{{{X: 0, Y: 0}, {X: 125, Y: 0}, {X: 125, Y: 50}}}
+	if err != nil || !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v (err %v)", expected, got, err)
+	}
+	if _, err := ParsePath("M0 0L1 1", clipper.MaxPrecisionD+1); !errors.Is(err, clipper.ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for precision out of range, got %v", err)
+	}
+}
@@ -0,0 +1,226 @@
+package svg
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+
+	clipper "github.com/go-clipper/clipper2/port"
+)
+
+// Colors of the layers added by AddSubject, AddClip and AddSolution, as
+// ARGB values like Clipper2's SvgUtils
+const (
+	SubjectFill    uint32 = 0x1200009C
+	SubjectStroke  uint32 = 0xCCD3D3DA
+	ClipFill       uint32 = 0x129C0000
+	ClipStroke     uint32 = 0xCCFFA07A
+	SolutionFill   uint32 = 0x4466FF66
+	SolutionStroke uint32 = 0xFF003300
+	OpenStroke     uint32 = 0xFF006600
+)
+
+// ==============================================================================
+// Debug SVG Writer
+// ==============================================================================
+
+// Writer collects layers of paths and labels and renders them scaled to fit
+// an SVG image, in the order they were added
+type Writer struct {
+	layers []layer
+	texts  []label
+}
+
+// layer is one group of paths drawn with the same style
+type layer struct {
+	paths       clipper.Paths64
+	isOpen      bool
+	fillRule    clipper.FillRule
+	fill        uint32
+	stroke      uint32
+	strokeWidth float64
+	showCoords  bool
+}
+
+// label is text placed at a point in path coordinates
+type label struct {
+	text     string
+	pt       clipper.Point64
+	fontSize int
+	color    uint32
+}
+
+// NewWriter returns an empty writer
+func NewWriter() *Writer {
+	return &Writer{}
+}
+
+// AddPaths adds a layer of paths. Closed paths are filled under fillRule
+// and outlined; open paths are only stroked. Colors are ARGB values and the
+// stroke width is in pixels. With showCoords every vertex is labelled with
+// its coordinates.
+func (w *Writer) AddPaths(paths clipper.Paths64, isOpen bool, fillRule clipper.FillRule, fill, stroke uint32, strokeWidth float64, showCoords bool) {
+	w.layers = append(w.layers, layer{paths, isOpen, fillRule, fill, stroke, strokeWidth, showCoords})
+}
+
+// AddSubject adds closed subject paths in translucent blue
+func (w *Writer) AddSubject(paths clipper.Paths64, fillRule clipper.FillRule) {
+	w.AddPaths(paths, false, fillRule, SubjectFill, SubjectStroke, 0.8, false)
+}
+
+// AddOpenSubject adds open subject paths as grey lines
+func (w *Writer) AddOpenSubject(paths clipper.Paths64) {
+	w.AddPaths(paths, true, clipper.NonZero, 0, SubjectStroke, 1.3, false)
+}
+
+// AddClip adds clip paths in translucent red
+func (w *Writer) AddClip(paths clipper.Paths64, fillRule clipper.FillRule) {
+	w.AddPaths(paths, false, fillRule, ClipFill, ClipStroke, 0.8, false)
+}
+
+// AddSolution adds the closed paths of a solution in green
+func (w *Writer) AddSolution(paths clipper.Paths64, fillRule clipper.FillRule, showCoords bool) {
+	w.AddPaths(paths, false, fillRule, SolutionFill, SolutionStroke, 1.2, showCoords)
+}
+
+// AddOpenSolution adds the open paths of a solution as dark green lines
+func (w *Writer) AddOpenSolution(paths clipper.Paths64, showCoords bool) {
+	w.AddPaths(paths, true, clipper.NonZero, 0, OpenStroke, 1.8, showCoords)
+}
+
+// AddText adds a label at pt, in path coordinates
+func (w *Writer) AddText(text string, pt clipper.Point64, fontSize int, color uint32) {
+	w.texts = append(w.texts, label{text, pt, fontSize, color})
+}
+
+// SaveToFile renders the image to a file, as Render
+func (w *Writer) SaveToFile(filename string, maxWidth, maxHeight, margin int) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	if err := w.Render(f, maxWidth, maxHeight, margin); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Render writes the SVG image. The paths are scaled uniformly to fit within
+// maxWidth by maxHeight pixels less margin on each side. SVG has no Positive
+// or Negative fill, so layers with those rules are unioned under the rule
+// first and filled with nonzero.
+func (w *Writer) Render(out io.Writer, maxWidth, maxHeight, margin int) error {
+	if maxWidth <= 2*margin || maxHeight <= 2*margin || margin < 0 {
+		return fmt.Errorf("%w: image %dx%d too small for margin %d", clipper.ErrInvalidInput, maxWidth, maxHeight, margin)
+	}
+
+	bounds := clipper.InvalidRect64
+	for _, l := range w.layers {
+		bounds = bounds.UnionRect(clipper.BoundsPaths64(l.paths))
+	}
+	for _, t := range w.texts {
+		bounds = bounds.Union(t.pt)
+	}
+	if !bounds.IsValid() {
+		bounds = clipper.Rect64{}
+	}
+	scale := math.Min(
+		float64(maxWidth-2*margin)/math.Max(1, float64(bounds.Width())),
+		float64(maxHeight-2*margin)/math.Max(1, float64(bounds.Height())),
+	)
+	tx := func(pt clipper.Point64) (string, string) {
+		return coord(float64(pt.X-bounds.Left)*scale + float64(margin)),
+			coord(float64(pt.Y-bounds.Top)*scale + float64(margin))
+	}
+	width := int(math.Ceil(float64(bounds.Width())*scale)) + 2*margin
+	height := int(math.Ceil(float64(bounds.Height())*scale)) + 2*margin
+
+	bw := bufio.NewWriter(out)
+	fmt.Fprintf(bw, "<?xml version=\"1.0\" standalone=\"no\"?>\n")
+	fmt.Fprintf(bw, "<svg width=\"%dpx\" height=\"%dpx\" viewBox=\"0 0 %d %d\" version=\"1.1\" xmlns=\"http://www.w3.org/2000/svg\">\n",
+		width, height, width, height)
+
+	for _, l := range w.layers {
+		paths, fillRule := l.paths, "nonzero"
+		switch {
+		case l.isOpen:
+		case l.fillRule == clipper.EvenOdd:
+			fillRule = "evenodd"
+		case l.fillRule == clipper.Positive || l.fillRule == clipper.Negative:
+			filled, err := clipper.Union64(paths, nil, l.fillRule)
+			if err != nil {
+				return err
+			}
+			paths = filled
+		}
+
+		bw.WriteString("  <path d=\"")
+		sep := ""
+		for _, path := range paths {
+			if len(path) < 2 {
+				continue
+			}
+			for i, pt := range path {
+				x, y := tx(pt)
+				cmd := "L "
+				if i == 0 {
+					cmd = "M "
+				}
+				bw.WriteString(sep + cmd + x + " " + y)
+				sep = " "
+			}
+			if !l.isOpen {
+				bw.WriteString(" Z")
+			}
+		}
+		if l.isOpen {
+			fmt.Fprintf(bw, "\" style=\"fill:none; stroke:%s; stroke-opacity:%s; stroke-width:%s;\"/>\n",
+				color(l.stroke), opacity(l.stroke), coord(l.strokeWidth))
+		} else {
+			fmt.Fprintf(bw, "\" style=\"fill:%s; fill-opacity:%s; fill-rule:%s; stroke:%s; stroke-opacity:%s; stroke-width:%s;\"/>\n",
+				color(l.fill), opacity(l.fill), fillRule, color(l.stroke), opacity(l.stroke), coord(l.strokeWidth))
+		}
+
+		if l.showCoords {
+			bw.WriteString("  <g font-family=\"Verdana\" font-size=\"11\" fill=\"#000000\">\n")
+			for _, path := range paths {
+				for _, pt := range path {
+					x, y := tx(pt)
+					fmt.Fprintf(bw, "    <text x=\"%s\" y=\"%s\">%d,%d</text>\n", x, y, pt.X, pt.Y)
+				}
+			}
+			bw.WriteString("  </g>\n")
+		}
+	}
+
+	for _, t := range w.texts {
+		x, y := tx(t.pt)
+		fmt.Fprintf(bw, "  <text x=\"%s\" y=\"%s\" font-family=\"Verdana\" font-size=\"%d\" fill=\"%s\" fill-opacity=\"%s\">",
+			x, y, t.fontSize, color(t.color), opacity(t.color))
+		xml.EscapeText(bw, []byte(t.text))
+		bw.WriteString("</text>\n")
+	}
+
+	bw.WriteString("</svg>\n")
+	return bw.Flush()
+}
+
+// color returns the RGB part of an ARGB value as #rrggbb
+func color(argb uint32) string {
+	return fmt.Sprintf("#%06x", argb&0xFFFFFF)
+}
+
+// opacity returns the alpha part of an ARGB value between 0 and 1
+func opacity(argb uint32) string {
+	return coord(float64(argb>>24) / 255)
+}
+
+// coord formats v with at most two decimals
+func coord(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
@@ -0,0 +1,72 @@
+package svg
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	clipper "github.com/go-clipper/clipper2/port"
+)
+
+func TestWriterRender(t *testing.T) {
+	subjects := clipper.Paths64{{{X: 0, Y: 0}, {X: 100, Y: 0}, {X: 100, Y: 50}, {X: 0, Y: 50}}}
+	clips := clipper.Paths64{{{X: 50, Y: 0}, {X: 200, Y: 0}, {X: 200, Y: 50}}}
+	solution, err := clipper.Intersect64(subjects, clips, clipper.EvenOdd)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	w := NewWriter()
+	w.AddSubject(subjects, clipper.EvenOdd)
+	w.AddClip(clips, clipper.NonZero)
+	w.AddSolution(solution, clipper.EvenOdd, true)
+	w.AddOpenSolution(clipper.Paths64{{{X: 0, Y: 25}, {X: 200, Y: 25}}}, false)
+	w.AddText("a < b", clipper.Point64{X: 10, Y: 10}, 12, 0xFF000000)
+
+	var buf bytes.Buffer
+	if err := w.Render(&buf, 420, 400, 10); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+
+	// 200x50 units scale by 2 to fit 400 pixels between the margins
+	for _, expected := range []string{
+		`<svg width="420px" height="120px" viewBox="0 0 420 120"`,
+		`<path d="M 10 10 L 210 10 L 210 110 L 10 110 Z" style="fill:#00009c; fill-opacity:0.07; fill-rule:evenodd;`,
+		`style="fill:#9c0000; fill-opacity:0.07; fill-rule:nonzero;`,
+		`<path d="M 10 60 L 410 60" style="fill:none; stroke:#006600;`,
+		`<text x="210" y="10">100,0</text>`,
+		`>a &lt; b</text>`,
+		"</svg>\n",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected output to contain %q, got:\n%s", expected, out)
+		}
+	}
+
+	if err := w.Render(&buf, 20, 20, 10); !errors.Is(err, clipper.ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for no room inside the margins, got %v", err)
+	}
+}
+
+func TestWriterSaveToFile(t *testing.T) {
+	w := NewWriter()
+	w.AddSubject(clipper.Paths64{{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 0, Y: 10}}}, clipper.Positive)
+	name := filepath.Join(t.TempDir(), "debug.svg")
+	if err := w.SaveToFile(name, 100, 100, 5); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := os.ReadFile(name)
+	if err != nil || !bytes.Contains(data, []byte("fill-rule:nonzero")) {
+		t.Errorf("expected a saved image filled with nonzero, got %s (err %v)", data, err)
+	}
+
+	// The empty image still has its margins
+	var buf bytes.Buffer
+	if err := NewWriter().Render(&buf, 100, 100, 5); err != nil || !strings.Contains(buf.String(), `width="10px"`) {
+		t.Errorf("expected an empty image, got %s (err %v)", buf.String(), err)
+	}
+}
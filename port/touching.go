@@ -0,0 +1,72 @@
+package clipper
+
+import "sort"
+
+// ==============================================================================
+// Touching Point Vertices (T-junctions)
+// ==============================================================================
+
+// insertTouchingVertices returns a copy of paths where every edge passing
+// through a vertex of any ring (including another part of its own ring) is
+// split at that vertex. Rings that touch then share a vertex at each touch
+// point, which mesh generators require to avoid T-junctions.
+func insertTouchingVertices(paths Paths64) Paths64 {
+	// All vertices sorted by X so each edge only inspects its own X range
+	var vertices []Point64
+	for _, path := range paths {
+		vertices = append(vertices, path...)
+	}
+	sort.Slice(vertices, func(i, j int) bool {
+		if vertices[i].X != vertices[j].X {
+			return vertices[i].X < vertices[j].X
+		}
+		return vertices[i].Y < vertices[j].Y
+	})
+
+	result := make(Paths64, len(paths))
+	for i, path := range paths {
+		if len(path) < 2 {
+			result[i] = path.Clone()
+			continue
+		}
+		out := make(Path64, 0, len(path))
+		for j, a := range path {
+			b := path[(j+1)%len(path)]
+			out = append(out, a)
+			out = append(out, touchingPointsOnEdge(vertices, a, b)...)
+		}
+		result[i] = out
+	}
+	return result
+}
+
+// touchingPointsOnEdge returns the distinct vertices lying strictly inside
+// segment a-b, ordered from a towards b
+func touchingPointsOnEdge(sorted []Point64, a, b Point64) []Point64 {
+	minX, maxX := minMax64(a.X, b.X)
+	minY, maxY := minMax64(a.Y, b.Y)
+	start := sort.Search(len(sorted), func(i int) bool { return sorted[i].X >= minX })
+
+	var found []Point64
+	for _, pt := range sorted[start:] {
+		if pt.X > maxX {
+			break
+		}
+		if pt.Y < minY || pt.Y > maxY || pt == a || pt == b {
+			continue
+		}
+		if len(found) > 0 && found[len(found)-1] == pt {
+			continue // duplicate vertex shared by several rings
+		}
+		if CrossProduct128(a, b, pt).IsZero() {
+			found = append(found, pt)
+		}
+	}
+
+	// Collinear points inside the box are ordered by distance along the edge
+	dir := b.Sub(a)
+	sort.Slice(found, func(i, j int) bool {
+		return found[i].Sub(a).Dot128(dir).Cmp(found[j].Sub(a).Dot128(dir)) < 0
+	})
+	return found
+}
@@ -0,0 +1,67 @@
+package clipper
+
+import "testing"
+
+// TestInsertTouchingVertices tests T-junction vertex insertion between rings
+func TestInsertTouchingVertices(t *testing.T) {
+	t.Run("Vertex touching another ring's edge", func(t *testing.T) {
+		square := Path64{{0, 0}, {10, 0}, {10, 10}, {0, 10}}
+		triangle := Path64{{10, 5}, {15, 0}, {15, 10}}
+
+		result := insertTouchingVertices(Paths64{square, triangle})
+		expected := Path64{{0, 0}, {10, 0}, {10, 5}, {10, 10}, {0, 10}}
+		if !identicalPath(result[0], expected) {
+			t.Errorf("Expected %v, got %v", expected, result[0])
+		}
+		if !identicalPath(result[1], triangle) {
+			t.Errorf("Expected triangle unchanged, got %v", result[1])
+		}
+		if len(square) != 4 {
+			t.Error("Input path was modified")
+		}
+	})
+
+	t.Run("Several points ordered along the edge", func(t *testing.T) {
+		bar := Path64{{0, 0}, {30, 0}, {30, -5}, {0, -5}}
+		above := Paths64{
+			{{20, 0}, {25, 5}, {15, 5}},
+			{{5, 0}, {10, 5}, {0, 5}},
+		}
+		result := insertTouchingVertices(append(Paths64{bar}, above...))
+		expected := Path64{{0, 0}, {5, 0}, {20, 0}, {30, 0}, {30, -5}, {0, -5}}
+		if !identicalPath(result[0], expected) {
+			t.Errorf("Expected %v, got %v", expected, result[0])
+		}
+	})
+
+	t.Run("Reverse edge direction", func(t *testing.T) {
+		edge := Path64{{30, 0}, {0, 0}, {0, -5}}
+		result := insertTouchingVertices(Paths64{edge, {{10, 0}, {20, 5}, {20, 0}}})
+		expected := Path64{{30, 0}, {20, 0}, {10, 0}, {0, 0}, {0, -5}}
+		if !identicalPath(result[0], expected) {
+			t.Errorf("Expected %v, got %v", expected, result[0])
+		}
+	})
+
+	t.Run("No touching points", func(t *testing.T) {
+		a := Path64{{0, 0}, {10, 0}, {10, 10}, {0, 10}}
+		b := Path64{{20, 0}, {30, 0}, {30, 10}, {20, 10}}
+		result := insertTouchingVertices(Paths64{a, b})
+		if !identicalPath(result[0], a) || !identicalPath(result[1], b) {
+			t.Errorf("Expected paths unchanged, got %v", result)
+		}
+	})
+}
+
+// identicalPath reports whether two paths have identical points in the same order
+func identicalPath(a, b Path64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,122 @@
+package clipper
+
+import "math"
+
+// ==============================================================================
+// Centroid and Scaling
+// ==============================================================================
+
+// exactCentroidLimit bounds coordinates (relative to the first vertex) for
+// which centroid moments fit in Int128: cross products stay below 2^63 and
+// each moment term below 2^95
+const exactCentroidLimit = 1 << 31
+
+// Centroid64 returns the area centroid of a closed path. Cross products and
+// moments are accumulated in Int128 relative to the first vertex, so the only
+// rounding is the final division. Paths with zero area return the mean of
+// their vertices, and an empty path returns the origin.
+func Centroid64(path Path64) PointD {
+	if len(path) == 0 {
+		return PointD{}
+	}
+
+	origin := path[0]
+	exact := true
+	for _, pt := range path {
+		d := pt.Sub(origin)
+		if d.X <= -exactCentroidLimit || d.X >= exactCentroidLimit ||
+			d.Y <= -exactCentroidLimit || d.Y >= exactCentroidLimit {
+			exact = false
+			break
+		}
+	}
+
+	var area2, momentX, momentY float64
+	if exact {
+		var a, mx, my Int128
+		for i := range path {
+			p := path[i].Sub(origin)
+			q := path[(i+1)%len(path)].Sub(origin)
+			cross := p.Cross128(q)
+			a = a.Add(cross)
+			mx = mx.Add(cross.Mul64(p.X + q.X))
+			my = my.Add(cross.Mul64(p.Y + q.Y))
+		}
+		area2, momentX, momentY = a.ToFloat64(), mx.ToFloat64(), my.ToFloat64()
+	} else {
+		for i := range path {
+			p := path[i].Sub(origin)
+			q := path[(i+1)%len(path)].Sub(origin)
+			cross := p.Cross128(q).ToFloat64()
+			area2 += cross
+			momentX += cross * (float64(p.X) + float64(q.X))
+			momentY += cross * (float64(p.Y) + float64(q.Y))
+		}
+	}
+
+	if area2 == 0 {
+		var sumX, sumY float64
+		for _, pt := range path {
+			sumX += float64(pt.X - origin.X)
+			sumY += float64(pt.Y - origin.Y)
+		}
+		n := float64(len(path))
+		return PointD{X: float64(origin.X) + sumX/n, Y: float64(origin.Y) + sumY/n}
+	}
+
+	return PointD{
+		X: float64(origin.X) + momentX/(3*area2),
+		Y: float64(origin.Y) + momentY/(3*area2),
+	}
+}
+
+// CentroidPaths64 returns the area centroid of the region paths bound, the
+// centroids of the rings weighted by their signed areas, so clockwise holes
+// pull it away from themselves. Paths with zero net area return the mean of
+// all vertices, and no vertices return the origin.
+func CentroidPaths64(paths Paths64) PointD {
+	var area, momentX, momentY, sumX, sumY float64
+	n := 0
+	for _, path := range paths {
+		for _, pt := range path {
+			sumX += float64(pt.X)
+			sumY += float64(pt.Y)
+		}
+		n += len(path)
+		a := Area64(path)
+		if a == 0 {
+			continue
+		}
+		c := Centroid64(path)
+		area += a
+		momentX += a * c.X
+		momentY += a * c.Y
+	}
+	switch {
+	case n == 0:
+		return PointD{}
+	case area == 0:
+		return PointD{X: sumX / float64(n), Y: sumY / float64(n)}
+	}
+	return PointD{X: momentX / area, Y: momentY / area}
+}
+
+// ScaleAboutCentroid64 scales a path by factor about its area centroid, so the
+// polygon shrinks or grows in place instead of moving towards or away from
+// the origin. The area changes by factor squared. Returns nil for an empty
+// path or a non-finite factor.
+func ScaleAboutCentroid64(path Path64, factor float64) Path64 {
+	if len(path) == 0 || math.IsNaN(factor) || math.IsInf(factor, 0) {
+		return nil
+	}
+
+	c := Centroid64(path)
+	result := make(Path64, len(path))
+	for i, pt := range path {
+		result[i] = RoundPointD(PointD{
+			X: c.X + (float64(pt.X)-c.X)*factor,
+			Y: c.Y + (float64(pt.Y)-c.Y)*factor,
+		})
+	}
+	return result
+}
@@ -0,0 +1,84 @@
+package clipper
+
+import (
+	"math"
+	"testing"
+)
+
+// TestCentroid64 tests centroid computation for regular, offset and degenerate paths
+func TestCentroid64(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     Path64
+		expected PointD
+	}{
+		{"Square", Path64{{0, 0}, {10, 0}, {10, 10}, {0, 10}}, PointD{5, 5}},
+		{"Clockwise square", Path64{{0, 10}, {10, 10}, {10, 0}, {0, 0}}, PointD{5, 5}},
+		{"Triangle", Path64{{0, 0}, {9, 0}, {0, 9}}, PointD{3, 3}},
+		// Area-weighted, not the vertex mean (which would be biased by the extra vertices)
+		{"Extra collinear vertices", Path64{{0, 0}, {1, 0}, {2, 0}, {3, 0}, {4, 0}, {4, 4}, {0, 4}}, PointD{2, 2}},
+		{"Far from origin", Path64{{1 << 40, 1 << 40}, {1<<40 + 6, 1 << 40}, {1<<40 + 6, 1<<40 + 6}, {1 << 40, 1<<40 + 6}}, PointD{1<<40 + 3, 1<<40 + 3}},
+		{"Huge extent", Path64{{-(1 << 50), -(1 << 50)}, {1 << 50, -(1 << 50)}, {1 << 50, 1 << 50}, {-(1 << 50), 1 << 50}}, PointD{0, 0}},
+		{"Zero area", Path64{{0, 0}, {10, 0}, {20, 0}}, PointD{10, 0}},
+		{"Empty", nil, PointD{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := Centroid64(test.path)
+			if math.Abs(c.X-test.expected.X) > 1e-9 || math.Abs(c.Y-test.expected.Y) > 1e-9 {
+				t.Errorf("Expected centroid %v, got %v", test.expected, c)
+			}
+		})
+	}
+}
+
+// TestCentroidPaths64 tests area-weighted centroids of rings with holes
+func TestCentroidPaths64(t *testing.T) {
+	square := Path64{{0, 0}, {10, 0}, {10, 10}, {0, 10}}
+	tests := []struct {
+		name     string
+		paths    Paths64
+		expected PointD
+	}{
+		{"Single ring", Paths64{square}, PointD{5, 5}},
+		{"Two equal rings", Paths64{square, {{20, 0}, {30, 0}, {30, 10}, {20, 10}}}, PointD{15, 5}},
+		// 100 at (5,5) less 25 at (7.5,7.5)
+		{"Hole in a corner", Paths64{square, {{5, 5}, {5, 10}, {10, 10}, {10, 5}}}, PointD{(500 - 187.5) / 75, (500 - 187.5) / 75}},
+		{"Zero net area", Paths64{square, Reverse64(square)}, PointD{5, 5}},
+		{"Degenerate ring ignored", Paths64{square, {{100, 100}, {200, 100}}}, PointD{5, 5}},
+		{"Empty", nil, PointD{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := CentroidPaths64(test.paths)
+			if math.Abs(c.X-test.expected.X) > 1e-9 || math.Abs(c.Y-test.expected.Y) > 1e-9 {
+				t.Errorf("Expected centroid %v, got %v", test.expected, c)
+			}
+		})
+	}
+}
+
+// TestScaleAboutCentroid64 tests that scaling keeps the centroid fixed
+func TestScaleAboutCentroid64(t *testing.T) {
+	square := Path64{{100, 100}, {140, 100}, {140, 140}, {100, 140}}
+
+	half := ScaleAboutCentroid64(square, 0.5)
+	expected := Path64{{110, 110}, {130, 110}, {130, 130}, {110, 130}}
+	for i, pt := range half {
+		if pt != expected[i] {
+			t.Errorf("Point %d: expected %v, got %v", i, expected[i], pt)
+		}
+	}
+	if area := Area64(half); area != Area64(square)/4 {
+		t.Errorf("Expected area to scale by factor squared, got %v", area)
+	}
+	if c := Centroid64(half); c != Centroid64(square) {
+		t.Errorf("Expected centroid to stay at %v, got %v", Centroid64(square), c)
+	}
+
+	if p := ScaleAboutCentroid64(square, math.NaN()); p != nil {
+		t.Errorf("Expected nil for NaN factor, got %v", p)
+	}
+}
@@ -12,12 +12,36 @@ type Point64 struct {
 	X, Y int64
 }
 
-// Path64 represents a sequence of points forming a path
+// Path64 represents a sequence of points forming a path. Closed paths (rings)
+// are closed implicitly: the last point connects back to the first, which it
+// should not repeat (see RingClosure).
 type Path64 []Point64
 
 // Paths64 represents a collection of paths
 type Paths64 []Path64
 
+// Point32 represents a point with 32-bit integer coordinates
+type Point32 struct {
+	X, Y int32
+}
+
+// Path32 represents a sequence of 32-bit points forming a path
+type Path32 []Point32
+
+// Paths32 represents a collection of 32-bit paths
+type Paths32 []Path32
+
+// PointD represents a point with floating-point coordinates
+type PointD struct {
+	X, Y float64
+}
+
+// PathD represents a sequence of floating-point points forming a path
+type PathD []PointD
+
+// PathsD represents a collection of floating-point paths
+type PathsD []PathD
+
 // ClipType specifies the type of boolean operation
 type ClipType uint8
 
@@ -60,10 +84,140 @@ const (
 
 // OffsetOptions contains options for path offsetting
 type OffsetOptions struct {
-	MiterLimit   float64 // maximum allowed miter join length (default: 2.0)
-	ArcTolerance float64 // maximum allowed deviation from true arc (default: 0.25)
+	MiterLimit   float64 // maximum allowed miter join length (default: DefaultMiterLimit)
+	ArcTolerance float64 // maximum allowed deviation from true arc (default: DefaultArcTolerance)
+
+	// MaxCornerPoints caps the vertices emitted for each rounded corner or
+	// end cap, coarsening ArcTolerance where needed, so it only shows on the
+	// C++ library that InflatePaths64 offsets with (default: 0, no cap)
+	MaxCornerPoints int
+
+	// OrientByContainment reorients ClosedPolygon contours by nesting depth
+	// before offsetting: contours inside an even number of others become
+	// outers and the rest holes, so glyph outlines whose nested contours are
+	// not consistently wound still bolden on both sides. It discards the
+	// winding of the input, so contours meant to overlap under NonZero are
+	// offset as holes instead. Only the reorientation runs in Go; the offset
+	// still needs the C++ library (default: false)
+	OrientByContainment bool
+}
+
+// ClipperOptions contains options for boolean operations
+type ClipperOptions struct {
+	// KeepTouchingPointsAsVertices inserts a vertex into every output edge that
+	// passes through a vertex of another ring (a T-junction), so rings that
+	// touch always share vertices at their touch points (default: false)
+	KeepTouchingPointsAsVertices bool
+
+	// ZeroAreaRings controls closed input rings whose vertices are all
+	// collinear (default: ZeroAreaDrop)
+	ZeroAreaRings ZeroAreaPolicy
+
+	// PartialResults returns the rings completed before an execution failure
+	// together with the error, instead of nil, to help diagnose bad inputs.
+	// A non-nil solution returned alongside an error is partial; it is not
+	// normalized and may miss any part of the result (default: false)
+	PartialResults bool
+
+	// WeldTolerance merges output vertices closer than this distance into
+	// one, removing micro-segments left by rounding intersection points
+	// before they reach meshing or offsetting (default: 0, no welding)
+	WeldTolerance int64
+
+	// MinArea drops output rings, outers and holes alike, whose absolute area
+	// is below this, as CleanPaths64 does (default: 0, keep all)
+	MinArea float64
+
+	// MinEdgeLength collapses output edges shorter than this onto their
+	// first vertex before MinArea is applied, as CleanPaths64 does
+	// (default: 0, keep all)
+	MinEdgeLength int64
+
+	// PreNodeSelfIntersections inserts a vertex wherever edges within the
+	// subjects or within the clips cross before the sweep, so self-
+	// intersecting input gives results consistent with upstream Clipper2
+	// (default: false)
+	PreNodeSelfIntersections bool
+
+	// SharedEdges controls boundary segments that subject and clip share
+	// without enclosing area in an Intersection or Difference (default:
+	// SharedEdgesExclude, as upstream Clipper2)
+	SharedEdges SharedEdgePolicy
+
+	// MaxOutputVertices caps the total number of vertices in the closed and
+	// open solution, for results embedded in size-limited payloads (default:
+	// 0, no limit)
+	MaxOutputVertices int
+
+	// OutputBudget decides what happens when the solution exceeds
+	// MaxOutputVertices (default: OutputBudgetAbort)
+	OutputBudget OutputBudgetPolicy
+
+	// Rounding maps computed intersection points onto the integer grid
+	// (default: nil, NearestRounding). A custom strategy always runs on the
+	// pure Go engine, since backends have no rounding hook.
+	Rounding RoundingStrategy
+
+	// PreserveCollinear keeps vertices lying on a straight line between
+	// their neighbours in the output; only 180 degree spikes are removed.
+	// It always runs on the pure Go engine (default: false)
+	PreserveCollinear bool
+
+	// ReverseSolution reverses the orientation of every output path, so
+	// outer rings are clockwise and holes counter-clockwise (default: false)
+	ReverseSolution bool
+
+	// SkipSanitize passes closed subjects and clips to the sweep as they
+	// are, instead of first stripping repeated points, removing spikes and
+	// splitting rings that pass through a point twice. The region is the
+	// same either way; skip it only for input known to be clean
+	// (default: false)
+	SkipSanitize bool
 }
 
+// OutputBudgetPolicy specifies how a solution with more vertices than
+// ClipperOptions.MaxOutputVertices is handled
+type OutputBudgetPolicy uint8
+
+const (
+	OutputBudgetAbort    OutputBudgetPolicy = iota // fail with ErrComplexityExceeded
+	OutputBudgetSimplify                           // simplify with a growing tolerance until it fits
+)
+
+// SharedEdgePolicy specifies whether boundary segments shared by subject and
+// clip, which bound no area of the result, are part of it. Intersection of
+// polygons touching along an edge has that edge as its only common part;
+// Difference keeps the part of the subject boundary lying on the clip
+// boundary when the clip is treated as open.
+type SharedEdgePolicy uint8
+
+const (
+	SharedEdgesExclude SharedEdgePolicy = iota // return only regions with area
+	SharedEdgesInclude                         // also return shared segments as open paths
+)
+
+// ZeroAreaPolicy specifies how closed input rings with all vertices collinear
+// are handled. Such rings enclose no area and contribute nothing to the fill.
+type ZeroAreaPolicy uint8
+
+const (
+	ZeroAreaDrop     ZeroAreaPolicy = iota // silently ignore the ring
+	ZeroAreaError                          // fail with ErrInvalidInput
+	ZeroAreaKeepOpen                       // pass subject rings on as open paths spanning their extent
+)
+
+// RingClosure specifies how closed input rings whose last point repeats the
+// first are handled. Rings are closed implicitly, so such a point adds a
+// zero-length closing edge that the sweep skips but preprocessing steps such
+// as pre-noding and zero-area detection see.
+type RingClosure uint8
+
+const (
+	RingClosureImplicit  RingClosure = iota // pass rings on unchanged
+	RingClosureAutoClose                    // drop the repeated closing point before any processing
+	RingClosureStrict                       // fail with ErrInvalidInput
+)
+
 // ==============================================================================
 // Vatti Algorithm Types
 // ==============================================================================
@@ -85,7 +239,6 @@ type Edge struct {
 	WindDx      int          // +1 or -1 depending on winding direction
 	WindCount   int          // accumulated winding count
 	WindCount2  int          // accumulated winding count for clip polygons
-	OutRec      *OutRec      // output record this edge contributes to
 	
 	// Active Edge List (AEL) - Vatti's AET (active edge table)
 	// Linked list of all edges (from left to right) that are present
@@ -103,7 +256,8 @@ type Edge struct {
 	VertexTop   *Vertex      // top vertex of this edge
 	LocalMin    *LocalMinima // local minima this edge belongs to
 	IsLeftBound bool         // true if this is a left bound edge
-	JoinWith    JoinWith     // join specification
+	outputs     [outputLayers]edgeOutput // output record and join per output layer (see vatti_layers.go)
+	layer       *int         // the engine's current output layer
 }
 
 // LocalMinima represents a local minimum point where edges start (aligned with Clipper2)
@@ -112,16 +266,20 @@ type LocalMinima struct {
 	PathType PathType     // subject or clip path type
 	IsOpen   bool         // true if this is an open path
 	Next     *LocalMinima // next local minima (sorted by Y)
+
+	vertexBase int // offset of the indices of its vertices within the sweep
 }
 
 // OutRec represents an output polygon record
 type OutRec struct {
-	Idx      int     // index in the output record list
-	Owner    *OutRec // parent polygon for holes
-	State    OutRecState
-	Pts      *OutPt    // linked list of output points
-	BottomPt *OutPt    // bottommost point
-	PolyPath *PolyPath // hierarchical path structure
+	Idx       int     // index in the output record list
+	Owner     *OutRec // parent polygon for holes
+	State     OutRecState
+	Pts       *OutPt    // linked list of output points
+	BottomPt  *OutPt    // bottommost point
+	PolyPath  *PolyPath // hierarchical path structure
+	FrontEdge *Edge     // edge adding points at the front (Pts) of the ring
+	BackEdge  *Edge     // edge adding points at the back (Pts.Next) of the ring
 }
 
 // OutRecState represents the state of an output record
@@ -136,10 +294,19 @@ const (
 
 // OutPt represents a point in an output polygon
 type OutPt struct {
-	Pt   Point64 // the point coordinates
-	Next *OutPt  // next point in the polygon
-	Prev *OutPt  // previous point in the polygon
-	Idx  int     // index for debugging
+	Pt     Point64 // the point coordinates
+	Next   *OutPt  // next point in the polygon
+	Prev   *OutPt  // previous point in the polygon
+	Idx    int     // index for debugging
+	OutRec *OutRec // output record owning this point
+	horz   bool    // starts a horizontal segment queued for joining
+}
+
+// IntersectNode records a crossing of two adjacent active edges within a scanbeam
+type IntersectNode struct {
+	Pt    Point64 // the crossing point
+	Edge1 *Edge   // left edge before the crossing
+	Edge2 *Edge   // right edge before the crossing
 }
 
 // PolyPath represents a hierarchical polygon path structure
@@ -148,13 +315,3 @@ type PolyPath struct {
 	Children []*PolyPath // child paths (holes)
 	Parent   *PolyPath   // parent path
 }
-
-// Clipper64 implements the Vatti polygon clipping algorithm
-type Clipper64 struct {
-	_minimaList     *LocalMinima // sorted list of local minima
-	_activeEdgeList *Edge        // active edge list during scanline
-	_scanY          int64        // current scanline Y position
-	_outRecList     []*OutRec    // list of output records
-	_fillRule       FillRule     // fill rule for polygon interiors
-	_clipType       ClipType     // boolean operation type
-}
@@ -0,0 +1,111 @@
+// Package units converts between millimeter dimensions and the fixed-point
+// int64 coordinates clipping works on. CAD/CAM code repeats this scaling in
+// every project and the factor is easy to get wrong by an order of
+// magnitude; here it is always expressed as the number of microns one
+// integer unit represents.
+package units
+
+import (
+	"math"
+	"strconv"
+
+	clipper "github.com/go-clipper/clipper2/port"
+)
+
+// Common resolutions, in microns per integer unit
+const (
+	Nanometer = 0.001 // 1 unit = 1 nm (1,000,000 units per mm)
+	Micron    = 1.0   // 1 unit = 1 µm (1,000 units per mm)
+	TenMicron = 10.0  // 1 unit = 10 µm (100 units per mm)
+)
+
+// maxCoord is the largest coordinate magnitude produced, leaving headroom
+// for the arithmetic of clipping and offsetting
+const maxCoord = math.MaxInt64 >> 2
+
+// MMToUnits converts a length in millimeters to integer units, rounding
+// halves away from zero like the rest of the library. It fails with
+// clipper.ErrInvalidInput for a non-positive resolution or a value that is
+// not finite or out of range.
+func MMToUnits(mm, micronsPerUnit float64) (int64, error) {
+	if !(micronsPerUnit > 0) || math.IsInf(micronsPerUnit, 0) {
+		return 0, clipper.ErrInvalidInput
+	}
+	v := mm * 1000 / micronsPerUnit
+	if math.IsNaN(v) || math.Abs(v) > maxCoord {
+		return 0, clipper.ErrInvalidInput
+	}
+	return clipper.RoundHalfAway(v), nil
+}
+
+// UnitsToMM converts integer units back to millimeters
+func UnitsToMM(v int64, micronsPerUnit float64) float64 {
+	return float64(v) * micronsPerUnit / 1000
+}
+
+// MMToPath64 converts a path with millimeter coordinates to integer units
+func MMToPath64(path clipper.PathD, micronsPerUnit float64) (clipper.Path64, error) {
+	result := make(clipper.Path64, len(path))
+	for i, pt := range path {
+		x, err := MMToUnits(pt.X, micronsPerUnit)
+		if err != nil {
+			return nil, err
+		}
+		y, err := MMToUnits(pt.Y, micronsPerUnit)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = clipper.Point64{X: x, Y: y}
+	}
+	return result, nil
+}
+
+// MMToPaths64 converts paths with millimeter coordinates to integer units
+func MMToPaths64(paths clipper.PathsD, micronsPerUnit float64) (clipper.Paths64, error) {
+	result := make(clipper.Paths64, len(paths))
+	for i, path := range paths {
+		converted, err := MMToPath64(path, micronsPerUnit)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = converted
+	}
+	return result, nil
+}
+
+// Path64ToMM converts a path in integer units to millimeters
+func Path64ToMM(path clipper.Path64, micronsPerUnit float64) clipper.PathD {
+	result := make(clipper.PathD, len(path))
+	for i, pt := range path {
+		result[i] = clipper.PointD{X: UnitsToMM(pt.X, micronsPerUnit), Y: UnitsToMM(pt.Y, micronsPerUnit)}
+	}
+	return result
+}
+
+// Paths64ToMM converts paths in integer units to millimeters
+func Paths64ToMM(paths clipper.Paths64, micronsPerUnit float64) clipper.PathsD {
+	result := make(clipper.PathsD, len(paths))
+	for i, path := range paths {
+		result[i] = Path64ToMM(path, micronsPerUnit)
+	}
+	return result
+}
+
+// Decimals returns how many decimal places millimeter values need at the
+// resolution: 3 for Micron, 6 for Nanometer, 2 for TenMicron (at most 12)
+func Decimals(micronsPerUnit float64) int {
+	step := micronsPerUnit / 1000
+	for d := 0; d < 12; d++ {
+		if scaled := step * math.Pow10(d); math.Abs(scaled-math.Round(scaled)) < 1e-9*scaled {
+			return d
+		}
+	}
+	return 12
+}
+
+// FormatMM formats integer units as millimeters with exactly as many
+// decimals as the resolution represents, so no spurious digits appear
+// (12345 units at Micron format as "12.345")
+func FormatMM(v int64, micronsPerUnit float64) string {
+	return strconv.FormatFloat(UnitsToMM(v, micronsPerUnit), 'f', Decimals(micronsPerUnit), 64)
+}
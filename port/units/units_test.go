@@ -0,0 +1,75 @@
+package units
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	clipper "github.com/go-clipper/clipper2/port"
+)
+
+func TestMMToUnits(t *testing.T) {
+	tests := []struct {
+		mm             float64
+		micronsPerUnit float64
+		expected       int64
+	}{
+		{12.345, Micron, 12345},
+		{12.345, Nanometer, 12345000},
+		{12.345, TenMicron, 1235}, // rounds half away from zero
+		{-0.0005, Micron, -1},
+		{25.4, 2.54, 10000}, // 1 unit = 0.0001 inch
+	}
+	for _, tt := range tests {
+		got, err := MMToUnits(tt.mm, tt.micronsPerUnit)
+		if err != nil || got != tt.expected {
+			t.Errorf("MMToUnits(%v, %v) = %v, %v; expected %v", tt.mm, tt.micronsPerUnit, got, err, tt.expected)
+		}
+	}
+
+	for _, bad := range [][2]float64{{1, 0}, {1, -1}, {math.NaN(), 1}, {math.Inf(1), 1}, {1e30, Nanometer}} {
+		if _, err := MMToUnits(bad[0], bad[1]); !errors.Is(err, clipper.ErrInvalidInput) {
+			t.Errorf("MMToUnits(%v, %v): expected ErrInvalidInput, got %v", bad[0], bad[1], err)
+		}
+	}
+}
+
+func TestPathRoundTrip(t *testing.T) {
+	paths := clipper.PathsD{{{X: 0, Y: 0}, {X: 10.5, Y: 0}, {X: 10.5, Y: 2.25}}}
+	units, err := MMToPaths64(paths, Micron)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := clipper.Paths64{{{X: 0, Y: 0}, {X: 10500, Y: 0}, {X: 10500, Y: 2250}}}
+	if !reflect.DeepEqual(units, expected) {
+		t.Errorf("expected %v, got %v", expected, units)
+	}
+	if back := Paths64ToMM(units, Micron); !reflect.DeepEqual(back, paths) {
+		t.Errorf("expected round trip to %v, got %v", paths, back)
+	}
+
+	if _, err := MMToPaths64(clipper.PathsD{{{X: math.NaN(), Y: 0}}}, Micron); !errors.Is(err, clipper.ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for NaN, got %v", err)
+	}
+}
+
+func TestFormatMM(t *testing.T) {
+	tests := []struct {
+		v              int64
+		micronsPerUnit float64
+		expected       string
+	}{
+		{12345, Micron, "12.345"},
+		{12345, Nanometer, "0.012345"},
+		{12345, TenMicron, "123.45"},
+		{-5, Micron, "-0.005"},
+		{7, 1000, "7"},
+		{3, 2.5, "0.0075"},
+	}
+	for _, tt := range tests {
+		if got := FormatMM(tt.v, tt.micronsPerUnit); got != tt.expected {
+			t.Errorf("FormatMM(%v, %v) = %q, expected %q", tt.v, tt.micronsPerUnit, got, tt.expected)
+		}
+	}
+}
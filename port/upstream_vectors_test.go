@@ -0,0 +1,285 @@
+package clipper
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// This file runs boolean operation test vectors in the format of the upstream
+// Clipper2 suite (Tests/Polygons.txt). testdata/polygons_sample.txt is always
+// run; the full upstream dataset is picked up from the third_party/clipper2
+// submodule when it is checked out, and the share of vectors inside their
+// envelopes is logged as the port's compatibility score.
+
+// upstreamPolygonFiles are the places the upstream dataset is looked for
+var upstreamPolygonFiles = []string{
+	"../third_party/clipper2/Tests/Polygons.txt",
+	"../third_party/clipper2/CPP/Tests/Polygons.txt",
+}
+
+// polygonVector is one test of a Polygons.txt file
+type polygonVector struct {
+	num                           int
+	caption                       string
+	clipType                      ClipType
+	fillRule                      FillRule
+	solArea                       int64 // expected solution area, 0 if not checked
+	solCount                      int64 // expected closed plus open path count, 0 if not checked
+	subjects, subjectsOpen, clips Paths64
+}
+
+// loadPolygonVectors parses a file in the upstream Polygons.txt format
+func loadPolygonVectors(r io.Reader) ([]polygonVector, error) {
+	var vectors []polygonVector
+	var section *Paths64
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 1<<20), 1<<26)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		key, value, hasValue := strings.Cut(text, ":")
+		value = strings.TrimSpace(value)
+		if key == "CAPTION" {
+			num, _, _ := strings.Cut(value, ".")
+			n, err := strconv.Atoi(strings.TrimSpace(num))
+			if err != nil {
+				n = len(vectors) + 1
+			}
+			vectors = append(vectors, polygonVector{num: n, caption: value, fillRule: EvenOdd})
+			section = nil
+			continue
+		}
+		if len(vectors) == 0 || text == "" {
+			section = nil
+			continue
+		}
+		v := &vectors[len(vectors)-1]
+		var err error
+		switch {
+		case hasValue && key == "CLIPTYPE":
+			v.clipType, err = parseClipType(value)
+		case hasValue && key == "FILLRULE":
+			v.fillRule, err = parseFillRule(value)
+		case hasValue && key == "SOL_AREA":
+			v.solArea, err = strconv.ParseInt(value, 10, 64)
+		case hasValue && key == "SOL_COUNT":
+			v.solCount, err = strconv.ParseInt(value, 10, 64)
+		case text == "SUBJECTS":
+			section = &v.subjects
+		case text == "SUBJECTS_OPEN":
+			section = &v.subjectsOpen
+		case text == "CLIPS":
+			section = &v.clips
+		case section != nil:
+			var path Path64
+			path, err = parsePathLine(text)
+			*section = append(*section, path)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+	return vectors, scanner.Err()
+}
+
+// parsePathLine parses "x,y, x,y, ..." with commas and/or spaces as separators
+func parsePathLine(text string) (Path64, error) {
+	fields := strings.FieldsFunc(text, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' })
+	if len(fields)%2 != 0 {
+		return nil, fmt.Errorf("odd number of coordinates in %q", text)
+	}
+	path := make(Path64, 0, len(fields)/2)
+	for i := 0; i < len(fields); i += 2 {
+		x, err := strconv.ParseInt(fields[i], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		y, err := strconv.ParseInt(fields[i+1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		path = append(path, Point64{X: x, Y: y})
+	}
+	return path, nil
+}
+
+func parseClipType(s string) (ClipType, error) {
+	switch s {
+	case "INTERSECTION":
+		return Intersection, nil
+	case "UNION":
+		return Union, nil
+	case "DIFFERENCE":
+		return Difference, nil
+	case "XOR":
+		return Xor, nil
+	}
+	return 0, fmt.Errorf("unknown clip type %q", s)
+}
+
+func parseFillRule(s string) (FillRule, error) {
+	switch s {
+	case "EVENODD":
+		return EvenOdd, nil
+	case "NONZERO":
+		return NonZero, nil
+	case "POSITIVE":
+		return Positive, nil
+	case "NEGATIVE":
+		return Negative, nil
+	}
+	return 0, fmt.Errorf("unknown fill rule %q", s)
+}
+
+// checkEnvelope compares a solution with the stored expectations the way the
+// upstream suite does: the path count must match, apart from a few vectors
+// allowed to differ by a small number of paths (and, from vector 120 on, by
+// 2%), and the area must be within 0.5% once it differs by more than 100
+// units. It returns a description of the first violation, or "".
+func (v *polygonVector) checkEnvelope(solution, solutionOpen Paths64) string {
+	var area float64
+	for _, path := range solution {
+		area += Area64(path)
+	}
+	count := int64(len(solution) + len(solutionOpen))
+
+	if v.solCount > 0 {
+		diff := count - v.solCount
+		if diff < 0 {
+			diff = -diff
+		}
+		var ok bool
+		switch {
+		case v.num == 23:
+			ok = diff <= 4
+		case v.num == 27:
+			ok = diff <= 2
+		case slices.Contains([]int{18, 32, 42, 43, 45, 87, 102, 103, 105, 106, 114, 115, 116, 118, 119, 120, 121, 126, 140, 150, 165, 166, 172, 173, 176, 177, 179}, v.num):
+			ok = diff <= 1
+		case v.num >= 120:
+			ok = float64(diff)/float64(v.solCount) <= 0.02
+		default:
+			ok = diff == 0
+		}
+		if !ok {
+			return fmt.Sprintf("expected %d paths, got %d", v.solCount, count)
+		}
+	}
+
+	if v.solArea > 0 {
+		diff := math.Abs(area - float64(v.solArea))
+		switch {
+		case slices.Contains([]int{22, 23, 24}, v.num):
+			if diff > 8 {
+				return fmt.Sprintf("expected area %d, got %v", v.solArea, area)
+			}
+		case diff > 100 && diff/area > 0.005:
+			return fmt.Sprintf("expected area %d, got %v", v.solArea, area)
+		}
+	}
+	return ""
+}
+
+// runPolygonVectors runs every vector of a file and returns how many passed
+func runPolygonVectors(t *testing.T, path string) (passed, total int) {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open %s: %v", path, err)
+	}
+	defer f.Close()
+	vectors, err := loadPolygonVectors(f)
+	if err != nil {
+		t.Fatalf("load %s: %v", path, err)
+	}
+	for i := range vectors {
+		v := &vectors[i]
+		solution, solutionOpen, err := BooleanOp64(v.clipType, v.fillRule, v.subjects, v.subjectsOpen, v.clips)
+		msg := ""
+		if err != nil {
+			msg = err.Error()
+		} else {
+			msg = v.checkEnvelope(solution, solutionOpen)
+		}
+		if msg != "" {
+			t.Errorf("vector %d (%s): %s", v.num, v.caption, msg)
+			continue
+		}
+		passed++
+	}
+	return passed, len(vectors)
+}
+
+// TestPolygonVectorsSample tests the loader and runner on the bundled sample
+func TestPolygonVectorsSample(t *testing.T) {
+	passed, total := runPolygonVectors(t, "testdata/polygons_sample.txt")
+	if total != 8 {
+		t.Errorf("expected 8 vectors, loaded %d", total)
+	}
+	t.Logf("sample vectors: %d of %d inside their envelopes", passed, total)
+}
+
+// TestPolygonVectorsUpstream runs the upstream dataset from the submodule
+func TestPolygonVectorsUpstream(t *testing.T) {
+	for _, path := range upstreamPolygonFiles {
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		passed, total := runPolygonVectors(t, path)
+		t.Logf("upstream vectors: %d of %d inside their envelopes (%.1f%%)", passed, total, 100*float64(passed)/float64(max(total, 1)))
+		return
+	}
+	t.Skip("upstream Polygons.txt not found; run git submodule update --init")
+}
+
+// TestLoadPolygonVectors tests parsing of the upstream file format
+func TestLoadPolygonVectors(t *testing.T) {
+	input := `CAPTION: 12. mixed separators
+CLIPTYPE: DIFFERENCE
+FILLRULE: NONZERO
+SOL_AREA: 42
+SOL_COUNT: 3
+SUBJECTS
+0,0, 10,0 10,10,0,10
+SUBJECTS_OPEN
+1 2 3 4
+CLIPS
+-1,-1, 5,-1, 5,5
+
+CAPTION: 13.
+CLIPTYPE: UNION
+`
+	vectors, err := loadPolygonVectors(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(vectors) != 2 {
+		t.Fatalf("expected 2 vectors, got %d", len(vectors))
+	}
+	v := vectors[0]
+	if v.num != 12 || v.clipType != Difference || v.fillRule != NonZero || v.solArea != 42 || v.solCount != 3 {
+		t.Errorf("unexpected header %+v", v)
+	}
+	if len(v.subjects) != 1 || len(v.subjects[0]) != 4 || v.subjects[0][2] != (Point64{10, 10}) {
+		t.Errorf("unexpected subjects %v", v.subjects)
+	}
+	if len(v.subjectsOpen) != 1 || v.subjectsOpen[0][1] != (Point64{3, 4}) {
+		t.Errorf("unexpected open subjects %v", v.subjectsOpen)
+	}
+	if len(v.clips) != 1 || len(v.clips[0]) != 3 {
+		t.Errorf("unexpected clips %v", v.clips)
+	}
+	if vectors[1].num != 13 || vectors[1].clipType != Union || vectors[1].fillRule != EvenOdd {
+		t.Errorf("unexpected second vector %+v", vectors[1])
+	}
+
+	if _, err := loadPolygonVectors(strings.NewReader("CAPTION: 1.\nFILLRULE: ODD\n")); err == nil {
+		t.Errorf("expected an error for an unknown fill rule")
+	}
+}
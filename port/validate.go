@@ -0,0 +1,186 @@
+package clipper
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+)
+
+// ==============================================================================
+// Result Validation
+// ==============================================================================
+
+// IssueKind classifies a topology problem found by ValidatePaths64
+type IssueKind uint8
+
+const (
+	IssueDuplicateVertex  IssueKind = iota // consecutive vertices of a ring coincide
+	IssueZeroArea                          // ring has fewer than three vertices or encloses no area
+	IssueSelfIntersection                  // two edges cross at a point interior to both
+	IssueNesting                           // ring orientation disagrees with its nesting depth
+)
+
+// String returns the name of the issue kind
+func (k IssueKind) String() string {
+	switch k {
+	case IssueDuplicateVertex:
+		return "DuplicateVertex"
+	case IssueZeroArea:
+		return "ZeroArea"
+	case IssueSelfIntersection:
+		return "SelfIntersection"
+	case IssueNesting:
+		return "Nesting"
+	default:
+		return "Unknown"
+	}
+}
+
+// TopologyIssue is one problem found by ValidatePaths64
+type TopologyIssue struct {
+	Kind  IssueKind
+	Ring  int     // index of the ring in the validated paths
+	Other int     // second ring of a crossing, or the ring containing a misnested one; -1 if none
+	Point Point64 // duplicate vertex, crossing (rounded) or first vertex of the ring
+}
+
+// String describes the issue
+func (i TopologyIssue) String() string {
+	if i.Other < 0 {
+		return fmt.Sprintf("%v in ring %d at %v", i.Kind, i.Ring, i.Point)
+	}
+	return fmt.Sprintf("%v in ring %d with ring %d at %v", i.Kind, i.Ring, i.Other, i.Point)
+}
+
+// ValidationReport lists the topology issues of a polygon set, ordered by
+// ring, kind and position
+type ValidationReport struct {
+	Issues []TopologyIssue
+}
+
+// Valid reports whether no issues were found
+func (r ValidationReport) Valid() bool {
+	return len(r.Issues) == 0
+}
+
+// Count returns the number of issues of the given kind
+func (r ValidationReport) Count(kind IssueKind) int {
+	n := 0
+	for _, issue := range r.Issues {
+		if issue.Kind == kind {
+			n++
+		}
+	}
+	return n
+}
+
+// ValidatePaths64 checks closed paths against the output contract of the
+// boolean operations: no repeated consecutive vertices, no rings without
+// area, no edges crossing each other in the same or different rings, and
+// rings inside an even number of others wound counter-clockwise with the
+// rest clockwise. Rings touching at vertices or along edges are allowed, as
+// Clipper2 produces them. Use it in tests to assert that solutions are
+// topologically clean; it never modifies paths.
+func ValidatePaths64(paths Paths64) ValidationReport {
+	var issues []TopologyIssue
+	areas := make([]float64, len(paths))
+	for i, path := range paths {
+		for j, pt := range path {
+			if len(path) > 1 && pt == path[(j+1)%len(path)] {
+				issues = append(issues, TopologyIssue{IssueDuplicateVertex, i, -1, pt})
+			}
+		}
+		if areas[i] = Area64(path); len(path) < 3 || areas[i] == 0 {
+			var first Point64
+			if len(path) > 0 {
+				first = path[0]
+			}
+			issues = append(issues, TopologyIssue{IssueZeroArea, i, -1, first})
+		}
+	}
+
+	issues = append(issues, edgeCrossings(paths)...)
+	issues = append(issues, nestingIssues(paths, areas)...)
+
+	slices.SortFunc(issues, func(a, b TopologyIssue) int {
+		return cmp.Or(
+			cmp.Compare(a.Ring, b.Ring),
+			cmp.Compare(a.Kind, b.Kind),
+			cmp.Compare(a.Other, b.Other),
+			cmp.Compare(a.Point.X, b.Point.X),
+			cmp.Compare(a.Point.Y, b.Point.Y),
+		)
+	})
+	return ValidationReport{Issues: issues}
+}
+
+// edgeCrossings reports every pair of edges crossing at a point interior to
+// both, once, under the lower ring index. Edges are scanned in order of
+// their left end so each only inspects those it can reach.
+func edgeCrossings(paths Paths64) []TopologyIssue {
+	type edge struct {
+		a, b Point64
+		ring int
+	}
+	var edges []edge
+	for i, path := range paths {
+		for j, a := range path {
+			if b := path[(j+1)%len(path)]; a != b {
+				edges = append(edges, edge{a, b, i})
+			}
+		}
+	}
+	minX := func(e edge) int64 { return min64(e.a.X, e.b.X) }
+	sort.Slice(edges, func(i, j int) bool { return minX(edges[i]) < minX(edges[j]) })
+
+	var issues []TopologyIssue
+	for k, e := range edges {
+		maxX := max64(e.a.X, e.b.X)
+		minY, maxY := minMax64(e.a.Y, e.b.Y)
+		for _, f := range edges[k+1:] {
+			if minX(f) > maxX {
+				break
+			}
+			if max64(f.a.Y, f.b.Y) < minY || min64(f.a.Y, f.b.Y) > maxY {
+				continue
+			}
+			if !segmentsIntersectStrict(e.a, e.b, f.a, f.b) {
+				continue
+			}
+			pt, _ := getSegmentIntersectPt(e.a, e.b, f.a, f.b)
+			ring, other := min(e.ring, f.ring), max(e.ring, f.ring)
+			issues = append(issues, TopologyIssue{IssueSelfIntersection, ring, other, pt})
+		}
+	}
+	return issues
+}
+
+// nestingIssues reports rings whose orientation disagrees with their depth:
+// rings inside an even number of others must have positive area, the rest
+// negative. Other is the smallest ring containing the misnested one.
+func nestingIssues(paths Paths64, areas []float64) []TopologyIssue {
+	valid := func(i int) bool { return len(paths[i]) >= 3 && areas[i] != 0 }
+	depths := make([]int, len(paths))
+	parents := make([]int, len(paths))
+	for i := range parents {
+		parents[i] = -1
+	}
+	sweepContainment(paths, BoundsEach64(paths), func(inner, outer int) bool {
+		return valid(inner) && valid(outer)
+	}, func(inner, outer int) {
+		depths[inner]++
+		if p := parents[inner]; p < 0 || math.Abs(areas[outer]) < math.Abs(areas[p]) {
+			parents[inner] = outer
+		}
+	})
+
+	var issues []TopologyIssue
+	for i, path := range paths {
+		if valid(i) && (areas[i] > 0) != (depths[i]%2 == 0) {
+			issues = append(issues, TopologyIssue{IssueNesting, i, parents[i], path[0]})
+		}
+	}
+	return issues
+}
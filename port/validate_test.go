@@ -0,0 +1,99 @@
+package clipper
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidatePaths64(t *testing.T) {
+	square := func(lo, hi int64) Path64 { return Path64{{lo, lo}, {hi, lo}, {hi, hi}, {lo, hi}} }
+
+	tests := []struct {
+		name     string
+		paths    Paths64
+		expected []TopologyIssue
+	}{
+		{"Outer with hole and island", Paths64{square(0, 100), Reverse64(square(20, 80)), square(40, 60)}, nil},
+		{"Rings touching at a vertex", Paths64{square(0, 10), square(10, 20)}, nil},
+		{
+			"Duplicate vertices",
+			Paths64{{{0, 0}, {10, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}},
+			[]TopologyIssue{
+				{IssueDuplicateVertex, 0, -1, Point64{0, 0}},
+				{IssueDuplicateVertex, 0, -1, Point64{10, 0}},
+			},
+		},
+		{
+			"Zero area",
+			Paths64{{{0, 0}, {5, 5}, {10, 10}}, {{3, 3}, {4, 4}}, {}},
+			[]TopologyIssue{
+				{IssueZeroArea, 0, -1, Point64{0, 0}},
+				{IssueZeroArea, 1, -1, Point64{3, 3}},
+				{IssueZeroArea, 2, -1, Point64{}},
+			},
+		},
+		{
+			"Bow-tie",
+			Paths64{{{0, 0}, {10, 10}, {10, 0}, {0, 10}}},
+			[]TopologyIssue{{IssueZeroArea, 0, -1, Point64{0, 0}}, {IssueSelfIntersection, 0, 0, Point64{5, 5}}},
+		},
+		{
+			"Overlapping rings",
+			Paths64{square(0, 10), {{5, -5}, {15, -5}, {15, 5}, {5, 5}}},
+			[]TopologyIssue{
+				{IssueSelfIntersection, 0, 1, Point64{5, 0}},
+				{IssueSelfIntersection, 0, 1, Point64{10, 5}},
+			},
+		},
+		{
+			"Hole wound like an outer",
+			Paths64{square(0, 100), square(20, 80)},
+			[]TopologyIssue{{IssueNesting, 1, 0, Point64{20, 20}}},
+		},
+		{
+			"Hole without outer",
+			Paths64{Reverse64(square(0, 10))},
+			[]TopologyIssue{{IssueNesting, 0, -1, Point64{0, 10}}},
+		},
+		{
+			"Island wound like a hole",
+			Paths64{square(0, 100), Reverse64(square(20, 80)), Reverse64(square(40, 60))},
+			[]TopologyIssue{{IssueNesting, 2, 1, Point64{40, 60}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := ValidatePaths64(tt.paths)
+			if !reflect.DeepEqual(report.Issues, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, report.Issues)
+			}
+			if report.Valid() != (len(tt.expected) == 0) {
+				t.Errorf("expected Valid %v", len(tt.expected) == 0)
+			}
+		})
+	}
+
+	report := ValidatePaths64(Paths64{square(0, 100), square(20, 80), {{0, 0}, {1, 1}}})
+	if report.Count(IssueNesting) != 1 || report.Count(IssueZeroArea) != 1 || report.Count(IssueSelfIntersection) != 0 {
+		t.Errorf("unexpected counts for %v", report.Issues)
+	}
+	if s := report.Issues[0].String(); s != "Nesting in ring 1 with ring 0 at {20 20}" {
+		t.Errorf("unexpected description %q", s)
+	}
+}
+
+// TestValidateBooleanResults tests that solutions of the engine pass validation
+func TestValidateBooleanResults(t *testing.T) {
+	subjects := append(Annulus64(Point64{0, 0}, 1000, 400, 64), RegularPolygon64(Point64{300, 0}, 900, 7, 0.3))
+	clips := Paths64{RegularPolygon64(Point64{500, 200}, 700, 9, 0)}
+	for _, clipType := range []ClipType{Intersection, Union, Difference, Xor} {
+		solution, _, err := BooleanOp64(clipType, NonZero, subjects, nil, clips)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if report := ValidatePaths64(solution); !report.Valid() {
+			t.Errorf("clip type %d: expected a clean solution, got %v", clipType, report.Issues)
+		}
+	}
+}
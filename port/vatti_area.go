@@ -0,0 +1,160 @@
+package clipper
+
+// ==============================================================================
+// Area-Only Sweep
+// ==============================================================================
+
+// ExecuteArea runs the sweep of ExecuteClipping on closed subjects and clips
+// and returns the area of the solution without building it: no output
+// points are created, and every output segment adds the trapezoid between
+// it and the X axis as the sweep lays it down, so an output ring is only
+// tracked by its two open ends. Crossings are handled exactly as for
+// ExecuteClipping, and since splitting rings at self-intersections and
+// joining them along horizontals leaves their summed area unchanged, the
+// result equals the summed Area64 of its solution up to floating point
+// rounding of the sums, well under 1e-9 relative. Like ExecuteClipping, it
+// runs once on a new or Reset engine.
+func (ve *VattiEngine) ExecuteArea(subjects, clips Paths64) (float64, error) {
+	subjects, clips = ve.snapRound(subjects, clips)
+	if err := ve.addPaths(subjects, PathTypeSubject, false); err != nil {
+		return 0, err
+	}
+	if err := ve.addPaths(clips, PathTypeClip, false); err != nil {
+		return 0, err
+	}
+	if len(ve.minimaList) == 0 {
+		return 0, nil
+	}
+	ve.sortLocalMinima()
+	ve.area = &areaSweep{}
+	ve.area.shared.Next, ve.area.shared.Prev = &ve.area.shared, &ve.area.shared
+	if !ve.executeScanlineAlgorithm() {
+		if ve.err == nil {
+			return 0, ErrClipperExecution
+		}
+		return 0, ve.err
+	}
+
+	// every ring still holding points is closed by the link from its front
+	// to its back end
+	sum := ve.area.sum
+	for _, outRec := range ve.outRecords {
+		if outRec.Pts != nil {
+			ends := ve.area.ends[outRec.Idx]
+			sum += trapezoid(ends.front, ends.back)
+		}
+	}
+	// Rings run mirrored, like in buildSolutionPaths
+	return -sum * 0.5, nil
+}
+
+// areaSweep accumulates the area of the output rings in place of their
+// points. Like the points of a ring, which the front edge adds after
+// OutRec.Pts and the back edge before OutRec.Pts.Next, the links between
+// them run from the back end to the front end.
+type areaSweep struct {
+	ends   []ringEnds // by OutRec.Idx
+	sum    float64    // twice the signed area of the links laid down so far
+	shared OutPt      // Pts of every record holding points, linked to itself
+}
+
+// ringEnds are the open ends of a ring under construction
+type ringEnds struct {
+	front, back Point64
+}
+
+// trapezoid returns twice the signed area between the link from a to b and
+// the X axis, the term of a and b in the shoelace formula of outPtArea
+func trapezoid(a, b Point64) float64 {
+	return float64(a.Y+b.Y) * float64(a.X-b.X)
+}
+
+// start starts the ring of outRec at pt
+func (a *areaSweep) start(outRec *OutRec, pt Point64) *OutPt {
+	for len(a.ends) <= outRec.Idx {
+		a.ends = append(a.ends, ringEnds{})
+	}
+	a.ends[outRec.Idx] = ringEnds{pt, pt}
+	return &a.shared
+}
+
+// add lays down the link from the front or back end of outRec's ring to pt
+func (a *areaSweep) add(outRec *OutRec, toFront bool, pt Point64) {
+	ends := &a.ends[outRec.Idx]
+	switch {
+	case toFront && pt != ends.front:
+		a.sum += trapezoid(ends.front, pt)
+		ends.front = pt
+	case !toFront && pt != ends.back:
+		a.sum += trapezoid(pt, ends.back)
+		ends.back = pt
+	}
+}
+
+// join links the rings of or1 and or2 like joinOutRecPaths, appending or2's
+// ring at the front end of or1's ring if atFront, or at its back end
+func (a *areaSweep) join(or1, or2 *OutRec, atFront bool) {
+	e1, e2 := &a.ends[or1.Idx], a.ends[or2.Idx]
+	if atFront {
+		a.sum += trapezoid(e1.front, e2.back)
+		e1.front = e2.front
+	} else {
+		a.sum += trapezoid(e2.front, e1.back)
+		e1.back = e2.back
+	}
+}
+
+// ==============================================================================
+// Area Segments
+// ==============================================================================
+
+// areaSegment is a non-horizontal input edge as seen by scanline queries of
+// filled regions (see filledSpans and SampleInterior64)
+type areaSegment struct {
+	bot, top Point64
+	dir      int // +1 if the path runs upward along the edge, -1 if downward
+	pathType PathType
+}
+
+// appendAreaSegments adds the non-horizontal edges of closed paths
+func appendAreaSegments(segments []areaSegment, paths Paths64, pathType PathType) []areaSegment {
+	for _, path := range paths {
+		if len(path) < 3 || isCollinearRing(path) {
+			continue
+		}
+		for i, a := range path {
+			b := path[(i+1)%len(path)]
+			switch {
+			case b.Y > a.Y:
+				segments = append(segments, areaSegment{a, b, 1, pathType})
+			case b.Y < a.Y:
+				segments = append(segments, areaSegment{b, a, -1, pathType})
+			}
+		}
+	}
+	return segments
+}
+
+// isFilledWinding applies a fill rule to a winding number where
+// counter-clockwise paths wind positively
+func isFilledWinding(wind int, fillRule FillRule) bool {
+	switch fillRule {
+	case EvenOdd:
+		return wind&1 != 0
+	case NonZero:
+		return wind != 0
+	case Positive:
+		return wind > 0
+	case Negative:
+		return wind < 0
+	}
+	return false
+}
+
+// segmentXAt returns the X coordinate of a segment's supporting line at y
+func segmentXAt(s areaSegment, y float64) float64 {
+	if y == float64(s.top.Y) {
+		return float64(s.top.X)
+	}
+	return float64(s.bot.X) + float64(s.top.X-s.bot.X)*(y-float64(s.bot.Y))/float64(s.top.Y-s.bot.Y)
+}
@@ -0,0 +1,77 @@
+package clipper
+
+// ==============================================================================
+// Arena Allocation
+// ==============================================================================
+
+// A sweep allocates a Vertex per input point, a LocalMinima per local
+// minimum, an Edge per bound and an OutPt per output vertex, plus an OutRec
+// per ring. The engine hands them out from blocks it keeps across
+// Reset, so repeated operations on one engine stop allocating them once the
+// blocks are large enough. A flushing engine allocates output points from
+// the heap instead, so the rings it has handed over can be collected.
+
+// arenaMinBlock and arenaMaxBlock bound the number of items per block; each
+// new block doubles the previous one up to arenaMaxBlock
+const (
+	arenaMinBlock = 64
+	arenaMaxBlock = 4096
+)
+
+// arena allocates zeroed values of T from blocks it reuses after reset
+type arena[T any] struct {
+	blocks [][]T
+	block  int // block allocated from
+	used   int // items of that block handed out
+}
+
+// alloc returns a pointer to a zeroed T
+func (a *arena[T]) alloc() *T {
+	return &a.allocN(1)[0]
+}
+
+// allocN returns n consecutive zeroed values of T
+func (a *arena[T]) allocN(n int) []T {
+	for a.block < len(a.blocks) && a.used+n > len(a.blocks[a.block]) {
+		a.block++
+		a.used = 0
+	}
+	if a.block == len(a.blocks) {
+		size := min(arenaMinBlock<<min(len(a.blocks), 6), arenaMaxBlock)
+		a.blocks = append(a.blocks, make([]T, max(size, n)))
+	}
+	items := a.blocks[a.block][a.used : a.used+n : a.used+n]
+	a.used += n
+	return items
+}
+
+// reset zeroes every item handed out so far and makes them available again
+func (a *arena[T]) reset() {
+	for i := 0; i < a.block && i < len(a.blocks); i++ {
+		clear(a.blocks[i])
+	}
+	if a.block < len(a.blocks) {
+		clear(a.blocks[a.block][:a.used])
+	}
+	a.block, a.used = 0, 0
+}
+
+// newEdge returns a zeroed edge
+func (ve *VattiEngine) newEdge() *Edge {
+	return ve.edges.alloc()
+}
+
+// newOp returns an output point at pt owned by outRec, not yet linked
+func (ve *VattiEngine) newOp(pt Point64, outRec *OutRec) *OutPt {
+	var op *OutPt
+	if ve.flush != nil {
+		op = &OutPt{}
+		if n := ve.flush.nest; n != nil && pt.Y == ve.scanY {
+			n.touch(op)
+		}
+	} else {
+		op = ve.outPts.alloc()
+	}
+	op.Pt, op.Idx, op.OutRec = pt, outRec.Idx, outRec
+	return op
+}
@@ -0,0 +1,60 @@
+package clipper
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// TestVattiEngineReset tests that a reset engine matches a new one and
+// allocates less once its arenas have grown
+func TestVattiEngineReset(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	ve := NewVattiEngine(Union, NonZero)
+	for i := 0; i < 300; i++ {
+		clipType, fillRule := ClipType(i%4), FillRule(i%2)
+		subjects := Paths64{randomPath(r, 3+r.Intn(20), 1000), randomPath(r, 3+r.Intn(20), 1000)}
+		clips := Paths64{randomPath(r, 3+r.Intn(20), 1000)}
+
+		expected, _, expectedErr := NewVattiEngine(clipType, fillRule).ExecuteClipping(subjects, nil, clips)
+		ve = renewVattiEngine(ve, clipType, fillRule)
+		got, _, err := ve.ExecuteClipping(subjects, nil, clips)
+		if err != expectedErr || !reflect.DeepEqual(got, expected) {
+			t.Fatalf("%v %v: expected %v (err %v), got %v (err %v)", clipType, fillRule, expected, expectedErr, got, err)
+		}
+	}
+
+	w := StandardWorkloads()[0]
+	fresh := testing.AllocsPerRun(5, func() {
+		NewVattiEngine(w.ClipType, w.FillRule).ExecuteClipping(w.Subjects, nil, w.Clips)
+	})
+	ve = NewVattiEngine(w.ClipType, w.FillRule)
+	reused := testing.AllocsPerRun(5, func() {
+		ve.Reset()
+		ve.ExecuteClipping(w.Subjects, nil, w.Clips)
+	})
+	if reused >= fresh {
+		t.Errorf("expected fewer allocations after Reset than the %v of a new engine, got %v", fresh, reused)
+	}
+}
+
+// BenchmarkVattiEngineReset compares new engines with one engine reset
+// between operations; run with -benchmem to see allocs/op
+func BenchmarkVattiEngineReset(b *testing.B) {
+	for _, w := range StandardWorkloads() {
+		b.Run(w.Name+"/new", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				NewVattiEngine(w.ClipType, w.FillRule).ExecuteClipping(w.Subjects, nil, w.Clips)
+			}
+		})
+		b.Run(w.Name+"/reset", func(b *testing.B) {
+			b.ReportAllocs()
+			ve := NewVattiEngine(w.ClipType, w.FillRule)
+			for i := 0; i < b.N; i++ {
+				ve.Reset()
+				ve.ExecuteClipping(w.Subjects, nil, w.Clips)
+			}
+		})
+	}
+}
@@ -314,6 +314,14 @@ func TestIntersectDifferentFillRules(t *testing.T) {
 
 			t.Logf("%s result: %v", fillRuleNames[i], result)
 
+			// Both squares are counter-clockwise and wind +1, so Negative fills nothing
+			if fillRule == Negative {
+				if len(result) != 0 {
+					t.Errorf("Expected empty result for %s fill rule, got %v", fillRuleNames[i], result)
+				}
+				return
+			}
+
 			if len(result) == 0 {
 				t.Errorf("Expected non-empty result for %s fill rule", fillRuleNames[i])
 			}
@@ -394,3 +402,25 @@ func TestIntersectManyVertices(t *testing.T) {
 		t.Logf("Result polygon has %d vertices", len(result[0]))
 	}
 }
+
+// TestScanlinesSortedOnce tests that every vertex Y becomes exactly one scanline
+func TestScanlinesSortedOnce(t *testing.T) {
+	ve := NewVattiEngine(Union, NonZero)
+	paths := Paths64{
+		{{0, 30}, {10, 0}, {20, 30}},
+		{{5, 10}, {15, 10}, {15, 30}, {5, 30}},
+	}
+	if err := ve.addPaths(paths, PathTypeSubject, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := ve.sortScanlines()
+	expected := []int64{0, 10, 30}
+	if len(got) != len(expected) {
+		t.Fatalf("expected scanlines %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("expected scanlines %v, got %v", expected, got)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package clipper
 import (
 	"fmt"
 	"math"
+	"slices"
 	"sort"
 )
 
@@ -10,28 +11,138 @@ import (
 // Vatti Scanline Algorithm Implementation
 // ==============================================================================
 
+// The engine follows Clipper2's ClipperBase. Clipper2 works in a Y-down frame
+// (scanning from the largest Y towards the smallest), while this package is
+// Y-up and scans from the smallest Y upwards. The engine therefore behaves like
+// Clipper2 run on the vertically mirrored input: Y comparisons and the signs of
+// cross products are flipped relative to the C++ source, and output rings are
+// read in the opposite direction so that outer polygons have a positive area.
+
 // VattiEngine implements the Vatti scanline algorithm for polygon boolean operations
 type VattiEngine struct {
-	clipType    ClipType
-	fillRule    FillRule
-	minimaList  []*LocalMinima // sorted list of local minima
-	activeEdges *Edge          // head of active edge list (AEL)
-	currentY    int64          // current scanline Y position
-	outRecords  []*OutRec      // list of output records
-	succeeded   bool           // algorithm execution status
+	clipType      ClipType
+	fillRule      FillRule
+	minimaList    []*LocalMinima  // sorted list of local minima
+	currentLocMin int             // index of the next local minimum to insert
+	activeEdges   *Edge           // head of active edge list (AEL)
+	sel           *Edge           // head of sorted edge list (SEL), also the stack of pending horizontals
+	intersectList []IntersectNode // crossings found in the current scanbeam
+	botY          int64           // Y of the bottom of the current scanbeam
+	outRecords    []*OutRec       // list of output records
+	layer         int             // current output layer
+	layers        []outputLayer   // output state of every layer of a layered sweep (see vatti_layers.go)
+	hasOpenPaths  bool            // true if any open subject paths were added
+	succeeded     bool            // algorithm execution status
+	err           error           // diagnostic error recorded when execution fails
 
 	// Scanline processing
-	scanlineSet map[int64]bool // set of Y coordinates to process
+	scanlines []int64 // Y of every vertex; sorted and deduplicated when the sweep starts
+
+	// Maxima pairing (see registerMaximaEdge)
+	vertexCount int        // vertices added, each indexing maximaEdges
+	maximaEdges [][2]*Edge // bounds that reached each vertex as a local maximum
+
+	observer    scanbeamObserver // optional white-box hook (see vatti_snapshot.go)
+	cancelCheck func() error     // optional check run after every scanbeam (see SetCancelCheck)
+	flush       *flushState      // set when finished rings are emitted during the sweep (see vatti_flush.go)
+	area        *areaSweep       // set when only the area of the solution is summed (see vatti_area.go)
+	rounding    RoundingStrategy // rounding of computed points (nil: nearest)
+
+	preserveCollinear bool // keep collinear output vertices (ClipperOptions.PreserveCollinear)
+	reverseSolution   bool // reverse the paths emitted while flushing (ClipperOptions.ReverseSolution)
+
+	// Safety budget (see vatti_stall.go)
+	budget     SweepBudget // limits of the sweep; zero fields are sized from the input
+	work       int64       // work units spent
+	joins      int64       // ring joins performed
+	scanY      int64       // scanline being processed
+	lastOutRec *OutRec     // output record last extended
+
+	// Joins (see vatti_join.go)
+	horzSegs  []horzSegment // horizontal output runs of the current scanline
+	horzJoins []horzJoin    // rings touching along horizontals, joined after the sweep
+
+	// Arenas of the sweep's structs, kept across Reset (see vatti_arena.go)
+	vertices    arena[Vertex]
+	localMinima arena[LocalMinima]
+	edges       arena[Edge]
+	outPts      arena[OutPt]
+	outRecs     arena[OutRec]
+}
+
+// scanbeamObserver receives the engine state after every processed scanbeam
+type scanbeamObserver interface {
+	observeScanbeam(ve *VattiEngine, y int64)
+}
+
+// outRecObserver is a scanbeamObserver that also receives the output records
+// once the sweep has finished, before the solution is built from them
+type outRecObserver interface {
+	observeOutRecs(ve *VattiEngine)
 }
 
 // NewVattiEngine creates a new Vatti algorithm engine
 func NewVattiEngine(clipType ClipType, fillRule FillRule) *VattiEngine {
 	return &VattiEngine{
-		clipType:    clipType,
-		fillRule:    fillRule,
-		scanlineSet: make(map[int64]bool),
-		succeeded:   true,
+		clipType:  clipType,
+		fillRule:  fillRule,
+		succeeded: true,
+	}
+}
+
+// Reset prepares the engine for another operation with the same clip type
+// and fill rule, dropping everything else set on it, including the cancel
+// check. The edges, output points and buffers of the last operation are
+// reused, so an engine executing many operations allocates little beyond
+// their solutions, which stay valid.
+func (ve *VattiEngine) Reset() {
+	clear(ve.minimaList)
+	clear(ve.intersectList)
+	clear(ve.outRecords)
+	clear(ve.horzSegs)
+	clear(ve.horzJoins)
+	clear(ve.maximaEdges)
+	ve.vertices.reset()
+	ve.localMinima.reset()
+	ve.edges.reset()
+	ve.outPts.reset()
+	ve.outRecs.reset()
+	*ve = VattiEngine{
+		clipType:      ve.clipType,
+		fillRule:      ve.fillRule,
+		succeeded:     true,
+		minimaList:    ve.minimaList[:0],
+		intersectList: ve.intersectList[:0],
+		outRecords:    ve.outRecords[:0],
+		scanlines:     ve.scanlines[:0],
+		horzSegs:      ve.horzSegs[:0],
+		horzJoins:     ve.horzJoins[:0],
+		maximaEdges:   ve.maximaEdges[:0],
+		vertices:      ve.vertices,
+		localMinima:   ve.localMinima,
+		edges:         ve.edges,
+		outPts:        ve.outPts,
+		outRecs:       ve.outRecs,
+	}
+}
+
+// renewVattiEngine returns ve reset for a new operation, or a new engine if
+// ve is nil
+func renewVattiEngine(ve *VattiEngine, clipType ClipType, fillRule FillRule) *VattiEngine {
+	if ve == nil {
+		return NewVattiEngine(clipType, fillRule)
 	}
+	ve.Reset()
+	ve.clipType, ve.fillRule = clipType, fillRule
+	return ve
+}
+
+// SetCancelCheck makes the engine call check after every scanbeam. A non-nil
+// error stops the sweep, and ExecuteClipping returns it wrapped so it matches
+// both ErrClipperExecution and the error itself. Passing ctx.Err lets a
+// context abort pathological inputs; nil removes the check.
+func (ve *VattiEngine) SetCancelCheck(check func() error) {
+	ve.cancelCheck = check
 }
 
 // ExecuteClipping performs the complete boolean clipping operation
@@ -43,13 +154,22 @@ func (ve *VattiEngine) ExecuteClipping(subjects, subjectsOpen, clips Paths64) (s
 
 	// Phase 2: Path preprocessing - Convert paths to vertex chains and find local minima
 	debugLogPhase("PATH PREPROCESSING")
+	subjects, clips = ve.snapRound(subjects, clips)
 	if err := ve.addPaths(subjects, PathTypeSubject, false); err != nil {
 		return nil, nil, err
 	}
+	if err := ve.addPaths(subjectsOpen, PathTypeSubject, true); err != nil {
+		return nil, nil, err
+	}
 	if err := ve.addPaths(clips, PathTypeClip, false); err != nil {
 		return nil, nil, err
 	}
+	return ve.execute()
+}
 
+// execute runs the sweep over the local minima and scanlines already added
+// and builds the solution
+func (ve *VattiEngine) execute() (solution, solutionOpen Paths64, err error) {
 	debugLog("Found %d local minima", len(ve.minimaList))
 
 	// Handle empty input case
@@ -60,21 +180,34 @@ func (ve *VattiEngine) ExecuteClipping(subjects, subjectsOpen, clips Paths64) (s
 	// Sort local minima by Y coordinate
 	ve.sortLocalMinima()
 
-	debugLog("Sorted local minima:")
-	for i, lm := range ve.minimaList {
-		debugLog("  LM[%d]: Y=%d, Point=%v", i, lm.Vertex.Pt.Y, lm.Vertex.Pt)
+	if VattiDebug { // the arguments would be boxed even with logging off
+		debugLog("Sorted local minima:")
+		for i, lm := range ve.minimaList {
+			debugLog("  LM[%d]: Y=%d, Point=%v", i, lm.Vertex.Pt.Y, lm.Vertex.Pt)
+		}
 	}
 
 	// Execute main scanline algorithm
 	debugLogPhase("SCANLINE ALGORITHM")
-	if !ve.executeScanlineAlgorithm() {
-		return nil, nil, ErrClipperExecution
+	ok := ve.executeScanlineAlgorithm()
+	if o, isOutRecObserver := ve.observer.(outRecObserver); isOutRecObserver {
+		o.observeOutRecs(ve)
+	}
+	if !ok {
+		err := ve.err
+		if err == nil {
+			err = ErrClipperExecution
+		}
+		// Return the rings completed before the failure so callers that
+		// asked for partial results have something to inspect
+		solution, solutionOpen = ve.buildPartialSolution()
+		return solution, solutionOpen, err
 	}
 
 	// Phase 6: Build output paths
 	debugLogPhase("BUILD OUTPUT")
-	solution = ve.buildSolutionPaths()
-	solutionOpen = Paths64{} // Open paths not yet supported
+	solution, solutionOpen = ve.buildSolutionPaths()
+	solution = normalizeSolution(solution, ve.preserveCollinear)
 
 	debugLog("Solution paths: %v", solution)
 
@@ -94,6 +227,9 @@ func (ve *VattiEngine) addPaths(paths Paths64, pathType PathType, isOpen bool) e
 		if len(path) < 2 && isOpen {
 			continue // Skip degenerate open paths
 		}
+		if !isOpen && isCollinearRing(path) {
+			continue // Skip zero-area rings; they would create coincident bounds
+		}
 
 		if err := ve.addPath(path, pathType, isOpen); err != nil {
 			return err
@@ -105,7 +241,7 @@ func (ve *VattiEngine) addPaths(paths Paths64, pathType PathType, isOpen bool) e
 // addPath processes a single path and identifies local minima
 func (ve *VattiEngine) addPath(path Path64, pathType PathType, isOpen bool) error {
 	// Convert path to vertex chain
-	startVertex := createVertexFromPath(path, isOpen)
+	startVertex := buildVertexChain(ve.vertices.allocN(len(path)), path, isOpen)
 	if startVertex == nil {
 		return nil // Skip invalid paths
 	}
@@ -115,20 +251,20 @@ func (ve *VattiEngine) addPath(path Path64, pathType PathType, isOpen bool) erro
 		return ErrInvalidInput
 	}
 
-	// Find local minima in the vertex chain
-	localMinima := findLocalMinima(startVertex, pathType, isOpen)
-
-	// Add minima to the list and collect scanline Y coordinates
-	for _, lm := range localMinima {
-		ve.minimaList = append(ve.minimaList, lm)
-		ve.scanlineSet[lm.Vertex.Pt.Y] = true
+	// Add the local minima of the vertex chain to the list
+	if ve.addLocalMinima(startVertex, pathType, isOpen) && isOpen {
+		ve.hasOpenPaths = true
+	}
 
-		// Also add top points of edges to scanlines
-		if lm.Vertex.Next != nil {
-			ve.scanlineSet[lm.Vertex.Next.Pt.Y] = true
-		}
-		if lm.Vertex.Prev != nil {
-			ve.scanlineSet[lm.Vertex.Prev.Pt.Y] = true
+	// Every vertex is the top of some edge, so every vertex Y is a scanline
+	v := startVertex
+	for {
+		ve.scanlines = append(ve.scanlines, v.Pt.Y)
+		v.idx = ve.vertexCount
+		ve.vertexCount++
+		v = v.Next
+		if v == nil || v == startVertex {
+			break
 		}
 	}
 
@@ -150,134 +286,120 @@ func (ve *VattiEngine) sortLocalMinima() {
 // Main Scanline Algorithm Execution
 // ==============================================================================
 
-// executeScanlineAlgorithm runs the main Vatti scanline algorithm
+// executeScanlineAlgorithm runs the main Vatti scanline algorithm. At every
+// scanline the edges crossing inside the scanbeam below it are swapped, edges
+// reaching their top are advanced or removed, and then new local minima are
+// inserted; horizontal edges are processed after each of these phases.
 func (ve *VattiEngine) executeScanlineAlgorithm() bool {
+	// Every vertex added one scanline, so size the budget before deduplicating
+	ve.budget = ve.budget.resolve(len(ve.scanlines))
+	ve.work, ve.joins = 0, 0
+	ve.maximaEdges = slices.Grow(ve.maximaEdges[:0], ve.vertexCount)[:ve.vertexCount]
+	clear(ve.maximaEdges)
+
 	// Build sorted list of scanline Y coordinates
-	scanlines := ve.getSortedScanlines()
+	scanlines := ve.sortScanlines()
 
 	debugLog("Processing %d scanlines: %v", len(scanlines), scanlines)
 
-	minimaIndex := 0 // Index into sorted minima list
-
-	// Process each scanline from bottom to top
-	for _, y := range scanlines {
-		ve.currentY = y
+	if len(scanlines) == 0 {
+		return true
+	}
 
-		debugLog("\n--- Scanline Y=%d ---", y)
+	y := scanlines[0]
+	for i := 1; ve.succeeded; i++ {
+		if VattiDebug {
+			debugLog("\n--- Scanline Y=%d ---", y)
+		}
+		ve.scanY = y
 
 		// Phase 3: Insert local minima into Active Edge List
-		minimaIndex = ve.insertLocalMinimaIntoAEL(minimaIndex, y)
+		ve.insertLocalMinimaIntoAEL(y)
+		ve.processHorizontals()
+		ve.eachLayer(func() {
+			if len(ve.horzSegs) > 0 {
+				ve.convertHorzSegsToJoins()
+			}
+		})
 
 		debugLog("After inserting minima:")
 		debugLogAEL(ve.activeEdges)
 
-		// Phase 4: Update edge X positions for current scanline
-		ve.updateEdgePositions(y)
+		if ve.observer != nil {
+			ve.observer.observeScanbeam(ve, y)
+		}
+		if ve.cancelCheck != nil {
+			if err := ve.cancelCheck(); err != nil {
+				ve.fail(fmt.Errorf("%w: %w", ErrClipperExecution, err))
+			}
+		}
+		if ve.flush != nil {
+			ve.flushFinished()
+		}
 
-		// Phase 5: Process intersections and add output points
-		if !ve.processIntersections(y) {
-			return false
+		ve.botY = y
+		if i >= len(scanlines) {
+			break
 		}
+		y = scanlines[i]
+		ve.scanY = y
+
+		// Phase 4: Process the intersections inside the scanbeam
+		ve.doIntersections(y)
 
 		debugLog("After processing intersections:")
 		debugLogAEL(ve.activeEdges)
 
-		// Phase 6: Remove edges that have reached their top
-		ve.removeTopEdges(y)
+		// Phase 5: Advance or remove edges that have reached their top
+		ve.doTopOfScanbeam(y)
+		ve.processHorizontals()
 
-		debugLog("After removing top edges:")
+		debugLog("After processing the top of the scanbeam:")
 		debugLogAEL(ve.activeEdges)
-
-		if !ve.succeeded {
-			break
-		}
 	}
 
+	if ve.succeeded {
+		ve.eachLayer(ve.processHorzJoins)
+	}
 	return ve.succeeded
 }
 
-// getSortedScanlines returns sorted list of Y coordinates to process
-func (ve *VattiEngine) getSortedScanlines() []int64 {
-	scanlines := make([]int64, 0, len(ve.scanlineSet))
-	for y := range ve.scanlineSet {
-		scanlines = append(scanlines, y)
-	}
-	sort.Slice(scanlines, func(i, j int) bool {
-		return scanlines[i] < scanlines[j]
-	})
-	return scanlines
+// sortScanlines sorts the scanlines and removes duplicates in place, so the
+// sweep visits every Y once in a deterministic order without a map of all
+// vertex Ys
+func (ve *VattiEngine) sortScanlines() []int64 {
+	slices.Sort(ve.scanlines)
+	ve.scanlines = slices.Compact(ve.scanlines)
+	return ve.scanlines
 }
 
-// ==============================================================================
-// Phase 3: Active Edge List Management
-// ==============================================================================
-
-// insertLocalMinimaIntoAEL inserts local minima starting at current Y into AEL
-func (ve *VattiEngine) insertLocalMinimaIntoAEL(startIndex int, y int64) int {
-	index := startIndex
-
-	// Process all local minima at this Y coordinate
-	for index < len(ve.minimaList) && ve.minimaList[index].Vertex.Pt.Y == y {
-		lm := ve.minimaList[index]
-
-		// Create left and right bound edges from this local minimum
-		leftEdge, rightEdge := ve.createEdgesFromLocalMinimum(lm)
-
-		if leftEdge != nil {
-			ve.insertEdgeIntoAEL(leftEdge)
-		}
-		if rightEdge != nil {
-			ve.insertEdgeIntoAEL(rightEdge)
-		}
-
-		index++
+// fail records a diagnostic error and stops the scanline loop
+func (ve *VattiEngine) fail(err error) {
+	if ve.err == nil {
+		ve.err = err
 	}
-
-	return index
+	ve.succeeded = false
 }
 
-// createEdgesFromLocalMinimum creates left and right bound edges from a local minimum
-func (ve *VattiEngine) createEdgesFromLocalMinimum(lm *LocalMinima) (*Edge, *Edge) {
-	vertex := lm.Vertex
-
-	// Find the edges going up from this local minimum
-	var leftEdge, rightEdge *Edge
-
-	// Check previous vertex (left bound) - exclude horizontal edges
-	if vertex.Prev != nil && vertex.Prev.Pt.Y > vertex.Pt.Y {
-		leftEdge = ve.createEdge(vertex, vertex.Prev, lm, true)
-	}
-
-	// Check next vertex (right bound) - exclude horizontal edges
-	if vertex.Next != nil && vertex.Next.Pt.Y > vertex.Pt.Y {
-		rightEdge = ve.createEdge(vertex, vertex.Next, lm, false)
-	}
-
-	return leftEdge, rightEdge
-}
+// ==============================================================================
+// Edge Helpers
+// ==============================================================================
 
-// createEdge creates an edge from two vertices
+// createEdge creates an edge from two vertices. Left bounds (isLeftBound) follow
+// the vertex chain backwards and have a WindDx of -1, right bounds follow it
+// forwards with a WindDx of +1.
 func (ve *VattiEngine) createEdge(botVertex, topVertex *Vertex, localMin *LocalMinima, isLeftBound bool) *Edge {
-	edge := &Edge{
+	edge := ve.newEdge()
+	*edge = Edge{
 		Bot:         botVertex.Pt,
 		Top:         topVertex.Pt,
 		CurrX:       botVertex.Pt.X,
 		VertexTop:   topVertex,
 		LocalMin:    localMin,
 		IsLeftBound: isLeftBound,
+		layer:       &ve.layer,
 	}
-
-	// Calculate slope (Dx)
-	if topVertex.Pt.Y != botVertex.Pt.Y {
-		edge.Dx = float64(topVertex.Pt.X-botVertex.Pt.X) / float64(topVertex.Pt.Y-botVertex.Pt.Y)
-	} else {
-		// Horizontal edge
-		if topVertex.Pt.X > botVertex.Pt.X {
-			edge.Dx = -math.Inf(1)
-		} else {
-			edge.Dx = math.Inf(1)
-		}
-	}
+	setDx(edge)
 
 	// Set winding direction
 	if isLeftBound {
@@ -286,422 +408,1404 @@ func (ve *VattiEngine) createEdge(botVertex, topVertex *Vertex, localMin *LocalM
 		edge.WindDx = 1 // Right bounds contribute positive winding
 	}
 
+	if topVertex.isLocalMaximum() {
+		ve.registerMaximaEdge(edge)
+	}
+
 	return edge
 }
 
-// insertEdgeIntoAEL inserts an edge into the Active Edge List in sorted X order
-func (ve *VattiEngine) insertEdgeIntoAEL(edge *Edge) {
-	if ve.activeEdges == nil || edge.CurrX < ve.activeEdges.CurrX {
-		// Insert at beginning of list
-		edge.NextInAEL = ve.activeEdges
-		if ve.activeEdges != nil {
-			ve.activeEdges.PrevInAEL = edge
-		}
-		ve.activeEdges = edge
-		return
+// setDx updates an edge's slope (delta X per unit Y). Horizontal edges get
+// -Inf when heading right and +Inf when heading left, as in Clipper2.
+func setDx(e *Edge) {
+	dy := e.Top.Y - e.Bot.Y
+	switch {
+	case dy != 0:
+		e.Dx = float64(e.Top.X-e.Bot.X) / float64(dy)
+	case e.Top.X > e.Bot.X:
+		e.Dx = math.Inf(-1)
+	default:
+		e.Dx = math.Inf(1)
 	}
+}
 
-	// Find insertion point in sorted order
-	current := ve.activeEdges
-	for current.NextInAEL != nil && current.NextInAEL.CurrX <= edge.CurrX {
-		current = current.NextInAEL
+// topX returns the X coordinate of an edge at scanline y
+func topX(e *Edge, y int64) int64 {
+	if y == e.Top.Y || e.Top.X == e.Bot.X {
+		return e.Top.X
 	}
-
-	// Insert after current
-	edge.NextInAEL = current.NextInAEL
-	edge.PrevInAEL = current
-	if current.NextInAEL != nil {
-		current.NextInAEL.PrevInAEL = edge
+	if y == e.Bot.Y {
+		return e.Bot.X
 	}
-	current.NextInAEL = edge
+	return e.Bot.X + RoundHalfAway(e.Dx*float64(y-e.Bot.Y))
 }
 
-// removeTopEdges removes edges that have reached their top Y coordinate
-func (ve *VattiEngine) removeTopEdges(y int64) {
-	edge := ve.activeEdges
+// isHorizontal reports whether an edge is horizontal
+func isHorizontal(e *Edge) bool {
+	return e.Top.Y == e.Bot.Y
+}
 
-	for edge != nil {
-		nextEdge := edge.NextInAEL
+// isHeadingRightHorz reports whether a horizontal edge runs towards +X
+func isHeadingRightHorz(e *Edge) bool {
+	return math.IsInf(e.Dx, -1)
+}
 
-		// Check if edge has reached its top
-		if edge.Top.Y == y {
-			debugLog("Removing edge at X=%d (reached top)", edge.CurrX)
-			ve.removeEdgeFromAEL(edge)
-		}
+// isHeadingLeftHorz reports whether a horizontal edge runs towards -X
+func isHeadingLeftHorz(e *Edge) bool {
+	return math.IsInf(e.Dx, 1)
+}
+
+// isMaxima reports whether an edge ends at a local maximum
+func isMaxima(e *Edge) bool {
+	return e.VertexTop.isLocalMaximum()
+}
 
-		edge = nextEdge
+// isOpenEdge reports whether an edge belongs to an open path
+func isOpenEdge(e *Edge) bool {
+	return e.LocalMin.IsOpen
+}
+
+// isOpenEndVertex reports whether a vertex is either end of an open path
+func isOpenEndVertex(v *Vertex) bool {
+	return v.Flags&(VertexFlagsOpenStart|VertexFlagsOpenEnd) != 0
+}
+
+// isOpenEndEdge reports whether an open path edge ends at the path's end
+func isOpenEndEdge(e *Edge) bool {
+	return e.LocalMin.IsOpen && isOpenEndVertex(e.VertexTop)
+}
+
+// isHotEdge reports whether an edge is currently contributing to an output record
+func isHotEdge(e *Edge) bool {
+	return e.outRec() != nil
+}
+
+// isFront reports whether a hot edge adds its points at the front of its ring
+func isFront(e *Edge) bool {
+	return e == e.outRec().FrontEdge
+}
+
+// isJoined reports whether an edge is joined to a neighbour (see JoinWith)
+func isJoined(e *Edge) bool {
+	return e.joinWith() != JoinWithNoJoin
+}
+
+// polyType returns whether an edge belongs to a subject or a clip path
+func polyType(e *Edge) PathType {
+	return e.LocalMin.PathType
+}
+
+// isSamePolyType reports whether two edges belong to the same kind of path
+func isSamePolyType(e1, e2 *Edge) bool {
+	return e1.LocalMin.PathType == e2.LocalMin.PathType
+}
+
+// nextVertex returns the vertex after an edge's top along its bound
+func nextVertex(e *Edge) *Vertex {
+	if e.WindDx > 0 {
+		return e.VertexTop.Next
 	}
+	return e.VertexTop.Prev
 }
 
-// updateEdgePositions updates current X positions for all active edges
-func (ve *VattiEngine) updateEdgePositions(y int64) {
-	edge := ve.activeEdges
+// prevPrevVertex returns the vertex two steps before an edge's top along its bound
+func prevPrevVertex(e *Edge) *Vertex {
+	if e.WindDx > 0 {
+		return e.VertexTop.Prev.Prev
+	}
+	return e.VertexTop.Next.Next
+}
 
-	for edge != nil {
-		// Update current X position for this scanline
-		ve.updateEdgeCurrentX(edge, y)
-		edge = edge.NextInAEL
+// abs returns the absolute value of an int
+func abs(x int) int {
+	if x < 0 {
+		return -x
 	}
+	return x
+}
+
+// ==============================================================================
+// Bound Progression and Maxima Pairing
+// ==============================================================================
+
+// maximaSlot returns the bounds registered at the local maximum on top of
+// edge. They live in the engine rather than on the vertex, so vertex chains
+// shared by prepared data are never written during a sweep.
+func (ve *VattiEngine) maximaSlot(edge *Edge) *[2]*Edge {
+	return &ve.maximaEdges[edge.LocalMin.vertexBase+edge.VertexTop.idx]
 }
 
-// updateEdgeCurrentX updates an edge's current X position for the given Y
-func (ve *VattiEngine) updateEdgeCurrentX(edge *Edge, y int64) {
-	switch y {
-	case edge.Bot.Y:
-		edge.CurrX = edge.Bot.X
-	case edge.Top.Y:
-		edge.CurrX = edge.Top.X
+// registerMaximaEdge records an edge whose top is a local maximum for that
+// vertex, so its partner can be found in O(1)
+func (ve *VattiEngine) registerMaximaEdge(edge *Edge) {
+	slot := ve.maximaSlot(edge)
+	switch {
+	case slot[0] == edge || slot[1] == edge:
+	case slot[0] == nil:
+		slot[0] = edge
+	case slot[1] == nil:
+		slot[1] = edge
 	default:
-		// Calculate X using slope
-		deltaY := float64(y - edge.Bot.Y)
-		edge.CurrX = edge.Bot.X + int64(edge.Dx*deltaY+0.5) // Round to nearest
+		ve.fail(fmt.Errorf("%w: more than two bounds meet at maximum %v", ErrInternalTopology, edge.VertexTop.Pt))
 	}
 }
 
-// removeEdgeFromAEL removes an edge from the Active Edge List
-func (ve *VattiEngine) removeEdgeFromAEL(edge *Edge) {
-	if edge.PrevInAEL != nil {
-		edge.PrevInAEL.NextInAEL = edge.NextInAEL
-	} else {
-		ve.activeEdges = edge.NextInAEL
+// getMaximaPair returns the other edge ending at the same local maximum as edge,
+// or nil if that bound has not reached it
+func (ve *VattiEngine) getMaximaPair(edge *Edge) *Edge {
+	slot := ve.maximaSlot(edge)
+	if slot[0] == edge {
+		return slot[1]
 	}
-
-	if edge.NextInAEL != nil {
-		edge.NextInAEL.PrevInAEL = edge.PrevInAEL
+	if slot[1] == edge {
+		return slot[0]
 	}
+	return nil
+}
 
-	// Clear pointers
-	edge.PrevInAEL = nil
-	edge.NextInAEL = nil
+// isInAEL reports whether edge is currently linked into the active edge list
+func (ve *VattiEngine) isInAEL(edge *Edge) bool {
+	return edge.PrevInAEL != nil || ve.activeEdges == edge
 }
 
-// ==============================================================================
-// Phase 4: Intersection Processing (Simplified for now)
-// ==============================================================================
+// updateEdgeIntoAEL moves an edge that reached its top onto the next segment
+// of its bound, keeping its AEL position, winding and output record
+func (ve *VattiEngine) updateEdgeIntoAEL(e *Edge) {
+	e.Bot = e.Top
+	e.VertexTop = nextVertex(e)
+	e.Top = e.VertexTop.Pt
+	e.CurrX = e.Bot.X
+	setDx(e)
+	if isMaxima(e) {
+		ve.registerMaximaEdge(e)
+	}
+	ve.eachLayer(func() {
+		if isJoined(e) {
+			ve.split(e, e.Bot)
+		}
+	})
 
-// processIntersections handles edge intersections and updates winding counts
-func (ve *VattiEngine) processIntersections(y int64) bool {
-	// First, check if any edges are ending at this scanline
-	hasEndingEdges := false
-	for e := ve.activeEdges; e != nil; e = e.NextInAEL {
-		if e.Top.Y == y {
-			hasEndingEdges = true
+	if isHorizontal(e) {
+		if !isOpenEdge(e) {
+			ve.trimHorz(e, ve.preserveCollinear)
+		}
+		return
+	}
+	ve.eachLayer(func() {
+		ve.checkJoinLeft(e, e.Bot)
+		ve.checkJoinRight(e, e.Bot)
+	})
+}
+
+// trimHorz extends a horizontal edge over any following horizontal segments
+// of its bound (always across 180 degree spikes, otherwise only when collinear
+// vertices are not preserved)
+func (ve *VattiEngine) trimHorz(horz *Edge, preserveCollinear bool) {
+	wasTrimmed := false
+	pt := nextVertex(horz).Pt
+	for pt.Y == horz.Top.Y {
+		if preserveCollinear && (pt.X < horz.Top.X) != (horz.Bot.X < horz.Top.X) {
+			break
+		}
+		horz.VertexTop = nextVertex(horz)
+		horz.Top = pt
+		wasTrimmed = true
+		if isMaxima(horz) {
+			ve.registerMaximaEdge(horz)
 			break
 		}
+		pt = nextVertex(horz).Pt
 	}
+	if wasTrimmed {
+		setDx(horz)
+	}
+}
 
-	// Update winding counts for all edges
-	ve.updateWindingCounts()
+// ==============================================================================
+// Phase 3: Active Edge List Management
+// ==============================================================================
 
-	debugLog("Winding counts updated at Y=%d (hasEndingEdges=%v):", y, hasEndingEdges)
+// insertLocalMinimaIntoAEL inserts both bounds of every local minimum at botY
+func (ve *VattiEngine) insertLocalMinimaIntoAEL(botY int64) {
+	for ve.currentLocMin < len(ve.minimaList) && ve.minimaList[ve.currentLocMin].Vertex.Pt.Y == botY {
+		lm := ve.minimaList[ve.currentLocMin]
+		ve.currentLocMin++
+
+		// The left bound descends the vertex chain, the right bound ascends it;
+		// open path ends only have one of them
+		var leftBound, rightBound *Edge
+		if !lm.Vertex.isOpenStart() {
+			leftBound = ve.createEdge(lm.Vertex, lm.Vertex.Prev, lm, true)
+		}
+		if !lm.Vertex.isOpenEnd() {
+			rightBound = ve.createEdge(lm.Vertex, lm.Vertex.Next, lm, false)
+		}
 
-	// Process contribution transitions
-	// If we have ending edges and output records exist, use reverse order for closing
-	var transitionPoints []struct {
-		edge *Edge
-		pt   Point64
-		kind string // "enter" or "exit"
-	}
+		// Make sure leftBound really is on the left
+		if leftBound != nil && rightBound != nil {
+			switch {
+			case isHorizontal(leftBound):
+				if isHeadingRightHorz(leftBound) {
+					leftBound, rightBound = rightBound, leftBound
+				}
+			case isHorizontal(rightBound):
+				if isHeadingLeftHorz(rightBound) {
+					leftBound, rightBound = rightBound, leftBound
+				}
+			case leftBound.Dx > rightBound.Dx:
+				leftBound, rightBound = rightBound, leftBound
+			}
+		} else if leftBound == nil {
+			leftBound, rightBound = rightBound, nil
+		}
 
-	edge := ve.activeEdges
-	prevContributing := false
+		leftBound.IsLeftBound = true
+		ve.insertLeftEdge(leftBound)
 
-	for edge != nil {
-		currentContributing := ve.isContributingEdge(edge)
+		if isOpenEdge(leftBound) {
+			ve.setWindCountForOpenPathEdge(leftBound)
+		} else {
+			ve.setWindCountForClosedPathEdge(leftBound)
+		}
 
-		debugLogWindingCalc(edge, currentContributing)
+		if rightBound != nil {
+			rightBound.IsLeftBound = false
+			rightBound.WindCount = leftBound.WindCount
+			rightBound.WindCount2 = leftBound.WindCount2
+			insertRightEdge(leftBound, rightBound)
+
+			ve.eachLayer(func() {
+				if ve.isContributing(leftBound) {
+					ve.addLocalMinPoly(leftBound, rightBound, leftBound.Bot, true)
+					if !isHorizontal(leftBound) {
+						ve.checkJoinLeft(leftBound, leftBound.Bot)
+					}
+				}
+			})
+
+			for rightBound.NextInAEL != nil && isValidAELOrder(rightBound.NextInAEL, rightBound) {
+				ve.intersectEdges(rightBound, rightBound.NextInAEL, rightBound.Bot)
+				ve.swapPositionsInAEL(rightBound, rightBound.NextInAEL)
+			}
 
-		// Detect transitions
-		if prevContributing != currentContributing {
-			var kind string
-			if currentContributing {
-				kind = "enter"
+			if isHorizontal(rightBound) {
+				ve.pushHorz(rightBound)
 			} else {
-				kind = "exit"
+				ve.eachLayer(func() { ve.checkJoinRight(rightBound, rightBound.Bot) })
 			}
-			transitionPoints = append(transitionPoints, struct {
-				edge *Edge
-				pt   Point64
-				kind string
-			}{edge, Point64{edge.CurrX, y}, kind})
+		} else if ve.isContributing(leftBound) {
+			ve.startOpenPath(leftBound, leftBound.Bot)
 		}
 
-		prevContributing = currentContributing
+		if isHorizontal(leftBound) {
+			ve.pushHorz(leftBound)
+		}
+	}
+}
 
-		// Check for intersections with next edge
-		if edge.NextInAEL != nil && ve.edgesIntersect(edge, edge.NextInAEL) {
-			debugLog("    -> Edges intersect! Swapping edges at X=%d and X=%d", edge.CurrX, edge.NextInAEL.CurrX)
-			ve.swapAdjacentEdges(edge, edge.NextInAEL)
+// insertLeftEdge inserts a new left bound at its sorted position in the AEL
+func (ve *VattiEngine) insertLeftEdge(e *Edge) {
+	switch {
+	case ve.activeEdges == nil:
+		e.PrevInAEL = nil
+		e.NextInAEL = nil
+		ve.activeEdges = e
+	case !isValidAELOrder(ve.activeEdges, e):
+		e.PrevInAEL = nil
+		e.NextInAEL = ve.activeEdges
+		ve.activeEdges.PrevInAEL = e
+		ve.activeEdges = e
+	default:
+		e2 := ve.activeEdges
+		for e2.NextInAEL != nil && isValidAELOrder(e2.NextInAEL, e) {
+			e2 = e2.NextInAEL
+		}
+		// don't separate joined edges; in a layered sweep an edge can be
+		// joined to both neighbours, in different layers
+		for joinedInAnyLayer(e2, JoinWithRight) {
+			e2 = e2.NextInAEL
 		}
+		e.NextInAEL = e2.NextInAEL
+		if e2.NextInAEL != nil {
+			e2.NextInAEL.PrevInAEL = e
+		}
+		e.PrevInAEL = e2
+		e2.NextInAEL = e
+	}
+}
 
-		edge = edge.NextInAEL
+// insertRightEdge inserts e2 immediately to the right of e in the AEL
+func insertRightEdge(e, e2 *Edge) {
+	e2.NextInAEL = e.NextInAEL
+	if e.NextInAEL != nil {
+		e.NextInAEL.PrevInAEL = e2
 	}
+	e2.PrevInAEL = e
+	e.NextInAEL = e2
+}
 
-	// Add transition points
-	// If we have ending edges and already have points, add in REVERSE order
-	if hasEndingEdges && len(ve.outRecords) > 0 && len(transitionPoints) > 0 {
-		debugLog("Adding %d transition points in REVERSE order (ending scanline)", len(transitionPoints))
-		for i := len(transitionPoints) - 1; i >= 0; i-- {
-			tp := transitionPoints[i]
-			debugLog("    -> Adding %s point at (%d,%d)", tp.kind, tp.pt.X, tp.pt.Y)
-			ve.addOutputPoint(tp.edge, tp.pt)
-		}
-	} else {
-		// Normal order
-		for _, tp := range transitionPoints {
-			verb := "Entering"
-			if tp.kind == "exit" {
-				verb = "Exiting"
-			}
-			debugLog("    -> %s intersection at (%d,%d)", verb, tp.pt.X, tp.pt.Y)
-			ve.addOutputPoint(tp.edge, tp.pt)
+// isValidAELOrder reports whether newcomer belongs to the right of resident,
+// both being at the current scanline
+func isValidAELOrder(resident, newcomer *Edge) bool {
+	if newcomer.CurrX != resident.CurrX {
+		return newcomer.CurrX > resident.CurrX
+	}
+
+	// Edges less than a unit apart round to the same CurrX, so a newcomer
+	// starting beside the resident is placed on the side it starts on
+	if !isHorizontal(resident) {
+		if c := exactXCmp(resident, newcomer.Bot); c != 0 {
+			return c < 0
 		}
 	}
 
-	debugLog("Current output records: %d", len(ve.outRecords))
-	for _, outRec := range ve.outRecords {
-		debugLogOutRec(fmt.Sprintf("OutRec #%d", outRec.Idx), outRec)
+	// get the turning direction resident.Top, newcomer.Bot, newcomer.Top
+	d := CrossProduct128(resident.Top, newcomer.Bot, newcomer.Top).Cmp(Int128{})
+	if d != 0 {
+		return d > 0
 	}
 
-	return true
-}
+	// edges must be collinear to get here; for starting open paths, place them
+	// according to the direction they're about to turn
+	if !isMaxima(resident) && resident.Top.Y < newcomer.Top.Y {
+		return CrossProduct128(newcomer.Bot, resident.Top, nextVertex(resident).Pt).Cmp(Int128{}) >= 0
+	}
+	if !isMaxima(newcomer) && newcomer.Top.Y < resident.Top.Y {
+		return CrossProduct128(newcomer.Bot, newcomer.Top, nextVertex(newcomer).Pt).Cmp(Int128{}) <= 0
+	}
 
-// edgesIntersect checks if two edges intersect (simplified)
-func (ve *VattiEngine) edgesIntersect(e1, e2 *Edge) bool {
-	// Simple check: if current X positions are out of order, they likely intersected
-	return e1.CurrX > e2.CurrX
+	y := newcomer.Bot.Y
+	newcomerIsLeft := newcomer.IsLeftBound
+	if resident.Bot.Y != y || resident.LocalMin.Vertex.Pt.Y != y {
+		return newcomer.IsLeftBound
+	}
+	// resident must also have just been inserted
+	if resident.IsLeftBound != newcomerIsLeft {
+		return newcomerIsLeft
+	}
+	if CrossProduct128(prevPrevVertex(resident).Pt, resident.Bot, resident.Top).IsZero() {
+		return true
+	}
+	// compare turning direction of the alternate bound
+	return (CrossProduct128(prevPrevVertex(resident).Pt, newcomer.Bot, prevPrevVertex(newcomer).Pt).Cmp(Int128{}) < 0) == newcomerIsLeft
 }
 
-// swapAdjacentEdges swaps two adjacent edges in the AEL
-func (ve *VattiEngine) swapAdjacentEdges(e1, e2 *Edge) {
-	if e1.NextInAEL != e2 {
-		return // Not adjacent
+// swapPositionsInAEL swaps two adjacent edges (e1 immediately left of e2)
+func (ve *VattiEngine) swapPositionsInAEL(e1, e2 *Edge) {
+	next := e2.NextInAEL
+	if next != nil {
+		next.PrevInAEL = e1
 	}
-
-	// Update linked list pointers
-	if e1.PrevInAEL != nil {
-		e1.PrevInAEL.NextInAEL = e2
-	} else {
+	prev := e1.PrevInAEL
+	if prev != nil {
+		prev.NextInAEL = e2
+	}
+	e2.PrevInAEL = prev
+	e2.NextInAEL = e1
+	e1.PrevInAEL = e2
+	e1.NextInAEL = next
+	if e2.PrevInAEL == nil {
 		ve.activeEdges = e2
 	}
+}
 
-	if e2.NextInAEL != nil {
-		e2.NextInAEL.PrevInAEL = e1
+// deleteFromAEL removes an edge from the Active Edge List
+func (ve *VattiEngine) deleteFromAEL(e *Edge) {
+	prev, next := e.PrevInAEL, e.NextInAEL
+	if prev == nil && next == nil && e != ve.activeEdges {
+		return // already deleted
 	}
+	if prev != nil {
+		prev.NextInAEL = next
+	} else {
+		ve.activeEdges = next
+	}
+	if next != nil {
+		next.PrevInAEL = prev
+	}
+	e.PrevInAEL = nil
+	e.NextInAEL = nil
+}
 
-	e2.PrevInAEL = e1.PrevInAEL
-	e1.NextInAEL = e2.NextInAEL
-	e1.PrevInAEL = e2
-	e2.NextInAEL = e1
+// pushHorz queues a horizontal edge for processing (the SEL is reused as a stack)
+func (ve *VattiEngine) pushHorz(e *Edge) {
+	e.NextInSEL = ve.sel
+	ve.sel = e
+}
 
-	// Swap current X positions
-	e1.CurrX, e2.CurrX = e2.CurrX, e1.CurrX
+// popHorz takes the next queued horizontal edge, or nil
+func (ve *VattiEngine) popHorz() *Edge {
+	e := ve.sel
+	if e != nil {
+		ve.sel = e.NextInSEL
+	}
+	return e
+}
+
+// processHorizontals processes every queued horizontal edge
+func (ve *VattiEngine) processHorizontals() {
+	for e := ve.popHorz(); e != nil && ve.succeeded; e = ve.popHorz() {
+		ve.doHorizontal(e)
+	}
 }
 
 // ==============================================================================
-// Phase 5: Winding Count Calculation and Fill Rules
+// Winding Counts and Contribution
 // ==============================================================================
 
-// updateWindingCounts calculates winding counts for all active edges
-func (ve *VattiEngine) updateWindingCounts() {
-	windCountSubject := 0
-	windCountClip := 0
+// windingRule returns the fill rule applied to the engine's winding counts.
+// The sweep runs towards +Y where Clipper2's runs towards -Y, which mirrors
+// the winding counts: counter-clockwise paths (positive Area64) wind -1 in
+// the engine. Positive and Negative are swapped to compensate, so Positive
+// still fills the regions counter-clockwise paths wind around.
+func (ve *VattiEngine) windingRule() FillRule {
+	switch ve.fillRule {
+	case Positive:
+		return Negative
+	case Negative:
+		return Positive
+	}
+	return ve.fillRule
+}
 
-	edge := ve.activeEdges
-	for edge != nil {
-		// Update winding count based on this edge's path type
-		if edge.LocalMin.PathType == PathTypeSubject {
-			windCountSubject += edge.WindDx
+// setWindCountForClosedPathEdge sets the winding counts of a new closed path
+// edge. An edge's WindCount is the higher of the wind counts of the two regions
+// touching it; adjacent regions always differ by one.
+func (ve *VattiEngine) setWindCountForClosedPathEdge(e *Edge) {
+	rule := ve.windingRule()
+
+	// find the nearest closed path edge of the same PathType in the AEL (heading left)
+	pt := polyType(e)
+	e2 := e.PrevInAEL
+	for e2 != nil && (polyType(e2) != pt || isOpenEdge(e2)) {
+		e2 = e2.PrevInAEL
+	}
+
+	switch {
+	case e2 == nil:
+		e.WindCount = e.WindDx
+		e2 = ve.activeEdges
+	case rule == EvenOdd:
+		e.WindCount = e.WindDx
+		e.WindCount2 = e2.WindCount2
+		e2 = e2.NextInAEL
+	default:
+		// if e's WindCount is in the SAME direction as its WindDx, then polygon
+		// filling will be on the right of 'e'
+		if e2.WindCount*e2.WindDx < 0 {
+			// opposite directions so 'e' is outside 'e2'
+			if abs(e2.WindCount) > 1 {
+				// outside prev poly but still inside another
+				if e2.WindDx*e.WindDx < 0 {
+					e.WindCount = e2.WindCount // reversing direction so use the same WC
+				} else {
+					e.WindCount = e2.WindCount + e.WindDx
+				}
+			} else {
+				// now outside all polys of same polytype so set own WC
+				if isOpenEdge(e) {
+					e.WindCount = 1
+				} else {
+					e.WindCount = e.WindDx
+				}
+			}
 		} else {
-			windCountClip += edge.WindDx
+			// 'e' must be inside 'e2'
+			if e2.WindDx*e.WindDx < 0 {
+				e.WindCount = e2.WindCount // reversing direction so use the same WC
+			} else {
+				e.WindCount = e2.WindCount + e.WindDx
+			}
 		}
+		e.WindCount2 = e2.WindCount2
+		e2 = e2.NextInAEL
+	}
 
-		// All edges get both counts (subject and clip winding at this X position)
-		edge.WindCount = windCountSubject
-		edge.WindCount2 = windCountClip
-
-		edge = edge.NextInAEL
+	// update WindCount2
+	for ; e2 != e; e2 = e2.NextInAEL {
+		if polyType(e2) == pt || isOpenEdge(e2) {
+			continue
+		}
+		if rule == EvenOdd {
+			if e.WindCount2 == 0 {
+				e.WindCount2 = 1
+			} else {
+				e.WindCount2 = 0
+			}
+		} else {
+			e.WindCount2 += e2.WindDx
+		}
 	}
 }
 
-// isContributingEdge determines if an edge contributes to the output based on fill rules and clip type
-func (ve *VattiEngine) isContributingEdge(edge *Edge) bool {
-	// Get winding counts
-	windCnt := edge.WindCount
-	windCnt2 := edge.WindCount2
+// setWindCountForOpenPathEdge sets the winding counts of the closed subject
+// and clip regions around a new open path edge
+func (ve *VattiEngine) setWindCountForOpenPathEdge(e *Edge) {
+	if ve.windingRule() == EvenOdd {
+		cnt1, cnt2 := 0, 0
+		for e2 := ve.activeEdges; e2 != e; e2 = e2.NextInAEL {
+			if polyType(e2) == PathTypeClip {
+				cnt2++
+			} else if !isOpenEdge(e2) {
+				cnt1++
+			}
+		}
+		e.WindCount = cnt1 & 1
+		e.WindCount2 = cnt2 & 1
+		return
+	}
 
-	// Apply fill rule to determine if regions are filled
-	pft := ve.fillRule
-	var pftSubject, pftClip bool
+	for e2 := ve.activeEdges; e2 != e; e2 = e2.NextInAEL {
+		if polyType(e2) == PathTypeClip {
+			e.WindCount2 += e2.WindDx
+		} else if !isOpenEdge(e2) {
+			e.WindCount += e2.WindDx
+		}
+	}
+}
 
-	switch pft {
-	case EvenOdd:
-		pftSubject = (abs(windCnt) & 1) != 0
-		pftClip = (abs(windCnt2) & 1) != 0
+// isContributing reports whether a new left bound bounds the result
+func (ve *VattiEngine) isContributing(e *Edge) bool {
+	var contributing bool
+	if isOpenEdge(e) {
+		contributing = ve.isContributingOpen(e)
+	} else {
+		contributing = ve.isContributingClosed(e)
+	}
+	debugLogWindingCalc(e, contributing)
+	return contributing
+}
+
+// isContributingClosed reports whether a closed path edge bounds the result
+func (ve *VattiEngine) isContributingClosed(e *Edge) bool {
+	rule := ve.windingRule()
+	switch rule {
 	case NonZero:
-		pftSubject = windCnt != 0
-		pftClip = windCnt2 != 0
+		if abs(e.WindCount) != 1 {
+			return false
+		}
+	case Positive:
+		if e.WindCount != 1 {
+			return false
+		}
+	case Negative:
+		if e.WindCount != -1 {
+			return false
+		}
+	}
+
+	// whether the edge lies inside the region filled by the other path type
+	var inOther bool
+	switch rule {
 	case Positive:
-		// Positive fill rule: use absolute value for simple polygons (non-self-intersecting).
-		// This works for both CW (positive winding) and CCW (negative winding) polygon orientations.
-		pftSubject = abs(windCnt) > 0
-		pftClip = abs(windCnt2) > 0
+		inOther = e.WindCount2 > 0
 	case Negative:
-		// Negative fill rule: use absolute value for simple polygons (non-self-intersecting).
-		// Note: Both Positive and Negative use the same logic (abs(windCnt) > 0) because they
-		// differ only in their interpretation of "filled" regions, but in the Vatti algorithm's
-		// edge contribution logic, both simply need to check if the winding count is non-zero,
-		// regardless of its sign. The actual difference between Positive/Negative is handled
-		// by the polygon orientation during input processing.
-		pftSubject = abs(windCnt) > 0
-		pftClip = abs(windCnt2) > 0
-	}
-
-	var result bool
-	// Determine if edge contributes based on clip type
+		inOther = e.WindCount2 < 0
+	default:
+		inOther = e.WindCount2 != 0
+	}
+
 	switch ve.clipType {
-	case Union:
-		result = pftSubject || pftClip
 	case Intersection:
-		result = pftSubject && pftClip
+		return inOther
+	case Union:
+		return !inOther
 	case Difference:
-		if edge.LocalMin.PathType == PathTypeSubject {
-			result = pftSubject && !pftClip
-		} else {
-			result = pftClip && !pftSubject
+		if polyType(e) == PathTypeSubject {
+			return !inOther
 		}
+		return inOther
 	case Xor:
-		result = pftSubject != pftClip
-	default:
-		result = false
+		return true
 	}
-
-	return result
+	return false
 }
 
-// abs returns absolute value of an integer
-func abs(x int) int {
-	if x < 0 {
-		return -x
+// isContributingOpen reports whether an open path edge belongs to the result
+func (ve *VattiEngine) isContributingOpen(e *Edge) bool {
+	var inClip, inSubj bool
+	switch ve.windingRule() {
+	case Positive:
+		inClip, inSubj = e.WindCount2 > 0, e.WindCount > 0
+	case Negative:
+		inClip, inSubj = e.WindCount2 < 0, e.WindCount < 0
+	default:
+		inClip, inSubj = e.WindCount2 != 0, e.WindCount != 0
+	}
+
+	switch ve.clipType {
+	case Intersection:
+		return inClip
+	case Union:
+		return !inSubj && !inClip
+	default:
+		return !inClip
 	}
-	return x
 }
 
 // ==============================================================================
-// Phase 6: Output Building
+// Phase 4: Intersection Processing
 // ==============================================================================
 
-// addOutputPoint adds a point to the output polygon for a contributing edge
-func (ve *VattiEngine) addOutputPoint(edge *Edge, pt Point64) {
-	// For intersection operations, use a single shared output record
-	// This is a simplified approach - proper Vatti algorithm has more complex polygon linking
-	var outRec *OutRec
-
-	if ve.clipType == Intersection {
-		// Use single shared output record for intersection polygon
-		if len(ve.outRecords) == 0 {
-			outRec = &OutRec{
-				Idx:   0,
-				State: OutRecStateOuter,
-			}
-			ve.outRecords = append(ve.outRecords, outRec)
+// intersectEdges updates the winding counts and output records of two edges
+// crossing (or touching) at pt, e1 being the left one before the crossing
+func (ve *VattiEngine) intersectEdges(e1, e2 *Edge, pt Point64) {
+	// MANAGE OPEN PATH INTERSECTIONS SEPARATELY
+	if ve.hasOpenPaths && (isOpenEdge(e1) || isOpenEdge(e2)) {
+		ve.intersectOpenEdge(e1, e2, pt)
+		return
+	}
+
+	// MANAGING CLOSED PATHS FROM HERE ON
+	ve.eachLayer(func() {
+		if isJoined(e1) {
+			ve.split(e1, pt)
+		}
+		if isJoined(e2) {
+			ve.split(e2, pt)
+		}
+	})
+
+	rule := ve.windingRule()
+
+	// UPDATE WINDING COUNTS
+	if isSamePolyType(e1, e2) {
+		if rule == EvenOdd {
+			e1.WindCount, e2.WindCount = e2.WindCount, e1.WindCount
 		} else {
-			outRec = ve.outRecords[0] // Use first (and only) output record
+			if e1.WindCount+e2.WindDx == 0 {
+				e1.WindCount = -e1.WindCount
+			} else {
+				e1.WindCount += e2.WindDx
+			}
+			if e2.WindCount-e1.WindDx == 0 {
+				e2.WindCount = -e2.WindCount
+			} else {
+				e2.WindCount -= e1.WindDx
+			}
 		}
-		edge.OutRec = outRec
+	} else if rule != EvenOdd {
+		e1.WindCount2 += e2.WindDx
+		e2.WindCount2 -= e1.WindDx
 	} else {
-		// For other operations, create separate records per edge (original logic)
-		if edge.OutRec == nil {
-			edge.OutRec = &OutRec{
-				Idx:   len(ve.outRecords),
-				State: OutRecStateOuter,
+		e1.WindCount2 = 1 - e1.WindCount2
+		e2.WindCount2 = 1 - e2.WindCount2
+	}
+
+	ve.eachLayer(func() { ve.outputIntersection(e1, e2, pt) })
+}
+
+// outputIntersection updates the output records of two closed path edges
+// crossing at pt, their winding counts already updated
+func (ve *VattiEngine) outputIntersection(e1, e2 *Edge, pt Point64) {
+	rule := ve.windingRule()
+	var oldE1WindCount, oldE2WindCount int
+	switch rule {
+	case Positive:
+		oldE1WindCount, oldE2WindCount = e1.WindCount, e2.WindCount
+	case Negative:
+		oldE1WindCount, oldE2WindCount = -e1.WindCount, -e2.WindCount
+	default:
+		oldE1WindCount, oldE2WindCount = abs(e1.WindCount), abs(e2.WindCount)
+	}
+
+	e1WindCountIn01 := oldE1WindCount == 0 || oldE1WindCount == 1
+	e2WindCountIn01 := oldE2WindCount == 0 || oldE2WindCount == 1
+	if (!isHotEdge(e1) && !e1WindCountIn01) || (!isHotEdge(e2) && !e2WindCountIn01) {
+		return
+	}
+
+	// NOW PROCESS THE INTERSECTION
+	switch {
+	case isHotEdge(e1) && isHotEdge(e2):
+		if !e1WindCountIn01 || !e2WindCountIn01 ||
+			(!isSamePolyType(e1, e2) && ve.clipType != Xor) {
+			ve.addLocalMaxPoly(e1, e2, pt)
+		} else if isFront(e1) || e1.outRec() == e2.outRec() {
+			// split polygons that only touch at a common vertex (not at common edges)
+			ve.addLocalMaxPoly(e1, e2, pt)
+			ve.addLocalMinPoly(e1, e2, pt, false)
+		} else {
+			// can't treat as maxima & minima
+			ve.addOutPt(e1, pt)
+			ve.addOutPt(e2, pt)
+			swapOutRecs(e1, e2)
+		}
+	case isHotEdge(e1):
+		ve.addOutPt(e1, pt)
+		swapOutRecs(e1, e2)
+	case isHotEdge(e2):
+		ve.addOutPt(e2, pt)
+		swapOutRecs(e1, e2)
+	default:
+		// neither edge is 'hot'
+		var e1Wc2, e2Wc2 int
+		switch rule {
+		case Positive:
+			e1Wc2, e2Wc2 = e1.WindCount2, e2.WindCount2
+		case Negative:
+			e1Wc2, e2Wc2 = -e1.WindCount2, -e2.WindCount2
+		default:
+			e1Wc2, e2Wc2 = abs(e1.WindCount2), abs(e2.WindCount2)
+		}
+
+		if !isSamePolyType(e1, e2) {
+			ve.addLocalMinPoly(e1, e2, pt, false)
+		} else if oldE1WindCount == 1 && oldE2WindCount == 1 {
+			switch ve.clipType {
+			case Union:
+				if e1Wc2 <= 0 && e2Wc2 <= 0 {
+					ve.addLocalMinPoly(e1, e2, pt, false)
+				}
+			case Difference:
+				if (polyType(e1) == PathTypeClip && e1Wc2 > 0 && e2Wc2 > 0) ||
+					(polyType(e1) == PathTypeSubject && e1Wc2 <= 0 && e2Wc2 <= 0) {
+					ve.addLocalMinPoly(e1, e2, pt, false)
+				}
+			case Xor:
+				ve.addLocalMinPoly(e1, e2, pt, false)
+			default: // Intersection
+				if e1Wc2 > 0 && e2Wc2 > 0 {
+					ve.addLocalMinPoly(e1, e2, pt, false)
+				}
+			}
+		}
+	}
+}
+
+// intersectOpenEdge handles a crossing involving at least one open path edge,
+// toggling the open edge's contribution as it enters or leaves the result
+func (ve *VattiEngine) intersectOpenEdge(e1, e2 *Edge, pt Point64) {
+	if isOpenEdge(e1) && isOpenEdge(e2) {
+		return
+	}
+	edgeO, edgeC := e1, e2
+	if !isOpenEdge(e1) {
+		edgeO, edgeC = e2, e1
+	}
+
+	if abs(edgeC.WindCount) != 1 {
+		return
+	}
+	switch ve.clipType {
+	case Union:
+		if !isHotEdge(edgeC) {
+			return
+		}
+	default:
+		if edgeC.LocalMin.PathType == PathTypeSubject {
+			return
+		}
+	}
+	switch ve.windingRule() {
+	case Positive:
+		if edgeC.WindCount != 1 {
+			return
+		}
+	case Negative:
+		if edgeC.WindCount != -1 {
+			return
+		}
+	}
+
+	// toggle contribution
+	switch {
+	case isHotEdge(edgeO):
+		ve.addOutPt(edgeO, pt)
+		if isFront(edgeO) {
+			edgeO.outRec().FrontEdge = nil
+		} else {
+			edgeO.outRec().BackEdge = nil
+		}
+		edgeO.setOutRec(nil)
+	case pt == edgeO.LocalMin.Vertex.Pt && !isOpenEndVertex(edgeO.LocalMin.Vertex):
+		// horizontal edges can pass under open paths at a local minimum; find
+		// the other side of the minimum and if it's 'hot' join up with it
+		e3 := findEdgeWithMatchingLocMin(edgeO)
+		if e3 != nil && isHotEdge(e3) {
+			edgeO.setOutRec(e3.outRec())
+			if edgeO.WindDx > 0 {
+				setSides(e3.outRec(), edgeO, e3)
+			} else {
+				setSides(e3.outRec(), e3, edgeO)
 			}
-			ve.outRecords = append(ve.outRecords, edge.OutRec)
+			return
+		}
+		ve.startOpenPath(edgeO, pt)
+	default:
+		ve.startOpenPath(edgeO, pt)
+	}
+}
+
+// findEdgeWithMatchingLocMin returns the other bound of e's local minimum if
+// it is still level with e in the AEL
+func findEdgeWithMatchingLocMin(e *Edge) *Edge {
+	for result := e.NextInAEL; result != nil; result = result.NextInAEL {
+		if result.LocalMin == e.LocalMin {
+			return result
+		}
+		if !isHorizontal(result) && e.Bot != result.Bot {
+			break
+		}
+	}
+	for result := e.PrevInAEL; result != nil; result = result.PrevInAEL {
+		if result.LocalMin == e.LocalMin {
+			return result
+		}
+		if !isHorizontal(result) && e.Bot != result.Bot {
+			return nil
 		}
-		outRec = edge.OutRec
 	}
+	return nil
+}
 
-	// Create output point
-	outPt := &OutPt{
-		Pt:  pt,
-		Idx: outRec.Idx,
+// doIntersections processes all edge crossings inside the scanbeam ending at topY
+func (ve *VattiEngine) doIntersections(topY int64) {
+	if ve.buildIntersectList(topY) {
+		ve.processIntersectList()
+		ve.intersectList = ve.intersectList[:0]
 	}
+}
 
-	// Link into polygon chain with proper ordering
-	// Key insight: For intersection, we're building a polygon by adding points
-	// as we scan upward. We need to maintain proper counter-clockwise order:
-	// bottom-left → bottom-right → top-right → top-left
-	//
-	// The trick is that RIGHT edges should append (normal order going up)
-	// but LEFT edges should prepend to the start (reverse order going down)
-	if outRec.Pts == nil {
-		// First point in this polygon
-		outRec.Pts = outPt
-		outPt.Next = outPt
-		outPt.Prev = outPt
-	} else {
-		// For RIGHT bound edges: append at end (keeps going up the right side)
-		// For LEFT bound edges: prepend at start (prepares for going down the left side)
-		if !edge.IsLeftBound {
-			// Right bound: append to end
-			// Maintains: ... → last → outPt → Pts
-			lastPt := outRec.Pts.Prev
-			outPt.Next = outRec.Pts
-			outPt.Prev = lastPt
-			lastPt.Next = outPt
-			outRec.Pts.Prev = outPt
+// buildIntersectList finds all crossings in the scanbeam with a stable merge
+// sort of the edges by their X at topY, which guarantees that only adjacent
+// edges are recorded as intersecting
+func (ve *VattiEngine) buildIntersectList(topY int64) bool {
+	if ve.activeEdges == nil || ve.activeEdges.NextInAEL == nil {
+		return false
+	}
+
+	// Calculate edge positions at the top of the current scanbeam, and from this
+	// determine the intersections required to reach these new positions
+	ve.adjustCurrXAndCopyToSEL(topY)
+
+	left := ve.sel
+	for left != nil && left.Jump != nil {
+		var prevBase *Edge
+		for left != nil && left.Jump != nil {
+			currBase := left
+			right := left.Jump
+			lEnd := right
+			rEnd := right.Jump
+			left.Jump = rEnd
+			for left != lEnd && right != rEnd {
+				if right.CurrX > left.CurrX || (right.CurrX == left.CurrX && !exactXLess(right, left, topY)) {
+					left = left.NextInSEL
+					continue
+				}
+				for tmp := right.PrevInSEL; ; tmp = tmp.PrevInSEL {
+					ve.addNewIntersectNode(tmp, right, topY)
+					if tmp == left {
+						break
+					}
+				}
+
+				tmp := right
+				right = extractFromSEL(tmp)
+				lEnd = right
+				insert1Before2InSEL(tmp, left)
+				if left == currBase {
+					currBase = tmp
+					currBase.Jump = rEnd
+					if prevBase == nil {
+						ve.sel = currBase
+					} else {
+						prevBase.Jump = currBase
+					}
+				}
+			}
+			prevBase = currBase
+			left = rEnd
+		}
+		left = ve.sel
+	}
+	return len(ve.intersectList) > 0
+}
+
+// adjustCurrXAndCopyToSEL moves every active edge to its X at topY and copies
+// the AEL into the SEL
+func (ve *VattiEngine) adjustCurrXAndCopyToSEL(topY int64) {
+	ve.sel = ve.activeEdges
+	for e := ve.activeEdges; e != nil; e = e.NextInAEL {
+		e.PrevInSEL = e.PrevInAEL
+		e.NextInSEL = e.NextInAEL
+		e.Jump = e.NextInSEL
+		if joinedInAnyLayer(e, JoinWithLeft) {
+			e.CurrX = e.PrevInAEL.CurrX
 		} else {
-			// Left bound: prepend before Pts (but DON'T change Pts reference)
-			// This builds the return path down the left side in reverse
-			// Maintains: ... → prev → outPt → Pts → ...
-			outPt.Next = outRec.Pts
-			outPt.Prev = outRec.Pts.Prev
-			outRec.Pts.Prev.Next = outPt
-			outRec.Pts.Prev = outPt
-			// DON'T update outRec.Pts - keep the first point as the start.
-			// This is critical: outRec.Pts always points to the first point added to the polygon,
-			// ensuring that when we later traverse the circular linked list to build the final path,
-			// we start from the original starting point. Changing outRec.Pts here would break
-			// the correct order and could result in duplicate points or incorrect polygon orientation.
+			e.CurrX = ve.currX(e, topY)
 		}
 	}
 }
 
-// buildSolutionPaths builds the final solution paths from output records
-func (ve *VattiEngine) buildSolutionPaths() Paths64 {
-	var solution Paths64
+// extractFromSEL unlinks an edge from the SEL and returns its successor
+func extractFromSEL(e *Edge) *Edge {
+	res := e.NextInSEL
+	if res != nil {
+		res.PrevInSEL = e.PrevInSEL
+	}
+	e.PrevInSEL.NextInSEL = res
+	return res
+}
 
-	for _, outRec := range ve.outRecords {
-		if outRec.Pts == nil {
+// insert1Before2InSEL links e1 into the SEL immediately before e2
+func insert1Before2InSEL(e1, e2 *Edge) {
+	e1.PrevInSEL = e2.PrevInSEL
+	if e1.PrevInSEL != nil {
+		e1.PrevInSEL.NextInSEL = e1
+	}
+	e1.NextInSEL = e2
+	e2.PrevInSEL = e1
+}
+
+// addNewIntersectNode records the crossing of e1 and e2, clamping rounding
+// errors that would place it outside the current scanbeam
+func (ve *VattiEngine) addNewIntersectNode(e1, e2 *Edge, topY int64) {
+	ip, ok := ve.intersectPt(e1.Bot, e1.Top, e2.Bot, e2.Top)
+	if !ok {
+		ip = Point64{X: e1.CurrX, Y: topY} // parallel edges
+	}
+
+	if ip.Y < ve.botY || ip.Y > topY {
+		absDx1, absDx2 := math.Abs(e1.Dx), math.Abs(e2.Dx)
+		switch {
+		case absDx1 > 100 && absDx2 > 100:
+			if absDx1 > absDx2 {
+				ip = closestPointOnSegment(ip, e1.Bot, e1.Top)
+			} else {
+				ip = closestPointOnSegment(ip, e2.Bot, e2.Top)
+			}
+		case absDx1 > 100:
+			ip = closestPointOnSegment(ip, e1.Bot, e1.Top)
+		case absDx2 > 100:
+			ip = closestPointOnSegment(ip, e2.Bot, e2.Top)
+		default:
+			if ip.Y > topY {
+				ip.Y = topY
+			} else {
+				ip.Y = ve.botY
+			}
+			if absDx1 < absDx2 {
+				ip.X = ve.currX(e1, ip.Y)
+			} else {
+				ip.X = ve.currX(e2, ip.Y)
+			}
+		}
+	}
+	ve.intersectList = append(ve.intersectList, IntersectNode{Pt: ip, Edge1: e1, Edge2: e2})
+}
+
+// processIntersectList processes the recorded crossings from the bottom up,
+// reordering them where needed so that crossing edges are always adjacent
+func (ve *VattiEngine) processIntersectList() {
+	nodes := ve.intersectList
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].Pt.Y != nodes[j].Pt.Y {
+			return nodes[i].Pt.Y < nodes[j].Pt.Y
+		}
+		if nodes[i].Pt.X != nodes[j].Pt.X {
+			return nodes[i].Pt.X < nodes[j].Pt.X
+		}
+		return exactCrossingLess(&nodes[i], &nodes[j])
+	})
+
+	for i := range nodes {
+		if !ve.spend() {
+			return
+		}
+		if !edgesAdjacentInAEL(&nodes[i]) {
+			j := i + 1
+			for j < len(nodes) && !edgesAdjacentInAEL(&nodes[j]) {
+				j++
+			}
+			if j == len(nodes) {
+				ve.fail(fmt.Errorf("%w: no adjacent edges left to intersect", ErrInternalTopology))
+				return
+			}
+			nodes[i], nodes[j] = nodes[j], nodes[i]
+		}
+
+		node := &nodes[i]
+		ve.intersectEdges(node.Edge1, node.Edge2, node.Pt)
+		ve.swapPositionsInAEL(node.Edge1, node.Edge2)
+		node.Edge1.CurrX = node.Pt.X
+		node.Edge2.CurrX = node.Pt.X
+		ve.eachLayer(func() {
+			ve.checkJoinLeft(node.Edge2, node.Pt)
+			ve.checkJoinRight(node.Edge1, node.Pt)
+		})
+	}
+}
+
+// edgesAdjacentInAEL reports whether a node's edges are neighbours in the AEL
+func edgesAdjacentInAEL(node *IntersectNode) bool {
+	return node.Edge1.NextInAEL == node.Edge2 || node.Edge1.PrevInAEL == node.Edge2
+}
+
+// getSegmentIntersectPt returns the intersection of the lines through two
+// segments, clamped to the first segment; ok is false for parallel lines
+func getSegmentIntersectPt(ln1a, ln1b, ln2a, ln2b Point64) (Point64, bool) {
+	dx1 := float64(ln1b.X - ln1a.X)
+	dy1 := float64(ln1b.Y - ln1a.Y)
+	dx2 := float64(ln2b.X - ln2a.X)
+	dy2 := float64(ln2b.Y - ln2a.Y)
+
+	det := dy1*dx2 - dy2*dx1
+	if crossingNeedsExact(ln1a, ln2a, dx1, dy1, dx2, dy2, det) {
+		return exactSegmentIntersectPt(ln1a, ln1b, ln2a, ln2b)
+	}
+	if det == 0 {
+		return Point64{}, false
+	}
+	t := (float64(ln1a.X-ln2a.X)*dy2 - float64(ln1a.Y-ln2a.Y)*dx2) / det
+	switch {
+	case t <= 0:
+		return ln1a, true
+	case t >= 1:
+		return ln1b, true
+	}
+	return Point64{
+		X: ln1a.X + RoundHalfAway(t*dx1),
+		Y: ln1a.Y + RoundHalfAway(t*dy1),
+	}, true
+}
+
+// closestPointOnSegment returns the point of segment seg1-seg2 nearest to offPt
+func closestPointOnSegment(offPt, seg1, seg2 Point64) Point64 {
+	if seg1 == seg2 {
+		return seg1
+	}
+	dx := float64(seg2.X - seg1.X)
+	dy := float64(seg2.Y - seg1.Y)
+	q := (float64(offPt.X-seg1.X)*dx + float64(offPt.Y-seg1.Y)*dy) / (dx*dx + dy*dy)
+	q = math.Max(0, math.Min(1, q))
+	return Point64{X: seg1.X + RoundHalfAway(q*dx), Y: seg1.Y + RoundHalfAway(q*dy)}
+}
+
+// ==============================================================================
+// Phase 5: Top of Scanbeam, Maxima and Horizontals
+// ==============================================================================
+
+// doTopOfScanbeam advances every edge reaching scanline y onto the next
+// segment of its bound, closes the maxima and queues new horizontals
+func (ve *VattiEngine) doTopOfScanbeam(y int64) {
+	ve.sel = nil // the SEL is reused to queue horizontals
+	e := ve.activeEdges
+	for e != nil && ve.succeeded && ve.spend() {
+		// nb: 'e' will never be horizontal here
+		if e.Top.Y != y {
+			e.CurrX = ve.currX(e, y)
+			e = e.NextInAEL
 			continue
 		}
 
-		path := ve.buildPathFromOutRec(outRec)
-		if len(path) >= 3 { // Valid polygon needs at least 3 points
-			solution = append(solution, path)
+		e.CurrX = e.Top.X
+		if isMaxima(e) {
+			e = ve.doMaxima(e) // TOP OF BOUND (MAXIMA)
+			continue
+		}
+
+		// INTERMEDIATE VERTEX
+		ve.eachLayer(func() {
+			if isHotEdge(e) {
+				ve.addOutPt(e, e.Top)
+			}
+		})
+		ve.updateEdgeIntoAEL(e)
+		if isHorizontal(e) {
+			ve.pushHorz(e) // horizontals are processed later
 		}
+		e = e.NextInAEL
 	}
-	return solution
 }
 
-// buildPathFromOutRec converts an output record to a path
-func (ve *VattiEngine) buildPathFromOutRec(outRec *OutRec) Path64 {
-	if outRec.Pts == nil {
-		return Path64{}
+// doMaxima closes the two bounds meeting at a local maximum and returns the
+// next edge to process
+func (ve *VattiEngine) doMaxima(e *Edge) *Edge {
+	prevE := e.PrevInAEL
+	nextE := e.NextInAEL
+
+	if isOpenEndEdge(e) {
+		if isHotEdge(e) {
+			ve.addOutPt(e, e.Top)
+		}
+		if !isHorizontal(e) {
+			if isHotEdge(e) {
+				if isFront(e) {
+					e.outRec().FrontEdge = nil
+				} else {
+					e.outRec().BackEdge = nil
+				}
+				e.setOutRec(nil)
+			}
+			ve.deleteFromAEL(e)
+		}
+		return nextE
 	}
 
-	var path Path64
-	start := outRec.Pts
-	current := start
+	// A pair that is horizontal (or still below) is closed by doHorizontal
+	maxPair := ve.getMaximaPair(e)
+	if maxPair == nil || !ve.isInAEL(maxPair) || isHorizontal(maxPair) {
+		return nextE
+	}
 
-	// Traverse the circular linked list of points
-	for {
-		path = append(path, current.Pt)
-		current = current.Next
-		if current == start {
+	ve.eachLayer(func() {
+		if isJoined(e) {
+			ve.split(e, e.Top)
+		}
+		if isJoined(maxPair) {
+			ve.split(maxPair, maxPair.Top)
+		}
+	})
+
+	// only non-horizontal maxima here; process any edges between the pair
+	for nextE != maxPair {
+		if nextE == nil {
+			ve.fail(fmt.Errorf("%w: maxima pair of edge %v-%v is not to its right",
+				ErrInternalTopology, e.Bot, e.Top))
+			return nil
+		}
+		ve.intersectEdges(e, nextE, e.Top)
+		ve.swapPositionsInAEL(e, nextE)
+		nextE = e.NextInAEL
+	}
+
+	if isOpenEdge(e) {
+		if isHotEdge(e) {
+			ve.addLocalMaxPoly(e, maxPair, e.Top)
+		}
+		ve.deleteFromAEL(maxPair)
+		ve.deleteFromAEL(e)
+	} else {
+		// e.NextInAEL == maxPair
+		ve.eachLayer(func() {
+			if isHotEdge(e) {
+				ve.addLocalMaxPoly(e, maxPair, e.Top)
+			}
+		})
+		ve.deleteFromAEL(e)
+		ve.deleteFromAEL(maxPair)
+	}
+
+	if prevE != nil {
+		return prevE.NextInAEL
+	}
+	return ve.activeEdges
+}
+
+// currYMaximaVertex returns the local maximum ending the horizontal run that
+// starts at a horizontal edge's top, or nil if the run does not end at one
+func currYMaximaVertex(e *Edge) *Vertex {
+	result := e.VertexTop
+	if e.WindDx > 0 {
+		for result.Next.Pt.Y == result.Pt.Y {
+			result = result.Next
+		}
+	} else {
+		for result.Prev.Pt.Y == result.Pt.Y {
+			result = result.Prev
+		}
+	}
+	if !result.isLocalMaximum() {
+		return nil
+	}
+	return result
+}
+
+// currYMaximaVertexOpen is currYMaximaVertex for open paths, which must also
+// stop at the path's end
+func currYMaximaVertexOpen(e *Edge) *Vertex {
+	stop := VertexFlagsOpenEnd | VertexFlagsLocalMax
+	result := e.VertexTop
+	if e.WindDx > 0 {
+		for result.Next.Pt.Y == result.Pt.Y && result.Flags&stop == 0 {
+			result = result.Next
+		}
+	} else {
+		for result.Prev.Pt.Y == result.Pt.Y && result.Flags&stop == 0 {
+			result = result.Prev
+		}
+	}
+	if !result.isLocalMaximum() {
+		return nil
+	}
+	return result
+}
+
+// resetHorzDirection returns the X range a horizontal edge still has to sweep
+// and whether it heads right
+func resetHorzDirection(horz *Edge, maxVertex *Vertex) (horzLeft, horzRight int64, leftToRight bool) {
+	switch {
+	case horz.Bot.X == horz.Top.X:
+		// the horizontal edge is going nowhere
+		e := horz.NextInAEL
+		for e != nil && e.VertexTop != maxVertex {
+			e = e.NextInAEL
+		}
+		return horz.CurrX, horz.CurrX, e != nil
+	case horz.CurrX < horz.Top.X:
+		return horz.CurrX, horz.Top.X, true
+	default:
+		return horz.Top.X, horz.CurrX, false
+	}
+}
+
+// doHorizontal processes a horizontal edge (and any consecutive horizontals of
+// its bound). Horizontals at a scanline are processed as if layered: each one
+// intersects the non-horizontal edges and the bottom vertices of other
+// horizontals it passes over, and is then promoted to the next edge of its
+// bound, which in turn may be crossed by other horizontals. Like Clipper2's
+// DoHorizontal, the loop ends only where the bound leaves the scanline, at
+// its maximum or at an open end; the sweep budget, not an iteration cap,
+// guards against a corrupt edge list.
+func (ve *VattiEngine) doHorizontal(horz *Edge) {
+	horzIsOpen := isOpenEdge(horz)
+	y := horz.Bot.Y
+
+	var vertexMax *Vertex
+	if horzIsOpen {
+		vertexMax = currYMaximaVertexOpen(horz)
+	} else {
+		vertexMax = currYMaximaVertex(horz)
+	}
+
+	horzLeft, horzRight, leftToRight := resetHorzDirection(horz, vertexMax)
+
+	ve.eachLayer(func() {
+		if isHotEdge(horz) {
+			ve.addToHorzSegList(ve.addOutPt(horz, Point64{X: horz.CurrX, Y: y}))
+		}
+	})
+
+	for ve.succeeded && ve.spend() { // loop through consecutive horizontal edges
+		var e *Edge
+		if leftToRight {
+			e = horz.NextInAEL
+		} else {
+			e = horz.PrevInAEL
+		}
+
+		for e != nil {
+			if !ve.spend() {
+				return
+			}
+			if e.VertexTop == vertexMax {
+				// the horizontal ends at a maximum shared with e
+				ve.eachLayer(func() {
+					if isHotEdge(horz) && isJoined(e) {
+						ve.split(e, e.Top)
+					}
+				})
+				if hotInAnyLayer(horz) {
+					for horz.VertexTop != vertexMax {
+						ve.eachLayer(func() {
+							if isHotEdge(horz) {
+								ve.addOutPt(horz, horz.Top)
+							}
+						})
+						ve.updateEdgeIntoAEL(horz)
+					}
+					ve.eachLayer(func() {
+						switch {
+						case !isHotEdge(horz):
+						case leftToRight:
+							ve.addLocalMaxPoly(horz, e, horz.Top)
+						default:
+							ve.addLocalMaxPoly(e, horz, horz.Top)
+						}
+					})
+				}
+				ve.deleteFromAEL(e)
+				ve.deleteFromAEL(horz)
+				return
+			}
+
+			// if the horizontal is a maxima, keep going until its maxima pair is
+			// reached, otherwise check for break conditions
+			if vertexMax != horz.VertexTop || isOpenEndEdge(horz) {
+				// stop when e is beyond the end of the horizontal line
+				if (leftToRight && e.CurrX > horzRight) || (!leftToRight && e.CurrX < horzLeft) {
+					break
+				}
+				// or when e only rounds onto its end
+				if !isHorizontal(e) && ((leftToRight && e.CurrX == horzRight && exactXCmp(e, Point64{X: horzRight, Y: y}) > 0) ||
+					(!leftToRight && e.CurrX == horzLeft && exactXCmp(e, Point64{X: horzLeft, Y: y}) < 0)) {
+					break
+				}
+
+				if e.CurrX == horz.Top.X && !isHorizontal(e) {
+					pt := nextVertex(horz).Pt
+					if isOpenEdge(e) && !isSamePolyType(e, horz) && !isHotEdge(e) {
+						// to maximize the possibility of putting open edges into
+						// solutions, only break if it's past the horizontal's end
+						if (leftToRight && topX(e, pt.Y) > pt.X) || (!leftToRight && topX(e, pt.Y) < pt.X) {
+							break
+						}
+					} else if (leftToRight && topX(e, pt.Y) >= pt.X) || (!leftToRight && topX(e, pt.Y) <= pt.X) {
+						// for edges at the horizontal's end, only stop when its
+						// outslope is beyond e's slope in the direction of travel
+						break
+					}
+				}
+			}
+
+			pt := Point64{X: e.CurrX, Y: y}
+			if leftToRight {
+				ve.intersectEdges(horz, e, pt)
+				ve.swapPositionsInAEL(horz, e)
+				ve.eachLayer(func() { ve.checkJoinLeft(e, pt) })
+				horz.CurrX = e.CurrX
+				e = horz.NextInAEL
+			} else {
+				ve.intersectEdges(e, horz, pt)
+				ve.swapPositionsInAEL(e, horz)
+				ve.eachLayer(func() { ve.checkJoinRight(e, pt) })
+				horz.CurrX = e.CurrX
+				e = horz.PrevInAEL
+			}
+
+			// the record of the point intersectEdges added may no longer
+			// be the horizontal's
+			ve.eachLayer(func() {
+				if isHotEdge(horz) {
+					ve.addToHorzSegList(lastOp(horz))
+				}
+			})
+		}
+
+		// check if we've finished with (consecutive) horizontals
+		if horzIsOpen && isOpenEndEdge(horz) { // ie open at top
+			if isHotEdge(horz) {
+				ve.addOutPt(horz, horz.Top)
+				if isFront(horz) {
+					horz.outRec().FrontEdge = nil
+				} else {
+					horz.outRec().BackEdge = nil
+				}
+				horz.setOutRec(nil)
+			}
+			ve.deleteFromAEL(horz)
+			return
+		}
+		if nextVertex(horz).Pt.Y != horz.Top.Y {
 			break
 		}
+
+		// still more horizontals in bound to process
+		ve.eachLayer(func() {
+			if isHotEdge(horz) {
+				ve.addOutPt(horz, horz.Top)
+			}
+		})
+		ve.updateEdgeIntoAEL(horz)
+
+		horzLeft, horzRight, leftToRight = resetHorzDirection(horz, vertexMax)
 	}
 
-	return path
+	ve.eachLayer(func() {
+		if isHotEdge(horz) {
+			ve.addToHorzSegList(ve.addOutPt(horz, horz.Top))
+		}
+	})
+	ve.updateEdgeIntoAEL(horz) // end of an intermediate horizontal
 }
@@ -0,0 +1,135 @@
+package clipper
+
+import (
+	"math"
+	"math/big"
+)
+
+// ==============================================================================
+// Exact Fallback for Degenerate Crossings
+// ==============================================================================
+
+// The sweep computes crossings in floating point, which is exact enough for
+// almost all edge pairs. Nearly parallel edges and very long edges are the
+// exception: the determinant cancels or the offset along the edge exceeds the
+// mantissa, and the rounded point can land several units away from the true
+// crossing, so edges meeting at (nearly) one point are crossed in the wrong
+// order. For just those pairs the crossing is recomputed with big.Rat.
+
+// floatCrossingError bounds the error of the floating-point crossing in units
+// before it is handed to the exact fallback
+const floatCrossingError = 0.125
+
+// crossingNeedsExact reports whether the floating-point crossing of the lines
+// through two segments may be off by more than floatCrossingError. The error
+// of t is about eps*(|num terms| + |det terms|)/|det|, and scales with the
+// extent of the first segment.
+func crossingNeedsExact(ln1a, ln2a Point64, dx1, dy1, dx2, dy2, det float64) bool {
+	detMag := math.Abs(dy1*dx2) + math.Abs(dy2*dx1)
+	if detMag == 0 {
+		return false // a degenerate segment, no crossing to compute
+	}
+	numMag := math.Abs(float64(ln1a.X-ln2a.X)*dy2) + math.Abs(float64(ln1a.Y-ln2a.Y)*dx2)
+	extent := math.Abs(dx1) + math.Abs(dy1)
+	const eps = 1.0 / (1 << 52)
+	return (numMag+detMag)*extent*eps > floatCrossingError*math.Abs(det)
+}
+
+// exactCrossing returns the parameter t along ln1a-ln1b and the point where
+// the lines through both segments cross, computed exactly; ok is false for
+// parallel lines
+func exactCrossing(ln1a, ln1b, ln2a, ln2b Point64) (t, x, y *big.Rat, ok bool) {
+	diff := func(a, b int64) *big.Int { return new(big.Int).Sub(big.NewInt(a), big.NewInt(b)) }
+	mul := func(a, b *big.Int) *big.Int { return new(big.Int).Mul(a, b) }
+	dx1, dy1 := diff(ln1b.X, ln1a.X), diff(ln1b.Y, ln1a.Y)
+	dx2, dy2 := diff(ln2b.X, ln2a.X), diff(ln2b.Y, ln2a.Y)
+
+	det := new(big.Int).Sub(mul(dy1, dx2), mul(dy2, dx1))
+	if det.Sign() == 0 {
+		return nil, nil, nil, false
+	}
+	num := new(big.Int).Sub(mul(diff(ln1a.X, ln2a.X), dy2), mul(diff(ln1a.Y, ln2a.Y), dx2))
+	t = new(big.Rat).SetFrac(num, det)
+	x = new(big.Rat).Mul(t, new(big.Rat).SetInt(dx1))
+	x.Add(x, new(big.Rat).SetInt64(ln1a.X))
+	y = new(big.Rat).Mul(t, new(big.Rat).SetInt(dy1))
+	y.Add(y, new(big.Rat).SetInt64(ln1a.Y))
+	return t, x, y, true
+}
+
+// exactSegmentIntersectPt is getSegmentIntersectPt computed exactly, with the
+// crossing rounded half away from zero and clamped to the first segment
+func exactSegmentIntersectPt(ln1a, ln1b, ln2a, ln2b Point64) (Point64, bool) {
+	t, x, y, ok := exactCrossing(ln1a, ln1b, ln2a, ln2b)
+	switch {
+	case !ok:
+		return Point64{}, false
+	case t.Sign() <= 0:
+		return ln1a, true
+	case t.Cmp(big.NewRat(1, 1)) >= 0:
+		return ln1b, true
+	}
+	return Point64{X: roundRatHalfAway(x), Y: roundRatHalfAway(y)}, true
+}
+
+// roundRatHalfAway rounds r to the nearest integer, halves away from zero
+func roundRatHalfAway(r *big.Rat) int64 {
+	q, rem := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	rem.Abs(rem).Lsh(rem, 1)
+	if rem.Cmp(r.Denom()) >= 0 {
+		q.Add(q, big.NewInt(int64(r.Sign())))
+	}
+	return q.Int64()
+}
+
+// exactCrossingLess orders two intersection nodes rounded to the same point
+// by where their edges actually cross, bottom to top and then left to right,
+// so edges meeting at nearly one point are crossed in sweep order. Nodes of
+// parallel edges sort last.
+func exactCrossingLess(a, b *IntersectNode) bool {
+	_, ax, ay, aok := exactCrossing(a.Edge1.Bot, a.Edge1.Top, a.Edge2.Bot, a.Edge2.Top)
+	_, bx, by, bok := exactCrossing(b.Edge1.Bot, b.Edge1.Top, b.Edge2.Bot, b.Edge2.Top)
+	switch {
+	case !aok || !bok:
+		return aok && !bok
+	case ay.Cmp(by) != 0:
+		return ay.Cmp(by) < 0
+	}
+	return ax.Cmp(bx) < 0
+}
+
+// exactXLess reports whether e1 lies left of e2 at y, comparing where the
+// edges actually are rather than their rounded CurrX. Edges less than a unit
+// apart round to the same X, so at a tie only the exact order tells whether
+// they crossed in the scanbeam. Horizontal edges are at their CurrX.
+func exactXLess(e1, e2 *Edge, y int64) bool {
+	n1, d1 := exactX(e1, y)
+	n2, d2 := exactX(e2, y)
+	return n1.Mul(n1, d2).Cmp(n2.Mul(n2, d1)) < 0
+}
+
+// exactX returns the X of e at y as the fraction n/d with d > 0
+func exactX(e *Edge, y int64) (n, d *big.Int) {
+	dy := e.Top.Y - e.Bot.Y
+	if dy == 0 {
+		return big.NewInt(e.CurrX), big.NewInt(1)
+	}
+	n = new(big.Int).Mul(big.NewInt(e.Bot.X), big.NewInt(dy))
+	n.Add(n, new(big.Int).Mul(big.NewInt(y-e.Bot.Y), big.NewInt(e.Top.X-e.Bot.X)))
+	d = big.NewInt(dy)
+	if dy < 0 {
+		n.Neg(n)
+		d.Neg(d)
+	}
+	return n, d
+}
+
+// exactXCmp compares the exact X of the non-horizontal edge e at p.Y with
+// p.X, returning -1, 0 or +1
+func exactXCmp(e *Edge, p Point64) int {
+	c := e.Top.Sub(e.Bot).Cross128(p.Sub(e.Bot)).Cmp(Int128{})
+	if e.Top.Y < e.Bot.Y {
+		return -c
+	}
+	return c
+}
@@ -0,0 +1,83 @@
+package clipper
+
+import (
+	"math/big"
+	"testing"
+)
+
+// TestExactIntersectFallback tests that nearly parallel long edges cross at the exact point
+func TestExactIntersectFallback(t *testing.T) {
+	tests := []struct {
+		name                   string
+		ln1a, ln1b, ln2a, ln2b Point64
+		expected               Point64
+	}{
+		// Float arithmetic misses this crossing by about 6e13 units
+		{"nearly parallel", Point64{0, 0}, Point64{3000000000000001, 1000000000000000},
+			Point64{0, 7}, Point64{3000000000000000, 999999999999999}, Point64{2739130434782609, 913043478260869}},
+		{"ordinary", Point64{0, 0}, Point64{100, 100}, Point64{0, 100}, Point64{100, 0}, Point64{50, 50}},
+		{"beyond first segment", Point64{0, 0}, Point64{10, 0}, Point64{20, -5}, Point64{20, 5}, Point64{10, 0}},
+	}
+	for _, tt := range tests {
+		got, ok := getSegmentIntersectPt(tt.ln1a, tt.ln1b, tt.ln2a, tt.ln2b)
+		if !ok || got != tt.expected {
+			t.Errorf("%s: expected %v, got %v (ok %v)", tt.name, tt.expected, got, ok)
+		}
+	}
+
+	if _, ok := exactSegmentIntersectPt(Point64{0, 0}, Point64{10, 10}, Point64{0, 1}, Point64{10, 11}); ok {
+		t.Errorf("expected parallel segments to have no crossing")
+	}
+}
+
+// TestCrossingNeedsExact tests that only ill-conditioned crossings take the exact path
+func TestCrossingNeedsExact(t *testing.T) {
+	needs := func(ln1a, ln1b, ln2a, ln2b Point64) bool {
+		dx1, dy1 := float64(ln1b.X-ln1a.X), float64(ln1b.Y-ln1a.Y)
+		dx2, dy2 := float64(ln2b.X-ln2a.X), float64(ln2b.Y-ln2a.Y)
+		return crossingNeedsExact(ln1a, ln2a, dx1, dy1, dx2, dy2, dy1*dx2-dy2*dx1)
+	}
+	if needs(Point64{0, 0}, Point64{1000000, 1000000}, Point64{0, 1000000}, Point64{1000000, 0}) {
+		t.Errorf("expected a well-conditioned crossing to use floating point")
+	}
+	if !needs(Point64{0, 0}, Point64{3000000000000001, 1000000000000000}, Point64{0, 7}, Point64{3000000000000000, 999999999999999}) {
+		t.Errorf("expected a nearly parallel crossing to use the exact fallback")
+	}
+}
+
+// TestRoundRatHalfAway tests exact rounding of rationals
+func TestRoundRatHalfAway(t *testing.T) {
+	tests := []struct {
+		num, denom, expected int64
+	}{
+		{5, 2, 3},
+		{-5, 2, -3},
+		{7, 3, 2},
+		{-7, 3, -2},
+		{8, 3, 3},
+		{4, 2, 2},
+		{0, 5, 0},
+	}
+	for _, tt := range tests {
+		if got := roundRatHalfAway(big.NewRat(tt.num, tt.denom)); got != tt.expected {
+			t.Errorf("%d/%d: expected %d, got %d", tt.num, tt.denom, tt.expected, got)
+		}
+	}
+}
+
+// TestExactCrossingOrder tests the tie-break of nodes rounded to the same point
+func TestExactCrossingOrder(t *testing.T) {
+	edge := func(bot, top Point64) *Edge { return &Edge{Bot: bot, Top: top} }
+	vertical := edge(Point64{10, 0}, Point64{10, 20})
+	// Both cross the vertical edge at X=10 near Y=10, rounding to the same point
+	lower := IntersectNode{Pt: Point64{10, 10}, Edge1: edge(Point64{0, 0}, Point64{30, 29}), Edge2: vertical}
+	upper := IntersectNode{Pt: Point64{10, 10}, Edge1: edge(Point64{0, 10}, Point64{30, 11}), Edge2: vertical}
+	parallel := IntersectNode{Pt: Point64{10, 10}, Edge1: edge(Point64{10, 0}, Point64{10, 5}), Edge2: vertical}
+
+	if !exactCrossingLess(&lower, &upper) || exactCrossingLess(&upper, &lower) {
+		t.Errorf("expected the crossing at Y=9.67 before the one at Y=10.33")
+	}
+	if !exactCrossingLess(&upper, &parallel) || exactCrossingLess(&parallel, &lower) {
+		t.Errorf("expected parallel edges to sort last")
+	}
+}
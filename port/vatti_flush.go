@@ -0,0 +1,164 @@
+package clipper
+
+import "slices"
+
+// ==============================================================================
+// Incremental Output
+// ==============================================================================
+
+// A ring is finished once it is detached from both its front and back edge:
+// closed rings at the local maximum where their ends meet, open paths at
+// their last vertex. Nothing in the sweep touches a finished record again, so
+// when output is flushed its points are handed over after every scanbeam and
+// released, instead of being held until the solution is built.
+
+// flushState tracks the output records not yet flushed
+type flushState struct {
+	emit      func(path Path64, isOpen bool) error
+	nest      *flushNesting // set when closed rings are emitted with their nesting
+	unflushed []*OutRec     // records seen but still attached to edges
+	seen      int           // number of ve.outRecords moved to unflushed
+}
+
+// executeFlushing runs the sweep over the given paths, passing every output
+// path to emit as soon as its record is finished rather than building a
+// solution
+func (ve *VattiEngine) executeFlushing(subjects, subjectsOpen, clips Paths64, emit func(path Path64, isOpen bool) error) error {
+	return ve.runFlushing(subjects, subjectsOpen, clips, &flushState{emit: emit})
+}
+
+// executeNesting runs the sweep over the given closed paths, passing every
+// output ring to emit with its nesting as soon as the rings that may enclose
+// it are finished
+func (ve *VattiEngine) executeNesting(subjects, clips Paths64, emit func(ring Path64, isHole bool, parentIdx int) error) error {
+	return ve.runFlushing(subjects, nil, clips, &flushState{nest: newFlushNesting(emit)})
+}
+
+// runFlushing runs the sweep with output flushed by f
+func (ve *VattiEngine) runFlushing(subjects, subjectsOpen, clips Paths64, f *flushState) error {
+	subjects, clips = ve.snapRound(subjects, clips)
+	if err := ve.addPaths(subjects, PathTypeSubject, false); err != nil {
+		return err
+	}
+	if err := ve.addPaths(subjectsOpen, PathTypeSubject, true); err != nil {
+		return err
+	}
+	if err := ve.addPaths(clips, PathTypeClip, false); err != nil {
+		return err
+	}
+	if len(ve.minimaList) == 0 {
+		return nil
+	}
+	ve.sortLocalMinima()
+
+	ve.flush = f
+	if ve.executeScanlineAlgorithm() {
+		ve.flushFinished()
+	}
+	if f.nest != nil && ve.succeeded {
+		if err := f.nest.finish(); err != nil {
+			ve.fail(err)
+		}
+	}
+	if !ve.succeeded {
+		if ve.err == nil {
+			return ErrClipperExecution
+		}
+		return ve.err
+	}
+	return nil
+}
+
+// flushFinished emits and releases every finished output record. Cleaning a
+// ring can split it into new records, which are finished too and emitted in
+// the same call. Records still waiting for a horizontal join with a ring
+// under construction are kept until that ring is finished.
+func (ve *VattiEngine) flushFinished() {
+	f := ve.flush
+	if f.nest != nil {
+		f.nest.detach(ve, f.unflushed, ve.outRecords[f.seen:])
+	}
+	waiting := ve.processFinishedHorzJoins()
+	for pass := 0; ve.succeeded && (pass == 0 || f.seen < len(ve.outRecords)); pass++ {
+		f.unflushed = append(f.unflushed, ve.outRecords[f.seen:]...)
+		f.seen = len(ve.outRecords)
+
+		kept := f.unflushed[:0]
+		for _, outRec := range f.unflushed {
+			if _, isWaiting := slices.BinarySearch(waiting, outRec.Idx); isWaiting ||
+				outRec.FrontEdge != nil || outRec.BackEdge != nil {
+				kept = append(kept, outRec)
+				continue
+			}
+			if err := ve.emitOutRec(outRec); err != nil {
+				ve.fail(err)
+				return
+			}
+			if f.nest != nil {
+				f.nest.leave(outRec)
+			}
+		}
+		f.unflushed = kept
+	}
+	if f.nest != nil && ve.succeeded {
+		if err := f.nest.settle(); err != nil {
+			ve.fail(err)
+		}
+	}
+}
+
+// processFinishedHorzJoins processes the queued horizontal joins between
+// finished rings, which nothing in the sweep touches again, and returns the
+// sorted indices of the records the remaining joins refer to
+func (ve *VattiEngine) processFinishedHorzJoins() []int {
+	kept := ve.horzJoins[:0]
+	for _, j := range ve.horzJoins {
+		or1, or2 := realOutRec(j.op1.OutRec), realOutRec(j.op2.OutRec)
+		if or1.FrontEdge != nil || or1.BackEdge != nil || or2.FrontEdge != nil || or2.BackEdge != nil {
+			kept = append(kept, j)
+			continue
+		}
+		if ve.countJoin(); !ve.succeeded {
+			return nil
+		}
+		ve.processHorzJoin(j)
+	}
+	ve.horzJoins = kept
+
+	var waiting []int
+	for _, j := range ve.horzJoins {
+		waiting = append(waiting, realOutRec(j.op1.OutRec).Idx, realOutRec(j.op2.OutRec).Idx)
+	}
+	slices.Sort(waiting)
+	return waiting
+}
+
+// emitOutRec passes a finished record's path to the flush callback, cleaned
+// like in buildSolutionPaths, and drops its points
+func (ve *VattiEngine) emitOutRec(outRec *OutRec) error {
+	if outRec.Pts == nil {
+		return nil
+	}
+	isOpen := outRec.State == OutRecStateOpen
+	var path Path64
+	var ok bool
+	if isOpen {
+		path, ok = buildPath(outRec.Pts, ve.reverseSolution, true)
+	} else {
+		split := len(ve.outRecords)
+		ve.cleanCollinear(outRec, ve.preserveCollinear)
+		if n := ve.flush.nest; n != nil {
+			n.split(outRec, ve.outRecords[split:])
+		}
+		path, ok = buildPath(outRec.Pts, !ve.reverseSolution, false)
+	}
+	outRec.Pts = nil
+	if !ok {
+		return nil
+	}
+	if n := ve.flush.nest; n != nil {
+		n.add(outRec, path)
+		return nil
+	}
+	return ve.flush.emit(path, isOpen)
+}
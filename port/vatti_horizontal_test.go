@@ -0,0 +1,113 @@
+package clipper
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// TestConsecutiveHorizontals tests bounds with several horizontal edges in a
+// row, horizontals meeting at maxima and open paths running along horizontals
+func TestConsecutiveHorizontals(t *testing.T) {
+	square := Paths64{{{0, 0}, {10, 0}, {10, 10}, {0, 10}}}
+	stair := Paths64{{{0, 0}, {5, 0}, {10, 0}, {10, 5}, {15, 5}, {20, 5}, {20, 10}, {10, 10}, {5, 10}, {0, 10}}}
+	clip := Paths64{{{5, -5}, {15, -5}, {15, 0}, {15, 5}, {15, 15}, {5, 15}}}
+
+	closed := []struct {
+		clipType ClipType
+		expected float64
+	}{
+		{Intersection, 75},
+		{Union, 275},
+		{Difference, 75},
+		{Xor, 200},
+	}
+	for _, tt := range closed {
+		solution, _, err := BooleanOp64(tt.clipType, NonZero, stair, nil, clip)
+		if err != nil || AreaPaths64(solution) != tt.expected {
+			t.Errorf("clip type %d: expected area %v, got %v (err %v)", tt.clipType, tt.expected, AreaPaths64(solution), err)
+		}
+	}
+
+	open := []struct {
+		name     string
+		clipType ClipType
+		path     Path64
+		expected Paths64
+	}{
+		{"Step inside", Intersection, Path64{{-5, 5}, {5, 5}, {5, 7}, {15, 7}}, Paths64{{{0, 5}, {5, 5}, {5, 7}, {10, 7}}}},
+		{"Step outside", Difference, Path64{{-5, 5}, {5, 5}, {5, 7}, {15, 7}}, Paths64{{{-5, 5}, {0, 5}}, {{10, 7}, {15, 7}}}},
+		{"Dip from boundary", Intersection, Path64{{-5, 10}, {3, 10}, {3, 4}, {7, 4}, {7, 10}, {15, 10}}, Paths64{{{3, 10}, {3, 4}, {7, 4}, {7, 10}}}},
+		{"Collinear run", Intersection, Path64{{2, 3}, {4, 3}, {6, 3}, {8, 3}}, Paths64{{{2, 3}, {4, 3}, {6, 3}, {8, 3}}}},
+		{"Collinear run removed", Difference, Path64{{2, 3}, {4, 3}, {6, 3}, {8, 3}}, Paths64{}},
+	}
+	for _, tt := range open {
+		t.Run(tt.name, func(t *testing.T) {
+			_, solutionOpen, err := BooleanOp64(tt.clipType, NonZero, nil, Paths64{tt.path}, square)
+			if err != nil || !sameRings(solutionOpen, tt.expected) {
+				t.Errorf("expected %v, got %v (err %v)", tt.expected, solutionOpen, err)
+			}
+		})
+	}
+}
+
+// TestRasterOutlines tests boolean operations on outlines of random pixel
+// grids, where nearly every edge is horizontal or vertical and many of them
+// coincide. The second grid is shifted by half a pixel in some runs, so
+// horizontals of both grids overlap partially. Areas are checked against the
+// pixels themselves.
+func TestRasterOutlines(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	raster := func(n int, shift int64) (Paths64, map[Point64]bool) {
+		var pixels Paths64
+		cells := map[Point64]bool{}
+		for y := range n {
+			for x := range n {
+				if r.Intn(2) == 0 {
+					continue
+				}
+				px, py := int64(x)*2+shift, int64(y)*2+shift
+				pixels = append(pixels, Path64{{px, py}, {px + 2, py}, {px + 2, py + 2}, {px, py + 2}})
+				for _, c := range []Point64{{px, py}, {px + 1, py}, {px, py + 1}, {px + 1, py + 1}} {
+					cells[c] = true
+				}
+			}
+		}
+		outline, _, err := BooleanOp64(Union, NonZero, pixels, nil, nil)
+		if err != nil {
+			t.Fatalf("union of pixels: %v", err)
+		}
+		if AreaPaths64(outline) != float64(len(cells)) {
+			t.Fatalf("expected outline area %d, got %v for %v", len(cells), AreaPaths64(outline), pixels)
+		}
+		return outline, cells
+	}
+
+	for run := range 300 {
+		n := 2 + r.Intn(7)
+		a, cellsA := raster(n, 0)
+		b, cellsB := raster(n, int64(r.Intn(2)))
+
+		both := 0
+		for c := range cellsA {
+			if cellsB[c] {
+				both++
+			}
+		}
+		expected := map[ClipType]int{
+			Intersection: both,
+			Union:        len(cellsA) + len(cellsB) - both,
+			Difference:   len(cellsA) - both,
+			Xor:          len(cellsA) + len(cellsB) - 2*both,
+		}
+		for clipType, cells := range expected {
+			solution, _, err := BooleanOp64(clipType, NonZero, a, nil, b)
+			if err != nil || AreaPaths64(solution) != float64(cells) {
+				t.Fatalf("run %d, clip type %d: expected area %d, got %v (err %v)\nsubject %v\nclip %v",
+					run, clipType, cells, AreaPaths64(solution), err, a, b)
+			}
+			if report := ValidatePaths64(solution); !report.Valid() {
+				t.Fatalf("run %d, clip type %d: expected a clean solution, got %v", run, clipType, report.Issues)
+			}
+		}
+	}
+}
@@ -0,0 +1,296 @@
+package clipper
+
+import "sort"
+
+// ==============================================================================
+// Joins
+// ==============================================================================
+
+// Rings that touch along an edge are merged while the sweep runs. Two hot
+// edges meeting collinearly at the same X are joined: their rings become one
+// and the pair is flagged with JoinWith, moving as one edge until they part
+// again and split the ring there. Rings touching along horizontal edges are
+// found per scanline from the horizontal segments of their output points and
+// spliced together once the sweep has finished.
+
+// checkJoinLeft joins e with its left neighbour if both are hot and run
+// along the same line through pt. Upstream also joins edges that merely round
+// to the same X; requiring exact collinearity keeps the joined ring's
+// vertices where they are, so union stays idempotent.
+func (ve *VattiEngine) checkJoinLeft(e *Edge, pt Point64) {
+	prev := e.PrevInAEL
+	if prev == nil || !isHotEdge(e) || !isHotEdge(prev) ||
+		isHorizontal(e) || isHorizontal(prev) || isOpenEdge(e) || isOpenEdge(prev) {
+		return
+	}
+	// avoid trivial joins near the ends of either edge
+	if (pt.Y > e.Top.Y-2 || pt.Y > prev.Top.Y-2) && (e.Bot.Y < pt.Y || prev.Bot.Y < pt.Y) {
+		return
+	}
+	if !IsCollinear(prev.Bot, pt, prev.Top) || !IsCollinear(e.Top, pt, prev.Top) {
+		return
+	}
+
+	switch {
+	case e.outRec().Idx == prev.outRec().Idx:
+		ve.addLocalMaxPoly(prev, e, pt)
+	case e.outRec().Idx < prev.outRec().Idx:
+		ve.joinOutRecPaths(e, prev)
+	default:
+		ve.joinOutRecPaths(prev, e)
+	}
+	prev.setJoinWith(JoinWithRight)
+	e.setJoinWith(JoinWithLeft)
+}
+
+// checkJoinRight joins e with its right neighbour, like checkJoinLeft
+func (ve *VattiEngine) checkJoinRight(e *Edge, pt Point64) {
+	next := e.NextInAEL
+	if next == nil || !isHotEdge(e) || !isHotEdge(next) ||
+		isHorizontal(e) || isHorizontal(next) || isOpenEdge(e) || isOpenEdge(next) {
+		return
+	}
+	// avoid trivial joins near the ends of either edge
+	if (pt.Y > e.Top.Y-2 || pt.Y > next.Top.Y-2) && (e.Bot.Y < pt.Y || next.Bot.Y < pt.Y) {
+		return
+	}
+	if !IsCollinear(next.Bot, pt, next.Top) || !IsCollinear(e.Top, pt, next.Top) {
+		return
+	}
+
+	switch {
+	case e.outRec().Idx == next.outRec().Idx:
+		ve.addLocalMaxPoly(e, next, pt)
+	case e.outRec().Idx < next.outRec().Idx:
+		ve.joinOutRecPaths(e, next)
+	default:
+		ve.joinOutRecPaths(next, e)
+	}
+	e.setJoinWith(JoinWithRight)
+	next.setJoinWith(JoinWithLeft)
+}
+
+// split parts a joined edge from its partner at pt, starting a new ring
+// between them
+func (ve *VattiEngine) split(e *Edge, pt Point64) {
+	if e.joinWith() == JoinWithRight {
+		e.setJoinWith(JoinWithNoJoin)
+		e.NextInAEL.setJoinWith(JoinWithNoJoin)
+		ve.addLocalMinPoly(e, e.NextInAEL, pt, true)
+		return
+	}
+	e.setJoinWith(JoinWithNoJoin)
+	e.PrevInAEL.setJoinWith(JoinWithNoJoin)
+	ve.addLocalMinPoly(e.PrevInAEL, e, pt, true)
+}
+
+// ==============================================================================
+// Horizontal Joins
+// ==============================================================================
+
+// horzSegment is a horizontal run of a ring's points at one scanline
+type horzSegment struct {
+	leftOp, rightOp *OutPt
+	leftToRight     bool // the ring runs from leftOp to rightOp
+}
+
+// horzJoin links two rings touching along a horizontal: op1 continues into
+// op2, and op2's predecessor into op1's successor
+type horzJoin struct {
+	op1, op2 *OutPt
+}
+
+// lastOp returns the point a hot edge added last
+func lastOp(e *Edge) *OutPt {
+	op := e.outRec().Pts
+	if e != e.outRec().FrontEdge {
+		op = op.Next
+	}
+	return op
+}
+
+// addToHorzSegList queues the horizontal run through op for joining
+func (ve *VattiEngine) addToHorzSegList(op *OutPt) {
+	// joins along horizontals leave the summed area of the rings unchanged
+	if ve.area != nil || op.OutRec.State == OutRecStateOpen {
+		return
+	}
+	ve.horzSegs = append(ve.horzSegs, horzSegment{leftOp: op})
+}
+
+// updateHorzSegment extends hs over the whole horizontal run of its ring
+// through hs.leftOp. It reports false, leaving rightOp nil, when the run has
+// no length or was already claimed by another segment.
+func updateHorzSegment(hs *horzSegment) bool {
+	op := hs.leftOp
+	outRec := realOutRec(op.OutRec)
+	y := op.Pt.Y
+	opP, opN := op, op
+	if outRec.FrontEdge != nil {
+		// stop at the open ends of a ring under construction
+		opA, opZ := outRec.Pts, outRec.Pts.Next
+		for opP != opZ && opP.Prev.Pt.Y == y {
+			opP = opP.Prev
+		}
+		for opN != opA && opN.Next.Pt.Y == y {
+			opN = opN.Next
+		}
+	} else {
+		for opP.Prev != opN && opP.Prev.Pt.Y == y {
+			opP = opP.Prev
+		}
+		for opN.Next != opP && opN.Next.Pt.Y == y {
+			opN = opN.Next
+		}
+	}
+
+	if opP.Pt.X == opN.Pt.X {
+		hs.rightOp = nil
+		return false
+	}
+	if opP.Pt.X < opN.Pt.X {
+		hs.leftOp, hs.rightOp, hs.leftToRight = opP, opN, true
+	} else {
+		hs.leftOp, hs.rightOp, hs.leftToRight = opN, opP, false
+	}
+	if hs.leftOp.horz {
+		hs.rightOp = nil
+		return false
+	}
+	hs.leftOp.horz = true
+	return true
+}
+
+// duplicateOp inserts a copy of op after or before it and returns the copy
+func (ve *VattiEngine) duplicateOp(op *OutPt, insertAfter bool) *OutPt {
+	result := ve.newOp(op.Pt, op.OutRec)
+	result.Idx = op.Idx
+	if insertAfter {
+		result.Next = op.Next
+		result.Next.Prev = result
+		result.Prev = op
+		op.Next = result
+	} else {
+		result.Prev = op.Prev
+		result.Prev.Next = result
+		result.Next = op
+		op.Prev = result
+	}
+	return result
+}
+
+// convertHorzSegsToJoins pairs the overlapping horizontal segments of the
+// current scanline that run in opposite directions, which belong to rings
+// touching there, and queues a join for each pair
+func (ve *VattiEngine) convertHorzSegsToJoins() {
+	segs := ve.horzSegs
+	ve.horzSegs = segs[:0]
+	n := 0
+	for i := range segs {
+		if updateHorzSegment(&segs[i]) {
+			n++
+		}
+	}
+	if n < 2 {
+		return
+	}
+	// valid segments first, ordered by their left ends
+	sort.SliceStable(segs, func(i, j int) bool {
+		if segs[i].rightOp == nil || segs[j].rightOp == nil {
+			return segs[i].rightOp != nil
+		}
+		return segs[i].leftOp.Pt.X < segs[j].leftOp.Pt.X
+	})
+	segs = segs[:n]
+
+	for i := 0; i < n-1 && ve.spend(); i++ {
+		hs1 := &segs[i]
+		for j := i + 1; j < n; j++ {
+			hs2 := &segs[j]
+			if hs2.leftOp.Pt.X >= hs1.rightOp.Pt.X || hs2.leftToRight == hs1.leftToRight ||
+				hs2.rightOp.Pt.X <= hs1.leftOp.Pt.X {
+				continue
+			}
+			y := hs1.leftOp.Pt.Y
+			if hs1.leftToRight {
+				for hs1.leftOp.Next.Pt.Y == y && hs1.leftOp.Next.Pt.X <= hs2.leftOp.Pt.X {
+					hs1.leftOp = hs1.leftOp.Next
+				}
+				for hs2.leftOp.Prev.Pt.Y == y && hs2.leftOp.Prev.Pt.X <= hs1.leftOp.Pt.X {
+					hs2.leftOp = hs2.leftOp.Prev
+				}
+				ve.horzJoins = append(ve.horzJoins, horzJoin{ve.duplicateOp(hs1.leftOp, true), ve.duplicateOp(hs2.leftOp, false)})
+			} else {
+				for hs1.leftOp.Prev.Pt.Y == y && hs1.leftOp.Prev.Pt.X <= hs2.leftOp.Pt.X {
+					hs1.leftOp = hs1.leftOp.Prev
+				}
+				for hs2.leftOp.Next.Pt.Y == y && hs2.leftOp.Next.Pt.X <= hs1.leftOp.Pt.X {
+					hs2.leftOp = hs2.leftOp.Next
+				}
+				ve.horzJoins = append(ve.horzJoins, horzJoin{ve.duplicateOp(hs2.leftOp, true), ve.duplicateOp(hs1.leftOp, false)})
+			}
+		}
+	}
+}
+
+// processHorzJoins splices the rings of every queued horizontal join. Rings
+// joined to themselves are split in two instead, the new ring being owned
+// by the old one.
+func (ve *VattiEngine) processHorzJoins() {
+	for _, j := range ve.horzJoins {
+		if ve.countJoin(); !ve.succeeded {
+			return
+		}
+		ve.processHorzJoin(j)
+	}
+	ve.horzJoins = nil
+}
+
+// processHorzJoin splices the rings of one horizontal join
+func (ve *VattiEngine) processHorzJoin(j horzJoin) {
+	or1 := realOutRec(j.op1.OutRec)
+	or2 := realOutRec(j.op2.OutRec)
+
+	op1b, op2b := j.op1.Next, j.op2.Prev
+	j.op1.Next = j.op2
+	j.op2.Prev = j.op1
+	op1b.Prev = op2b
+	op2b.Next = op1b
+
+	if or1 != or2 {
+		or2.Pts = nil
+		or2.Owner = or1
+		if n := ve.nesting(); n != nil {
+			n.join(or2, or1)
+		}
+		return
+	}
+
+	// the join is really a split
+	or2 = ve.newOutRec()
+	or2.Pts = op1b
+	fixOutRecPts(or2)
+	// if or1.Pts moved to or2, or1 keeps the other half
+	if or1.Pts.OutRec == or2 {
+		or1.Pts = j.op1
+		or1.Pts.OutRec = or1
+		or1.Pts.Idx = or1.Idx
+	}
+	or2.Owner = or1
+	if n := ve.nesting(); n != nil {
+		n.split(or1, []*OutRec{or2})
+	}
+}
+
+// fixOutRecPts makes outRec the owner of every point of its ring
+func fixOutRecPts(outRec *OutRec) {
+	op := outRec.Pts
+	for {
+		op.OutRec = outRec
+		op.Idx = outRec.Idx
+		op = op.Next
+		if op == outRec.Pts {
+			break
+		}
+	}
+}
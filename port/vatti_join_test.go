@@ -0,0 +1,81 @@
+package clipper
+
+import "testing"
+
+// TestTouchingRingsJoin tests that a single sweep merges rings touching along
+// edges, without relying on solution normalization
+func TestTouchingRingsJoin(t *testing.T) {
+	square := func(x, y, w, h int64) Path64 {
+		return Path64{{x, y}, {x + w, y}, {x + w, y + h}, {x, y + h}}
+	}
+	tests := []struct {
+		name     string
+		paths    Paths64
+		expected Paths64
+	}{
+		{
+			"Side by side",
+			Paths64{square(0, 0, 10, 10), square(10, 0, 10, 10)},
+			Paths64{square(0, 0, 20, 10)},
+		},
+		{
+			"Stacked",
+			Paths64{square(0, 0, 10, 10), square(0, 10, 10, 10)},
+			Paths64{square(0, 0, 10, 20)},
+		},
+		{
+			"Stacked and shifted",
+			Paths64{square(0, 0, 10, 10), square(5, 10, 10, 10)},
+			Paths64{{{0, 0}, {10, 0}, {10, 10}, {15, 10}, {15, 20}, {5, 20}, {5, 10}, {0, 10}}},
+		},
+		{
+			"Frame around a hole",
+			Paths64{square(0, 0, 30, 10), square(0, 20, 30, 10), square(0, 10, 10, 10), square(20, 10, 10, 10)},
+			Paths64{square(0, 0, 30, 30), Reverse64(square(10, 10, 10, 10))},
+		},
+		{
+			"Grid of cells",
+			Paths64{square(0, 0, 10, 10), square(10, 0, 10, 10), square(0, 10, 10, 10), square(10, 10, 10, 10)},
+			Paths64{square(0, 0, 20, 20)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			solution, ok := unionPass(tt.paths, nil, false)
+			if !ok || !sameRings(solution, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, solution)
+			}
+
+			// Flushed rings must wait for joins with rings still under construction
+			var flushed Paths64
+			err := BooleanOp64Incremental(Union, NonZero, tt.paths, nil, nil, func(path Path64, isOpen bool) error {
+				flushed = append(flushed, path)
+				return nil
+			})
+			if err != nil || !sameRings(flushed, tt.expected) {
+				t.Errorf("expected %v when flushing, got %v (err %v)", tt.expected, flushed, err)
+			}
+		})
+	}
+}
+
+// unionPass runs the scanline algorithm once over closed paths, sanitized as
+// in BooleanOp64, with NonZero filling and without normalizing the result
+func unionPass(paths Paths64, rounding RoundingStrategy, preserveCollinear bool) (Paths64, bool) {
+	ve := NewVattiEngine(Union, NonZero)
+	ve.rounding = rounding
+	ve.preserveCollinear = preserveCollinear
+	if err := ve.addPaths(sanitizeRings(paths, false), PathTypeSubject, false); err != nil {
+		return nil, false
+	}
+	if len(ve.minimaList) == 0 {
+		return Paths64{}, true
+	}
+	ve.sortLocalMinima()
+	if !ve.executeScanlineAlgorithm() {
+		return nil, false
+	}
+	solution, _ := ve.buildSolutionPaths()
+	return solution, true
+}
@@ -0,0 +1,153 @@
+package clipper
+
+// ==============================================================================
+// Output Layers
+// ==============================================================================
+
+// Which regions a sweep outputs depends only on the clip type: the AEL, the
+// winding counts and the crossings are the same for every clip type. A
+// layered sweep therefore builds the solutions of several clip types at once.
+// Every output step runs once per layer, with that layer's clip type, output
+// records and horizontal joins current, and every edge keeps its output
+// record and join per layer. Open paths are not supported.
+
+// outputLayers is the number of output layers an edge has room for
+const outputLayers = 2
+
+// edgeOutput is the output state of an edge in one layer
+type edgeOutput struct {
+	outRec   *OutRec  // output record the edge contributes to
+	joinWith JoinWith // join with a neighbouring edge
+}
+
+// outputLayer holds the output state of a layer while another is current
+type outputLayer struct {
+	clipType   ClipType
+	outRecords []*OutRec
+	horzSegs   []horzSegment
+	horzJoins  []horzJoin
+}
+
+// outRec returns the output record of the edge in the current layer
+func (e *Edge) outRec() *OutRec {
+	return e.outputs[*e.layer].outRec
+}
+
+// setOutRec sets the output record of the edge in the current layer
+func (e *Edge) setOutRec(outRec *OutRec) {
+	e.outputs[*e.layer].outRec = outRec
+}
+
+// joinWith returns the join of the edge in the current layer
+func (e *Edge) joinWith() JoinWith {
+	return e.outputs[*e.layer].joinWith
+}
+
+// setJoinWith sets the join of the edge in the current layer
+func (e *Edge) setJoinWith(joinWith JoinWith) {
+	e.outputs[*e.layer].joinWith = joinWith
+}
+
+// hotInAnyLayer reports whether the edge contributes to an output record in
+// any layer
+func hotInAnyLayer(e *Edge) bool {
+	for _, out := range e.outputs {
+		if out.outRec != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// joinedInAnyLayer reports whether the edge is joined on the given side in
+// any layer. Edges joined in one layer move as one edge in all of them, as
+// the AEL is shared.
+func joinedInAnyLayer(e *Edge, joinWith JoinWith) bool {
+	for _, out := range e.outputs {
+		if out.joinWith == joinWith {
+			return true
+		}
+	}
+	return false
+}
+
+// eachLayer calls f once for every output layer with that layer current,
+// leaving the first layer current
+func (ve *VattiEngine) eachLayer(f func()) {
+	if ve.layers == nil {
+		f()
+		return
+	}
+	for i := range ve.layers {
+		ve.useLayer(i)
+		f()
+	}
+	ve.useLayer(0)
+}
+
+// useLayer makes layer i current, saving the output state of the current one
+func (ve *VattiEngine) useLayer(i int) {
+	if i == ve.layer {
+		return
+	}
+	ve.layers[ve.layer] = outputLayer{ve.clipType, ve.outRecords, ve.horzSegs, ve.horzJoins}
+	next := ve.layers[i]
+	ve.clipType, ve.outRecords, ve.horzSegs, ve.horzJoins = next.clipType, next.outRecords, next.horzSegs, next.horzJoins
+	ve.layer = i
+}
+
+// executeLayered performs one sweep over the closed subjects and clips and
+// returns the solution of every clip type in clipTypes, in order, each the
+// same as ExecuteClipping with that clip type would return. At most
+// outputLayers clip types are supported; the engine's own clip type is
+// ignored.
+func (ve *VattiEngine) executeLayered(clipTypes []ClipType, subjects, clips Paths64) ([]Paths64, error) {
+	if len(clipTypes) == 0 || len(clipTypes) > outputLayers {
+		return nil, ErrInvalidInput
+	}
+	ve.layers = make([]outputLayer, len(clipTypes))
+	for i, clipType := range clipTypes {
+		ve.layers[i].clipType = clipType
+	}
+	ve.clipType = clipTypes[0]
+
+	subjects, clips = ve.snapRound(subjects, clips)
+	if err := ve.addPaths(subjects, PathTypeSubject, false); err != nil {
+		return nil, err
+	}
+	if err := ve.addPaths(clips, PathTypeClip, false); err != nil {
+		return nil, err
+	}
+	solutions := make([]Paths64, len(clipTypes))
+	if len(ve.minimaList) == 0 {
+		for i := range solutions {
+			solutions[i] = Paths64{}
+		}
+		return solutions, nil
+	}
+	ve.sortLocalMinima()
+
+	if !ve.executeScanlineAlgorithm() {
+		if ve.err == nil {
+			return nil, ErrClipperExecution
+		}
+		return nil, ve.err
+	}
+	for i := range solutions {
+		ve.useLayer(i)
+		solutions[i], _ = ve.buildSolutionPaths()
+		solutions[i] = normalizeSolution(solutions[i], ve.preserveCollinear)
+	}
+	ve.useLayer(0)
+	return solutions, nil
+}
+
+// executeDifferenceWithRemainder returns the difference and the intersection
+// of subjects and clips from one layered sweep
+func (ve *VattiEngine) executeDifferenceWithRemainder(subjects, clips Paths64) (difference, remainder Paths64, err error) {
+	solutions, err := ve.executeLayered([]ClipType{Difference, Intersection}, subjects, clips)
+	if err != nil {
+		return nil, nil, err
+	}
+	return solutions[0], solutions[1], nil
+}
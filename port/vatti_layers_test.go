@@ -0,0 +1,51 @@
+package clipper
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// TestExecuteLayered tests that a layered sweep builds every layer's solution
+// exactly as a sweep with that clip type alone
+func TestExecuteLayered(t *testing.T) {
+	check := func(t *testing.T, clipTypes []ClipType, fillRule FillRule, subjects, clips Paths64) {
+		t.Helper()
+		got, err := NewVattiEngine(Union, fillRule).executeLayered(clipTypes, subjects, clips)
+		if err != nil {
+			t.Fatalf("executeLayered failed: %v", err)
+		}
+		for i, clipType := range clipTypes {
+			want, _, err := NewVattiEngine(clipType, fillRule).ExecuteClipping(subjects, nil, clips)
+			if err != nil {
+				t.Fatalf("ExecuteClipping failed: %v", err)
+			}
+			if !reflect.DeepEqual(got[i], want) {
+				t.Fatalf("%v %v of %v and %v: expected %v, got %v", clipType, fillRule, subjects, clips, want, got[i])
+			}
+		}
+	}
+
+	// Edges joined to their left in one layer and to their right in the other
+	check(t, []ClipType{Xor, Union}, EvenOdd,
+		Paths64{{{3, 0}, {1, 0}, {1, 3}, {2, 0}, {3, 3}}, {{3, 1}, {0, 0}, {3, 2}}},
+		Paths64{{{1, 3}, {3, 1}, {1, 0}, {2, 3}, {3, 0}}, {{1, 2}, {3, 0}, {2, 3}}, {{3, 0}, {1, 1}, {3, 2}}})
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		span := []int64{4, 20, 1000}[r.Intn(3)]
+		var subjects, clips Paths64
+		for k := r.Intn(3); k >= 0; k-- {
+			subjects = append(subjects, randomPath(r, 3+r.Intn(10), span))
+		}
+		for k := r.Intn(3); k >= 0; k-- {
+			clips = append(clips, randomPath(r, 3+r.Intn(10), span))
+		}
+		clipTypes := []ClipType{ClipType(r.Intn(4)), ClipType(r.Intn(4))}
+		check(t, clipTypes, FillRule(r.Intn(4)), subjects, clips)
+	}
+
+	if _, err := NewVattiEngine(Union, NonZero).executeLayered(make([]ClipType, outputLayers+1), nil, nil); err != ErrInvalidInput {
+		t.Errorf("expected ErrInvalidInput for %d layers, got %v", outputLayers+1, err)
+	}
+}
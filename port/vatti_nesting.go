@@ -0,0 +1,513 @@
+package clipper
+
+import (
+	"math"
+	"sort"
+)
+
+// ==============================================================================
+// Nesting Flushed Rings
+// ==============================================================================
+
+// A flushed ring can only be handed over with its parent once every ring
+// that might enclose it is known. An encloser still under construction
+// crosses the scanline at which the ring finished to the left of the ring's
+// top, and the only boundaries between the two crossings are those of rings
+// inside the encloser as well. The ring therefore waits for the records whose
+// hot edges, or whose points added at the scanline, are nearest to its top on
+// the left or touch it. Once those are placed, the enclosers of the ring are
+// among the rings they left and the ancestors of those rings. Rings finished
+// at the same scanline are nested among themselves. A ring's parent is the
+// smallest of its possible enclosers containing it, and the ring is handed
+// over as soon as its parent has been. Rings are thus held only while a ring
+// around them, or one to their left, is open, not until the solution is
+// complete.
+
+// nestGroup collects the rings of one finished record, including the pieces
+// it is split into and the finished records joined to it, which all share
+// the same enclosers
+type nestGroup struct {
+	merged  *nestGroup   // group this one was merged into, if any
+	pending int          // records of the group not flushed yet
+	waits   int          // records and groups waited for not placed yet
+	waiters []*nestGroup // groups waiting for this one to be placed
+	cands   []*nestRing  // rings that, with their ancestors, may enclose the group
+	rings   []*nestRing
+	placed  bool
+}
+
+// find returns the group g was merged into
+func (g *nestGroup) find() *nestGroup {
+	for g.merged != nil {
+		g = g.merged
+	}
+	return g
+}
+
+// nestRing is a flushed ring not emitted yet, or a possible encloser of one
+type nestRing struct {
+	path   Path64
+	bounds Rect64
+	area   float64     // absolute area
+	local  *nestRing   // smallest ring placed along with it containing it
+	parent *nestRing   // set once the ring is placed
+	deps   []*nestRing // rings waiting for this one to be emitted
+	idx    int         // position in the emitted sequence, -1 until emitted
+	isHole bool
+}
+
+// flushNesting tracks the flushed rings waiting to be emitted with their
+// parents
+type flushNesting struct {
+	emit    func(ring Path64, isHole bool, parentIdx int) error
+	groupOf map[*OutRec]*nestGroup   // finished records
+	waitOn  map[*OutRec][]*nestGroup // groups waiting for a record under construction
+	open    []*nestGroup             // groups not placed yet, and some placed since
+	kept    int                      // length of open when last compacted
+	touched []*OutPt                 // points added at the current scanline
+	fresh   []*nestGroup             // groups created by the current flush
+	left    []*OutRec                // records flushed by the current flush
+	batch   []*nestRing              // rings flushed by the current flush
+	ready   []*nestGroup             // groups that may be placed
+	count   int                      // rings emitted
+}
+
+// newFlushNesting returns the nesting state of a flush passing rings to emit
+func newFlushNesting(emit func(ring Path64, isHole bool, parentIdx int) error) *flushNesting {
+	return &flushNesting{
+		emit:    emit,
+		groupOf: make(map[*OutRec]*nestGroup),
+		waitOn:  make(map[*OutRec][]*nestGroup),
+	}
+}
+
+// nesting returns the nesting state of the current flush, or nil
+func (ve *VattiEngine) nesting() *flushNesting {
+	if ve.flush == nil {
+		return nil
+	}
+	return ve.flush.nest
+}
+
+// touch notes an output point added at the current scanline
+func (n *flushNesting) touch(op *OutPt) {
+	n.touched = append(n.touched, op)
+}
+
+// holder returns the record under construction or the finished group
+// holding the points of outRec, following the owners of emptied records
+func (n *flushNesting) holder(outRec *OutRec) (*OutRec, *nestGroup) {
+	for outRec != nil && outRec.State != OutRecStateOpen {
+		if g, ok := n.groupOf[outRec]; ok {
+			return nil, g.find()
+		}
+		if outRec.FrontEdge != nil || outRec.BackEdge != nil {
+			return outRec, nil
+		}
+		if outRec.Pts != nil {
+			break
+		}
+		outRec = outRec.Owner
+	}
+	return nil, nil
+}
+
+// liveMark is the position at a scanline of a hot edge or a new point of a
+// record under construction
+type liveMark struct {
+	outRec *OutRec
+	x      int64
+}
+
+// sortMarks sorts marks by their position
+func sortMarks(marks []liveMark) {
+	sort.Slice(marks, func(i, j int) bool { return marks[i].x < marks[j].x })
+}
+
+// detach starts a group for every closed record among records finished at
+// the current scanline and makes it wait for the records under construction
+// next to its top there
+func (n *flushNesting) detach(ve *VattiEngine, records ...[]*OutRec) {
+	touched := n.touched
+	n.touched = nil
+
+	var fresh []*OutRec
+	for _, list := range records {
+		for _, outRec := range list {
+			if outRec.Pts == nil || outRec.State == OutRecStateOpen ||
+				outRec.FrontEdge != nil || outRec.BackEdge != nil {
+				continue
+			}
+			if _, ok := n.groupOf[outRec]; !ok {
+				n.start(outRec)
+				fresh = append(fresh, outRec)
+			}
+		}
+	}
+	if len(fresh) == 0 {
+		return
+	}
+
+	var marks []liveMark
+	for e := ve.activeEdges; e != nil; e = e.NextInAEL {
+		if isHotEdge(e) && !isOpenEdge(e) {
+			marks = append(marks, liveMark{e.outRec(), e.CurrX})
+		}
+	}
+	for _, op := range touched {
+		if outRec, _ := n.holder(op.OutRec); outRec != nil {
+			marks = append(marks, liveMark{outRec, op.Pt.X})
+		}
+	}
+	sortMarks(marks)
+	for _, outRec := range fresh {
+		g := n.groupOf[outRec]
+		if left, right, ok := topSpan(outRec.Pts, ve.scanY); ok {
+			n.waitNear(g, marks, left, right)
+			continue
+		}
+		// not finished at this scanline, so any record may enclose it
+		n.waitNear(g, marks, math.MinInt64, math.MaxInt64)
+	}
+}
+
+// closeBelow starts the group of a record finished at an intersection pt
+// inside the scanbeam, where the edges of the records under construction
+// are all still in the AEL, and makes it wait for those next to pt
+func (n *flushNesting) closeBelow(ve *VattiEngine, outRec *OutRec, pt Point64) {
+	g := n.start(outRec)
+	var marks []liveMark
+	for e := ve.activeEdges; e != nil; e = e.NextInAEL {
+		if isHotEdge(e) && !isOpenEdge(e) && e.outRec() != outRec {
+			marks = append(marks, liveMark{e.outRec(), topX(e, pt.Y)})
+		}
+	}
+	sortMarks(marks)
+	n.waitNear(g, marks, pt.X, pt.X)
+}
+
+// nestSlack widens the top of a finished ring to allow for rounded
+// intersection points and edge positions
+const nestSlack = 2
+
+// waitNear makes g wait for the records marked between left and right, and
+// for those marked nearest to the left of them
+func (n *flushNesting) waitNear(g *nestGroup, marks []liveMark, left, right int64) {
+	left = max(left, math.MinInt64+nestSlack) - nestSlack
+	right = min(right, math.MaxInt64-nestSlack) + nestSlack
+	var seen []*OutRec
+	wait := func(m liveMark) {
+		for _, outRec := range seen {
+			if outRec == m.outRec {
+				return
+			}
+		}
+		seen = append(seen, m.outRec)
+		n.wait(g, m.outRec)
+	}
+	i := sort.Search(len(marks), func(i int) bool { return marks[i].x >= left })
+	for j := i - 1; j >= 0 && marks[j].x == marks[i-1].x; j-- {
+		wait(marks[j])
+	}
+	for ; i < len(marks) && marks[i].x <= right; i++ {
+		wait(marks[i])
+	}
+}
+
+// start creates the group of a finished record
+func (n *flushNesting) start(outRec *OutRec) *nestGroup {
+	g := &nestGroup{pending: 1}
+	n.groupOf[outRec] = g
+	n.fresh = append(n.fresh, g)
+	n.open = append(n.open, g)
+	return g
+}
+
+// topSpan returns the range of X of the points of a ring at y
+func topSpan(pts *OutPt, y int64) (left, right int64, ok bool) {
+	op := pts
+	for {
+		if op.Pt.Y == y {
+			if !ok {
+				left, right, ok = op.Pt.X, op.Pt.X, true
+			}
+			left = min(left, op.Pt.X)
+			right = max(right, op.Pt.X)
+		}
+		op = op.Next
+		if op == pts {
+			return left, right, ok
+		}
+	}
+}
+
+// wait makes g wait for a record under construction
+func (n *flushNesting) wait(g *nestGroup, outRec *OutRec) {
+	g.waits++
+	n.waitOn[outRec] = append(n.waitOn[outRec], g)
+}
+
+// waitFor makes g wait for the group on to be placed, or resolves the wait
+// at once if it is
+func (n *flushNesting) waitFor(g, on *nestGroup) {
+	if on.placed || on == g.find() {
+		n.resolve(g, on)
+		return
+	}
+	on.waiters = append(on.waiters, g)
+}
+
+// resolve ends a wait of g for on, which is placed, or nil if the record
+// waited for left no ring
+func (n *flushNesting) resolve(g, on *nestGroup) {
+	g = g.find()
+	if on != nil && on != g {
+		g.cands = append(g.cands, on.rings...)
+		g.cands = append(g.cands, on.cands...)
+	}
+	if g.waits--; g.waits == 0 {
+		n.ready = append(n.ready, g)
+	}
+}
+
+// join merges the group of a finished record into the group of the record
+// its points were joined to
+func (n *flushNesting) join(outRec, into *OutRec) {
+	g, ok := n.groupOf[outRec]
+	h, ok2 := n.groupOf[into]
+	if !ok || !ok2 {
+		return
+	}
+	g, h = g.find(), h.find()
+	if g == h {
+		return
+	}
+	g.merged = h
+	h.pending += g.pending
+	h.waits += g.waits
+	h.cands = append(h.cands, g.cands...)
+	h.rings = append(h.rings, g.rings...)
+	// the merged group no longer waits for itself
+	var waiters []*nestGroup
+	for _, w := range append(h.waiters, g.waiters...) {
+		if w.find() == h {
+			h.waits--
+			continue
+		}
+		waiters = append(waiters, w)
+	}
+	h.waiters = waiters
+	g.waiters, g.cands, g.rings = nil, nil, nil
+}
+
+// split adds the records split from a finished record to its group
+func (n *flushNesting) split(outRec *OutRec, pieces []*OutRec) {
+	g, ok := n.groupOf[outRec]
+	if !ok {
+		return
+	}
+	for _, piece := range pieces {
+		n.groupOf[piece] = g
+		g.find().pending++
+	}
+}
+
+// add adds a ring flushed from a finished record to its group
+func (n *flushNesting) add(outRec *OutRec, ring Path64) {
+	g, ok := n.groupOf[outRec]
+	if !ok {
+		return
+	}
+	r := &nestRing{path: ring, bounds: Bounds64(ring), area: math.Abs(Area64(ring)), idx: -1}
+	g = g.find()
+	g.rings = append(g.rings, r)
+	n.batch = append(n.batch, r)
+}
+
+// leave notes a record flushed by the current flush
+func (n *flushNesting) leave(outRec *OutRec) {
+	n.left = append(n.left, outRec)
+	if g, ok := n.groupOf[outRec]; ok {
+		g = g.find()
+		if g.pending--; g.pending == 0 {
+			n.ready = append(n.ready, g)
+		}
+	}
+}
+
+// settle resolves the waits ended by the current flush and places every
+// group whose records are all flushed and whose waits are all resolved
+func (n *flushNesting) settle() error {
+	nestAmong(n.batch)
+
+	// Rings finished at the same scanline but flushed apart may enclose each
+	// other, so the groups flushed now wait for those still held
+	var fresh, held []*nestGroup
+	for _, g := range n.fresh {
+		if g.merged != nil {
+			continue
+		}
+		fresh = append(fresh, g)
+		if g.pending > 0 {
+			held = append(held, g)
+			g.cands = append(g.cands, n.batch...)
+		}
+	}
+	for _, g := range fresh {
+		if g.pending > 0 {
+			continue
+		}
+		for _, h := range held {
+			g.waits++
+			h.waiters = append(h.waiters, g)
+		}
+	}
+	n.ready = append(n.ready, fresh...)
+
+	for _, outRec := range n.left {
+		waiters, ok := n.waitOn[outRec]
+		if !ok {
+			continue
+		}
+		delete(n.waitOn, outRec)
+		owner, g := n.holder(outRec)
+		for _, w := range waiters {
+			switch {
+			case owner != nil:
+				n.waitOn[owner] = append(n.waitOn[owner], w)
+			case g != nil:
+				n.waitFor(w, g)
+			default:
+				n.resolve(w, nil)
+			}
+		}
+	}
+	for _, outRec := range n.left {
+		delete(n.groupOf, outRec)
+	}
+	n.fresh, n.left, n.batch = nil, nil, nil
+
+	for len(n.ready) > 0 {
+		g := n.ready[len(n.ready)-1].find()
+		n.ready = n.ready[:len(n.ready)-1]
+		if g.placed || g.pending > 0 || g.waits > 0 {
+			continue
+		}
+		if err := n.place(g); err != nil {
+			return err
+		}
+	}
+
+	// drop the placed groups once they make up half of the list
+	if len(n.open) >= 2*max(n.kept, 16) {
+		open := n.open[:0]
+		for _, g := range n.open {
+			if g.merged == nil && !g.placed {
+				open = append(open, g)
+			}
+		}
+		clear(n.open[len(open):])
+		n.open, n.kept = open, len(open)
+	}
+	return nil
+}
+
+// place places the rings of a group, emitting those whose parent is emitted,
+// and resolves the waits for it
+func (n *flushNesting) place(g *nestGroup) error {
+	g.placed = true
+	nestAmong(g.rings)
+	for _, r := range g.rings {
+		if err := n.placeRing(r, g.cands); err != nil {
+			return err
+		}
+	}
+	// a group without rings passes its candidates on to its waiters,
+	// otherwise the parents of its rings are the enclosers left to pass on
+	if len(g.rings) > 0 {
+		parents := make(map[*nestRing]bool)
+		g.cands = g.cands[:0]
+		for _, r := range g.rings {
+			if p := r.parent; p != nil && !parents[p] {
+				parents[p] = true
+				g.cands = append(g.cands, p)
+			}
+		}
+	}
+	for _, w := range g.waiters {
+		n.resolve(w, g)
+	}
+	g.waiters = nil
+	return nil
+}
+
+// nestAmong notes for every ring the smallest of the rings containing it
+func nestAmong(rings []*nestRing) {
+	if len(rings) < 2 {
+		return
+	}
+	paths := make(Paths64, len(rings))
+	for i, r := range rings {
+		paths[i] = r.path
+	}
+	_, parents := nestingParents(paths)
+	for i, p := range parents {
+		if r := rings[i]; p >= 0 && (r.local == nil || rings[p].area < r.local.area) {
+			r.local = rings[p]
+		}
+	}
+}
+
+// placeRing finds the parent of a ring among its candidate enclosers and
+// their ancestors and emits the ring, or leaves it to its parent if that is
+// not emitted yet
+func (n *flushNesting) placeRing(r *nestRing, cands []*nestRing) error {
+	parent := r.local
+	for _, c := range cands {
+		// ancestors are nested, so the first one containing r is the smallest
+		for ; c != nil && (parent == nil || c.area < parent.area); c = c.parent {
+			if c != r && c.area > r.area && c.bounds.ContainsRect(r.bounds) && ringContainsRing(c.path, r.path) {
+				parent = c
+				break
+			}
+		}
+	}
+	r.parent = parent
+	if parent != nil && parent.idx < 0 {
+		parent.deps = append(parent.deps, r)
+		return nil
+	}
+
+	queue := []*nestRing{r}
+	for len(queue) > 0 {
+		r := queue[0]
+		queue = queue[1:]
+		parentIdx := -1
+		if r.parent != nil {
+			parentIdx = r.parent.idx
+			r.isHole = !r.parent.isHole
+		}
+		r.idx = n.count
+		n.count++
+		if err := n.emit(r.path, r.isHole, parentIdx); err != nil {
+			return err
+		}
+		queue = append(queue, r.deps...)
+		r.deps = nil
+	}
+	return nil
+}
+
+// finish places the groups left once the sweep is complete, which can only
+// be waiting for each other, nesting their rings among themselves
+func (n *flushNesting) finish() error {
+	var rest nestGroup
+	for _, g := range n.open {
+		if g.merged == nil && !g.placed {
+			rest.rings = append(rest.rings, g.rings...)
+			rest.cands = append(rest.cands, g.cands...)
+		}
+	}
+	n.open = nil
+	return n.place(&rest)
+}
@@ -0,0 +1,302 @@
+package clipper
+
+import "sort"
+
+// ==============================================================================
+// Solution Normalization
+// ==============================================================================
+
+// normalizeSolution rebuilds the closed output of a sweep as canonical rings,
+// which depend only on the region they bound, not on the order in which the
+// sweep happened to link their edges. Rings may touch themselves or each
+// other where the sweep emits them separately or as one, and a straight run
+// of boundary may be split by vertices that merely lie on it. Here every edge
+// is first split at the vertices of other rings lying on it, edges walked in
+// both directions cancel out, and the boundary is traced again, turning at
+// each vertex into the filled wedge the incoming edge borders. Rings passing
+// through a point twice are then split there, and collinear vertices are
+// removed unless preserveCollinear is set, in which case only the vertices
+// added by splitting are. With the default rounding the sweep runs on
+// snap-rounded input (see snapRoundRings) and never rounds, so a union of
+// normalized rings bounds the same region and normalizes to the same rings.
+// The cost is that of sorting the edges plus, for every edge, the vertices
+// within its X range.
+func normalizeSolution(solution Paths64, preserveCollinear bool) Paths64 {
+	if len(solution) == 0 {
+		return solution
+	}
+	rings := solution
+	if !preserveCollinear {
+		rings = make(Paths64, 0, len(solution))
+		for _, ring := range solution {
+			if trimmed := TrimCollinear64(ring, false); trimmed != nil {
+				rings = append(rings, trimmed)
+			}
+		}
+	}
+
+	edges := boundaryEdges(rings)
+	result := make(Paths64, 0, len(rings))
+	for _, ring := range traceBoundary(edges) {
+		for _, loop := range splitRepeatedPoints(ring, edges) {
+			pts := make(Path64, 0, len(loop))
+			for _, e := range loop {
+				pts = append(pts, edges[e].a)
+			}
+			if !preserveCollinear {
+				pts = TrimCollinear64(pts, false)
+			} else {
+				pts = trimAddedVertices(pts, loop, edges)
+			}
+			if len(pts) >= 3 && Area64(pts) != 0 {
+				result = append(result, pts)
+			}
+		}
+	}
+	return result
+}
+
+// tracedEdge is a directed edge of the boundary traced by normalizeSolution
+type tracedEdge struct {
+	a, b  Point64
+	added bool // a was added by splitting the edge at another ring's vertex
+	used  bool
+}
+
+// boundaryEdges returns the edges of rings split at every vertex lying
+// inside them, without the pairs of edges running in opposite directions
+// between the same points, sorted by start point and then counter-clockwise
+// by direction
+func boundaryEdges(rings Paths64) []tracedEdge {
+	var vertices []Point64
+	for _, ring := range rings {
+		vertices = append(vertices, ring...)
+	}
+	sort.Slice(vertices, func(i, j int) bool { return pointLess(vertices[i], vertices[j]) })
+
+	var edges []tracedEdge
+	for _, ring := range rings {
+		for i, a := range ring {
+			b := ring[(i+1)%len(ring)]
+			if a == b {
+				continue
+			}
+			prev, added := a, false
+			for _, pt := range append(touchingPointsOnEdge(vertices, a, b), b) {
+				edges = append(edges, tracedEdge{a: prev, b: pt, added: added})
+				prev, added = pt, true
+			}
+		}
+	}
+
+	// Sorting by both ends puts each edge next to its duplicates, so the
+	// edges running the other way are found by binary search
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].a != edges[j].a {
+			return pointLess(edges[i].a, edges[j].a)
+		}
+		return pointLess(edges[i].b, edges[j].b)
+	})
+	find := func(a, b Point64) int {
+		return sort.Search(len(edges), func(i int) bool {
+			e := edges[i]
+			return !pointLess(e.a, a) && (e.a != a || !pointLess(e.b, b))
+		})
+	}
+	for i := range edges {
+		e := &edges[i]
+		if e.used {
+			continue
+		}
+		for j := find(e.b, e.a); j < len(edges) && edges[j].a == e.b && edges[j].b == e.a; j++ {
+			if !edges[j].used {
+				e.used, edges[j].used = true, true
+				break
+			}
+		}
+	}
+	kept := edges[:0]
+	for _, e := range edges {
+		if !e.used {
+			kept = append(kept, e)
+		}
+	}
+	sort.SliceStable(kept, func(i, j int) bool {
+		if kept[i].a != kept[j].a {
+			return pointLess(kept[i].a, kept[j].a)
+		}
+		return angleLess(kept[i].b.Sub(kept[i].a), kept[j].b.Sub(kept[j].a))
+	})
+	return kept
+}
+
+// traceBoundary links edges into rings, returned as lists of edge indices.
+// Each ring leaves a vertex by the first edge clockwise from the one it
+// arrived by, which keeps the filled wedge it borders on its left.
+func traceBoundary(edges []tracedEdge) [][]int {
+	var rings [][]int
+	for start := range edges {
+		if edges[start].used {
+			continue
+		}
+		ring := []int{start}
+		edges[start].used = true
+		for e := start; ; {
+			next := nextBoundaryEdge(edges, e, start)
+			if next < 0 || next == start {
+				break
+			}
+			edges[next].used = true
+			ring = append(ring, next)
+			e = next
+		}
+		rings = append(rings, ring)
+	}
+	return rings
+}
+
+// nextBoundaryEdge returns the unused edge, or start, that leaves the end of
+// edge e first clockwise from the direction back along e, or -1 if there is
+// none
+func nextBoundaryEdge(edges []tracedEdge, e, start int) int {
+	v, back := edges[e].b, edges[e].a.Sub(edges[e].b)
+	lo := sort.Search(len(edges), func(i int) bool { return !pointLess(edges[i].a, v) })
+	hi := lo
+	for hi < len(edges) && edges[hi].a == v {
+		hi++
+	}
+	// Edges in [lo, hi) are ordered counter-clockwise; those before back
+	// come first clockwise from it, the last of them first of all
+	split := lo
+	for split < hi && angleLess(edges[split].b.Sub(v), back) {
+		split++
+	}
+	for k := 1; k <= hi-lo; k++ {
+		i := lo + (split-lo-k+2*(hi-lo))%(hi-lo)
+		if !edges[i].used || i == start {
+			return i
+		}
+	}
+	return -1
+}
+
+// splitRepeatedPoints splits a traced ring wherever it passes through the
+// same point twice, so each loop visits every point once
+func splitRepeatedPoints(ring []int, edges []tracedEdge) [][]int {
+	pts := make([]Point64, len(ring))
+	for i, e := range ring {
+		pts[i] = edges[e].a
+	}
+	sort.Slice(pts, func(i, j int) bool { return pointLess(pts[i], pts[j]) })
+	repeated := false
+	for i := 1; i < len(pts) && !repeated; i++ {
+		repeated = pts[i] == pts[i-1]
+	}
+	if !repeated {
+		return [][]int{ring}
+	}
+
+	// Edges are stacked until one starts where a stacked one did; those
+	// from there on close a loop
+	var loops [][]int
+	stack := make([]int, 0, len(ring))
+	at := make(map[Point64]int, len(ring))
+	for _, e := range ring {
+		pt := edges[e].a
+		if j, ok := at[pt]; ok {
+			for _, f := range stack[j:] {
+				delete(at, edges[f].a)
+			}
+			loops = append(loops, append([]int(nil), stack[j:]...))
+			stack = stack[:j]
+		}
+		at[pt] = len(stack)
+		stack = append(stack, e)
+	}
+	return append(loops, stack)
+}
+
+// trimAddedVertices removes the vertices of a loop that were added by
+// splitting edges and still lie on the line through their neighbours
+func trimAddedVertices(pts Path64, loop []int, edges []tracedEdge) Path64 {
+	n := len(pts)
+	kept := make(Path64, 0, n)
+	for i, pt := range pts {
+		if !edges[loop[i]].added || !IsCollinear(pts[(i+n-1)%n], pt, pts[(i+1)%n]) {
+			kept = append(kept, pt)
+		}
+	}
+	return kept
+}
+
+// orderOutersBeforeHoles reorders closed output so every outer ring is
+// immediately followed by its holes, and the islands inside those holes come
+// after them, each again followed by its own holes. Renderers that punch
+// holes by winding can then draw the flat result outer by outer. Rings keep
+// their relative order otherwise; solutions without holes are returned as
+// they are.
+func orderOutersBeforeHoles(solution Paths64) Paths64 {
+	ordered, _ := nestRings(solution)
+	return ordered
+}
+
+// nestRings orders solution as orderOutersBeforeHoles does and also returns,
+// for every ordered ring, the index of the ring directly containing it, or -1
+func nestRings(solution Paths64) (Paths64, []int) {
+	n := len(solution)
+	hasHoles := false
+	for _, ring := range solution {
+		if Area64(ring) < 0 {
+			hasHoles = true
+			break
+		}
+	}
+	if !hasHoles {
+		parents := make([]int, n)
+		for i := range parents {
+			parents[i] = -1
+		}
+		return solution, parents
+	}
+
+	// The smallest container is the direct parent
+	_, parent := nestingParents(solution)
+
+	children := make([][]int, n)
+	var roots []int
+	for i := range solution {
+		if parent[i] < 0 {
+			roots = append(roots, i)
+		} else {
+			children[parent[i]] = append(children[parent[i]], i)
+		}
+	}
+	result := make(Paths64, 0, n)
+	parents := make([]int, 0, n)
+	newIndex := make([]int, n)
+	place := func(i int) {
+		newIndex[i] = len(result)
+		result = append(result, solution[i])
+		if parent[i] < 0 {
+			parents = append(parents, -1)
+		} else {
+			parents = append(parents, newIndex[parent[i]])
+		}
+	}
+	var emit func(outer int)
+	emit = func(outer int) {
+		place(outer)
+		for _, hole := range children[outer] {
+			place(hole)
+		}
+		for _, hole := range children[outer] {
+			for _, island := range children[hole] {
+				emit(island)
+			}
+		}
+	}
+	for _, root := range roots {
+		emit(root)
+	}
+	return result, parents
+}
@@ -0,0 +1,550 @@
+package clipper
+
+import (
+	"fmt"
+	"math"
+)
+
+// ==============================================================================
+// Phase 6: Output Records
+// ==============================================================================
+
+// Every output ring is a circular doubly linked list of OutPt. A ring under
+// construction has two hot edges: its front edge adds points at OutRec.Pts and
+// its back edge at OutRec.Pts.Next, so Pts is the front end and Pts.Next the
+// back end of the open chain.
+
+// newOutRec creates and registers a new output record
+func (ve *VattiEngine) newOutRec() *OutRec {
+	outRec := ve.outRecs.alloc()
+	outRec.Idx = len(ve.outRecords)
+	ve.outRecords = append(ve.outRecords, outRec)
+	return outRec
+}
+
+// newOutPt creates a single-point ring owned by outRec
+func (ve *VattiEngine) newOutPt(pt Point64, outRec *OutRec) *OutPt {
+	if ve.area != nil {
+		return ve.area.start(outRec, pt)
+	}
+	op := ve.newOp(pt, outRec)
+	op.Next = op
+	op.Prev = op
+	return op
+}
+
+// setSides assigns the front and back edges of an output record
+func setSides(outRec *OutRec, startEdge, endEdge *Edge) {
+	outRec.FrontEdge = startEdge
+	outRec.BackEdge = endEdge
+}
+
+// swapOutRecs exchanges the output records of two edges
+func swapOutRecs(e1, e2 *Edge) {
+	or1, or2 := e1.outRec(), e2.outRec()
+	if or1 == or2 {
+		or1.FrontEdge, or1.BackEdge = or1.BackEdge, or1.FrontEdge
+		return
+	}
+	if or1 != nil {
+		if e1 == or1.FrontEdge {
+			or1.FrontEdge = e2
+		} else {
+			or1.BackEdge = e2
+		}
+	}
+	if or2 != nil {
+		if e2 == or2.FrontEdge {
+			or2.FrontEdge = e1
+		} else {
+			or2.BackEdge = e1
+		}
+	}
+	e1.setOutRec(or2)
+	e2.setOutRec(or1)
+}
+
+// swapFrontBackSides swaps the ends of an open path's output record
+func swapFrontBackSides(outRec *OutRec) {
+	outRec.FrontEdge, outRec.BackEdge = outRec.BackEdge, outRec.FrontEdge
+	outRec.Pts = outRec.Pts.Next
+}
+
+// outRecIsAscending reports whether a hot edge is the front edge of its ring
+func outRecIsAscending(hotEdge *Edge) bool {
+	return hotEdge == hotEdge.outRec().FrontEdge
+}
+
+// prevHotEdge returns the nearest closed path hot edge left of e
+func prevHotEdge(e *Edge) *Edge {
+	prev := e.PrevInAEL
+	for prev != nil && (isOpenEdge(prev) || !isHotEdge(prev)) {
+		prev = prev.PrevInAEL
+	}
+	return prev
+}
+
+// uncoupleOutRec detaches a finished ring from its front and back edges
+func uncoupleOutRec(e *Edge) {
+	outRec := e.outRec()
+	if outRec == nil {
+		return
+	}
+	outRec.FrontEdge.setOutRec(nil)
+	outRec.BackEdge.setOutRec(nil)
+	outRec.FrontEdge = nil
+	outRec.BackEdge = nil
+}
+
+// realOutRec follows the owners of an emptied (joined) record to the record
+// now holding its points
+func realOutRec(outRec *OutRec) *OutRec {
+	for outRec != nil && outRec.Pts == nil {
+		outRec = outRec.Owner
+	}
+	return outRec
+}
+
+// setOwner makes newOwner the owner of outRec without creating an owner cycle
+func setOwner(outRec, newOwner *OutRec) {
+	for newOwner.Owner != nil && newOwner.Owner.Pts == nil {
+		newOwner.Owner = newOwner.Owner.Owner
+	}
+	tmp := newOwner
+	for tmp != nil && tmp != outRec {
+		tmp = tmp.Owner
+	}
+	if tmp != nil {
+		newOwner.Owner = outRec.Owner
+	}
+	outRec.Owner = newOwner
+}
+
+// addLocalMinPoly starts a new output ring at pt bounded by e1 and e2. Which
+// edge becomes the front depends on whether the new ring is an outer polygon
+// or a hole, which in turn depends on the nearest hot edge to the left.
+func (ve *VattiEngine) addLocalMinPoly(e1, e2 *Edge, pt Point64, isNew bool) *OutPt {
+	outRec := ve.newOutRec()
+	e1.setOutRec(outRec)
+	e2.setOutRec(outRec)
+
+	if isOpenEdge(e1) {
+		outRec.Owner = nil
+		outRec.State = OutRecStateOpen
+		if e1.WindDx > 0 {
+			setSides(outRec, e1, e2)
+		} else {
+			setSides(outRec, e2, e1)
+		}
+	} else {
+		// WindDx is the winding direction of the input paths and unrelated to
+		// the orientation of output rings, which is fixed by the front edge
+		if prevHot := prevHotEdge(e1); prevHot != nil {
+			if outRecIsAscending(prevHot) == isNew {
+				setSides(outRec, e2, e1)
+			} else {
+				setSides(outRec, e1, e2)
+			}
+		} else {
+			outRec.Owner = nil
+			if isNew {
+				setSides(outRec, e1, e2)
+			} else {
+				setSides(outRec, e2, e1)
+			}
+		}
+	}
+
+	op := ve.newOutPt(pt, outRec)
+	outRec.Pts = op
+	return op
+}
+
+// addLocalMaxPoly closes the ring(s) of two hot edges meeting at pt: a ring
+// whose two ends meet is finished, otherwise the two rings are joined
+func (ve *VattiEngine) addLocalMaxPoly(e1, e2 *Edge, pt Point64) *OutPt {
+	if isFront(e1) == isFront(e2) {
+		switch {
+		case isOpenEndEdge(e1):
+			swapFrontBackSides(e1.outRec())
+		case isOpenEndEdge(e2):
+			swapFrontBackSides(e2.outRec())
+		default:
+			ve.fail(fmt.Errorf("%w: rings meeting at %v have the same orientation", ErrInternalTopology, pt))
+			return nil
+		}
+	}
+
+	result := ve.addOutPt(e1, pt)
+	switch {
+	case e1.outRec() == e2.outRec():
+		outRec := e1.outRec()
+		outRec.Pts = result
+		uncoupleOutRec(e1)
+		result = outRec.Pts
+		if outRec.Owner != nil && outRec.Owner.FrontEdge == nil {
+			outRec.Owner = realOutRec(outRec.Owner)
+		}
+		if n := ve.nesting(); n != nil && pt.Y != ve.scanY {
+			n.closeBelow(ve, outRec, pt)
+		}
+	case isOpenEdge(e1):
+		// preserve the winding orientation of the open path
+		if e1.WindDx < 0 {
+			ve.joinOutRecPaths(e1, e2)
+		} else {
+			ve.joinOutRecPaths(e2, e1)
+		}
+	case e1.outRec().Idx < e2.outRec().Idx:
+		ve.joinOutRecPaths(e1, e2)
+	default:
+		ve.joinOutRecPaths(e2, e1)
+	}
+	return result
+}
+
+// joinOutRecPaths appends e2's ring onto e1's ring and empties e2's record
+func (ve *VattiEngine) joinOutRecPaths(e1, e2 *Edge) {
+	or1, or2 := e1.outRec(), e2.outRec()
+	p1Start := or1.Pts
+	p2Start := or2.Pts
+	p1End := p1Start.Next
+	p2End := p2Start.Next
+	ve.countJoin()
+	if ve.area != nil {
+		// the shared point of the area sweep stays linked to itself
+		ve.area.join(or1, or2, isFront(e1))
+	}
+	if isFront(e1) {
+		p2End.Prev = p1Start
+		p1Start.Next = p2End
+		p2Start.Next = p1End
+		p1End.Prev = p2Start
+		or1.Pts = p2Start
+		// nb: if IsOpen(e1) then e1 & e2 must be a 'maximaPair'
+		or1.FrontEdge = or2.FrontEdge
+		if or1.FrontEdge != nil {
+			or1.FrontEdge.setOutRec(or1)
+		}
+	} else {
+		p1End.Prev = p2Start
+		p2Start.Next = p1End
+		p1Start.Next = p2End
+		p2End.Prev = p1Start
+		or1.BackEdge = or2.BackEdge
+		if or1.BackEdge != nil {
+			or1.BackEdge.setOutRec(or1)
+		}
+	}
+
+	// after joining, e2's record must contain no vertices
+	or2.FrontEdge = nil
+	or2.BackEdge = nil
+	or2.Pts = nil
+	setOwner(or2, or1)
+
+	if isOpenEndEdge(e1) {
+		or2.Pts = or1.Pts
+		or1.Pts = nil
+	}
+
+	// e1 and e2 are maxima about to be dropped from the AEL, or joined edges
+	// now inside the merged ring
+	e1.setOutRec(nil)
+	e2.setOutRec(nil)
+}
+
+// addOutPt adds pt at the hot edge's end of its ring, unless it repeats the
+// point already there
+func (ve *VattiEngine) addOutPt(e *Edge, pt Point64) *OutPt {
+	outRec := e.outRec()
+	ve.lastOutRec = outRec
+	toFront := isFront(e)
+	if ve.area != nil {
+		ve.area.add(outRec, toFront, pt)
+		return outRec.Pts
+	}
+	opFront := outRec.Pts
+	opBack := opFront.Next
+
+	if toFront {
+		if pt == opFront.Pt {
+			return opFront
+		}
+	} else if pt == opBack.Pt {
+		return opBack
+	}
+
+	newOp := ve.newOp(pt, outRec)
+	opBack.Prev = newOp
+	newOp.Prev = opFront
+	newOp.Next = opBack
+	opFront.Next = newOp
+	if toFront {
+		outRec.Pts = newOp
+	}
+	return newOp
+}
+
+// startOpenPath starts a new output record for an open path edge
+func (ve *VattiEngine) startOpenPath(e *Edge, pt Point64) *OutPt {
+	outRec := ve.newOutRec()
+	outRec.State = OutRecStateOpen
+	if e.WindDx > 0 {
+		outRec.FrontEdge = e
+		outRec.BackEdge = nil
+	} else {
+		outRec.FrontEdge = nil
+		outRec.BackEdge = e
+	}
+	e.setOutRec(outRec)
+
+	op := ve.newOutPt(pt, outRec)
+	outRec.Pts = op
+	return op
+}
+
+// ==============================================================================
+// Building the Solution
+// ==============================================================================
+
+// buildSolutionPaths converts the finished output records into paths. Closed
+// rings are cleaned of collinear points and self-intersections first, which
+// may split records and append new ones.
+func (ve *VattiEngine) buildSolutionPaths() (solution, solutionOpen Paths64) {
+	solution = make(Paths64, 0, len(ve.outRecords))
+	solutionOpen = Paths64{}
+
+	// nb: ve.outRecords may grow while cleaning
+	for i := 0; i < len(ve.outRecords); i++ {
+		outRec := ve.outRecords[i]
+		if outRec.Pts == nil {
+			continue
+		}
+
+		if outRec.State == OutRecStateOpen {
+			if path, ok := buildPath(outRec.Pts, false, true); ok {
+				solutionOpen = append(solutionOpen, path)
+			}
+			continue
+		}
+
+		ve.cleanCollinear(outRec, ve.preserveCollinear)
+		// Rings are read backwards: the engine runs mirrored relative to
+		// Clipper2, so this gives outer polygons a positive area
+		if path, ok := buildPath(outRec.Pts, true, false); ok {
+			solution = append(solution, path)
+		}
+	}
+	return solution, solutionOpen
+}
+
+// buildPartialSolution builds the output of a failed execution from the
+// rings that were already closed; rings still attached to active edges are
+// incomplete and dropped
+func (ve *VattiEngine) buildPartialSolution() (solution, solutionOpen Paths64) {
+	for _, outRec := range ve.outRecords {
+		if outRec.FrontEdge != nil || outRec.BackEdge != nil {
+			outRec.Pts = nil
+		}
+	}
+	return ve.buildSolutionPaths()
+}
+
+// buildPath reads a ring (or open chain) into a path, dropping repeated
+// points; ok is false for degenerate rings
+func buildPath(op *OutPt, reverse, isOpen bool) (Path64, bool) {
+	if op == nil || op.Next == op || (!isOpen && op.Next == op.Prev) {
+		return nil, false
+	}
+
+	var lastPt Point64
+	var op2 *OutPt
+	if reverse {
+		lastPt = op.Pt
+		op2 = op.Prev
+	} else {
+		op = op.Next
+		lastPt = op.Pt
+		op2 = op.Next
+	}
+	// size the path for the whole ring, so it is allocated once
+	n := 1
+	for p := op.Next; p != op; p = p.Next {
+		n++
+	}
+	path := make(Path64, 1, n)
+	path[0] = lastPt
+
+	for op2 != op {
+		if op2.Pt != lastPt {
+			lastPt = op2.Pt
+			path = append(path, lastPt)
+		}
+		if reverse {
+			op2 = op2.Prev
+		} else {
+			op2 = op2.Next
+		}
+	}
+
+	return path, true
+}
+
+// isValidClosedPath reports whether a ring still has three vertices.
+// Clipper2 also drops triangles with two vertices less than two units apart;
+// on snap-rounded input such triangles are real parts of the result, and
+// dropping them would lose area that a union of the output keeps.
+func isValidClosedPath(op *OutPt) bool {
+	return op != nil && op.Next != op && op.Next != op.Prev
+}
+
+// disposeOutPt unlinks op from its ring and returns its successor
+func disposeOutPt(op *OutPt) *OutPt {
+	result := op.Next
+	op.Prev.Next = op.Next
+	op.Next.Prev = op.Prev
+	return result
+}
+
+// cleanCollinear removes collinear points from a finished ring (only 180
+// degree spikes when preserveCollinear is set) and then splits it at any
+// self-intersections
+func (ve *VattiEngine) cleanCollinear(outRec *OutRec, preserveCollinear bool) {
+	outRec = realOutRec(outRec)
+	if outRec == nil || outRec.State == OutRecStateOpen {
+		return
+	}
+	if !isValidClosedPath(outRec.Pts) {
+		outRec.Pts = nil
+		return
+	}
+
+	startOp := outRec.Pts
+	op2 := startOp
+	for {
+		if CrossProduct128(op2.Prev.Pt, op2.Pt, op2.Next.Pt).IsZero() &&
+			(op2.Pt == op2.Prev.Pt || op2.Pt == op2.Next.Pt || !preserveCollinear ||
+				op2.Pt.Sub(op2.Prev.Pt).Dot128(op2.Next.Pt.Sub(op2.Pt)).IsNegative()) {
+			if op2 == outRec.Pts {
+				outRec.Pts = op2.Prev
+			}
+			op2 = disposeOutPt(op2)
+			if !isValidClosedPath(op2) {
+				outRec.Pts = nil
+				return
+			}
+			startOp = op2
+			continue
+		}
+		op2 = op2.Next
+		if op2 == startOp {
+			break
+		}
+	}
+	ve.fixSelfIntersects(outRec)
+}
+
+// fixSelfIntersects splits a ring wherever two edges one apart cross
+func (ve *VattiEngine) fixSelfIntersects(outRec *OutRec) {
+	op2 := outRec.Pts
+	for {
+		// triangles can't self-intersect
+		if op2.Prev == op2.Next.Next {
+			break
+		}
+		if segmentsIntersectStrict(op2.Prev.Pt, op2.Pt, op2.Next.Pt, op2.Next.Next.Pt) {
+			if op2 == outRec.Pts || op2.Next == outRec.Pts {
+				outRec.Pts = outRec.Pts.Prev
+			}
+			ve.doSplitOp(outRec, op2)
+			if outRec.Pts == nil {
+				break
+			}
+			op2 = outRec.Pts
+			continue
+		}
+		op2 = op2.Next
+		if op2 == outRec.Pts {
+			break
+		}
+	}
+}
+
+// doSplitOp removes the small loop formed where the edges before splitOp and
+// after splitOp.Next cross, keeping the loop as a separate ring if it has the
+// same orientation as the rest of the ring
+func (ve *VattiEngine) doSplitOp(outRec *OutRec, splitOp *OutPt) {
+	prevOp := splitOp.Prev
+	nextNextOp := splitOp.Next.Next
+	outRec.Pts = prevOp
+
+	ip, _ := ve.intersectPt(prevOp.Pt, splitOp.Pt, splitOp.Next.Pt, nextNextOp.Pt)
+
+	area1 := outPtArea(prevOp)
+	absArea1 := math.Abs(area1)
+	if absArea1 == 0 {
+		outRec.Pts = nil
+		return
+	}
+
+	area2 := triangleArea(ip, splitOp.Pt, splitOp.Next.Pt)
+	absArea2 := math.Abs(area2)
+
+	// de-link splitOp and splitOp.Next from the path while inserting the
+	// intersection point
+	if ip == prevOp.Pt || ip == nextNextOp.Pt {
+		nextNextOp.Prev = prevOp
+		prevOp.Next = nextNextOp
+	} else {
+		newOp2 := ve.newOp(ip, outRec)
+		newOp2.Prev, newOp2.Next = prevOp, nextNextOp
+		nextNextOp.Prev = newOp2
+		prevOp.Next = newOp2
+	}
+
+	// area1 is the ring's area before splitting and area2 the area of the
+	// triangle cut off; they can only share a sign if the triangle is larger
+	// than the rest or if there is more than one self-intersection
+	if absArea2 > 0 && (absArea2 > absArea1 || (area2 > 0) == (area1 > 0)) {
+		newOr := ve.newOutRec()
+		newOr.Owner = outRec.Owner
+		splitOp.OutRec = newOr
+		splitOp.Next.OutRec = newOr
+
+		newOp := ve.newOp(ip, newOr)
+		newOp.Prev, newOp.Next = splitOp.Next, splitOp
+		newOr.Pts = newOp
+		splitOp.Prev = newOp
+		splitOp.Next.Next = newOp
+	}
+}
+
+// outPtArea returns the signed area of the ring containing op
+func outPtArea(op *OutPt) float64 {
+	area := 0.0
+	op2 := op
+	for {
+		area += float64(op2.Prev.Pt.Y+op2.Pt.Y) * float64(op2.Prev.Pt.X-op2.Pt.X)
+		op2 = op2.Next
+		if op2 == op {
+			break
+		}
+	}
+	return area * 0.5
+}
+
+// triangleArea returns twice the signed area of a triangle
+func triangleArea(pt1, pt2, pt3 Point64) float64 {
+	return float64(pt3.Y+pt1.Y)*float64(pt3.X-pt1.X) +
+		float64(pt1.Y+pt2.Y)*float64(pt1.X-pt2.X) +
+		float64(pt2.Y+pt3.Y)*float64(pt2.X-pt3.X)
+}
+
+// segmentsIntersectStrict reports whether two segments cross at a point
+// interior to both
+func segmentsIntersectStrict(seg1a, seg1b, seg2a, seg2b Point64) bool {
+	zero := Int128{}
+	return CrossProduct128(seg1a, seg2a, seg2b).Cmp(zero)*CrossProduct128(seg1b, seg2a, seg2b).Cmp(zero) < 0 &&
+		CrossProduct128(seg2a, seg1a, seg1b).Cmp(zero)*CrossProduct128(seg2b, seg1a, seg1b).Cmp(zero) < 0
+}
@@ -0,0 +1,42 @@
+package clipper
+
+import (
+	"errors"
+	"testing"
+)
+
+// failingObserver makes the engine fail once the sweep reaches failY
+type failingObserver struct {
+	failY int64
+}
+
+func (o failingObserver) observeScanbeam(ve *VattiEngine, y int64) {
+	if y >= o.failY {
+		ve.fail(ErrInternalTopology)
+	}
+}
+
+// TestPartialSolution tests that a failed sweep keeps the rings it completed
+func TestPartialSolution(t *testing.T) {
+	lower := Path64{{0, 0}, {10, 0}, {10, 10}, {0, 10}}
+	upper := Path64{{0, 100}, {10, 100}, {10, 110}, {0, 110}}
+
+	engine := NewVattiEngine(Union, NonZero)
+	engine.observer = failingObserver{failY: 100}
+	solution, _, err := engine.ExecuteClipping(Paths64{lower, upper}, nil, nil)
+	if !errors.Is(err, ErrInternalTopology) {
+		t.Fatalf("expected ErrInternalTopology, got %v", err)
+	}
+	if !sameRings(solution, Paths64{lower}) {
+		t.Errorf("expected only the completed lower ring, got %v", solution)
+	}
+}
+
+// TestPartialResultsOption tests that successful operations are unaffected
+func TestPartialResultsOption(t *testing.T) {
+	square := Paths64{{{0, 0}, {10, 0}, {10, 10}, {0, 10}}}
+	solution, _, err := BooleanOp64(Union, NonZero, square, nil, nil, ClipperOptions{PartialResults: true})
+	if err != nil || len(solution) != 1 {
+		t.Errorf("expected one ring and no error, got %v, %v", solution, err)
+	}
+}
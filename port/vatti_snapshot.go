@@ -0,0 +1,253 @@
+//go:build clipper_debug
+
+package clipper
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// This file contains the white-box engine debugger, only compiled with -tags=clipper_debug
+// It records the active and sorted edge lists after every scanbeam so regression tests can
+// locate the first scanline where two runs (or two versions of the engine) diverge
+
+// EdgeSnapshot captures the state of a single edge at the end of a scanbeam
+type EdgeSnapshot struct {
+	Bot         Point64  `json:"bot"`
+	Top         Point64  `json:"top"`
+	CurrX       int64    `json:"currX"`
+	Dx          float64  `json:"dx"`
+	WindDx      int      `json:"windDx"`
+	WindCount   int      `json:"windCount"`
+	WindCount2  int      `json:"windCount2"`
+	PathType    PathType `json:"pathType"`
+	IsLeftBound bool     `json:"isLeftBound"`
+	OutRecIdx   int      `json:"outRecIdx"` // -1 if the edge is not contributing
+}
+
+// ScanbeamSnapshot captures the engine state after the scanbeam at Y was processed
+type ScanbeamSnapshot struct {
+	Y           int64          `json:"y"`
+	AEL         []EdgeSnapshot `json:"ael"`
+	SEL         []EdgeSnapshot `json:"sel,omitempty"`
+	OutRecCount int            `json:"outRecCount"`
+}
+
+// OutRecSnapshot captures one output record of the final OutRec/OutPt graph
+type OutRecSnapshot struct {
+	Idx   int         `json:"idx"`
+	State OutRecState `json:"state"`
+	Owner int         `json:"owner"` // -1 if the record has no owner
+	// Pts lists the ring from OutRec.Pts following the Next links; empty for
+	// records merged into others
+	Pts []Point64 `json:"pts,omitempty"`
+	// BrokenLinks is set when a Prev link does not mirror its Next link
+	BrokenLinks bool `json:"brokenLinks,omitempty"`
+	// FrontEdge and BackEdge are the edges last seen adding points at the
+	// front and back of the ring at the end of a scanbeam
+	FrontEdge *EdgeSnapshot `json:"frontEdge,omitempty"`
+	BackEdge  *EdgeSnapshot `json:"backEdge,omitempty"`
+}
+
+// EngineDebugger records a ScanbeamSnapshot for every scanbeam of an attached
+// engine and the OutRec graph the sweep leaves behind
+type EngineDebugger struct {
+	Snapshots []ScanbeamSnapshot `json:"snapshots"`
+	OutRecs   []OutRecSnapshot   `json:"outRecs,omitempty"`
+
+	lastEdges [][2]*EdgeSnapshot // by OutRec index, the last front and back edge seen
+}
+
+// NewEngineDebugger creates an empty debugger
+func NewEngineDebugger() *EngineDebugger {
+	return &EngineDebugger{}
+}
+
+// SetDebugger attaches a debugger to the engine (nil detaches it)
+func (ve *VattiEngine) SetDebugger(d *EngineDebugger) {
+	if d == nil {
+		ve.observer = nil
+		return
+	}
+	ve.observer = d
+}
+
+// DebugBooleanOp64 runs the pure Go engine with the debugger attached, regardless
+// of the clipper_cgo build tag, and returns the engine's solution
+func DebugBooleanOp64(d *EngineDebugger, clipType ClipType, fillRule FillRule, subjects, subjectsOpen, clips Paths64) (solution, solutionOpen Paths64, err error) {
+	engine := NewVattiEngine(clipType, fillRule)
+	engine.SetDebugger(d)
+	return engine.ExecuteClipping(subjects, subjectsOpen, clips)
+}
+
+// WriteJSON serializes all recorded snapshots
+func (d *EngineDebugger) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(d)
+}
+
+// ReadEngineSnapshots deserializes snapshots previously written with WriteJSON
+func ReadEngineSnapshots(r io.Reader) (*EngineDebugger, error) {
+	d := NewEngineDebugger()
+	if err := json.NewDecoder(r).Decode(d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// FirstDivergence returns the index of the first scanbeam whose snapshot differs
+// between d and other, or -1 if the recordings are identical
+func (d *EngineDebugger) FirstDivergence(other *EngineDebugger) int {
+	n := min(len(d.Snapshots), len(other.Snapshots))
+	for i := 0; i < n; i++ {
+		if !snapshotsEqual(&d.Snapshots[i], &other.Snapshots[i]) {
+			return i
+		}
+	}
+	if len(d.Snapshots) != len(other.Snapshots) {
+		return n
+	}
+	return -1
+}
+
+// observeScanbeam implements scanbeamObserver
+func (d *EngineDebugger) observeScanbeam(ve *VattiEngine, y int64) {
+	snap := ScanbeamSnapshot{
+		Y:           y,
+		OutRecCount: len(ve.outRecords),
+	}
+
+	var selHead *Edge
+	for e := ve.activeEdges; e != nil; e = e.NextInAEL {
+		snap.AEL = append(snap.AEL, snapshotEdge(e))
+		if e.PrevInSEL == nil && e.NextInSEL != nil {
+			selHead = e
+		}
+	}
+	for e := selHead; e != nil; e = e.NextInSEL {
+		snap.SEL = append(snap.SEL, snapshotEdge(e))
+	}
+
+	d.Snapshots = append(d.Snapshots, snap)
+
+	for _, outRec := range ve.outRecords {
+		if outRec.FrontEdge == nil && outRec.BackEdge == nil {
+			continue
+		}
+		for len(d.lastEdges) <= outRec.Idx {
+			d.lastEdges = append(d.lastEdges, [2]*EdgeSnapshot{})
+		}
+		var edges [2]*EdgeSnapshot
+		for i, e := range []*Edge{outRec.FrontEdge, outRec.BackEdge} {
+			if e != nil {
+				s := snapshotEdge(e)
+				edges[i] = &s
+			}
+		}
+		d.lastEdges[outRec.Idx] = edges
+	}
+}
+
+// observeOutRecs implements outRecObserver
+func (d *EngineDebugger) observeOutRecs(ve *VattiEngine) {
+	d.OutRecs = d.OutRecs[:0]
+	for _, outRec := range ve.outRecords {
+		snap := OutRecSnapshot{Idx: outRec.Idx, State: outRec.State, Owner: -1}
+		if outRec.Owner != nil {
+			snap.Owner = outRec.Owner.Idx
+		}
+		if op := outRec.Pts; op != nil {
+			for {
+				snap.Pts = append(snap.Pts, op.Pt)
+				if op.Next == nil || op.Next.Prev != op {
+					snap.BrokenLinks = true
+					break
+				}
+				op = op.Next
+				if op == outRec.Pts || len(snap.Pts) > 1<<24 {
+					break
+				}
+			}
+		}
+		var edges [2]*EdgeSnapshot
+		if outRec.Idx < len(d.lastEdges) {
+			edges = d.lastEdges[outRec.Idx]
+		}
+		snap.FrontEdge, snap.BackEdge = edges[0], edges[1]
+		d.OutRecs = append(d.OutRecs, snap)
+	}
+}
+
+// FirstOutRecDivergence returns the index of the first output record whose
+// structure differs between d and other, or -1 if both graphs are identical
+func (d *EngineDebugger) FirstOutRecDivergence(other *EngineDebugger) int {
+	n := min(len(d.OutRecs), len(other.OutRecs))
+	for i := 0; i < n; i++ {
+		if !outRecsEqual(&d.OutRecs[i], &other.OutRecs[i]) {
+			return i
+		}
+	}
+	if len(d.OutRecs) != len(other.OutRecs) {
+		return n
+	}
+	return -1
+}
+
+// snapshotEdge copies the debug-relevant state of an edge
+func snapshotEdge(e *Edge) EdgeSnapshot {
+	s := EdgeSnapshot{
+		Bot:         e.Bot,
+		Top:         e.Top,
+		CurrX:       e.CurrX,
+		Dx:          e.Dx,
+		WindDx:      e.WindDx,
+		WindCount:   e.WindCount,
+		WindCount2:  e.WindCount2,
+		IsLeftBound: e.IsLeftBound,
+		OutRecIdx:   -1,
+	}
+	if e.LocalMin != nil {
+		s.PathType = e.LocalMin.PathType
+	}
+	if e.outRec() != nil {
+		s.OutRecIdx = e.outRec().Idx
+	}
+	return s
+}
+
+// snapshotsEqual compares two scanbeam snapshots field by field
+func snapshotsEqual(a, b *ScanbeamSnapshot) bool {
+	if a.Y != b.Y || a.OutRecCount != b.OutRecCount ||
+		len(a.AEL) != len(b.AEL) || len(a.SEL) != len(b.SEL) {
+		return false
+	}
+	for i := range a.AEL {
+		if a.AEL[i] != b.AEL[i] {
+			return false
+		}
+	}
+	for i := range a.SEL {
+		if a.SEL[i] != b.SEL[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// outRecsEqual compares two output record snapshots field by field
+func outRecsEqual(a, b *OutRecSnapshot) bool {
+	if a.Idx != b.Idx || a.State != b.State || a.Owner != b.Owner ||
+		a.BrokenLinks != b.BrokenLinks || len(a.Pts) != len(b.Pts) {
+		return false
+	}
+	for i := range a.Pts {
+		if a.Pts[i] != b.Pts[i] {
+			return false
+		}
+	}
+	sameEdge := func(x, y *EdgeSnapshot) bool {
+		return (x == nil && y == nil) || (x != nil && y != nil && *x == *y)
+	}
+	return sameEdge(a.FrontEdge, b.FrontEdge) && sameEdge(a.BackEdge, b.BackEdge)
+}
@@ -0,0 +1,106 @@
+//go:build clipper_debug
+
+package clipper
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestEngineDebuggerSnapshots tests that a snapshot is recorded per scanbeam
+func TestEngineDebuggerSnapshots(t *testing.T) {
+	subject := Paths64{{{0, 0}, {10, 0}, {10, 10}, {0, 10}}}
+	clip := Paths64{{{5, 5}, {15, 5}, {15, 15}, {5, 15}}}
+
+	d := NewEngineDebugger()
+	if _, _, err := DebugBooleanOp64(d, Intersection, NonZero, subject, nil, clip); err != nil {
+		t.Fatalf("DebugBooleanOp64 failed: %v", err)
+	}
+
+	expectedY := []int64{0, 5, 10, 15}
+	if len(d.Snapshots) != len(expectedY) {
+		t.Fatalf("Expected %d snapshots, got %d", len(expectedY), len(d.Snapshots))
+	}
+	for i, snap := range d.Snapshots {
+		if snap.Y != expectedY[i] {
+			t.Errorf("Snapshot %d: expected Y=%d, got %d", i, expectedY[i], snap.Y)
+		}
+	}
+	if len(d.Snapshots[0].AEL) == 0 {
+		t.Error("Expected edges in the AEL after the first scanbeam")
+	}
+}
+
+// TestEngineDebuggerRoundTrip tests JSON serialization and divergence detection
+func TestEngineDebuggerRoundTrip(t *testing.T) {
+	subject := Paths64{{{0, 0}, {10, 0}, {5, 10}}}
+	clip := Paths64{{{0, 5}, {10, 5}, {10, 15}, {0, 15}}}
+
+	d := NewEngineDebugger()
+	if _, _, err := DebugBooleanOp64(d, Union, NonZero, subject, nil, clip); err != nil {
+		t.Fatalf("DebugBooleanOp64 failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := d.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON failed: %v", err)
+	}
+	golden, err := ReadEngineSnapshots(&buf)
+	if err != nil {
+		t.Fatalf("ReadEngineSnapshots failed: %v", err)
+	}
+	if idx := d.FirstDivergence(golden); idx != -1 {
+		t.Errorf("Expected identical recordings, diverged at %d", idx)
+	}
+
+	golden.Snapshots[1].AEL[0].CurrX++
+	if idx := d.FirstDivergence(golden); idx != 1 {
+		t.Errorf("Expected divergence at scanbeam 1, got %d", idx)
+	}
+}
+
+// TestEngineDebuggerOutRecGraph tests the export of the final OutRec graph
+func TestEngineDebuggerOutRecGraph(t *testing.T) {
+	// Two overlapping squares start three rings that are joined into one;
+	// the emptied records name the surviving one as their owner
+	subject := Paths64{
+		{{0, 0}, {20, 0}, {20, 20}, {0, 20}},
+		{{10, 10}, {30, 10}, {30, 30}, {10, 30}},
+	}
+
+	d := NewEngineDebugger()
+	if _, _, err := DebugBooleanOp64(d, Union, NonZero, subject, nil, nil); err != nil {
+		t.Fatalf("DebugBooleanOp64 failed: %v", err)
+	}
+	if len(d.OutRecs) != 3 {
+		t.Fatalf("Expected 3 output records, got %+v", d.OutRecs)
+	}
+	survivor := d.OutRecs[0]
+	if len(survivor.Pts) != 8 || survivor.BrokenLinks || survivor.Owner != -1 {
+		t.Errorf("Expected an intact 8-point ring without owner, got %+v", survivor)
+	}
+	if survivor.FrontEdge == nil || survivor.BackEdge == nil {
+		t.Errorf("Expected the front and back edges of the ring to be recorded")
+	}
+	for _, rec := range d.OutRecs[1:] {
+		if len(rec.Pts) != 0 || rec.Owner != survivor.Idx {
+			t.Errorf("OutRec %d: expected an empty record owned by %d, got %+v", rec.Idx, survivor.Idx, rec)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := d.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON failed: %v", err)
+	}
+	golden, err := ReadEngineSnapshots(&buf)
+	if err != nil {
+		t.Fatalf("ReadEngineSnapshots failed: %v", err)
+	}
+	if idx := d.FirstOutRecDivergence(golden); idx != -1 {
+		t.Errorf("Expected identical graphs, diverged at %d", idx)
+	}
+	golden.OutRecs[2].Owner = 1
+	if idx := d.FirstOutRecDivergence(golden); idx != 2 {
+		t.Errorf("Expected divergence at record 2, got %d", idx)
+	}
+}
@@ -0,0 +1,95 @@
+package clipper
+
+import (
+	"fmt"
+	"math"
+)
+
+// ==============================================================================
+// Sweep Budget
+// ==============================================================================
+
+// SweepBudget bounds the work of one sweep, so that no input, however
+// degenerate, can keep the engine running forever. Work counts the edges
+// visited at the top of each scanbeam, along horizontals and while swapping
+// crossing edges, which grows like scanlines times active edges; joins count
+// merges of two output rings. Zero fields are sized from the input, far
+// above anything a valid sweep needs.
+type SweepBudget struct {
+	MaxWork  int64
+	MaxJoins int64
+}
+
+// defaultBudgetPerVertexSq scales the default budget with the square of the
+// vertex count, the order of the work of a sweep with many crossings
+const defaultBudgetPerVertexSq = 64
+
+// resolve fills in the zero fields of b for an input of n vertices
+func (b SweepBudget) resolve(n int) SweepBudget {
+	m := float64(n + 8)
+	limit := int64(math.Min(defaultBudgetPerVertexSq*m*m, math.MaxInt64/2))
+	if b.MaxWork <= 0 {
+		b.MaxWork = limit
+	}
+	if b.MaxJoins <= 0 {
+		b.MaxJoins = limit
+	}
+	return b
+}
+
+// StallError reports a sweep stopped by its SweepBudget, with the state it
+// was in. It matches ErrClipperStalled and ErrClipperExecution.
+type StallError struct {
+	Y           int64 // scanline being processed
+	ActiveEdges int   // edges in the active edge list
+	OutRec      int   // index of the output record last extended, or -1
+	Work        int64 // work spent
+	Joins       int64 // ring joins performed
+}
+
+// Error implements the error interface
+func (e *StallError) Error() string {
+	return fmt.Sprintf("%v at Y=%d: %d active edges, output record %d, %d work units, %d joins",
+		ErrClipperStalled, e.Y, e.ActiveEdges, e.OutRec, e.Work, e.Joins)
+}
+
+// Unwrap returns ErrClipperStalled
+func (e *StallError) Unwrap() error {
+	return ErrClipperStalled
+}
+
+// SetSweepBudget limits the work of the next sweep; see SweepBudget
+func (ve *VattiEngine) SetSweepBudget(b SweepBudget) {
+	ve.budget = b
+}
+
+// spend charges one unit of work and fails the sweep once the budget is
+// exhausted; it returns false then
+func (ve *VattiEngine) spend() bool {
+	ve.work++
+	if ve.work > ve.budget.MaxWork {
+		ve.stall()
+		return false
+	}
+	return true
+}
+
+// countJoin charges one ring join against the budget
+func (ve *VattiEngine) countJoin() {
+	ve.joins++
+	if ve.joins > ve.budget.MaxJoins {
+		ve.stall()
+	}
+}
+
+// stall fails the sweep with a StallError describing its current state
+func (ve *VattiEngine) stall() {
+	err := &StallError{Y: ve.scanY, OutRec: -1, Work: ve.work, Joins: ve.joins}
+	for e := ve.activeEdges; e != nil; e = e.NextInAEL {
+		err.ActiveEdges++
+	}
+	if ve.lastOutRec != nil {
+		err.OutRec = ve.lastOutRec.Idx
+	}
+	ve.fail(err)
+}
@@ -0,0 +1,58 @@
+package clipper
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestSweepBudget tests that an exhausted budget stops the sweep with a StallError
+func TestSweepBudget(t *testing.T) {
+	star := Paths64{{{0, 0}, {100, 60}, {-20, 60}, {80, 0}, {40, 100}}}
+	clip := Paths64{{{-10, 20}, {110, 20}, {110, 40}, {-10, 40}}}
+
+	expected, _, err := BooleanOp64(Union, NonZero, star, nil, clip)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, _, err := BooleanOp64(Union, NonZero, star, nil, clip, WithSweepBudget(SweepBudget{}))
+	if err != nil || !sameRings(got, expected) {
+		t.Errorf("expected the default budget to leave %v unchanged, got %v (err %v)", expected, got, err)
+	}
+
+	tests := []struct {
+		name   string
+		budget SweepBudget
+	}{
+		{"Work", SweepBudget{MaxWork: 5}},
+		{"Joins", SweepBudget{MaxJoins: 1}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, _, err := BooleanOp64(Union, NonZero, star, nil, clip, WithSweepBudget(test.budget))
+			if !errors.Is(err, ErrClipperStalled) || !errors.Is(err, ErrClipperExecution) {
+				t.Fatalf("expected ErrClipperStalled, got %v", err)
+			}
+			if code := ErrorCodeOf(err); code != CodeStalled {
+				t.Errorf("expected code %v, got %v", CodeStalled, code)
+			}
+			var stall *StallError
+			if !errors.As(err, &stall) {
+				t.Fatalf("expected a *StallError, got %T", err)
+			}
+			if stall.Y < 0 || stall.Y > 100 || stall.ActiveEdges == 0 || stall.OutRec < -1 {
+				t.Errorf("expected the state of a running sweep, got %+v", stall)
+			}
+		})
+	}
+
+	c := NewClipper64()
+	_ = c.AddSubject(star)
+	_ = c.AddClip(clip)
+	if _, _, err := c.Execute(Union, NonZero, WithSweepBudget(SweepBudget{MaxWork: 5})); !errors.Is(err, ErrClipperStalled) {
+		t.Errorf("expected ErrClipperStalled from Clipper64, got %v", err)
+	}
+	got, _, err = c.Execute(Union, NonZero)
+	if err != nil || !sameRings(got, expected) {
+		t.Errorf("expected %v after a stalled run, got %v (err %v)", expected, got, err)
+	}
+}
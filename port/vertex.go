@@ -22,6 +22,10 @@ type Vertex struct {
 	Next  *Vertex      // Next vertex in the polygon chain
 	Prev  *Vertex      // Previous vertex in the polygon chain
 	Flags VertexFlags  // Vertex flags (local min/max, open start/end, etc.)
+
+	// Position among the vertices added to its engine or PreparedPaths64,
+	// locating the vertex's maxima pair in the sweep (see registerMaximaEdge)
+	idx int
 }
 
 // JoinWith specifies how an edge joins with other edges
@@ -33,82 +37,99 @@ const (
 	JoinWithRight
 )
 
-// createVertexFromPath converts a Path64 to a linked chain of vertices
+// createVertexFromPath converts a Path64 to a circular chain of vertices, as
+// Clipper2 does for both open and closed paths (the open ends are flagged).
+// Consecutive duplicate points and a closing point repeating the first are
+// dropped. Returns nil for paths with too few distinct points.
 func createVertexFromPath(path Path64, isOpen bool) *Vertex {
-	if len(path) < 2 {
-		return nil // Degenerate path
-	}
+	return buildVertexChain(make([]Vertex, len(path)), path, isOpen)
+}
 
-	// Create vertices
-	vertices := make([]*Vertex, len(path))
-	for i, pt := range path {
-		vertices[i] = &Vertex{
-			Pt:    pt,
-			Flags: VertexFlagsEmpty,
+// buildVertexChain is createVertexFromPath storing the vertices in buf,
+// which must hold at least len(path) zeroed vertices
+func buildVertexChain(buf []Vertex, path Path64, isOpen bool) *Vertex {
+	vertices := buf[:0]
+	for _, pt := range path {
+		if len(vertices) > 0 && vertices[len(vertices)-1].Pt == pt {
+			continue // skip duplicates
 		}
+		vertices = append(vertices, Vertex{Pt: pt, Flags: VertexFlagsEmpty})
 	}
-
-	// Link vertices into a chain
-	for i := 0; i < len(vertices); i++ {
-		if isOpen {
-			// Open path: no wrap-around linking
-			if i > 0 {
-				vertices[i].Prev = vertices[i-1]
-			}
-			if i < len(vertices)-1 {
-				vertices[i].Next = vertices[i+1]
-			}
-		} else {
-			// Closed path: wrap-around linking
-			vertices[i].Prev = vertices[(i-1+len(vertices))%len(vertices)]
-			vertices[i].Next = vertices[(i+1)%len(vertices)]
-		}
+	if !isOpen && len(vertices) > 1 && vertices[len(vertices)-1].Pt == vertices[0].Pt {
+		vertices = vertices[:len(vertices)-1]
+	}
+	if len(vertices) < 2 || (!isOpen && len(vertices) < 3) {
+		return nil // Degenerate path
 	}
 
-	// Set open path flags
-	if isOpen && len(vertices) >= 2 {
-		vertices[0].Flags |= VertexFlagsOpenStart
-		vertices[len(vertices)-1].Flags |= VertexFlagsOpenEnd
+	// Link vertices into a circular chain
+	for i := range vertices {
+		vertices[i].Prev = &vertices[(i-1+len(vertices))%len(vertices)]
+		vertices[i].Next = &vertices[(i+1)%len(vertices)]
 	}
 
 	// Identify and mark local minima and maxima
-	markLocalMinimaAndMaxima(vertices, isOpen)
+	markLocalMinimaAndMaxima(&vertices[0], isOpen)
 
-	return vertices[0] // Return first vertex as chain head
+	return &vertices[0] // Return first vertex as chain head
 }
 
-// markLocalMinimaAndMaxima identifies and marks local minima and maxima in the vertex chain
-func markLocalMinimaAndMaxima(vertices []*Vertex, isOpen bool) {
-	if len(vertices) < 3 {
-		return // Cannot have local min/max with less than 3 vertices
+// markLocalMinimaAndMaxima marks local minima and maxima the way Clipper2 does:
+// a horizontal run at an extremum yields a single extremum at the run's last
+// vertex in path order, and a horizontal "step" between a rising and a falling
+// side is neither. Open paths also flag their ends, which become a minimum or a
+// maximum depending on the direction the path leaves them.
+func markLocalMinimaAndMaxima(v0 *Vertex, isOpen bool) {
+
+	var goingUp bool
+	if isOpen {
+		curr := v0.Next
+		for curr != v0 && curr.Pt.Y == v0.Pt.Y {
+			curr = curr.Next
+		}
+		goingUp = curr.Pt.Y >= v0.Pt.Y
+		if goingUp {
+			v0.Flags = VertexFlagsOpenStart | VertexFlagsLocalMin
+		} else {
+			v0.Flags = VertexFlagsOpenStart | VertexFlagsLocalMax
+		}
+	} else {
+		prev := v0.Prev
+		for prev != v0 && prev.Pt.Y == v0.Pt.Y {
+			prev = prev.Prev
+		}
+		if prev == v0 {
+			return // only open paths can be completely flat
+		}
+		goingUp = prev.Pt.Y < v0.Pt.Y
 	}
 
-	for i, v := range vertices {
-		var prevV, nextV *Vertex
-
-		if isOpen {
-			// For open paths, don't check first and last vertices as min/max
-			if i == 0 || i == len(vertices)-1 {
-				continue
-			}
-			prevV = vertices[i-1]
-			nextV = vertices[i+1]
-		} else {
-			// For closed paths, wrap around
-			prevV = vertices[(i-1+len(vertices))%len(vertices)]
-			nextV = vertices[(i+1)%len(vertices)]
+	goingUp0 := goingUp
+	prev := v0
+	for curr := v0.Next; curr != v0; curr = curr.Next {
+		if curr.Pt.Y < prev.Pt.Y && goingUp {
+			prev.Flags |= VertexFlagsLocalMax
+			goingUp = false
+		} else if curr.Pt.Y > prev.Pt.Y && !goingUp {
+			prev.Flags |= VertexFlagsLocalMin
+			goingUp = true
 		}
+		prev = curr
+	}
 
-		// Check for local minimum - Y is smaller than OR equal to both neighbors
-		// but at least one neighbor has a larger Y
-		if v.Pt.Y <= prevV.Pt.Y && v.Pt.Y <= nextV.Pt.Y && (prevV.Pt.Y > v.Pt.Y || nextV.Pt.Y > v.Pt.Y) {
-			v.Flags |= VertexFlagsLocalMin
+	switch {
+	case isOpen:
+		prev.Flags |= VertexFlagsOpenEnd
+		if goingUp {
+			prev.Flags |= VertexFlagsLocalMax
+		} else {
+			prev.Flags |= VertexFlagsLocalMin
 		}
-		
-		// Check for local maximum - Y is larger than OR equal to both neighbors  
-		// but at least one neighbor has a smaller Y
-		if v.Pt.Y >= prevV.Pt.Y && v.Pt.Y >= nextV.Pt.Y && (prevV.Pt.Y < v.Pt.Y || nextV.Pt.Y < v.Pt.Y) {
-			v.Flags |= VertexFlagsLocalMax
+	case goingUp != goingUp0:
+		if goingUp0 {
+			prev.Flags |= VertexFlagsLocalMin
+		} else {
+			prev.Flags |= VertexFlagsLocalMax
 		}
 	}
 }
@@ -133,25 +154,24 @@ func (v *Vertex) isOpenEnd() bool {
 	return (v.Flags & VertexFlagsOpenEnd) != 0
 }
 
-// findLocalMinima finds all local minima in a vertex chain and creates LocalMinima structures
-func findLocalMinima(startVertex *Vertex, pathType PathType, isOpen bool) []*LocalMinima {
+// addLocalMinima adds a LocalMinima for every local minimum of a vertex
+// chain to the minima list and reports whether there was any
+func (ve *VattiEngine) addLocalMinima(startVertex *Vertex, pathType PathType, isOpen bool) bool {
 	if startVertex == nil {
-		return nil
+		return false
 	}
 
-	var localMinima []*LocalMinima
+	found := false
 	current := startVertex
 
 	// Traverse the vertex chain
 	for {
 		if current.isLocalMinimum() {
 			// Create local minimum entry
-			lm := &LocalMinima{
-				Vertex:   current,
-				PathType: pathType,
-				IsOpen:   isOpen,
-			}
-			localMinima = append(localMinima, lm)
+			lm := ve.localMinima.alloc()
+			lm.Vertex, lm.PathType, lm.IsOpen = current, pathType, isOpen
+			ve.minimaList = append(ve.minimaList, lm)
+			found = true
 		}
 
 		current = current.Next
@@ -160,7 +180,7 @@ func findLocalMinima(startVertex *Vertex, pathType PathType, isOpen bool) []*Loc
 		}
 	}
 
-	return localMinima
+	return found
 }
 
 // getVertexChainLength returns the number of vertices in the chain
@@ -186,17 +206,12 @@ func validateVertexChain(startVertex *Vertex) bool {
 		return false
 	}
 
-	// Check for basic integrity
+	// Check for basic integrity. With every link checked in both directions
+	// the walk cannot re-enter the chain anywhere but at its start, so it
+	// ends without tracking visited vertices.
 	current := startVertex
-	visited := make(map[*Vertex]bool)
 	
 	for {
-		if visited[current] {
-			// We've seen this vertex before - check if it's the start (valid loop) or internal (invalid)
-			return current == startVertex
-		}
-		visited[current] = true
-		
 		// Check bidirectional linking
 		if current.Next != nil && current.Next.Prev != current {
 			return false // Forward/backward link mismatch
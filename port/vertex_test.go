@@ -0,0 +1,109 @@
+package clipper
+
+import (
+	"errors"
+	"testing"
+)
+
+// collectFlags returns the vertices of a chain carrying the given flag
+func collectFlags(start *Vertex, flag VertexFlags) []Point64 {
+	var pts []Point64
+	v := start
+	for {
+		if v.Flags&flag != 0 {
+			pts = append(pts, v.Pt)
+		}
+		v = v.Next
+		if v == nil || v == start {
+			return pts
+		}
+	}
+}
+
+// TestMarkLocalMinimaAndMaximaPlateaus tests extrema detection across horizontal runs
+func TestMarkLocalMinimaAndMaximaPlateaus(t *testing.T) {
+	t.Run("Square plateaus", func(t *testing.T) {
+		// A flat bottom (top) is a single minimum (maximum) at the last vertex of
+		// its run in path order
+		start := createVertexFromPath(Path64{{0, 0}, {10, 0}, {10, 10}, {0, 10}}, false)
+		if minima := collectFlags(start, VertexFlagsLocalMin); len(minima) != 1 || minima[0] != (Point64{10, 0}) {
+			t.Errorf("Expected the bottom run to end in one minimum at (10,0), got %v", minima)
+		}
+		if maxima := collectFlags(start, VertexFlagsLocalMax); len(maxima) != 1 || maxima[0] != (Point64{0, 10}) {
+			t.Errorf("Expected the top run to end in one maximum at (0,10), got %v", maxima)
+		}
+	})
+
+	t.Run("Duplicate points", func(t *testing.T) {
+		start := createVertexFromPath(Path64{{0, 0}, {0, 0}, {10, 0}, {5, 10}, {0, 0}}, false)
+		if n := getVertexChainLength(start); n != 3 {
+			t.Errorf("Expected repeated and closing points to be dropped, got %d vertices", n)
+		}
+	})
+
+	t.Run("Open path ends", func(t *testing.T) {
+		start := createVertexFromPath(Path64{{0, 0}, {5, 10}, {10, 5}}, true)
+		if flagged := collectFlags(start, VertexFlagsOpenStart|VertexFlagsLocalMin); len(flagged) != 2 {
+			t.Errorf("Expected the rising start and falling end as minima, got %v", flagged)
+		}
+		if maxima := collectFlags(start, VertexFlagsLocalMax); len(maxima) != 1 || maxima[0] != (Point64{5, 10}) {
+			t.Errorf("Expected one maximum at (5,10), got %v", maxima)
+		}
+	})
+
+	t.Run("Horizontal step", func(t *testing.T) {
+		// The run from (0,5) to (3,5) rises on one side and falls on the other
+		start := createVertexFromPath(Path64{{0, 0}, {6, 0}, {6, 10}, {3, 10}, {3, 5}, {0, 5}}, false)
+		for _, pt := range collectFlags(start, VertexFlagsLocalMin|VertexFlagsLocalMax) {
+			if pt.Y == 5 {
+				t.Errorf("Step vertex %v should not be an extremum", pt)
+			}
+		}
+	})
+
+	t.Run("Flat path", func(t *testing.T) {
+		start := createVertexFromPath(Path64{{0, 0}, {5, 0}, {10, 0}}, false)
+		if flagged := collectFlags(start, VertexFlagsLocalMin|VertexFlagsLocalMax); len(flagged) != 0 {
+			t.Errorf("Expected no extrema on a zero-height path, got %v", flagged)
+		}
+	})
+}
+
+// TestGetMaximaPair tests O(1) maxima pairing through vertex bookkeeping
+func TestGetMaximaPair(t *testing.T) {
+	ve := NewVattiEngine(Union, NonZero)
+	ve.maximaEdges = make([][2]*Edge, 1)
+	start := createVertexFromPath(Path64{{0, 0}, {10, 5}, {4, 10}}, false)
+	lm := &LocalMinima{Vertex: start}
+
+	top := start.Next.Next
+	left := ve.createEdge(start, top, lm, true)
+	if ve.getMaximaPair(left) != nil {
+		t.Error("Expected no pair before the second bound reaches the maximum")
+	}
+
+	right := ve.createEdge(start.Next, top, lm, false)
+	if ve.getMaximaPair(left) != right || ve.getMaximaPair(right) != left {
+		t.Error("Expected the two bounds of the maximum to be paired")
+	}
+
+	ve.createEdge(start, top, lm, true)
+	if !errors.Is(ve.err, ErrClipperExecution) || ve.succeeded {
+		t.Errorf("Expected a third bound at one maximum to fail with ErrClipperExecution, got %v", ve.err)
+	}
+}
+
+// TestBoundProgression tests that edges follow their bound through intermediate vertices
+func TestBoundProgression(t *testing.T) {
+	// A triangle whose right bound has an intermediate vertex at (10,5)
+	subject := Paths64{{{0, 0}, {10, 5}, {4, 10}}}
+	clip := Paths64{{{0, 0}, {10, 5}, {4, 10}}}
+
+	_, _, err := BooleanOp64(Intersection, NonZero, subject, nil, clip)
+	if err == ErrNotImplemented {
+		t.Skip("BooleanOp64 not yet implemented in pure Go")
+	}
+	if err != nil {
+		t.Fatalf("Expected every maximum to be paired, got %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+package clipper
+
+import "sort"
+
+// ==============================================================================
+// Vertex Welding
+// ==============================================================================
+
+// weldPaths merges output vertices closer than tolerance into one vertex, so
+// rounding noise does not leave micro-segments. Vertices are clustered
+// transitively across all closed and open paths and each cluster is
+// replaced by its lowest member (smallest Y, then X). Repeated vertices are
+// then removed, and closed rings left without area and open paths reduced to
+// a single point are dropped.
+func weldPaths(closed, open Paths64, tolerance int64) (Paths64, Paths64) {
+	weld := weldMap(AppendPaths(nil, closed, open), tolerance)
+	return weldRings(closed, weld, true), weldRings(open, weld, false)
+}
+
+// weldMap maps every vertex that is welded to its cluster representative
+func weldMap(paths Paths64, tolerance int64) map[Point64]Point64 {
+	// Unique vertices sorted by X so each only inspects its own X range
+	seen := make(map[Point64]bool)
+	var vertices []Point64
+	for _, path := range paths {
+		for _, pt := range path {
+			if !seen[pt] {
+				seen[pt] = true
+				vertices = append(vertices, pt)
+			}
+		}
+	}
+	sort.Slice(vertices, func(i, j int) bool {
+		if vertices[i].X != vertices[j].X {
+			return vertices[i].X < vertices[j].X
+		}
+		return vertices[i].Y < vertices[j].Y
+	})
+
+	parent := make([]int, len(vertices))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	for i, a := range vertices {
+		for j := i + 1; j < len(vertices) && vertices[j].X-a.X < tolerance; j++ {
+			if a.DistanceTo(vertices[j]) < float64(tolerance) {
+				parent[find(j)] = find(i)
+			}
+		}
+	}
+
+	lowest := make(map[int]Point64)
+	for i, pt := range vertices {
+		root := find(i)
+		if rep, ok := lowest[root]; !ok || pt.Y < rep.Y || (pt.Y == rep.Y && pt.X < rep.X) {
+			lowest[root] = pt
+		}
+	}
+	weld := make(map[Point64]Point64)
+	for i, pt := range vertices {
+		if rep := lowest[find(i)]; rep != pt {
+			weld[pt] = rep
+		}
+	}
+	return weld
+}
+
+// weldRings applies weld to paths, dropping the ones that degenerate
+func weldRings(paths Paths64, weld map[Point64]Point64, isClosed bool) Paths64 {
+	result := make(Paths64, 0, len(paths))
+	for _, path := range paths {
+		out := make(Path64, 0, len(path))
+		for _, pt := range path {
+			if rep, ok := weld[pt]; ok {
+				pt = rep
+			}
+			if len(out) == 0 || out[len(out)-1] != pt {
+				out = append(out, pt)
+			}
+		}
+		if isClosed {
+			for len(out) > 1 && out[0] == out[len(out)-1] {
+				out = out[:len(out)-1]
+			}
+			if len(out) < 3 || Area64(out) == 0 {
+				continue
+			}
+		} else if len(out) < 2 {
+			continue
+		}
+		result = append(result, out)
+	}
+	return result
+}
@@ -0,0 +1,69 @@
+package clipper
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestWeldPaths(t *testing.T) {
+	closed := Paths64{
+		{{0, 0}, {100, 0}, {101, 1}, {100, 100}, {0, 100}}, // micro-segment at (100,0)
+		{{200, 0}, {201, 0}, {201, 1}},                     // collapses entirely
+	}
+	open := Paths64{
+		{{0, 200}, {1, 200}, {50, 250}}, // first segment collapses
+		{{300, 300}, {301, 301}},        // collapses to a point
+	}
+
+	gotClosed, gotOpen := weldPaths(closed, open, 2)
+	expectedClosed := Paths64{{{0, 0}, {100, 0}, {100, 100}, {0, 100}}}
+	expectedOpen := Paths64{{{0, 200}, {50, 250}}}
+	if !reflect.DeepEqual(gotClosed, expectedClosed) {
+		t.Errorf("expected closed %v, got %v", expectedClosed, gotClosed)
+	}
+	if !reflect.DeepEqual(gotOpen, expectedOpen) {
+		t.Errorf("expected open %v, got %v", expectedOpen, gotOpen)
+	}
+}
+
+func TestWeldPathsAcrossRings(t *testing.T) {
+	// vertices of neighbouring rings that nearly coincide become shared
+	rings := Paths64{
+		{{0, 0}, {10, 0}, {10, 10}, {0, 10}},
+		{{11, 1}, {20, 0}, {20, 10}, {10, 10}},
+	}
+	welded, _ := weldPaths(rings, nil, 2)
+	if welded[1][0] != (Point64{10, 0}) {
+		t.Errorf("expected (11,1) welded to (10,0), got %v", welded[1][0])
+	}
+	// a distance equal to the tolerance is not welded
+	welded, _ = weldPaths(rings, nil, 1)
+	if !reflect.DeepEqual(welded, rings) {
+		t.Errorf("expected no welding at tolerance 1, got %v", welded)
+	}
+}
+
+func TestBooleanOp64WeldTolerance(t *testing.T) {
+	subject := Paths64{{{0, 0}, {100, 0}, {100, 100}, {0, 100}}}
+	clip := Paths64{{{50, 1}, {150, 1}, {150, 101}, {50, 101}}}
+
+	solution, _, err := BooleanOp64(Union, NonZero, subject, nil, clip, ClipperOptions{WeldTolerance: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, ring := range solution {
+		for i, pt := range ring {
+			if d := pt.DistanceTo(ring[(i+1)%len(ring)]); d < 2 {
+				t.Errorf("expected no edge shorter than 2, got %v in %v", d, ring)
+			}
+		}
+	}
+	if len(solution) != 1 || len(solution[0]) != 6 {
+		t.Errorf("expected one ring of 6 vertices, got %v", solution)
+	}
+
+	if _, _, err := BooleanOp64(Union, NonZero, subject, nil, clip, ClipperOptions{WeldTolerance: -1}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for negative tolerance, got %v", err)
+	}
+}
@@ -0,0 +1,163 @@
+package clipper
+
+import "math"
+
+// ==============================================================================
+// Z Values - Per-Vertex Metadata Through Boolean Operations
+// ==============================================================================
+
+// Point64Z is a point carrying a Z value, as in the USINGZ build of Clipper2.
+// Z is not a third dimension: it is per-vertex metadata, such as a feed rate
+// or layer index, that boolean operations pass through to their output.
+type Point64Z struct {
+	X, Y, Z int64
+}
+
+// Path64Z is a path of points with Z values
+type Path64Z []Point64Z
+
+// Paths64Z is a collection of paths with Z values
+type Paths64Z []Path64Z
+
+// XY returns the point without its Z value
+func (p Point64Z) XY() Point64 {
+	return Point64{X: p.X, Y: p.Y}
+}
+
+// ZCallback64 computes the Z value of an output vertex created where the
+// edge e1bot-e1top crosses the edge e2bot-e2top, each running upwards (bot
+// has the smaller Y). When a subject and a clip edge cross, the subject edge
+// comes first.
+type ZCallback64 func(e1bot, e1top, e2bot, e2top Point64Z, pt Point64) int64
+
+// InterpolateZ64 is a ZCallback64 averaging the Z values interpolated along
+// both edges at pt
+func InterpolateZ64(e1bot, e1top, e2bot, e2top Point64Z, pt Point64) int64 {
+	return RoundHalfAway((interpolateZ(e1bot, e1top, pt) + interpolateZ(e2bot, e2top, pt)) / 2)
+}
+
+// interpolateZ returns the Z value at the projection of pt onto the edge a-b
+func interpolateZ(a, b Point64Z, pt Point64) float64 {
+	dx, dy := float64(b.X-a.X), float64(b.Y-a.Y)
+	t := 0.0
+	if lenSq := dx*dx + dy*dy; lenSq > 0 {
+		t = math.Max(0, math.Min(1, (float64(pt.X-a.X)*dx+float64(pt.Y-a.Y)*dy)/lenSq))
+	}
+	return float64(a.Z) + t*float64(b.Z-a.Z)
+}
+
+// BooleanOp64Z performs a boolean operation like BooleanOp64 on paths with Z
+// values. Every output vertex at the position of an input vertex takes that
+// vertex's Z, the first one found among closed subjects, then open subjects,
+// then clips. Vertices created at intersections take the Z computed by zFn
+// from the two input edges crossing there, or 0 if zFn is nil; those edges
+// are the ones nearest to the vertex, since intersections are rounded onto
+// the integer grid.
+func BooleanOp64Z(clipType ClipType, fillRule FillRule, subjects, subjectsOpen, clips Paths64Z, zFn ZCallback64, opts ...Option) (solution, solutionOpen Paths64Z, err error) {
+	flat := [3]Paths64{stripZ(subjects), stripZ(subjectsOpen), stripZ(clips)}
+	solution64, solutionOpen64, err := BooleanOp64(clipType, fillRule, flat[0], flat[1], flat[2], opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	a := newZAssigner([3]Paths64Z{subjects, subjectsOpen, clips}, flat, zFn)
+	return a.assign(solution64), a.assign(solutionOpen64), nil
+}
+
+// stripZ returns paths without their Z values
+func stripZ(paths Paths64Z) Paths64 {
+	result := make(Paths64, len(paths))
+	for i, path := range paths {
+		result[i] = make(Path64, len(path))
+		for j, pt := range path {
+			result[i][j] = pt.XY()
+		}
+	}
+	return result
+}
+
+// zAssigner looks up the Z values of output vertices in the input
+type zAssigner struct {
+	zFn      ZCallback64
+	vertexZ  map[Point64]int64
+	edges    [][2]Point64Z // input edges, subjects before clips
+	index    *edgeIndex    // the same edges, for nearest-edge queries
+	subjects int           // number of subject edges at the start of edges
+}
+
+// newZAssigner indexes the vertices and edges of the closed subjects, open
+// subjects and clips in paths, whose Z values are stripped in flat
+func newZAssigner(paths [3]Paths64Z, flat [3]Paths64, zFn ZCallback64) *zAssigner {
+	a := &zAssigner{zFn: zFn, vertexZ: make(map[Point64]int64)}
+	var edges [][2]PointD
+	for group, set := range paths {
+		isClosed := group != clipperSubjectsOpen
+		for _, path := range set {
+			for _, pt := range path {
+				if _, ok := a.vertexZ[pt.XY()]; !ok {
+					a.vertexZ[pt.XY()] = pt.Z
+				}
+			}
+			n := len(path)
+			if !isClosed {
+				n--
+			}
+			for i := 0; i < n; i++ {
+				bot, top := path[i], path[(i+1)%len(path)]
+				if top.Y < bot.Y {
+					bot, top = top, bot
+				}
+				a.edges = append(a.edges, [2]Point64Z{bot, top})
+			}
+		}
+		edges = appendPathEdges(edges, flat[group], isClosed)
+		if group == clipperSubjectsOpen {
+			a.subjects = len(edges)
+		}
+	}
+	if zFn != nil {
+		a.index = newEdgeIndex(edges, len(a.vertexZ))
+	}
+	return a
+}
+
+// assign returns paths with the Z value of every vertex
+func (a *zAssigner) assign(paths Paths64) Paths64Z {
+	result := make(Paths64Z, len(paths))
+	for i, path := range paths {
+		result[i] = make(Path64Z, len(path))
+		for j, pt := range path {
+			result[i][j] = Point64Z{X: pt.X, Y: pt.Y, Z: a.z(pt)}
+		}
+	}
+	return result
+}
+
+// z returns the Z value of an output vertex
+func (a *zAssigner) z(pt Point64) int64 {
+	if z, ok := a.vertexZ[pt]; ok {
+		return z
+	}
+	if a.zFn == nil {
+		return 0
+	}
+	x, y := float64(pt.X), float64(pt.Y)
+	_, i := a.index.nearest(x, y, nil)
+	if i < 0 {
+		return 0
+	}
+	// The other edge is the nearest one not meeting the first at a vertex
+	e1bot, e1top := a.edges[i][0].XY(), a.edges[i][1].XY()
+	_, j := a.index.nearest(x, y, func(k int) bool {
+		bot, top := a.edges[k][0].XY(), a.edges[k][1].XY()
+		return bot == e1bot || bot == e1top || top == e1bot || top == e1top
+	})
+	if j < 0 {
+		j = i
+	}
+	if j < a.subjects && i >= a.subjects {
+		i, j = j, i
+	}
+	e1, e2 := a.edges[i], a.edges[j]
+	return a.zFn(e1[0], e1[1], e2[0], e2[1], pt)
+}
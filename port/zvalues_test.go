@@ -0,0 +1,51 @@
+package clipper
+
+import "testing"
+
+// TestBooleanOp64Z tests Z values of input vertices and intersections
+func TestBooleanOp64Z(t *testing.T) {
+	subjects := Paths64Z{{{0, 0, 0}, {100, 0, 100}, {100, 100, 100}, {0, 100, 0}}}
+	clips := Paths64Z{{{50, -50, 10}, {150, -50, 10}, {150, 50, 10}, {50, 50, 10}}}
+
+	solution, _, err := BooleanOp64Z(Intersection, NonZero, subjects, nil, clips, InterpolateZ64)
+	if err != nil || len(solution) != 1 {
+		t.Fatalf("expected one ring, got %v (err %v)", solution, err)
+	}
+	expected := map[Point64]int64{
+		{X: 100, Y: 0}:  100, // subject vertex
+		{X: 50, Y: 50}:  10,  // clip vertex
+		{X: 50, Y: 0}:   30,  // 50 along the subject edge, 10 along the clip edge
+		{X: 100, Y: 50}: 55,  // 100 along the subject edge, 10 along the clip edge
+	}
+	if len(solution[0]) != len(expected) {
+		t.Fatalf("expected %d vertices, got %v", len(expected), solution[0])
+	}
+	for _, pt := range solution[0] {
+		if z, ok := expected[pt.XY()]; !ok || pt.Z != z {
+			t.Errorf("vertex %v: expected Z %d", pt, z)
+		}
+	}
+
+	// The callback sees the crossing edges, subject first and running upwards
+	var calls int
+	_, _, err = BooleanOp64Z(Intersection, NonZero, subjects, nil, clips, func(e1bot, e1top, e2bot, e2top Point64Z, pt Point64) int64 {
+		calls++
+		if e1bot.Z == 10 || e2bot.Z != 10 || e1bot.Y > e1top.Y || e2bot.Y > e2top.Y {
+			t.Errorf("expected the subject edge first and upward edges, got %v-%v and %v-%v at %v", e1bot, e1top, e2bot, e2top, pt)
+		}
+		return 0
+	})
+	if err != nil || calls != 2 {
+		t.Errorf("expected 2 callback calls, got %d (err %v)", calls, err)
+	}
+
+	solution, solutionOpen, err := BooleanOp64Z(Intersection, NonZero, nil, Paths64Z{{{0, 50, 1}, {200, 50, 3}}}, subjects, nil)
+	if err != nil || len(solution) != 0 || len(solutionOpen) != 1 {
+		t.Fatalf("expected one open path, got %v %v (err %v)", solution, solutionOpen, err)
+	}
+	for _, pt := range solutionOpen[0] {
+		if expectedZ := map[int64]int64{0: 1, 100: 0}[pt.X]; pt.Z != expectedZ {
+			t.Errorf("vertex %v: expected Z %d without a callback", pt, expectedZ)
+		}
+	}
+}